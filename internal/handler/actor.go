@@ -2,12 +2,17 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
 	"github.com/EgMeln/filmLibraryPrivate/internal/service"
 )
@@ -33,6 +38,7 @@ func NewActorHandler(actorService service.ActorService) *ActorHandler {
 //	@Param			actor	body		model.Actor	true	"Actor object to be created"
 //	@Success		200		{string}	string		"OK"
 //	@Failure		400		{string}	string		"Failed to decode request body"
+//	@Failure		422		{object}	service.ValidationError	"Invalid actor fields, e.g. an unknown gender, a birth date in the future or a link without an http or https URL"
 //	@Failure		500		{string}	string		"Failed to create actor"
 //	@Router			/actors/create [post]
 func (ah *ActorHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -45,7 +51,11 @@ func (ah *ActorHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := ah.actorService.Create(&actor); err != nil {
+	username, _ := middleware.UsernameFromContext(r.Context())
+	if err := ah.actorService.Create(&actor, username); err != nil {
+		if writeValidationError(w, err) {
+			return
+		}
 		http.Error(w, "Failed to create actor", http.StatusInternalServerError)
 		log.Printf("Failed to create actor: %v", err)
 		return
@@ -55,17 +65,64 @@ func (ah *ActorHandler) Create(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Create Actor request handled successfully.")
 }
 
+// GetByID handles HTTP requests to retrieve an actor by ID.
+//	@Summary		Retrieve an actor
+//	@Description	Retrieve an actor with their profile by ID, their version is returned as the ETag to send in the If-Match header of updates and deletes
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			actor_id	query		string		true	"ID of the actor"
+//	@Success		200			{object}	model.Actor	"OK"
+//	@Header			200			{string}	ETag		"Version of the actor"
+//	@Failure		400			{string}	string		"Invalid actor ID"
+//	@Failure		404			{string}	string		"Actor not found"
+//	@Failure		500			{string}	string		"Failed to fetch actor"
+//	@Router			/actors/getByID [get]
+func (ah *ActorHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetByID Actor request...")
+
+	actorIDStr := r.URL.Query().Get("actor_id")
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", actorIDStr)
+		return
+	}
+
+	actor, err := ah.actorService.GetByID(actorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Actor not found", http.StatusNotFound)
+			log.Printf("Actor not found: %s", actorID)
+			return
+		}
+		http.Error(w, "Failed to fetch actor", http.StatusInternalServerError)
+		log.Printf("Failed to fetch actor: %v", err)
+		return
+	}
+
+	setETag(w, actor.Version)
+	writeJSON(w, http.StatusOK, actor)
+
+	log.Printf("GetByID Actor request handled successfully.")
+}
+
 // Update handles HTTP requests to update an existing actor.
 //	@Summary		Update an existing actor
-//	@Description	Update an existing actor in the film library
+//	@Description	Update an existing actor in the film library. The version of the actor is checked against the If-Match header, which may be required by the server
 //	@Tags			actors
 //	@Accept			json
 //	@Produce		json
 //	@Param			actor_id	query		string		true	"ID of the actor to be updated"
+//	@Param			If-Match	header		string		false	"ETag of the actor as last read"
 //	@Param			actor		body		model.Actor	true	"Actor object with updated information"
 //	@Success		200			{string}	string		"OK"
 //	@Failure		400			{string}	string		"Invalid actor ID"
 //	@Failure		400			{string}	string		"Failed to decode request body"
+//	@Failure		404			{string}	string		"Actor not found"
+//	@Failure		412			{string}	string		"Actor changed since they were read"
+//	@Failure		428			{string}	string		"If-Match header required"
+//	@Failure		422			{object}	service.ValidationError	"Invalid actor fields, e.g. an unknown gender, a birth date in the future or a link without an http or https URL"
 //	@Failure		500			{string}	string		"Failed to update actor"
 //	@Router			/actors/update [put]
 func (ah *ActorHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -85,8 +142,21 @@ func (ah *ActorHandler) Update(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Failed to decode request body: %v", err)
 		return
 	}
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	updatedActor.Version = version
 
-	if err := ah.actorService.Update(actorID, &updatedActor); err != nil {
+	username, _ := middleware.UsernameFromContext(r.Context())
+	if err := ah.actorService.Update(actorID, &updatedActor, username); err != nil {
+		if writeValidationError(w, err) {
+			return
+		}
+		if writeVersionError(w, err, "Actor not found", "Actor changed since they were read") {
+			log.Printf("Failed to update actor: %v", err)
+			return
+		}
 		http.Error(w, "Failed to update actor", http.StatusInternalServerError)
 		log.Printf("Failed to update actor: %v", err)
 		return
@@ -97,16 +167,88 @@ func (ah *ActorHandler) Update(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Update Actor request handled successfully.")
 }
 
+// Patch handles HTTP requests to partially update an existing actor.
+//	@Summary		Patch an existing actor
+//	@Description	Partially update an existing actor with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) applied to the actor as returned by their reads. Unlike updates, fields set to null or empty are cleared, e.g. a null DeathDate. The version of the actor is checked against the If-Match header, which may be required by the server
+//	@Tags			actors
+//	@Accept			application/merge-patch+json,application/json-patch+json
+//	@Produce		json
+//	@Param			actor_id	query		string		true	"ID of the actor to be patched"
+//	@Param			If-Match	header		string		false	"ETag of the actor as last read"
+//	@Param			patch		body		object		true	"JSON Merge Patch object or JSON Patch operations"
+//	@Success		200			{object}	model.Actor	"OK"
+//	@Header			200			{string}	ETag		"Version of the actor"
+//	@Failure		400			{string}	string		"Invalid actor ID or invalid patch"
+//	@Failure		404			{string}	string		"Actor not found"
+//	@Failure		409			{string}	string		"Patch cannot be applied to the actor, e.g. a failed test operation"
+//	@Failure		412			{string}	string		"Actor changed since they were read"
+//	@Failure		415			{string}	string		"Unsupported patch media type"
+//	@Failure		428			{string}	string		"If-Match header required"
+//	@Failure		422			{object}	service.ValidationError	"Invalid actor fields, e.g. an unknown gender, a birth date in the future or a link without an http or https URL"
+//	@Failure		500			{string}	string		"Failed to patch actor"
+//	@Router			/actors/patch [patch]
+func (ah *ActorHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Patch Actor request...")
+
+	actorIDStr := r.URL.Query().Get("actor_id")
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", actorIDStr)
+		return
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		log.Printf("Failed to read request body: %v", err)
+		return
+	}
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	actor, err := ah.actorService.Patch(actorID, patchMediaType(r), patch, version, username)
+	if err != nil {
+		if writeValidationError(w, err) {
+			return
+		}
+		if writePatchError(w, err) {
+			return
+		}
+		if writeVersionError(w, err, "Actor not found", "Actor changed since they were read") {
+			log.Printf("Failed to patch actor: %v", err)
+			return
+		}
+		http.Error(w, "Failed to patch actor", http.StatusInternalServerError)
+		log.Printf("Failed to patch actor: %v", err)
+		return
+	}
+
+	setETag(w, actor.Version)
+	writeJSON(w, http.StatusOK, actor)
+
+	log.Printf("Patch Actor request handled successfully.")
+}
+
 // Delete handles HTTP requests to delete an actor by ID.
 //	@Summary		Delete an actor
-//	@Description	Delete an actor from the film library by ID
+//	@Description	Move an actor to the trash by ID. With the reject policy, the default one, an actor credited on movies is not deleted and the movies are listed; the detach policy removes the credits of the actor and the soft policy keeps them, hidden until the actor is restored. The version of the actor is checked against the If-Match header, which may be required by the server
 //	@Tags			actors
 //	@Accept			json
 //	@Produce		json
-//	@Param			actor_id	query		string	true	"ID of the actor to be deleted"
-//	@Success		200			{string}	string	"OK"
-//	@Failure		400			{string}	string	"Invalid actor ID"
-//	@Failure		500			{string}	string	"Failed to delete actor"
+//	@Param			actor_id	query		string					true	"ID of the actor to be deleted"
+//	@Param			policy		query		string					false	"Delete policy: reject, detach or soft"
+//	@Param			If-Match	header		string					false	"ETag of the actor as last read"
+//	@Success		200			{string}	string					"OK"
+//	@Failure		400			{string}	string					"Invalid actor ID or delete policy"
+//	@Failure		404			{string}	string					"Actor not found"
+//	@Failure		409			{object}	service.ActorInUseError	"Actor credited on movies, the movies are listed"
+//	@Failure		412			{string}	string					"Actor changed since they were read"
+//	@Failure		428			{string}	string					"If-Match header required"
+//	@Failure		500			{string}	string					"Failed to delete actor"
 //	@Router			/actors/delete [delete]
 func (ah *ActorHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Handling Delete Actor request...")
@@ -119,8 +261,26 @@ func (ah *ActorHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := ah.actorService.Delete(actorID); err != nil {
-		http.Error(w, "Failed to delete actor", http.StatusInternalServerError)
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	if err := ah.actorService.Delete(actorID, version, r.URL.Query().Get("policy"), username); err != nil {
+		var inUse *service.ActorInUseError
+		switch {
+		case errors.As(err, &inUse):
+			writeJSON(w, http.StatusConflict, inUse)
+		case errors.Is(err, service.ErrInvalidDeletePolicy):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrVersionMismatch):
+			http.Error(w, "Actor changed since they were read", http.StatusPreconditionFailed)
+		case errors.Is(err, sql.ErrNoRows):
+			http.Error(w, "Actor not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to delete actor", http.StatusInternalServerError)
+		}
 		log.Printf("Failed to delete actor: %v", err)
 		return
 	}
@@ -162,3 +322,293 @@ func (ah *ActorHandler) GetAllWithMovies(w http.ResponseWriter, r *http.Request)
 
 	log.Printf("GetAllWithMovies Actors request handled successfully.")
 }
+
+// GetAll handles HTTP requests to retrieve a page of actors matching a search.
+//	@Summary		Search actors
+//	@Description	Retrieve a page of actors, including the actors without movies, filtered by name, gender and birth year
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			name			query		string			false	"Fragment of the name or of an alias of the actor"
+//	@Param			gender			query		string			false	"Gender of the actor"
+//	@Param			min_birth_year	query		int				false	"Earliest birth year of the actor"
+//	@Param			max_birth_year	query		int				false	"Latest birth year of the actor"
+//	@Param			sort			query		string			false	"Sort order: name (default), birth_date or film_count"
+//	@Param			order			query		string			false	"Sort direction: asc (default) or desc"
+//	@Param			include_movies	query		bool			false	"Whether the movies of the actors are included"
+//	@Param			page			query		int				false	"Number of the page, starting at 1"
+//	@Param			page_size		query		int				false	"Number of actors of a page, 20 by default and 100 at most"
+//	@Success		200				{object}	model.ActorPage	"OK"
+//	@Failure		400				{string}	string			"Invalid query parameter"
+//	@Failure		500				{string}	string			"Failed to fetch actors"
+//	@Router			/actors [get]
+func (ah *ActorHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetAll Actors request...")
+
+	query := r.URL.Query()
+	actorQuery := model.ActorQuery{
+		Name:   query.Get("name"),
+		Gender: query.Get("gender"),
+		Sort:   query.Get("sort"),
+	}
+
+	switch order := query.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		actorQuery.Descending = true
+	default:
+		http.Error(w, "Invalid order", http.StatusBadRequest)
+		log.Printf("Invalid order: %s", order)
+		return
+	}
+
+	if includeMoviesStr := query.Get("include_movies"); includeMoviesStr != "" {
+		includeMovies, err := strconv.ParseBool(includeMoviesStr)
+		if err != nil {
+			http.Error(w, "Invalid include_movies flag", http.StatusBadRequest)
+			log.Printf("Invalid include_movies flag: %s", includeMoviesStr)
+			return
+		}
+		actorQuery.IncludeMovies = includeMovies
+	}
+
+	var page, pageSize int
+	var err error
+	if minBirthYearStr := query.Get("min_birth_year"); minBirthYearStr != "" {
+		if actorQuery.MinBirthYear, err = strconv.Atoi(minBirthYearStr); err != nil {
+			http.Error(w, "Invalid minimum birth year", http.StatusBadRequest)
+			log.Printf("Invalid minimum birth year: %s", minBirthYearStr)
+			return
+		}
+	}
+	if maxBirthYearStr := query.Get("max_birth_year"); maxBirthYearStr != "" {
+		if actorQuery.MaxBirthYear, err = strconv.Atoi(maxBirthYearStr); err != nil {
+			http.Error(w, "Invalid maximum birth year", http.StatusBadRequest)
+			log.Printf("Invalid maximum birth year: %s", maxBirthYearStr)
+			return
+		}
+	}
+	if pageStr := query.Get("page"); pageStr != "" {
+		if page, err = strconv.Atoi(pageStr); err != nil {
+			http.Error(w, "Invalid page", http.StatusBadRequest)
+			log.Printf("Invalid page: %s", pageStr)
+			return
+		}
+	}
+	if pageSizeStr := query.Get("page_size"); pageSizeStr != "" {
+		if pageSize, err = strconv.Atoi(pageSizeStr); err != nil {
+			http.Error(w, "Invalid page size", http.StatusBadRequest)
+			log.Printf("Invalid page size: %s", pageSizeStr)
+			return
+		}
+	}
+
+	actorPage, err := ah.actorService.GetAll(actorQuery, page, pageSize)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPage) || errors.Is(err, service.ErrInvalidActorSort) ||
+			errors.Is(err, service.ErrInvalidBirthYears) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			log.Printf("Invalid actor query: %v", err)
+			return
+		}
+		http.Error(w, "Failed to fetch actors", http.StatusInternalServerError)
+		log.Printf("Failed to fetch actors: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, actorPage)
+
+	log.Printf("GetAll Actors request handled successfully.")
+}
+
+// GetFilmography handles HTTP requests to retrieve the filmography of an actor grouped by credit role.
+//	@Summary		Retrieve the filmography of an actor
+//	@Description	Retrieve the movies a person worked on, grouped by role (actor, director, writer, producer, composer), with their age at the release of each movie
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			actor_id	query		string				true	"ID of the actor"
+//	@Success		200			{object}	model.Filmography	"OK"
+//	@Failure		400			{string}	string				"Invalid actor ID"
+//	@Failure		404			{string}	string				"Actor not found"
+//	@Failure		500			{string}	string				"Failed to fetch filmography"
+//	@Router			/actors/getFilmography [get]
+func (ah *ActorHandler) GetFilmography(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetFilmography Actor request...")
+
+	actorIDStr := r.URL.Query().Get("actor_id")
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", actorIDStr)
+		return
+	}
+
+	filmography, err := ah.actorService.GetFilmography(actorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Actor not found", http.StatusNotFound)
+			log.Printf("Actor not found: %s", actorIDStr)
+			return
+		}
+		http.Error(w, "Failed to fetch filmography", http.StatusInternalServerError)
+		log.Printf("Failed to fetch filmography: %v", err)
+		return
+	}
+
+	jsonResponse, err := json.Marshal(filmography)
+	if err != nil {
+		http.Error(w, "Failed to encode filmography", http.StatusInternalServerError)
+		log.Printf("Failed to encode filmography: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResponse)
+
+	log.Printf("GetFilmography Actor request handled successfully.")
+}
+
+// GetCareerTimeline handles HTTP requests to retrieve the career timeline of an actor.
+//	@Summary		Retrieve the career timeline of an actor
+//	@Description	Retrieve the first and last movies, the active years and the number and average rating of the movies per year of a person
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string					true	"ID of the actor"
+//	@Success		200	{object}	model.CareerTimeline	"OK"
+//	@Failure		400	{string}	string					"Invalid actor ID"
+//	@Failure		404	{string}	string					"Actor not found"
+//	@Failure		500	{string}	string					"Failed to fetch career timeline"
+//	@Router			/actors/{id}/timeline [get]
+func (ah *ActorHandler) GetCareerTimeline(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetCareerTimeline Actor request...")
+
+	actorIDStr := r.PathValue("id")
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", actorIDStr)
+		return
+	}
+
+	timeline, err := ah.actorService.GetCareerTimeline(actorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Actor not found", http.StatusNotFound)
+			log.Printf("Actor not found: %s", actorIDStr)
+			return
+		}
+		http.Error(w, "Failed to fetch career timeline", http.StatusInternalServerError)
+		log.Printf("Failed to fetch career timeline: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, timeline)
+
+	log.Printf("GetCareerTimeline Actor request handled successfully.")
+}
+
+// GetCollaborators handles HTTP requests to retrieve the frequent collaborators of an actor.
+//	@Summary		Retrieve the frequent collaborators of an actor
+//	@Description	Retrieve the actors who starred in the most movies with an actor, along with the shared movies
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"ID of the actor"
+//	@Param			limit	query		int						false	"Maximum number of collaborators, 10 by default and 100 at most"
+//	@Success		200		{object}	[]model.Collaborator	"OK"
+//	@Failure		400		{string}	string					"Invalid actor ID or limit"
+//	@Failure		404		{string}	string					"Actor not found"
+//	@Failure		500		{string}	string					"Failed to fetch collaborators"
+//	@Router			/actors/{id}/collaborators [get]
+func (ah *ActorHandler) GetCollaborators(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetCollaborators Actor request...")
+
+	actorIDStr := r.PathValue("id")
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", actorIDStr)
+		return
+	}
+
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			log.Printf("Invalid limit: %s", limitStr)
+			return
+		}
+	}
+
+	collaborators, err := ah.actorService.GetCollaborators(actorID, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCollaboratorsLimit):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, sql.ErrNoRows):
+			http.Error(w, "Actor not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to fetch collaborators", http.StatusInternalServerError)
+		}
+		log.Printf("Failed to fetch collaborators: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, collaborators)
+
+	log.Printf("GetCollaborators Actor request handled successfully.")
+}
+
+// GetConnection handles HTTP requests to find the shortest chain of movies connecting two actors.
+//	@Summary		Retrieve the degrees of separation of two actors
+//	@Description	Find the shortest chain of movies, 6 at most, connecting two actors through their co-stars
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string				true	"ID of the first actor"
+//	@Param			targetId	path		string				true	"ID of the second actor"
+//	@Success		200			{object}	model.Connection	"OK"
+//	@Failure		400			{string}	string				"Invalid actor ID"
+//	@Failure		404			{string}	string				"Actor not found or actors not connected"
+//	@Failure		500			{string}	string				"Failed to connect actors"
+//	@Router			/actors/{id}/connection/{targetId} [get]
+func (ah *ActorHandler) GetConnection(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetConnection Actor request...")
+
+	sourceIDStr := r.PathValue("id")
+	sourceID, err := uuid.Parse(sourceIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", sourceIDStr)
+		return
+	}
+
+	targetIDStr := r.PathValue("targetId")
+	targetID, err := uuid.Parse(targetIDStr)
+	if err != nil {
+		http.Error(w, "Invalid target actor ID", http.StatusBadRequest)
+		log.Printf("Invalid target actor ID: %s", targetIDStr)
+		return
+	}
+
+	connection, err := ah.actorService.GetConnection(sourceID, targetID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNoConnection):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, sql.ErrNoRows):
+			http.Error(w, "Actor not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to connect actors", http.StatusInternalServerError)
+		}
+		log.Printf("Failed to connect actors: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, connection)
+
+	log.Printf("GetConnection Actor request handled successfully.")
+}
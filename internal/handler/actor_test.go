@@ -2,40 +2,79 @@ package handler
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
 )
 
 type mockActorService struct {
-	CreateFunc           func(actor *model.Actor) error
-	UpdateFunc           func(actorID uuid.UUID, updatedActor *model.Actor) error
-	DeleteFunc           func(actorID uuid.UUID) error
-	GetAllWithMoviesFunc func() ([]*model.ActorMovies, error)
+	CreateFunc            func(actor *model.Actor) error
+	GetByIDFunc           func(actorID uuid.UUID) (*model.Actor, error)
+	UpdateFunc            func(actorID uuid.UUID, updatedActor *model.Actor) error
+	PatchFunc             func(actorID uuid.UUID, mediaType string, patch []byte, version int) (*model.Actor, error)
+	DeleteFunc            func(actorID uuid.UUID, version int, policy string) error
+	GetAllWithMoviesFunc  func() ([]*model.ActorMovies, error)
+	GetAllFunc            func(query model.ActorQuery, page, pageSize int) (*model.ActorPage, error)
+	GetFilmographyFunc    func(actorID uuid.UUID) (*model.Filmography, error)
+	GetCareerTimelineFunc func(actorID uuid.UUID) (*model.CareerTimeline, error)
+	GetCollaboratorsFunc  func(actorID uuid.UUID, limit int) ([]model.Collaborator, error)
+	GetConnectionFunc     func(sourceID, targetID uuid.UUID) (*model.Connection, error)
 }
 
-func (mas *mockActorService) Create(actor *model.Actor) error {
+func (mas *mockActorService) Create(actor *model.Actor, username string) error {
 	return mas.CreateFunc(actor)
 }
 
-func (mas *mockActorService) Update(actorID uuid.UUID, updatedActor *model.Actor) error {
+func (mas *mockActorService) GetByID(actorID uuid.UUID) (*model.Actor, error) {
+	return mas.GetByIDFunc(actorID)
+}
+
+func (mas *mockActorService) Update(actorID uuid.UUID, updatedActor *model.Actor, username string) error {
 	return mas.UpdateFunc(actorID, updatedActor)
 }
 
-func (mas *mockActorService) Delete(actorID uuid.UUID) error {
-	return mas.DeleteFunc(actorID)
+func (mas *mockActorService) Patch(actorID uuid.UUID, mediaType string, patch []byte, version int,
+	username string) (*model.Actor, error) {
+	return mas.PatchFunc(actorID, mediaType, patch, version)
+}
+
+func (mas *mockActorService) Delete(actorID uuid.UUID, version int, policy, username string) error {
+	return mas.DeleteFunc(actorID, version, policy)
 }
 
 func (mas *mockActorService) GetAllWithMovies() ([]*model.ActorMovies, error) {
 	return mas.GetAllWithMoviesFunc()
 }
 
+func (mas *mockActorService) GetAll(query model.ActorQuery, page, pageSize int) (*model.ActorPage, error) {
+	return mas.GetAllFunc(query, page, pageSize)
+}
+
+func (mas *mockActorService) GetFilmography(actorID uuid.UUID) (*model.Filmography, error) {
+	return mas.GetFilmographyFunc(actorID)
+}
+
+func (mas *mockActorService) GetCareerTimeline(actorID uuid.UUID) (*model.CareerTimeline, error) {
+	return mas.GetCareerTimelineFunc(actorID)
+}
+
+func (mas *mockActorService) GetCollaborators(actorID uuid.UUID, limit int) ([]model.Collaborator, error) {
+	return mas.GetCollaboratorsFunc(actorID, limit)
+}
+
+func (mas *mockActorService) GetConnection(sourceID, targetID uuid.UUID) (*model.Connection, error) {
+	return mas.GetConnectionFunc(sourceID, targetID)
+}
+
 func TestActorHandler_Create(t *testing.T) {
 	t.Parallel()
 
@@ -67,6 +106,21 @@ func TestActorHandler_Create(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+
+		{
+			name: "DeathBeforeBirth",
+			actor: model.Actor{
+				Name:      "name",
+				BirthDate: time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC),
+				DeathDate: time.Date(1940, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			createFunc: func(actor *model.Actor) error {
+				return &service.ValidationError{Fields: []service.FieldError{
+					{Field: "DeathDate", Rule: service.RuleRange, Message: "must not be before the birth date nor in the future"},
+				}}
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, tc := range tests {
@@ -97,6 +151,29 @@ func TestActorHandler_Create(t *testing.T) {
 	}
 }
 
+func TestActorHandler_GetByID(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+	actorService := &mockActorService{
+		GetByIDFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+			return &model.Actor{ID: actorID, Name: "Al Pacino", Version: 3}, nil
+		},
+	}
+	actorHandler := NewActorHandler(actorService)
+
+	req := httptest.NewRequest(http.MethodGet, "/actors/getByID?actor_id="+actorID.String(), nil)
+	recorder := httptest.NewRecorder()
+	actorHandler.GetByID(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if etag := recorder.Header().Get("ETag"); etag != `"3"` {
+		t.Errorf("Expected ETag %q, got %q", `"3"`, etag)
+	}
+}
+
 func TestActorHandler_Update(t *testing.T) {
 	t.Parallel()
 
@@ -104,6 +181,7 @@ func TestActorHandler_Update(t *testing.T) {
 		name               string
 		actorID            uuid.UUID
 		updatedActor       model.Actor
+		ifMatch            string
 		updateFunc         func(actorID uuid.UUID, updatedActor *model.Actor) error
 		expectedStatusCode int
 	}{
@@ -119,6 +197,21 @@ func TestActorHandler_Update(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:    "VersionMismatch",
+			actorID: uuid.New(),
+			updatedActor: model.Actor{
+				Name: "updatedName",
+			},
+			ifMatch: `"1"`,
+			updateFunc: func(actorID uuid.UUID, updatedActor *model.Actor) error {
+				if updatedActor.Version != 1 {
+					return errors.New("unexpected version")
+				}
+				return service.ErrVersionMismatch
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
 		{
 			name:    "ServiceError",
 			actorID: uuid.New(),
@@ -150,6 +243,9 @@ func TestActorHandler_Update(t *testing.T) {
 				t.Fatal(err)
 			}
 			req.Header.Set("Content-Type", "application/json")
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			recorder := httptest.NewRecorder()
 			actorHandler.Update(recorder, req)
@@ -167,21 +263,72 @@ func TestActorHandler_Delete(t *testing.T) {
 	tests := []struct {
 		name               string
 		actorID            uuid.UUID
-		deleteFunc         func(actorID uuid.UUID) error
+		policy             string
+		ifMatch            string
+		deleteFunc         func(actorID uuid.UUID, version int, policy string) error
 		expectedStatusCode int
 	}{
 		{
 			name:    "Success",
 			actorID: uuid.New(),
-			deleteFunc: func(actorID uuid.UUID) error {
+			deleteFunc: func(actorID uuid.UUID, version int, policy string) error {
 				return nil
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:    "ActorInUse",
+			actorID: uuid.New(),
+			deleteFunc: func(actorID uuid.UUID, version int, policy string) error {
+				return &service.ActorInUseError{Movies: []*model.Movie{{ID: uuid.New(), Title: "Cast Away"}}}
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:    "Detach",
+			actorID: uuid.New(),
+			policy:  "detach",
+			deleteFunc: func(actorID uuid.UUID, version int, policy string) error {
+				if policy != model.ActorDeleteDetach {
+					return service.ErrInvalidDeletePolicy
+				}
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "InvalidPolicy",
+			actorID: uuid.New(),
+			policy:  "cascade",
+			deleteFunc: func(actorID uuid.UUID, version int, policy string) error {
+				return service.ErrInvalidDeletePolicy
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "ActorNotFound",
+			actorID: uuid.New(),
+			deleteFunc: func(actorID uuid.UUID, version int, policy string) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "VersionMismatch",
+			actorID: uuid.New(),
+			ifMatch: `"2"`,
+			deleteFunc: func(actorID uuid.UUID, version int, policy string) error {
+				if version != 2 {
+					return errors.New("unexpected version")
+				}
+				return service.ErrVersionMismatch
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
 		{
 			name:    "ServiceError",
 			actorID: uuid.New(),
-			deleteFunc: func(actorID uuid.UUID) error {
+			deleteFunc: func(actorID uuid.UUID, version int, policy string) error {
 				return errors.New("service error")
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -195,10 +342,13 @@ func TestActorHandler_Delete(t *testing.T) {
 			}
 			actorHandler := NewActorHandler(actorService)
 
-			req, err := http.NewRequest(http.MethodDelete, "/actors/delete?actor_id="+tc.actorID.String(), nil)
+			req, err := http.NewRequest(http.MethodDelete, "/actors/delete?actor_id="+tc.actorID.String()+"&policy="+tc.policy, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			recorder := httptest.NewRecorder()
 			actorHandler.Delete(recorder, req)
@@ -279,3 +429,332 @@ func TestActorHandler_GetAllWithMovies(t *testing.T) {
 		})
 	}
 }
+
+func TestActorHandler_GetAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		query              string
+		getAllFunc         func(query model.ActorQuery, page, pageSize int) (*model.ActorPage, error)
+		expectedStatusCode int
+	}{
+		{
+			name:  "Success",
+			query: "?name=keanu&gender=male&min_birth_year=1960&sort=film_count&order=desc&include_movies=true&page=2",
+			getAllFunc: func(query model.ActorQuery, page, pageSize int) (*model.ActorPage, error) {
+				expected := model.ActorQuery{
+					Name:          "keanu",
+					Gender:        "male",
+					MinBirthYear:  1960,
+					Sort:          model.ActorSortFilmCount,
+					Descending:    true,
+					IncludeMovies: true,
+				}
+				if query != expected || page != 2 || pageSize != 0 {
+					return nil, errors.New("unexpected query")
+				}
+				return &model.ActorPage{Actors: []*model.ActorMovies{{Name: "Keanu Reeves"}}, Page: page}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidOrder",
+			query:              "?order=up",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "InvalidBirthYear",
+			query:              "?max_birth_year=nineteen",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "InvalidSort",
+			query: "?sort=age",
+			getAllFunc: func(query model.ActorQuery, page, pageSize int) (*model.ActorPage, error) {
+				return nil, service.ErrInvalidActorSort
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "ServiceError",
+			getAllFunc: func(query model.ActorQuery, page, pageSize int) (*model.ActorPage, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actorService := &mockActorService{
+				GetAllFunc: tc.getAllFunc,
+			}
+			actorHandler := NewActorHandler(actorService)
+
+			req, err := http.NewRequest(http.MethodGet, "/actors"+tc.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recorder := httptest.NewRecorder()
+			actorHandler.GetAll(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestActorHandler_GetFilmography(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		actorID            string
+		getFilmographyFunc func(actorID uuid.UUID) (*model.Filmography, error)
+		expectedStatusCode int
+	}{
+		{
+			name:    "Success",
+			actorID: uuid.New().String(),
+			getFilmographyFunc: func(actorID uuid.UUID) (*model.Filmography, error) {
+				return &model.Filmography{
+					Person: model.Person{ID: actorID, Name: "Clint Eastwood"},
+					Credits: map[string][]*model.FilmographyMovie{
+						model.RoleDirector: {{Movie: model.Movie{Title: "Gran Torino"}}},
+					},
+				}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidActorID",
+			actorID:            "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "NotFound",
+			actorID: uuid.New().String(),
+			getFilmographyFunc: func(actorID uuid.UUID) (*model.Filmography, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "ServiceError",
+			actorID: uuid.New().String(),
+			getFilmographyFunc: func(actorID uuid.UUID) (*model.Filmography, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actorService := &mockActorService{
+				GetFilmographyFunc: tc.getFilmographyFunc,
+			}
+			actorHandler := NewActorHandler(actorService)
+
+			req, err := http.NewRequest(http.MethodGet, "/actors/getFilmography?actor_id="+tc.actorID, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recorder := httptest.NewRecorder()
+			actorHandler.GetFilmography(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestActorHandler_GetCollaborators(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		path                 string
+		getCollaboratorsFunc func(actorID uuid.UUID, limit int) ([]model.Collaborator, error)
+		expectedStatusCode   int
+	}{
+		{
+			name: "Success",
+			path: "/actors/" + uuid.New().String() + "/collaborators?limit=5",
+			getCollaboratorsFunc: func(actorID uuid.UUID, limit int) ([]model.Collaborator, error) {
+				if limit != 5 {
+					return nil, errors.New("unexpected limit")
+				}
+				return []model.Collaborator{{Name: "Robert De Niro", SharedMovies: 10}}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidActorID",
+			path:               "/actors/invalid/collaborators",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidLimit",
+			path: "/actors/" + uuid.New().String() + "/collaborators?limit=1000",
+			getCollaboratorsFunc: func(actorID uuid.UUID, limit int) ([]model.Collaborator, error) {
+				return nil, service.ErrInvalidCollaboratorsLimit
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "ActorNotFound",
+			path: "/actors/" + uuid.New().String() + "/collaborators",
+			getCollaboratorsFunc: func(actorID uuid.UUID, limit int) ([]model.Collaborator, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actorService := &mockActorService{
+				GetCollaboratorsFunc: tc.getCollaboratorsFunc,
+			}
+			actorHandler := NewActorHandler(actorService)
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /actors/{id}/collaborators", actorHandler.GetCollaborators)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestActorHandler_GetConnection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		targetID           string
+		getConnectionFunc  func(sourceID, targetID uuid.UUID) (*model.Connection, error)
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			targetID: uuid.New().String(),
+			getConnectionFunc: func(sourceID, targetID uuid.UUID) (*model.Connection, error) {
+				return &model.Connection{
+					Degrees: 1,
+					Steps: []model.ConnectionStep{
+						{ActorID: sourceID, ActorName: "Kevin Bacon"},
+						{ActorID: targetID, ActorName: "Tom Hanks", MovieTitle: "Apollo 13"},
+					},
+				}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidTargetID",
+			targetID:           "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "NotConnected",
+			targetID: uuid.New().String(),
+			getConnectionFunc: func(sourceID, targetID uuid.UUID) (*model.Connection, error) {
+				return nil, service.ErrNoConnection
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:     "ServiceError",
+			targetID: uuid.New().String(),
+			getConnectionFunc: func(sourceID, targetID uuid.UUID) (*model.Connection, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actorService := &mockActorService{
+				GetConnectionFunc: tc.getConnectionFunc,
+			}
+			actorHandler := NewActorHandler(actorService)
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /actors/{id}/connection/{targetId}", actorHandler.GetConnection)
+
+			req := httptest.NewRequest(http.MethodGet, "/actors/"+uuid.New().String()+"/connection/"+tc.targetID, nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestActorHandler_GetCareerTimeline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		actorID               string
+		getCareerTimelineFunc func(actorID uuid.UUID) (*model.CareerTimeline, error)
+		expectedStatusCode    int
+	}{
+		{
+			name:    "Success",
+			actorID: uuid.New().String(),
+			getCareerTimelineFunc: func(actorID uuid.UUID) (*model.CareerTimeline, error) {
+				return &model.CareerTimeline{
+					Person:      model.Person{ID: actorID, Name: "Clint Eastwood"},
+					TotalMovies: 1,
+					Years:       []model.CareerYear{{Year: 2008, Movies: 1, AverageRating: 8}},
+				}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidActorID",
+			actorID:            "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "NotFound",
+			actorID: uuid.New().String(),
+			getCareerTimelineFunc: func(actorID uuid.UUID) (*model.CareerTimeline, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actorService := &mockActorService{
+				GetCareerTimelineFunc: tc.getCareerTimelineFunc,
+			}
+			actorHandler := NewActorHandler(actorService)
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /actors/{id}/timeline", actorHandler.GetCareerTimeline)
+
+			req := httptest.NewRequest(http.MethodGet, "/actors/"+tc.actorID+"/timeline", nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
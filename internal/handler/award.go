@@ -0,0 +1,515 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// AwardHandler handles HTTP requests related to awards, their categories, ceremonies and nominations.
+type AwardHandler struct {
+	awardService service.AwardService
+}
+
+// NewAwardHandler creates a new AwardHandler instance.
+func NewAwardHandler(awardService service.AwardService) *AwardHandler {
+	return &AwardHandler{
+		awardService: awardService,
+	}
+}
+
+// Create handles the HTTP request to create an award.
+// @Summary Create an award
+// @Description Create an award without categories and ceremonies
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param award body model.Award true "Award object, Name and Description are read"
+// @Success 201 {object} model.Award "Award created"
+// @Failure 400 {string} string "Failed to decode request body or invalid award"
+// @Failure 409 {string} string "An award with this name already exists"
+// @Failure 500 {string} string "Failed to create award"
+// @Router /awards [post]
+func (ah *AwardHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Create Award request...")
+
+	var award model.Award
+	if err := json.NewDecoder(r.Body).Decode(&award); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ah.awardService.Create(&award); err != nil {
+		writeAwardError(w, err, "Award not found", "Failed to create award")
+		log.Printf("Failed to create award: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, award)
+
+	log.Printf("Create Award request handled successfully.")
+}
+
+// GetAll handles the HTTP request to retrieve all awards.
+// @Summary Get all awards
+// @Description Retrieve all awards ordered by name, without their categories and ceremonies
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.Award "Awards retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch awards"
+// @Router /awards [get]
+func (ah *AwardHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetAll Awards request...")
+
+	awards, err := ah.awardService.GetAll()
+	if err != nil {
+		http.Error(w, "Failed to fetch awards", http.StatusInternalServerError)
+		log.Printf("Failed to fetch awards: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, awards)
+
+	log.Printf("GetAll Awards request handled successfully.")
+}
+
+// GetByID handles the HTTP request to retrieve an award with its categories and ceremonies.
+// @Summary Get an award
+// @Description Retrieve an award with its categories and ceremonies, most recent ceremony first
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the award"
+// @Success 200 {object} model.Award "Award retrieved successfully"
+// @Failure 400 {string} string "Invalid award ID"
+// @Failure 404 {string} string "Award not found"
+// @Failure 500 {string} string "Failed to fetch award"
+// @Router /awards/{id} [get]
+func (ah *AwardHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetByID Award request...")
+
+	awardIDStr := r.PathValue("id")
+	awardID, err := uuid.Parse(awardIDStr)
+	if err != nil {
+		http.Error(w, "Invalid award ID", http.StatusBadRequest)
+		log.Printf("Invalid award ID: %s", awardIDStr)
+		return
+	}
+
+	award, err := ah.awardService.GetByID(awardID)
+	if err != nil {
+		writeAwardError(w, err, "Award not found", "Failed to fetch award")
+		log.Printf("Failed to fetch award: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, award)
+
+	log.Printf("GetByID Award request handled successfully.")
+}
+
+// Update handles the HTTP request to update an award.
+// @Summary Update an award
+// @Description Change the name and description of an award
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the award"
+// @Param award body model.Award true "Award object, Name and Description are read"
+// @Success 200 {string} string "Award updated"
+// @Failure 400 {string} string "Invalid award ID, failed to decode request body or invalid award"
+// @Failure 404 {string} string "Award not found"
+// @Failure 409 {string} string "An award with this name already exists"
+// @Failure 500 {string} string "Failed to update award"
+// @Router /awards/{id} [put]
+func (ah *AwardHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Update Award request...")
+
+	awardIDStr := r.PathValue("id")
+	awardID, err := uuid.Parse(awardIDStr)
+	if err != nil {
+		http.Error(w, "Invalid award ID", http.StatusBadRequest)
+		log.Printf("Invalid award ID: %s", awardIDStr)
+		return
+	}
+
+	var award model.Award
+	if err := json.NewDecoder(r.Body).Decode(&award); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ah.awardService.Update(awardID, award); err != nil {
+		writeAwardError(w, err, "Award not found", "Failed to update award")
+		log.Printf("Failed to update award: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Update Award request handled successfully.")
+}
+
+// Delete handles the HTTP request to delete an award.
+// @Summary Delete an award
+// @Description Delete an award along with its categories, ceremonies and nominations
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the award"
+// @Success 200 {string} string "Award deleted"
+// @Failure 400 {string} string "Invalid award ID"
+// @Failure 500 {string} string "Failed to delete award"
+// @Router /awards/{id} [delete]
+func (ah *AwardHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Delete Award request...")
+
+	awardIDStr := r.PathValue("id")
+	awardID, err := uuid.Parse(awardIDStr)
+	if err != nil {
+		http.Error(w, "Invalid award ID", http.StatusBadRequest)
+		log.Printf("Invalid award ID: %s", awardIDStr)
+		return
+	}
+
+	if err := ah.awardService.Delete(awardID); err != nil {
+		http.Error(w, "Failed to delete award", http.StatusInternalServerError)
+		log.Printf("Failed to delete award: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Delete Award request handled successfully.")
+}
+
+// CreateCategory handles the HTTP request to add a category to an award.
+// @Summary Add an award category
+// @Description Add a category to an award
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the award"
+// @Param category body model.AwardCategory true "Category object, only Name is read"
+// @Success 201 {object} model.AwardCategory "Category created"
+// @Failure 400 {string} string "Invalid award ID, failed to decode request body or invalid category"
+// @Failure 404 {string} string "Award not found"
+// @Failure 409 {string} string "The award already has a category with this name"
+// @Failure 500 {string} string "Failed to create category"
+// @Router /awards/{id}/categories [post]
+func (ah *AwardHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling CreateCategory Award request...")
+
+	awardIDStr := r.PathValue("id")
+	awardID, err := uuid.Parse(awardIDStr)
+	if err != nil {
+		http.Error(w, "Invalid award ID", http.StatusBadRequest)
+		log.Printf("Invalid award ID: %s", awardIDStr)
+		return
+	}
+
+	var category model.AwardCategory
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ah.awardService.CreateCategory(awardID, &category); err != nil {
+		writeAwardError(w, err, "Award not found", "Failed to create category")
+		log.Printf("Failed to create category: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, category)
+
+	log.Printf("CreateCategory Award request handled successfully.")
+}
+
+// DeleteCategory handles the HTTP request to delete an award category.
+// @Summary Delete an award category
+// @Description Delete an award category along with its nominations
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the category"
+// @Success 200 {string} string "Category deleted"
+// @Failure 400 {string} string "Invalid category ID"
+// @Failure 500 {string} string "Failed to delete category"
+// @Router /award-categories/{id} [delete]
+func (ah *AwardHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling DeleteCategory Award request...")
+
+	categoryIDStr := r.PathValue("id")
+	categoryID, err := uuid.Parse(categoryIDStr)
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		log.Printf("Invalid category ID: %s", categoryIDStr)
+		return
+	}
+
+	if err := ah.awardService.DeleteCategory(categoryID); err != nil {
+		http.Error(w, "Failed to delete category", http.StatusInternalServerError)
+		log.Printf("Failed to delete category: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("DeleteCategory Award request handled successfully.")
+}
+
+// CreateCeremony handles the HTTP request to add a ceremony to an award.
+// @Summary Add a ceremony
+// @Description Add the ceremony of a year to an award
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the award"
+// @Param ceremony body model.Ceremony true "Ceremony object, Year and Date are read"
+// @Success 201 {object} model.Ceremony "Ceremony created"
+// @Failure 400 {string} string "Invalid award ID, failed to decode request body or invalid ceremony"
+// @Failure 404 {string} string "Award not found"
+// @Failure 409 {string} string "The award already has a ceremony this year"
+// @Failure 500 {string} string "Failed to create ceremony"
+// @Router /awards/{id}/ceremonies [post]
+func (ah *AwardHandler) CreateCeremony(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling CreateCeremony Award request...")
+
+	awardIDStr := r.PathValue("id")
+	awardID, err := uuid.Parse(awardIDStr)
+	if err != nil {
+		http.Error(w, "Invalid award ID", http.StatusBadRequest)
+		log.Printf("Invalid award ID: %s", awardIDStr)
+		return
+	}
+
+	var ceremony model.Ceremony
+	if err := json.NewDecoder(r.Body).Decode(&ceremony); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ah.awardService.CreateCeremony(awardID, &ceremony); err != nil {
+		writeAwardError(w, err, "Award not found", "Failed to create ceremony")
+		log.Printf("Failed to create ceremony: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, ceremony)
+
+	log.Printf("CreateCeremony Award request handled successfully.")
+}
+
+// GetCeremonyByID handles the HTTP request to retrieve a ceremony with its nominations.
+// @Summary Get a ceremony
+// @Description Retrieve a ceremony with its nominations ordered by category, winners first
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the ceremony"
+// @Success 200 {object} model.Ceremony "Ceremony retrieved successfully"
+// @Failure 400 {string} string "Invalid ceremony ID"
+// @Failure 404 {string} string "Ceremony not found"
+// @Failure 500 {string} string "Failed to fetch ceremony"
+// @Router /ceremonies/{id} [get]
+func (ah *AwardHandler) GetCeremonyByID(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetCeremonyByID Award request...")
+
+	ceremonyIDStr := r.PathValue("id")
+	ceremonyID, err := uuid.Parse(ceremonyIDStr)
+	if err != nil {
+		http.Error(w, "Invalid ceremony ID", http.StatusBadRequest)
+		log.Printf("Invalid ceremony ID: %s", ceremonyIDStr)
+		return
+	}
+
+	ceremony, err := ah.awardService.GetCeremonyByID(ceremonyID)
+	if err != nil {
+		writeAwardError(w, err, "Ceremony not found", "Failed to fetch ceremony")
+		log.Printf("Failed to fetch ceremony: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ceremony)
+
+	log.Printf("GetCeremonyByID Award request handled successfully.")
+}
+
+// DeleteCeremony handles the HTTP request to delete a ceremony.
+// @Summary Delete a ceremony
+// @Description Delete a ceremony along with its nominations
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the ceremony"
+// @Success 200 {string} string "Ceremony deleted"
+// @Failure 400 {string} string "Invalid ceremony ID"
+// @Failure 500 {string} string "Failed to delete ceremony"
+// @Router /ceremonies/{id} [delete]
+func (ah *AwardHandler) DeleteCeremony(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling DeleteCeremony Award request...")
+
+	ceremonyIDStr := r.PathValue("id")
+	ceremonyID, err := uuid.Parse(ceremonyIDStr)
+	if err != nil {
+		http.Error(w, "Invalid ceremony ID", http.StatusBadRequest)
+		log.Printf("Invalid ceremony ID: %s", ceremonyIDStr)
+		return
+	}
+
+	if err := ah.awardService.DeleteCeremony(ceremonyID); err != nil {
+		http.Error(w, "Failed to delete ceremony", http.StatusInternalServerError)
+		log.Printf("Failed to delete ceremony: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("DeleteCeremony Award request handled successfully.")
+}
+
+// CreateNomination handles the HTTP request to add a nomination to a ceremony.
+// @Summary Add a nomination
+// @Description Nominate a movie, or a person for their work on a movie, in a category of a ceremony
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the ceremony"
+// @Param nomination body model.Nomination true "Nomination object, CategoryID, MovieID, PersonID, Won and Note are read"
+// @Success 201 {object} model.Nomination "Nomination created"
+// @Failure 400 {string} string "Invalid ceremony ID, failed to decode request body or invalid nomination"
+// @Failure 404 {string} string "Ceremony, category, movie or person not found"
+// @Failure 500 {string} string "Failed to create nomination"
+// @Router /ceremonies/{id}/nominations [post]
+func (ah *AwardHandler) CreateNomination(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling CreateNomination Award request...")
+
+	ceremonyIDStr := r.PathValue("id")
+	ceremonyID, err := uuid.Parse(ceremonyIDStr)
+	if err != nil {
+		http.Error(w, "Invalid ceremony ID", http.StatusBadRequest)
+		log.Printf("Invalid ceremony ID: %s", ceremonyIDStr)
+		return
+	}
+
+	var nomination model.Nomination
+	if err := json.NewDecoder(r.Body).Decode(&nomination); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ah.awardService.CreateNomination(ceremonyID, &nomination); err != nil {
+		writeAwardError(w, err, "Ceremony, category, movie or person not found", "Failed to create nomination")
+		log.Printf("Failed to create nomination: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, nomination)
+
+	log.Printf("CreateNomination Award request handled successfully.")
+}
+
+// UpdateNomination handles the HTTP request to update a nomination.
+// @Summary Update a nomination
+// @Description Change a nomination; the category, movie and person are changed when given, Won and Note are always replaced
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the nomination"
+// @Param nomination body model.Nomination true "Nomination object, CategoryID, MovieID, PersonID, Won and Note are read"
+// @Success 200 {object} model.Nomination "Nomination updated"
+// @Failure 400 {string} string "Invalid nomination ID, failed to decode request body or invalid nomination"
+// @Failure 404 {string} string "Nomination, category, movie or person not found"
+// @Failure 500 {string} string "Failed to update nomination"
+// @Router /nominations/{id} [put]
+func (ah *AwardHandler) UpdateNomination(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling UpdateNomination Award request...")
+
+	nominationIDStr := r.PathValue("id")
+	nominationID, err := uuid.Parse(nominationIDStr)
+	if err != nil {
+		http.Error(w, "Invalid nomination ID", http.StatusBadRequest)
+		log.Printf("Invalid nomination ID: %s", nominationIDStr)
+		return
+	}
+
+	var nomination model.Nomination
+	if err := json.NewDecoder(r.Body).Decode(&nomination); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	updated, err := ah.awardService.UpdateNomination(nominationID, nomination)
+	if err != nil {
+		writeAwardError(w, err, "Nomination, category, movie or person not found", "Failed to update nomination")
+		log.Printf("Failed to update nomination: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+
+	log.Printf("UpdateNomination Award request handled successfully.")
+}
+
+// DeleteNomination handles the HTTP request to delete a nomination.
+// @Summary Delete a nomination
+// @Description Delete a nomination
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the nomination"
+// @Success 200 {string} string "Nomination deleted"
+// @Failure 400 {string} string "Invalid nomination ID"
+// @Failure 500 {string} string "Failed to delete nomination"
+// @Router /nominations/{id} [delete]
+func (ah *AwardHandler) DeleteNomination(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling DeleteNomination Award request...")
+
+	nominationIDStr := r.PathValue("id")
+	nominationID, err := uuid.Parse(nominationIDStr)
+	if err != nil {
+		http.Error(w, "Invalid nomination ID", http.StatusBadRequest)
+		log.Printf("Invalid nomination ID: %s", nominationIDStr)
+		return
+	}
+
+	if err := ah.awardService.DeleteNomination(nominationID); err != nil {
+		http.Error(w, "Failed to delete nomination", http.StatusInternalServerError)
+		log.Printf("Failed to delete nomination: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("DeleteNomination Award request handled successfully.")
+}
+
+// writeAwardError maps the errors of the award service to HTTP responses.
+func writeAwardError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidAward), errors.Is(err, service.ErrInvalidAwardCategory),
+		errors.Is(err, service.ErrInvalidCeremony), errors.Is(err, service.ErrInvalidNomination):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrAwardExists), errors.Is(err, service.ErrAwardCategoryExists),
+		errors.Is(err, service.ErrCeremonyExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockAwardService struct {
+	CreateFunc           func(award *model.Award) error
+	GetAllFunc           func() ([]*model.Award, error)
+	GetByIDFunc          func(awardID uuid.UUID) (*model.Award, error)
+	UpdateFunc           func(awardID uuid.UUID, award model.Award) error
+	DeleteFunc           func(awardID uuid.UUID) error
+	CreateCategoryFunc   func(awardID uuid.UUID, category *model.AwardCategory) error
+	DeleteCategoryFunc   func(categoryID uuid.UUID) error
+	CreateCeremonyFunc   func(awardID uuid.UUID, ceremony *model.Ceremony) error
+	GetCeremonyByIDFunc  func(ceremonyID uuid.UUID) (*model.Ceremony, error)
+	DeleteCeremonyFunc   func(ceremonyID uuid.UUID) error
+	CreateNominationFunc func(ceremonyID uuid.UUID, nomination *model.Nomination) error
+	UpdateNominationFunc func(nominationID uuid.UUID, nomination model.Nomination) (*model.Nomination, error)
+	DeleteNominationFunc func(nominationID uuid.UUID) error
+}
+
+func (m *mockAwardService) Create(award *model.Award) error {
+	return m.CreateFunc(award)
+}
+
+func (m *mockAwardService) GetAll() ([]*model.Award, error) {
+	return m.GetAllFunc()
+}
+
+func (m *mockAwardService) GetByID(awardID uuid.UUID) (*model.Award, error) {
+	return m.GetByIDFunc(awardID)
+}
+
+func (m *mockAwardService) Update(awardID uuid.UUID, award model.Award) error {
+	return m.UpdateFunc(awardID, award)
+}
+
+func (m *mockAwardService) Delete(awardID uuid.UUID) error {
+	return m.DeleteFunc(awardID)
+}
+
+func (m *mockAwardService) CreateCategory(awardID uuid.UUID, category *model.AwardCategory) error {
+	return m.CreateCategoryFunc(awardID, category)
+}
+
+func (m *mockAwardService) DeleteCategory(categoryID uuid.UUID) error {
+	return m.DeleteCategoryFunc(categoryID)
+}
+
+func (m *mockAwardService) CreateCeremony(awardID uuid.UUID, ceremony *model.Ceremony) error {
+	return m.CreateCeremonyFunc(awardID, ceremony)
+}
+
+func (m *mockAwardService) GetCeremonyByID(ceremonyID uuid.UUID) (*model.Ceremony, error) {
+	return m.GetCeremonyByIDFunc(ceremonyID)
+}
+
+func (m *mockAwardService) DeleteCeremony(ceremonyID uuid.UUID) error {
+	return m.DeleteCeremonyFunc(ceremonyID)
+}
+
+func (m *mockAwardService) CreateNomination(ceremonyID uuid.UUID, nomination *model.Nomination) error {
+	return m.CreateNominationFunc(ceremonyID, nomination)
+}
+
+func (m *mockAwardService) UpdateNomination(nominationID uuid.UUID, nomination model.Nomination) (*model.Nomination, error) {
+	return m.UpdateNominationFunc(nominationID, nomination)
+}
+
+func (m *mockAwardService) DeleteNomination(nominationID uuid.UUID) error {
+	return m.DeleteNominationFunc(nominationID)
+}
+
+func TestAwardHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		body               string
+		createFunc         func(award *model.Award) error
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			body: `{"Name": "Academy Awards"}`,
+			createFunc: func(award *model.Award) error {
+				award.ID = uuid.New()
+				return nil
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "InvalidBody",
+			body:               `{"Name": 42}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidAward",
+			body: `{"Name": ""}`,
+			createFunc: func(award *model.Award) error {
+				return service.ErrInvalidAward
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "DuplicateName",
+			body: `{"Name": "academy awards"}`,
+			createFunc: func(award *model.Award) error {
+				return service.ErrAwardExists
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			awardHandler := NewAwardHandler(&mockAwardService{CreateFunc: tc.createFunc})
+
+			req := httptest.NewRequest(http.MethodPost, "/awards", bytes.NewBufferString(tc.body))
+			recorder := httptest.NewRecorder()
+			awardHandler.Create(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestAwardHandler_CreateNomination(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		ceremonyID           string
+		body                 string
+		createNominationFunc func(ceremonyID uuid.UUID, nomination *model.Nomination) error
+		expectedStatusCode   int
+	}{
+		{
+			name:       "Success",
+			ceremonyID: uuid.New().String(),
+			body:       `{"CategoryID": "` + uuid.New().String() + `", "MovieID": "` + uuid.New().String() + `", "Won": true}`,
+			createNominationFunc: func(ceremonyID uuid.UUID, nomination *model.Nomination) error {
+				nomination.ID = uuid.New()
+				return nil
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "InvalidCeremonyID",
+			ceremonyID:         "invalid",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "CategoryOfAnotherAward",
+			ceremonyID: uuid.New().String(),
+			body:       `{"CategoryID": "` + uuid.New().String() + `", "MovieID": "` + uuid.New().String() + `"}`,
+			createNominationFunc: func(ceremonyID uuid.UUID, nomination *model.Nomination) error {
+				return service.ErrInvalidNomination
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "MovieNotFound",
+			ceremonyID: uuid.New().String(),
+			body:       `{"CategoryID": "` + uuid.New().String() + `", "MovieID": "` + uuid.New().String() + `"}`,
+			createNominationFunc: func(ceremonyID uuid.UUID, nomination *model.Nomination) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			awardHandler := NewAwardHandler(&mockAwardService{CreateNominationFunc: tc.createNominationFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /ceremonies/{id}/nominations", awardHandler.CreateNomination)
+
+			req := httptest.NewRequest(http.MethodPost, "/ceremonies/"+tc.ceremonyID+"/nominations", bytes.NewBufferString(tc.body))
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
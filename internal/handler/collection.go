@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// CollectionHandler handles HTTP requests related to franchises and other collections of movies.
+type CollectionHandler struct {
+	collectionService service.CollectionService
+}
+
+// NewCollectionHandler creates a new CollectionHandler instance.
+func NewCollectionHandler(collectionService service.CollectionService) *CollectionHandler {
+	return &CollectionHandler{
+		collectionService: collectionService,
+	}
+}
+
+// Create handles the HTTP request to create a collection.
+// @Summary Create a collection
+// @Description Create a franchise or shared universe grouping movies
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param collection body model.Collection true "Collection object, Name, Description and Ordering (release or chronological) are read"
+// @Success 201 {object} model.Collection "Collection created"
+// @Failure 400 {string} string "Failed to decode request body or invalid collection"
+// @Failure 500 {string} string "Failed to create collection"
+// @Router /collections [post]
+func (ch *CollectionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Create Collection request...")
+
+	var collection model.Collection
+	if err := json.NewDecoder(r.Body).Decode(&collection); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ch.collectionService.Create(&collection); err != nil {
+		writeCollectionError(w, err, "Collection not found", "Failed to create collection")
+		log.Printf("Failed to create collection: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, collection)
+
+	log.Printf("Create Collection request handled successfully.")
+}
+
+// GetByID handles the HTTP request to retrieve a collection with its movies.
+// @Summary Get a collection
+// @Description Retrieve a collection with its movies ordered by release or chronology
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the collection"
+// @Param order query string false "Ordering of the movies: release or chronological, defaults to the ordering of the collection"
+// @Success 200 {object} model.Collection "Collection retrieved successfully"
+// @Failure 400 {string} string "Invalid collection ID or ordering"
+// @Failure 404 {string} string "Collection not found"
+// @Failure 500 {string} string "Failed to fetch collection"
+// @Router /collections/{id} [get]
+func (ch *CollectionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetByID Collection request...")
+
+	collectionIDStr := r.PathValue("id")
+	collectionID, err := uuid.Parse(collectionIDStr)
+	if err != nil {
+		http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+		log.Printf("Invalid collection ID: %s", collectionIDStr)
+		return
+	}
+
+	collection, err := ch.collectionService.GetByID(collectionID, r.URL.Query().Get("order"))
+	if err != nil {
+		writeCollectionError(w, err, "Collection not found", "Failed to fetch collection")
+		log.Printf("Failed to fetch collection: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, collection)
+
+	log.Printf("GetByID Collection request handled successfully.")
+}
+
+// GetAll handles the HTTP request to retrieve all collections.
+// @Summary Get collections
+// @Description Retrieve all collections ordered by name, without their movies
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.Collection "Collections retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch collections"
+// @Router /collections [get]
+func (ch *CollectionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetAll Collections request...")
+
+	collections, err := ch.collectionService.GetAll()
+	if err != nil {
+		http.Error(w, "Failed to fetch collections", http.StatusInternalServerError)
+		log.Printf("Failed to fetch collections: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, collections)
+
+	log.Printf("GetAll Collections request handled successfully.")
+}
+
+// Update handles the HTTP request to update a collection.
+// @Summary Update a collection
+// @Description Change the name, description and default ordering of a collection
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the collection"
+// @Param collection body model.Collection true "Collection object, Name, Description and Ordering are read"
+// @Success 200 {string} string "Collection updated"
+// @Failure 400 {string} string "Invalid collection ID, failed to decode request body or invalid collection"
+// @Failure 404 {string} string "Collection not found"
+// @Failure 500 {string} string "Failed to update collection"
+// @Router /collections/{id} [put]
+func (ch *CollectionHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Update Collection request...")
+
+	collectionIDStr := r.PathValue("id")
+	collectionID, err := uuid.Parse(collectionIDStr)
+	if err != nil {
+		http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+		log.Printf("Invalid collection ID: %s", collectionIDStr)
+		return
+	}
+
+	var collection model.Collection
+	if err := json.NewDecoder(r.Body).Decode(&collection); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ch.collectionService.Update(collectionID, collection); err != nil {
+		writeCollectionError(w, err, "Collection not found", "Failed to update collection")
+		log.Printf("Failed to update collection: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Update Collection request handled successfully.")
+}
+
+// Delete handles the HTTP request to delete a collection.
+// @Summary Delete a collection
+// @Description Delete a collection, its movies are kept
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the collection"
+// @Success 200 {string} string "Collection deleted"
+// @Failure 400 {string} string "Invalid collection ID"
+// @Failure 500 {string} string "Failed to delete collection"
+// @Router /collections/{id} [delete]
+func (ch *CollectionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Delete Collection request...")
+
+	collectionIDStr := r.PathValue("id")
+	collectionID, err := uuid.Parse(collectionIDStr)
+	if err != nil {
+		http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+		log.Printf("Invalid collection ID: %s", collectionIDStr)
+		return
+	}
+
+	if err := ch.collectionService.Delete(collectionID); err != nil {
+		http.Error(w, "Failed to delete collection", http.StatusInternalServerError)
+		log.Printf("Failed to delete collection: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Delete Collection request handled successfully.")
+}
+
+// SetMovie handles the HTTP request to add a movie to a collection.
+// @Summary Add a movie to a collection
+// @Description Add a movie to a collection or change its chronological order, moving it out of any other collection
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the collection"
+// @Param movieId path string true "ID of the movie"
+// @Param movie body model.CollectionMovie false "Collection movie, only ChronologicalOrder is read, the movie is placed last when omitted"
+// @Success 200 {object} model.CollectionMovie "Movie added to the collection"
+// @Failure 400 {string} string "Invalid collection or movie ID, failed to decode request body or invalid chronological order"
+// @Failure 404 {string} string "Collection or movie not found"
+// @Failure 500 {string} string "Failed to add movie to collection"
+// @Router /collections/{id}/movies/{movieId} [put]
+func (ch *CollectionHandler) SetMovie(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling SetMovie Collection request...")
+
+	collectionIDStr := r.PathValue("id")
+	collectionID, err := uuid.Parse(collectionIDStr)
+	if err != nil {
+		http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+		log.Printf("Invalid collection ID: %s", collectionIDStr)
+		return
+	}
+
+	movieIDStr := r.PathValue("movieId")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	var movie model.CollectionMovie
+	if err := json.NewDecoder(r.Body).Decode(&movie); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+	movie.MovieID = movieID
+
+	if err := ch.collectionService.SetMovie(collectionID, &movie); err != nil {
+		writeCollectionError(w, err, "Collection or movie not found", "Failed to add movie to collection")
+		log.Printf("Failed to add movie to collection: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, movie)
+
+	log.Printf("SetMovie Collection request handled successfully.")
+}
+
+// RemoveMovie handles the HTTP request to remove a movie from a collection.
+// @Summary Remove a movie from a collection
+// @Description Remove a movie from a collection
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the collection"
+// @Param movieId path string true "ID of the movie"
+// @Success 200 {string} string "Movie removed from the collection"
+// @Failure 400 {string} string "Invalid collection or movie ID"
+// @Failure 404 {string} string "Movie does not belong to the collection"
+// @Failure 500 {string} string "Failed to remove movie from collection"
+// @Router /collections/{id}/movies/{movieId} [delete]
+func (ch *CollectionHandler) RemoveMovie(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling RemoveMovie Collection request...")
+
+	collectionIDStr := r.PathValue("id")
+	collectionID, err := uuid.Parse(collectionIDStr)
+	if err != nil {
+		http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+		log.Printf("Invalid collection ID: %s", collectionIDStr)
+		return
+	}
+
+	movieIDStr := r.PathValue("movieId")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	if err := ch.collectionService.RemoveMovie(collectionID, movieID); err != nil {
+		writeCollectionError(w, err, "Movie does not belong to the collection", "Failed to remove movie from collection")
+		log.Printf("Failed to remove movie from collection: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("RemoveMovie Collection request handled successfully.")
+}
+
+// writeCollectionError maps the errors of the collection service to HTTP responses.
+func writeCollectionError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCollection), errors.Is(err, service.ErrInvalidCollectionOrdering),
+		errors.Is(err, service.ErrInvalidChronologicalOrder):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockCollectionService struct {
+	CreateFunc      func(collection *model.Collection) error
+	GetByIDFunc     func(collectionID uuid.UUID, ordering string) (*model.Collection, error)
+	GetAllFunc      func() ([]*model.Collection, error)
+	UpdateFunc      func(collectionID uuid.UUID, collection model.Collection) error
+	DeleteFunc      func(collectionID uuid.UUID) error
+	SetMovieFunc    func(collectionID uuid.UUID, movie *model.CollectionMovie) error
+	RemoveMovieFunc func(collectionID, movieID uuid.UUID) error
+}
+
+func (m *mockCollectionService) Create(collection *model.Collection) error {
+	return m.CreateFunc(collection)
+}
+
+func (m *mockCollectionService) GetByID(collectionID uuid.UUID, ordering string) (*model.Collection, error) {
+	return m.GetByIDFunc(collectionID, ordering)
+}
+
+func (m *mockCollectionService) GetAll() ([]*model.Collection, error) {
+	return m.GetAllFunc()
+}
+
+func (m *mockCollectionService) Update(collectionID uuid.UUID, collection model.Collection) error {
+	return m.UpdateFunc(collectionID, collection)
+}
+
+func (m *mockCollectionService) Delete(collectionID uuid.UUID) error {
+	return m.DeleteFunc(collectionID)
+}
+
+func (m *mockCollectionService) SetMovie(collectionID uuid.UUID, movie *model.CollectionMovie) error {
+	return m.SetMovieFunc(collectionID, movie)
+}
+
+func (m *mockCollectionService) RemoveMovie(collectionID, movieID uuid.UUID) error {
+	return m.RemoveMovieFunc(collectionID, movieID)
+}
+
+func TestCollectionHandler_GetByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		url                string
+		getByIDFunc        func(collectionID uuid.UUID, ordering string) (*model.Collection, error)
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			url:  "/collections/" + uuid.New().String() + "?order=chronological",
+			getByIDFunc: func(collectionID uuid.UUID, ordering string) (*model.Collection, error) {
+				if ordering != model.CollectionOrderChronological {
+					return nil, service.ErrInvalidCollectionOrdering
+				}
+				return &model.Collection{ID: collectionID, Name: "Star Wars"}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidCollectionID",
+			url:                "/collections/invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidOrdering",
+			url:  "/collections/" + uuid.New().String() + "?order=alphabetical",
+			getByIDFunc: func(collectionID uuid.UUID, ordering string) (*model.Collection, error) {
+				return nil, service.ErrInvalidCollectionOrdering
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "NotFound",
+			url:  "/collections/" + uuid.New().String(),
+			getByIDFunc: func(collectionID uuid.UUID, ordering string) (*model.Collection, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			collectionHandler := NewCollectionHandler(&mockCollectionService{GetByIDFunc: tc.getByIDFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /collections/{id}", collectionHandler.GetByID)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestCollectionHandler_SetMovie(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		body               string
+		setMovieFunc       func(collectionID uuid.UUID, movie *model.CollectionMovie) error
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			body: `{"ChronologicalOrder": 2}`,
+			setMovieFunc: func(collectionID uuid.UUID, movie *model.CollectionMovie) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "SuccessWithoutBody",
+			setMovieFunc: func(collectionID uuid.UUID, movie *model.CollectionMovie) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "NegativeOrder",
+			body: `{"ChronologicalOrder": -1}`,
+			setMovieFunc: func(collectionID uuid.UUID, movie *model.CollectionMovie) error {
+				return service.ErrInvalidChronologicalOrder
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "MovieNotFound",
+			setMovieFunc: func(collectionID uuid.UUID, movie *model.CollectionMovie) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			collectionHandler := NewCollectionHandler(&mockCollectionService{SetMovieFunc: tc.setMovieFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /collections/{id}/movies/{movieId}", collectionHandler.SetMovie)
+
+			url := "/collections/" + uuid.New().String() + "/movies/" + uuid.New().String()
+			req := httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString(tc.body))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// CompanyHandler handles HTTP requests related to studios and production companies.
+type CompanyHandler struct {
+	companyService service.CompanyService
+}
+
+// NewCompanyHandler creates a new CompanyHandler instance.
+func NewCompanyHandler(companyService service.CompanyService) *CompanyHandler {
+	return &CompanyHandler{
+		companyService: companyService,
+	}
+}
+
+// Create handles the HTTP request to create a company.
+// @Summary Create a company
+// @Description Create a studio, production or distribution company
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param company body model.Company true "Company object, Name, Country (ISO 3166-1 alpha-2) and Description are read"
+// @Success 201 {object} model.Company "Company created"
+// @Failure 400 {string} string "Failed to decode request body"
+// @Failure 422 {object} service.ValidationError "Invalid company fields, e.g. a blank name or an unknown country code"
+// @Failure 500 {string} string "Failed to create company"
+// @Router /companies [post]
+func (ch *CompanyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Create Company request...")
+
+	var company model.Company
+	if err := json.NewDecoder(r.Body).Decode(&company); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ch.companyService.Create(&company); err != nil {
+		writeCompanyError(w, err, "Company not found", "Failed to create company")
+		log.Printf("Failed to create company: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, company)
+
+	log.Printf("Create Company request handled successfully.")
+}
+
+// GetByID handles the HTTP request to retrieve a company with its movies.
+// @Summary Get a company
+// @Description Retrieve a company with a page of the movies it worked on, most recent first
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the company"
+// @Param role query string false "Role of the company in the movies: production or distribution, any role when omitted"
+// @Param page query int false "Number of the page, starting at 1"
+// @Param page_size query int false "Number of movies of a page, 20 by default and 100 at most"
+// @Success 200 {object} model.CompanyPage "Company retrieved successfully"
+// @Failure 400 {string} string "Invalid company ID, role or page"
+// @Failure 404 {string} string "Company not found"
+// @Failure 500 {string} string "Failed to fetch company"
+// @Router /companies/{id} [get]
+func (ch *CompanyHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetByID Company request...")
+
+	companyIDStr := r.PathValue("id")
+	companyID, err := uuid.Parse(companyIDStr)
+	if err != nil {
+		http.Error(w, "Invalid company ID", http.StatusBadRequest)
+		log.Printf("Invalid company ID: %s", companyIDStr)
+		return
+	}
+
+	query := r.URL.Query()
+	var page, pageSize int
+	if pageStr := query.Get("page"); pageStr != "" {
+		if page, err = strconv.Atoi(pageStr); err != nil {
+			http.Error(w, "Invalid page", http.StatusBadRequest)
+			log.Printf("Invalid page: %s", pageStr)
+			return
+		}
+	}
+	if pageSizeStr := query.Get("page_size"); pageSizeStr != "" {
+		if pageSize, err = strconv.Atoi(pageSizeStr); err != nil {
+			http.Error(w, "Invalid page size", http.StatusBadRequest)
+			log.Printf("Invalid page size: %s", pageSizeStr)
+			return
+		}
+	}
+
+	company, err := ch.companyService.GetByID(companyID, query.Get("role"), page, pageSize)
+	if err != nil {
+		writeCompanyError(w, err, "Company not found", "Failed to fetch company")
+		log.Printf("Failed to fetch company: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, company)
+
+	log.Printf("GetByID Company request handled successfully.")
+}
+
+// GetAll handles the HTTP request to retrieve all companies.
+// @Summary Get companies
+// @Description Retrieve all companies ordered by name, without their movies
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.Company "Companies retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch companies"
+// @Router /companies [get]
+func (ch *CompanyHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetAll Companies request...")
+
+	companies, err := ch.companyService.GetAll()
+	if err != nil {
+		http.Error(w, "Failed to fetch companies", http.StatusInternalServerError)
+		log.Printf("Failed to fetch companies: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, companies)
+
+	log.Printf("GetAll Companies request handled successfully.")
+}
+
+// Update handles the HTTP request to update a company.
+// @Summary Update a company
+// @Description Change the name, country and description of a company
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the company"
+// @Param company body model.Company true "Company object, Name, Country and Description are read"
+// @Success 200 {string} string "Company updated"
+// @Failure 400 {string} string "Invalid company ID or failed to decode request body"
+// @Failure 422 {object} service.ValidationError "Invalid company fields, e.g. a blank name or an unknown country code"
+// @Failure 404 {string} string "Company not found"
+// @Failure 500 {string} string "Failed to update company"
+// @Router /companies/{id} [put]
+func (ch *CompanyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Update Company request...")
+
+	companyIDStr := r.PathValue("id")
+	companyID, err := uuid.Parse(companyIDStr)
+	if err != nil {
+		http.Error(w, "Invalid company ID", http.StatusBadRequest)
+		log.Printf("Invalid company ID: %s", companyIDStr)
+		return
+	}
+
+	var company model.Company
+	if err := json.NewDecoder(r.Body).Decode(&company); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := ch.companyService.Update(companyID, company); err != nil {
+		writeCompanyError(w, err, "Company not found", "Failed to update company")
+		log.Printf("Failed to update company: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Update Company request handled successfully.")
+}
+
+// Delete handles the HTTP request to delete a company.
+// @Summary Delete a company
+// @Description Delete a company, its movies are kept
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the company"
+// @Success 200 {string} string "Company deleted"
+// @Failure 400 {string} string "Invalid company ID"
+// @Failure 500 {string} string "Failed to delete company"
+// @Router /companies/{id} [delete]
+func (ch *CompanyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Delete Company request...")
+
+	companyIDStr := r.PathValue("id")
+	companyID, err := uuid.Parse(companyIDStr)
+	if err != nil {
+		http.Error(w, "Invalid company ID", http.StatusBadRequest)
+		log.Printf("Invalid company ID: %s", companyIDStr)
+		return
+	}
+
+	if err := ch.companyService.Delete(companyID); err != nil {
+		http.Error(w, "Failed to delete company", http.StatusInternalServerError)
+		log.Printf("Failed to delete company: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Delete Company request handled successfully.")
+}
+
+// writeCompanyError maps the errors of the company service to HTTP responses.
+func writeCompanyError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	if writeValidationError(w, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrInvalidCompanyRole), errors.Is(err, service.ErrInvalidPage):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
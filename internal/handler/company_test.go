@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockCompanyService struct {
+	CreateFunc  func(company *model.Company) error
+	GetByIDFunc func(companyID uuid.UUID, role string, page, pageSize int) (*model.CompanyPage, error)
+	GetAllFunc  func() ([]*model.Company, error)
+	UpdateFunc  func(companyID uuid.UUID, company model.Company) error
+	DeleteFunc  func(companyID uuid.UUID) error
+}
+
+func (m *mockCompanyService) Create(company *model.Company) error {
+	return m.CreateFunc(company)
+}
+
+func (m *mockCompanyService) GetByID(companyID uuid.UUID, role string, page, pageSize int) (*model.CompanyPage, error) {
+	return m.GetByIDFunc(companyID, role, page, pageSize)
+}
+
+func (m *mockCompanyService) GetAll() ([]*model.Company, error) {
+	return m.GetAllFunc()
+}
+
+func (m *mockCompanyService) Update(companyID uuid.UUID, company model.Company) error {
+	return m.UpdateFunc(companyID, company)
+}
+
+func (m *mockCompanyService) Delete(companyID uuid.UUID) error {
+	return m.DeleteFunc(companyID)
+}
+
+func TestCompanyHandler_GetByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		companyID          string
+		query              string
+		getByIDFunc        func(companyID uuid.UUID, role string, page, pageSize int) (*model.CompanyPage, error)
+		expectedStatusCode int
+	}{
+		{
+			name:      "Success",
+			companyID: uuid.New().String(),
+			query:     "?role=production&page=2&page_size=10",
+			getByIDFunc: func(companyID uuid.UUID, role string, page, pageSize int) (*model.CompanyPage, error) {
+				if role != "production" || page != 2 || pageSize != 10 {
+					return nil, service.ErrInvalidPage
+				}
+				return &model.CompanyPage{Company: model.Company{ID: companyID, Name: "Pixar"}, Page: page, PageSize: pageSize}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidCompanyID",
+			companyID:          "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "InvalidPage",
+			companyID:          uuid.New().String(),
+			query:              "?page=first",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "InvalidRole",
+			companyID: uuid.New().String(),
+			query:     "?role=financing",
+			getByIDFunc: func(companyID uuid.UUID, role string, page, pageSize int) (*model.CompanyPage, error) {
+				return nil, service.ErrInvalidCompanyRole
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "CompanyNotFound",
+			companyID: uuid.New().String(),
+			getByIDFunc: func(companyID uuid.UUID, role string, page, pageSize int) (*model.CompanyPage, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			companyHandler := NewCompanyHandler(&mockCompanyService{GetByIDFunc: tc.getByIDFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /companies/{id}", companyHandler.GetByID)
+
+			req := httptest.NewRequest(http.MethodGet, "/companies/"+tc.companyID+tc.query, nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
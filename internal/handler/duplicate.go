@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// DuplicateHandler handles HTTP requests related to duplicate actors and movies.
+type DuplicateHandler struct {
+	duplicateService service.DuplicateService
+}
+
+// NewDuplicateHandler creates a new DuplicateHandler instance.
+func NewDuplicateHandler(duplicateService service.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{
+		duplicateService: duplicateService,
+	}
+}
+
+// GetActorDuplicates handles the HTTP request to report duplicate actors.
+// @Summary Get duplicate actors
+// @Description Retrieve the groups of actors sharing the same normalized name and birth date
+// @Tags duplicates
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.DuplicateGroup "Duplicate actors retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch duplicate actors"
+// @Router /actors/duplicates [get]
+func (dh *DuplicateHandler) GetActorDuplicates(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetActorDuplicates request...")
+
+	duplicates, err := dh.duplicateService.GetActorDuplicates()
+	if err != nil {
+		http.Error(w, "Failed to fetch duplicate actors", http.StatusInternalServerError)
+		log.Printf("Failed to fetch duplicate actors: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, duplicates)
+
+	log.Printf("GetActorDuplicates request handled successfully.")
+}
+
+// GetMovieDuplicates handles the HTTP request to report duplicate movies.
+// @Summary Get duplicate movies
+// @Description Retrieve the groups of movies sharing the same normalized title and released at most a year apart
+// @Tags duplicates
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.DuplicateGroup "Duplicate movies retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch duplicate movies"
+// @Router /movies/duplicates [get]
+func (dh *DuplicateHandler) GetMovieDuplicates(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetMovieDuplicates request...")
+
+	duplicates, err := dh.duplicateService.GetMovieDuplicates()
+	if err != nil {
+		http.Error(w, "Failed to fetch duplicate movies", http.StatusInternalServerError)
+		log.Printf("Failed to fetch duplicate movies: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, duplicates)
+
+	log.Printf("GetMovieDuplicates request handled successfully.")
+}
+
+// MergeActors handles the HTTP request to merge an actor into another one.
+// @Summary Merge actors
+// @Description Move the credits, appearances, nominations and profile of an actor to another actor and delete it, its ID redirects to the other actor
+// @Tags duplicates
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the actor to merge"
+// @Param targetId path string true "ID of the actor to merge into"
+// @Success 200 {object} model.Actor "Actors merged, the merged actor is returned"
+// @Failure 400 {string} string "Invalid actor ID, actor merged into itself or conflicting life dates"
+// @Failure 404 {string} string "Actor not found"
+// @Failure 500 {string} string "Failed to merge actors"
+// @Router /actors/{id}/merge/{targetId} [post]
+func (dh *DuplicateHandler) MergeActors(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling MergeActors request...")
+
+	sourceID, targetID, ok := parseMergeIDs(w, r, "actor")
+	if !ok {
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	actor, err := dh.duplicateService.MergeActors(sourceID, targetID, username)
+	if err != nil {
+		writeDuplicateError(w, err, "Actor not found", "Failed to merge actors")
+		log.Printf("Failed to merge actors: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, actor)
+
+	log.Printf("MergeActors request handled successfully.")
+}
+
+// MergeMovies handles the HTTP request to merge a movie into another one.
+// @Summary Merge movies
+// @Description Move the cast, crew, user data and metadata of a movie to another movie and delete it, its ID redirects to the other movie
+// @Tags duplicates
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie to merge"
+// @Param targetId path string true "ID of the movie to merge into"
+// @Success 200 {object} model.Movie "Movies merged, the merged movie is returned"
+// @Failure 400 {string} string "Invalid movie ID or movie merged into itself"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Failed to merge movies"
+// @Router /movies/{id}/merge/{targetId} [post]
+func (dh *DuplicateHandler) MergeMovies(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling MergeMovies request...")
+
+	sourceID, targetID, ok := parseMergeIDs(w, r, "movie")
+	if !ok {
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	movie, err := dh.duplicateService.MergeMovies(sourceID, targetID, username)
+	if err != nil {
+		writeDuplicateError(w, err, "Movie not found", "Failed to merge movies")
+		log.Printf("Failed to merge movies: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, movie)
+
+	log.Printf("MergeMovies request handled successfully.")
+}
+
+// parseMergeIDs parses the IDs of the merged record and of the record it is merged into from the request path,
+// writing a bad request response when one of them is invalid.
+func parseMergeIDs(w http.ResponseWriter, r *http.Request, kind string) (uuid.UUID, uuid.UUID, bool) {
+	sourceIDStr := r.PathValue("id")
+	sourceID, err := uuid.Parse(sourceIDStr)
+	if err != nil {
+		http.Error(w, "Invalid "+kind+" ID", http.StatusBadRequest)
+		log.Printf("Invalid %s ID: %s", kind, sourceIDStr)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	targetIDStr := r.PathValue("targetId")
+	targetID, err := uuid.Parse(targetIDStr)
+	if err != nil {
+		http.Error(w, "Invalid target "+kind+" ID", http.StatusBadRequest)
+		log.Printf("Invalid target %s ID: %s", kind, targetIDStr)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return sourceID, targetID, true
+}
+
+// writeDuplicateError maps the errors of the duplicate service to HTTP responses.
+func writeDuplicateError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidMerge), errors.Is(err, service.ErrInvalidLifeDates):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
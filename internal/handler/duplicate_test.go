@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockDuplicateService struct {
+	GetActorDuplicatesFunc func() ([]model.DuplicateGroup, error)
+	GetMovieDuplicatesFunc func() ([]model.DuplicateGroup, error)
+	MergeActorsFunc        func(sourceID, targetID uuid.UUID) (*model.Actor, error)
+	MergeMoviesFunc        func(sourceID, targetID uuid.UUID) (*model.Movie, error)
+}
+
+func (m *mockDuplicateService) GetActorDuplicates() ([]model.DuplicateGroup, error) {
+	return m.GetActorDuplicatesFunc()
+}
+
+func (m *mockDuplicateService) GetMovieDuplicates() ([]model.DuplicateGroup, error) {
+	return m.GetMovieDuplicatesFunc()
+}
+
+func (m *mockDuplicateService) MergeActors(sourceID, targetID uuid.UUID, username string) (*model.Actor, error) {
+	return m.MergeActorsFunc(sourceID, targetID)
+}
+
+func (m *mockDuplicateService) MergeMovies(sourceID, targetID uuid.UUID, username string) (*model.Movie, error) {
+	return m.MergeMoviesFunc(sourceID, targetID)
+}
+
+func TestDuplicateHandler_GetMovieDuplicates(t *testing.T) {
+	t.Parallel()
+
+	duplicateHandler := NewDuplicateHandler(&mockDuplicateService{
+		GetMovieDuplicatesFunc: func() ([]model.DuplicateGroup, error) {
+			return []model.DuplicateGroup{{Key: "the matrix", Records: []model.DuplicateRecord{
+				{ID: uuid.New(), Name: "The Matrix"},
+				{ID: uuid.New(), Name: "the matrix"},
+			}}}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/movies/duplicates", nil)
+	recorder := httptest.NewRecorder()
+	duplicateHandler.GetMovieDuplicates(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func TestDuplicateHandler_MergeActors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		sourceID           string
+		targetID           string
+		mergeFunc          func(sourceID, targetID uuid.UUID) (*model.Actor, error)
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			sourceID: uuid.New().String(),
+			targetID: uuid.New().String(),
+			mergeFunc: func(sourceID, targetID uuid.UUID) (*model.Actor, error) {
+				return &model.Actor{ID: targetID, Name: "Keanu Reeves"}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidActorID",
+			sourceID:           "invalid",
+			targetID:           uuid.New().String(),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "InvalidTargetActorID",
+			sourceID:           uuid.New().String(),
+			targetID:           "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "MergeIntoItself",
+			sourceID: uuid.New().String(),
+			targetID: uuid.New().String(),
+			mergeFunc: func(sourceID, targetID uuid.UUID) (*model.Actor, error) {
+				return nil, service.ErrInvalidMerge
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "ConflictingLifeDates",
+			sourceID: uuid.New().String(),
+			targetID: uuid.New().String(),
+			mergeFunc: func(sourceID, targetID uuid.UUID) (*model.Actor, error) {
+				return nil, service.ErrInvalidLifeDates
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "ActorNotFound",
+			sourceID: uuid.New().String(),
+			targetID: uuid.New().String(),
+			mergeFunc: func(sourceID, targetID uuid.UUID) (*model.Actor, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			duplicateHandler := NewDuplicateHandler(&mockDuplicateService{MergeActorsFunc: tc.mergeFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /actors/{id}/merge/{targetId}", duplicateHandler.MergeActors)
+
+			req := httptest.NewRequest(http.MethodPost, "/actors/"+tc.sourceID+"/merge/"+tc.targetID, nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestDuplicateHandler_MergeMovies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		sourceID           string
+		targetID           string
+		mergeFunc          func(sourceID, targetID uuid.UUID) (*model.Movie, error)
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			sourceID: uuid.New().String(),
+			targetID: uuid.New().String(),
+			mergeFunc: func(sourceID, targetID uuid.UUID) (*model.Movie, error) {
+				return &model.Movie{ID: targetID, Title: "The Matrix"}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidMovieID",
+			sourceID:           "invalid",
+			targetID:           uuid.New().String(),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "MovieNotFound",
+			sourceID: uuid.New().String(),
+			targetID: uuid.New().String(),
+			mergeFunc: func(sourceID, targetID uuid.UUID) (*model.Movie, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			duplicateHandler := NewDuplicateHandler(&mockDuplicateService{MergeMoviesFunc: tc.mergeFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /movies/{id}/merge/{targetId}", duplicateHandler.MergeMovies)
+
+			req := httptest.NewRequest(http.MethodPost, "/movies/"+tc.sourceID+"/merge/"+tc.targetID, nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// errInvalidIfMatch is returned when the If-Match header of a request is not the strong ETag of a version.
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// setETag sets the version of a movie or an actor as the strong ETag of the response.
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion reads the version a movie or an actor is expected to have from the If-Match header of the request,
+// zero when the header is missing or matches any version. A precondition failed response is written when the header
+// is not the ETag of a version, as such a header cannot match the current one.
+func ifMatchVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, true
+	}
+
+	version, err := parseETag(ifMatch)
+	if err != nil {
+		http.Error(w, "Precondition failed", http.StatusPreconditionFailed)
+		log.Printf("Invalid If-Match header: %s", ifMatch)
+		return 0, false
+	}
+	return version, true
+}
+
+// parseETag parses the version from its strong ETag, weak ETags never matching for updates.
+func parseETag(etag string) (int, error) {
+	unquoted, err := strconv.Unquote(etag)
+	if err != nil || !strings.HasPrefix(etag, `"`) {
+		return 0, errInvalidIfMatch
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
+
+// writeVersionError writes the response of a movie or an actor that is not found or does not have the version
+// of the If-Match header, reporting whether err is one of them.
+func writeVersionError(w http.ResponseWriter, err error, notFoundMessage, mismatchMessage string) bool {
+	switch {
+	case errors.Is(err, service.ErrVersionMismatch):
+		http.Error(w, mismatchMessage, http.StatusPreconditionFailed)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		return false
+	}
+	return true
+}
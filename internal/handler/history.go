@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// HistoryHandler handles HTTP requests related to the change history of movies and actors.
+type HistoryHandler struct {
+	historyService service.HistoryService
+}
+
+// NewHistoryHandler creates a new HistoryHandler instance.
+func NewHistoryHandler(historyService service.HistoryService) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: historyService,
+	}
+}
+
+// GetMovieHistory handles the HTTP request to list the changes made to a movie.
+// @Summary Get the history of a movie
+// @Description Retrieve the changes made to a movie and its cast with the user who made them, most recent first
+// @Tags history
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Success 200 {object} []model.HistoryEntry "History retrieved successfully"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 500 {string} string "Failed to fetch the history of the movie"
+// @Router /movies/{id}/history [get]
+func (hh *HistoryHandler) GetMovieHistory(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetMovieHistory request...")
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	history, err := hh.historyService.GetMovieHistory(movieID)
+	if err != nil {
+		http.Error(w, "Failed to fetch the history of the movie", http.StatusInternalServerError)
+		log.Printf("Failed to fetch the history of the movie: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, history)
+
+	log.Printf("GetMovieHistory request handled successfully.")
+}
+
+// GetActorHistory handles the HTTP request to list the changes made to an actor.
+// @Summary Get the history of an actor
+// @Description Retrieve the changes made to an actor with the user who made them, most recent first
+// @Tags history
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the actor"
+// @Success 200 {object} []model.HistoryEntry "History retrieved successfully"
+// @Failure 400 {string} string "Invalid actor ID"
+// @Failure 500 {string} string "Failed to fetch the history of the actor"
+// @Router /actors/{id}/history [get]
+func (hh *HistoryHandler) GetActorHistory(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetActorHistory request...")
+
+	actorIDStr := r.PathValue("id")
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", actorIDStr)
+		return
+	}
+
+	history, err := hh.historyService.GetActorHistory(actorID)
+	if err != nil {
+		http.Error(w, "Failed to fetch the history of the actor", http.StatusInternalServerError)
+		log.Printf("Failed to fetch the history of the actor: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, history)
+
+	log.Printf("GetActorHistory request handled successfully.")
+}
+
+// RevertMovie handles the HTTP request to revert a movie to a previous version.
+// @Summary Revert a movie
+// @Description Bring the details and the cast of a movie back to their state right after a change of its history, the revert is recorded as a new change
+// @Tags history
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Param historyID path int true "ID of the history entry of the change"
+// @Success 200 {object} model.Movie "Movie reverted successfully"
+// @Failure 400 {string} string "Invalid movie ID, invalid history ID or movie deleted in the version"
+// @Failure 404 {string} string "Movie or history entry not found"
+// @Failure 409 {string} string "Movie changed during the revert"
+// @Failure 500 {string} string "Failed to revert movie"
+// @Router /movies/{id}/history/{historyID}/revert [post]
+func (hh *HistoryHandler) RevertMovie(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling RevertMovie request...")
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+	historyID, ok := parseHistoryID(w, r)
+	if !ok {
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	movie, err := hh.historyService.RevertMovie(movieID, historyID, username)
+	if err != nil {
+		writeHistoryError(w, err, "Movie or history entry not found", "Failed to revert movie")
+		log.Printf("Failed to revert movie: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, movie)
+
+	log.Printf("RevertMovie request handled successfully.")
+}
+
+// RevertActor handles the HTTP request to revert an actor to a previous version.
+// @Summary Revert an actor
+// @Description Bring an actor back to their state right after a change of their history, the revert is recorded as a new change
+// @Tags history
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the actor"
+// @Param historyID path int true "ID of the history entry of the change"
+// @Success 200 {object} model.Actor "Actor reverted successfully"
+// @Failure 400 {string} string "Invalid actor ID, invalid history ID or actor deleted in the version"
+// @Failure 404 {string} string "Actor or history entry not found"
+// @Failure 409 {string} string "Actor changed during the revert"
+// @Failure 500 {string} string "Failed to revert actor"
+// @Router /actors/{id}/history/{historyID}/revert [post]
+func (hh *HistoryHandler) RevertActor(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling RevertActor request...")
+
+	actorIDStr := r.PathValue("id")
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", actorIDStr)
+		return
+	}
+	historyID, ok := parseHistoryID(w, r)
+	if !ok {
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	actor, err := hh.historyService.RevertActor(actorID, historyID, username)
+	if err != nil {
+		writeHistoryError(w, err, "Actor or history entry not found", "Failed to revert actor")
+		log.Printf("Failed to revert actor: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, actor)
+
+	log.Printf("RevertActor request handled successfully.")
+}
+
+// parseHistoryID parses the ID of a history entry from the request path,
+// writing a bad request response when it is invalid.
+func parseHistoryID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	historyIDStr := r.PathValue("historyID")
+	historyID, err := strconv.ParseInt(historyIDStr, 10, 64)
+	if err != nil || historyID <= 0 {
+		http.Error(w, "Invalid history ID", http.StatusBadRequest)
+		log.Printf("Invalid history ID: %s", historyIDStr)
+		return 0, false
+	}
+	return historyID, true
+}
+
+// writeHistoryError maps the errors of the history service to HTTP responses.
+func writeHistoryError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrDeletedVersion):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrVersionMismatch):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
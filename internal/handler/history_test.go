@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockHistoryService struct {
+	GetMovieHistoryFunc func(movieID uuid.UUID) ([]model.HistoryEntry, error)
+	GetActorHistoryFunc func(actorID uuid.UUID) ([]model.HistoryEntry, error)
+	RevertMovieFunc     func(movieID uuid.UUID, historyID int64, username string) (*model.Movie, error)
+	RevertActorFunc     func(actorID uuid.UUID, historyID int64, username string) (*model.Actor, error)
+}
+
+func (m *mockHistoryService) GetMovieHistory(movieID uuid.UUID) ([]model.HistoryEntry, error) {
+	return m.GetMovieHistoryFunc(movieID)
+}
+
+func (m *mockHistoryService) GetActorHistory(actorID uuid.UUID) ([]model.HistoryEntry, error) {
+	return m.GetActorHistoryFunc(actorID)
+}
+
+func (m *mockHistoryService) RevertMovie(movieID uuid.UUID, historyID int64, username string) (*model.Movie, error) {
+	return m.RevertMovieFunc(movieID, historyID, username)
+}
+
+func (m *mockHistoryService) RevertActor(actorID uuid.UUID, historyID int64, username string) (*model.Actor, error) {
+	return m.RevertActorFunc(actorID, historyID, username)
+}
+
+func TestHistoryHandler_GetMovieHistory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		movieID            string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Success",
+			movieID:            uuid.New().String(),
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidMovieID",
+			movieID:            "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			historyHandler := NewHistoryHandler(&mockHistoryService{
+				GetMovieHistoryFunc: func(movieID uuid.UUID) ([]model.HistoryEntry, error) {
+					return []model.HistoryEntry{{ID: 1, EntityType: model.HistoryMovie, EntityID: movieID,
+						Action: model.HistoryCreate, Username: "admin"}}, nil
+				},
+			})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /movies/{id}/history", historyHandler.GetMovieHistory)
+
+			req := httptest.NewRequest(http.MethodGet, "/movies/"+tc.movieID+"/history", nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestHistoryHandler_RevertMovie(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		movieID            string
+		historyID          string
+		revertFunc         func(movieID uuid.UUID, historyID int64, username string) (*model.Movie, error)
+		expectedStatusCode int
+	}{
+		{
+			name:      "Success",
+			movieID:   uuid.New().String(),
+			historyID: "3",
+			revertFunc: func(movieID uuid.UUID, historyID int64, username string) (*model.Movie, error) {
+				if historyID != 3 || username != "admin" {
+					return nil, sql.ErrConnDone
+				}
+				return &model.Movie{ID: movieID, Title: "Heat"}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidMovieID",
+			movieID:            "invalid",
+			historyID:          "3",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "InvalidHistoryID",
+			movieID:            uuid.New().String(),
+			historyID:          "0",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "DeletedVersion",
+			movieID:   uuid.New().String(),
+			historyID: "4",
+			revertFunc: func(movieID uuid.UUID, historyID int64, username string) (*model.Movie, error) {
+				return nil, service.ErrDeletedVersion
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "HistoryEntryNotFound",
+			movieID:   uuid.New().String(),
+			historyID: "5",
+			revertFunc: func(movieID uuid.UUID, historyID int64, username string) (*model.Movie, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			historyHandler := NewHistoryHandler(&mockHistoryService{RevertMovieFunc: tc.revertFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /movies/{id}/history/{historyID}/revert", historyHandler.RevertMovie)
+
+			req := httptest.NewRequest(http.MethodPost, "/movies/"+tc.movieID+"/history/"+tc.historyID+"/revert", nil)
+			req = req.WithContext(middleware.WithUsername(req.Context(), "admin"))
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestHistoryHandler_RevertActor(t *testing.T) {
+	t.Parallel()
+
+	historyHandler := NewHistoryHandler(&mockHistoryService{
+		RevertActorFunc: func(actorID uuid.UUID, historyID int64, username string) (*model.Actor, error) {
+			return &model.Actor{ID: actorID, Name: "Al Pacino"}, nil
+		},
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /actors/{id}/history/{historyID}/revert", historyHandler.RevertActor)
+
+	req := httptest.NewRequest(http.MethodPost, "/actors/"+uuid.New().String()+"/history/7/revert", nil)
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
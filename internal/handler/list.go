@@ -0,0 +1,530 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// ListHandler handles HTTP requests related to user-curated movie lists.
+type ListHandler struct {
+	listService service.ListService
+}
+
+// NewListHandler creates a new ListHandler instance.
+func NewListHandler(listService service.ListService) *ListHandler {
+	return &ListHandler{
+		listService: listService,
+	}
+}
+
+// Create handles the HTTP request to create a movie list.
+// @Summary Create a list
+// @Description Create a movie list owned by the authenticated user, private unless another visibility is given
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param list body model.MovieList true "List object, Name, Description, Visibility and Entries (MovieID and Note, in order) are read"
+// @Success 201 {object} model.MovieList "List created"
+// @Failure 400 {string} string "Failed to decode request body or a movie given twice"
+// @Failure 422 {object} service.ValidationError "Invalid list fields, e.g. a blank name, an unknown visibility or a note longer than 500 characters"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Failed to create list"
+// @Router /lists [post]
+func (lh *ListHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Create List request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	var list model.MovieList
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := lh.listService.Create(username, &list); err != nil {
+		writeListError(w, err, "Movie not found", "Failed to create list")
+		log.Printf("Failed to create list: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, list)
+
+	log.Printf("Create List request handled successfully.")
+}
+
+// Get handles the HTTP request to retrieve a movie list with its movies.
+// @Summary Get a list
+// @Description Retrieve a movie list with its movies in order. Private lists can only be seen by their owner.
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the list"
+// @Success 200 {object} model.MovieList "List retrieved successfully"
+// @Failure 400 {string} string "Invalid list ID"
+// @Failure 404 {string} string "List not found"
+// @Failure 500 {string} string "Failed to fetch list"
+// @Router /lists/{id} [get]
+func (lh *ListHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Get List request...")
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+
+	listIDStr := r.PathValue("id")
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "Invalid list ID", http.StatusBadRequest)
+		log.Printf("Invalid list ID: %s", listIDStr)
+		return
+	}
+
+	list, err := lh.listService.Get(username, listID)
+	if err != nil {
+		writeListError(w, err, "List not found", "Failed to fetch list")
+		log.Printf("Failed to fetch list: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, list)
+
+	log.Printf("Get List request handled successfully.")
+}
+
+// GetMine handles the HTTP request to retrieve the lists of the current user.
+// @Summary Get my lists
+// @Description Retrieve all movie lists of the authenticated user, most recently changed first
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.MovieList "Lists retrieved successfully"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Failed to fetch lists"
+// @Router /me/lists [get]
+func (lh *ListHandler) GetMine(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetMine Lists request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	lists, err := lh.listService.GetMine(username)
+	if err != nil {
+		writeListError(w, err, "Lists not found", "Failed to fetch lists")
+		log.Printf("Failed to fetch lists: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, lists)
+
+	log.Printf("GetMine Lists request handled successfully.")
+}
+
+// GetPublic handles the HTTP request to retrieve the public lists.
+// @Summary Get public lists
+// @Description Retrieve all public movie lists, most recently changed first
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.MovieList "Lists retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch lists"
+// @Router /lists [get]
+func (lh *ListHandler) GetPublic(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetPublic Lists request...")
+
+	lists, err := lh.listService.GetPublic()
+	if err != nil {
+		http.Error(w, "Failed to fetch lists", http.StatusInternalServerError)
+		log.Printf("Failed to fetch lists: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, lists)
+
+	log.Printf("GetPublic Lists request handled successfully.")
+}
+
+// Update handles the HTTP request to change a movie list.
+// @Summary Update a list
+// @Description Change the name, description and visibility of a list of the authenticated user
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the list"
+// @Param list body model.MovieList true "List object, Name, Description and Visibility are read"
+// @Success 200 {string} string "List updated"
+// @Failure 400 {string} string "Invalid list ID or failed to decode request body"
+// @Failure 422 {object} service.ValidationError "Invalid list fields, e.g. a blank name or an unknown visibility"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Only the owner can change the list"
+// @Failure 404 {string} string "List not found"
+// @Failure 500 {string} string "Failed to update list"
+// @Router /lists/{id} [put]
+func (lh *ListHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Update List request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	listIDStr := r.PathValue("id")
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "Invalid list ID", http.StatusBadRequest)
+		log.Printf("Invalid list ID: %s", listIDStr)
+		return
+	}
+
+	var list model.MovieList
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := lh.listService.Update(username, listID, &list); err != nil {
+		writeListError(w, err, "List not found", "Failed to update list")
+		log.Printf("Failed to update list: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Update List request handled successfully.")
+}
+
+// Delete handles the HTTP request to delete a movie list.
+// @Summary Delete a list
+// @Description Delete a list of the authenticated user
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the list"
+// @Success 200 {string} string "List deleted"
+// @Failure 400 {string} string "Invalid list ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Only the owner can change the list"
+// @Failure 404 {string} string "List not found"
+// @Failure 500 {string} string "Failed to delete list"
+// @Router /lists/{id} [delete]
+func (lh *ListHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Delete List request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	listIDStr := r.PathValue("id")
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "Invalid list ID", http.StatusBadRequest)
+		log.Printf("Invalid list ID: %s", listIDStr)
+		return
+	}
+
+	if err := lh.listService.Delete(username, listID); err != nil {
+		writeListError(w, err, "List not found", "Failed to delete list")
+		log.Printf("Failed to delete list: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Delete List request handled successfully.")
+}
+
+// SetEntry handles the HTTP request to add a movie to a list or change its note.
+// @Summary Add a movie to a list
+// @Description Append a movie to a list of the authenticated user, or change its note if it is already on the list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the list"
+// @Param movieId path string true "ID of the movie"
+// @Param entry body model.ListEntry false "List entry, only Note is read"
+// @Success 200 {object} model.ListEntry "Movie added to the list"
+// @Failure 400 {string} string "Invalid list or movie ID or failed to decode request body"
+// @Failure 422 {object} service.ValidationError "Note longer than 500 characters"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Only the owner can change the list"
+// @Failure 404 {string} string "List or movie not found"
+// @Failure 500 {string} string "Failed to add movie to list"
+// @Router /lists/{id}/entries/{movieId} [put]
+func (lh *ListHandler) SetEntry(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling SetEntry List request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	listIDStr := r.PathValue("id")
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "Invalid list ID", http.StatusBadRequest)
+		log.Printf("Invalid list ID: %s", listIDStr)
+		return
+	}
+
+	movieIDStr := r.PathValue("movieId")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	var entry model.ListEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+	entry.MovieID = movieID
+
+	if err := lh.listService.SetEntry(username, listID, &entry); err != nil {
+		writeListError(w, err, "List or movie not found", "Failed to add movie to list")
+		log.Printf("Failed to add movie to list: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry)
+
+	log.Printf("SetEntry List request handled successfully.")
+}
+
+// RemoveEntry handles the HTTP request to remove a movie from a list.
+// @Summary Remove a movie from a list
+// @Description Remove a movie from a list of the authenticated user, the following movies move up
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the list"
+// @Param movieId path string true "ID of the movie"
+// @Success 200 {string} string "Movie removed from the list"
+// @Failure 400 {string} string "Invalid list or movie ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Only the owner can change the list"
+// @Failure 404 {string} string "List not found or movie is not on the list"
+// @Failure 500 {string} string "Failed to remove movie from list"
+// @Router /lists/{id}/entries/{movieId} [delete]
+func (lh *ListHandler) RemoveEntry(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling RemoveEntry List request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	listIDStr := r.PathValue("id")
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "Invalid list ID", http.StatusBadRequest)
+		log.Printf("Invalid list ID: %s", listIDStr)
+		return
+	}
+
+	movieIDStr := r.PathValue("movieId")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	if err := lh.listService.RemoveEntry(username, listID, movieID); err != nil {
+		writeListError(w, err, "List not found or movie is not on the list", "Failed to remove movie from list")
+		log.Printf("Failed to remove movie from list: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("RemoveEntry List request handled successfully.")
+}
+
+// Reorder handles the HTTP request to change the order of the movies of a list.
+// @Summary Reorder a list
+// @Description Put the movies of a list of the authenticated user in the given order
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the list"
+// @Param order body []string true "IDs of every movie of the list, in the new order"
+// @Success 200 {string} string "List reordered"
+// @Failure 400 {string} string "Invalid list ID, failed to decode request body or invalid order"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Only the owner can change the list"
+// @Failure 404 {string} string "List not found"
+// @Failure 500 {string} string "Failed to reorder list"
+// @Router /lists/{id}/order [put]
+func (lh *ListHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Reorder List request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	listIDStr := r.PathValue("id")
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "Invalid list ID", http.StatusBadRequest)
+		log.Printf("Invalid list ID: %s", listIDStr)
+		return
+	}
+
+	var movieIDs []uuid.UUID
+	if err := json.NewDecoder(r.Body).Decode(&movieIDs); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := lh.listService.Reorder(username, listID, movieIDs); err != nil {
+		writeListError(w, err, "List not found", "Failed to reorder list")
+		log.Printf("Failed to reorder list: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Reorder List request handled successfully.")
+}
+
+// Clone handles the HTTP request to copy a list into a new list of the current user.
+// @Summary Clone a list
+// @Description Copy a list the authenticated user can see, with its notes, into a new private list of the user
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the list"
+// @Success 201 {object} model.MovieList "List cloned"
+// @Failure 400 {string} string "Invalid list ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "List not found"
+// @Failure 500 {string} string "Failed to clone list"
+// @Router /lists/{id}/clone [post]
+func (lh *ListHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Clone List request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	listIDStr := r.PathValue("id")
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "Invalid list ID", http.StatusBadRequest)
+		log.Printf("Invalid list ID: %s", listIDStr)
+		return
+	}
+
+	list, err := lh.listService.Clone(username, listID)
+	if err != nil {
+		writeListError(w, err, "List not found", "Failed to clone list")
+		log.Printf("Failed to clone list: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, list)
+
+	log.Printf("Clone List request handled successfully.")
+}
+
+// Share handles the HTTP request to share a list by link.
+// @Summary Share a list
+// @Description Make a private list of the authenticated user unlisted, so that anyone with its link can see it
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the list"
+// @Success 200 {object} model.MovieList "List shared"
+// @Failure 400 {string} string "Invalid list ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Only the owner can change the list"
+// @Failure 404 {string} string "List not found"
+// @Failure 500 {string} string "Failed to share list"
+// @Router /lists/{id}/share [post]
+func (lh *ListHandler) Share(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Share List request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	listIDStr := r.PathValue("id")
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "Invalid list ID", http.StatusBadRequest)
+		log.Printf("Invalid list ID: %s", listIDStr)
+		return
+	}
+
+	list, err := lh.listService.Share(username, listID)
+	if err != nil {
+		writeListError(w, err, "List not found", "Failed to share list")
+		log.Printf("Failed to share list: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, list)
+
+	log.Printf("Share List request handled successfully.")
+}
+
+// writeListError maps the errors of the list service to HTTP responses.
+func writeListError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	if writeValidationError(w, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrDuplicateListEntry), errors.Is(err, service.ErrInvalidListOrder):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrUserNotFound):
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	case errors.Is(err, service.ErrNotListOwner):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockListService struct {
+	CreateFunc      func(username string, list *model.MovieList) error
+	GetFunc         func(username string, listID uuid.UUID) (*model.MovieList, error)
+	GetMineFunc     func(username string) ([]*model.MovieList, error)
+	GetPublicFunc   func() ([]*model.MovieList, error)
+	UpdateFunc      func(username string, listID uuid.UUID, list *model.MovieList) error
+	DeleteFunc      func(username string, listID uuid.UUID) error
+	SetEntryFunc    func(username string, listID uuid.UUID, entry *model.ListEntry) error
+	RemoveEntryFunc func(username string, listID, movieID uuid.UUID) error
+	ReorderFunc     func(username string, listID uuid.UUID, movieIDs []uuid.UUID) error
+	CloneFunc       func(username string, listID uuid.UUID) (*model.MovieList, error)
+	ShareFunc       func(username string, listID uuid.UUID) (*model.MovieList, error)
+}
+
+func (m *mockListService) Create(username string, list *model.MovieList) error {
+	return m.CreateFunc(username, list)
+}
+
+func (m *mockListService) Get(username string, listID uuid.UUID) (*model.MovieList, error) {
+	return m.GetFunc(username, listID)
+}
+
+func (m *mockListService) GetMine(username string) ([]*model.MovieList, error) {
+	return m.GetMineFunc(username)
+}
+
+func (m *mockListService) GetPublic() ([]*model.MovieList, error) {
+	return m.GetPublicFunc()
+}
+
+func (m *mockListService) Update(username string, listID uuid.UUID, list *model.MovieList) error {
+	return m.UpdateFunc(username, listID, list)
+}
+
+func (m *mockListService) Delete(username string, listID uuid.UUID) error {
+	return m.DeleteFunc(username, listID)
+}
+
+func (m *mockListService) SetEntry(username string, listID uuid.UUID, entry *model.ListEntry) error {
+	return m.SetEntryFunc(username, listID, entry)
+}
+
+func (m *mockListService) RemoveEntry(username string, listID, movieID uuid.UUID) error {
+	return m.RemoveEntryFunc(username, listID, movieID)
+}
+
+func (m *mockListService) Reorder(username string, listID uuid.UUID, movieIDs []uuid.UUID) error {
+	return m.ReorderFunc(username, listID, movieIDs)
+}
+
+func (m *mockListService) Clone(username string, listID uuid.UUID) (*model.MovieList, error) {
+	return m.CloneFunc(username, listID)
+}
+
+func (m *mockListService) Share(username string, listID uuid.UUID) (*model.MovieList, error) {
+	return m.ShareFunc(username, listID)
+}
+
+func TestListHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		username           string
+		body               string
+		createFunc         func(username string, list *model.MovieList) error
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			body:     `{"Name": "Best of 1990s noir", "Entries": [{"MovieID": "` + uuid.New().String() + `"}]}`,
+			createFunc: func(username string, list *model.MovieList) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Unauthorized",
+			body:               `{"Name": "Noir"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "InvalidBody",
+			username:           "forrest",
+			body:               `{"Name": 42}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "InvalidVisibility",
+			username: "forrest",
+			body:     `{"Name": "Noir", "Visibility": "secret"}`,
+			createFunc: func(username string, list *model.MovieList) error {
+				return &service.ValidationError{Fields: []service.FieldError{
+					{Field: "Visibility", Rule: service.RuleOneOf, Message: "must be private, unlisted or public"},
+				}}
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			listHandler := NewListHandler(&mockListService{CreateFunc: tc.createFunc})
+
+			req := httptest.NewRequest(http.MethodPost, "/lists", bytes.NewBufferString(tc.body))
+			req = req.WithContext(middleware.WithUsername(req.Context(), tc.username))
+
+			recorder := httptest.NewRecorder()
+			listHandler.Create(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestListHandler_Get(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		listID             string
+		getFunc            func(username string, listID uuid.UUID) (*model.MovieList, error)
+		expectedStatusCode int
+	}{
+		{
+			name:   "Success",
+			listID: uuid.New().String(),
+			getFunc: func(username string, listID uuid.UUID) (*model.MovieList, error) {
+				return &model.MovieList{ID: listID, Name: "Noir"}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidListID",
+			listID:             "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "PrivateList",
+			listID: uuid.New().String(),
+			getFunc: func(username string, listID uuid.UUID) (*model.MovieList, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			listHandler := NewListHandler(&mockListService{GetFunc: tc.getFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /lists/{id}", listHandler.Get)
+
+			req := httptest.NewRequest(http.MethodGet, "/lists/"+tc.listID, nil)
+			req = req.WithContext(middleware.WithUsername(req.Context(), "jenny"))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestListHandler_Reorder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		body               string
+		reorderFunc        func(username string, listID uuid.UUID, movieIDs []uuid.UUID) error
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			body: `["` + uuid.New().String() + `", "` + uuid.New().String() + `"]`,
+			reorderFunc: func(username string, listID uuid.UUID, movieIDs []uuid.UUID) error {
+				if len(movieIDs) != 2 {
+					return service.ErrInvalidListOrder
+				}
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidBody",
+			body:               `["not-a-uuid"]`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidOrder",
+			body: `[]`,
+			reorderFunc: func(username string, listID uuid.UUID, movieIDs []uuid.UUID) error {
+				return service.ErrInvalidListOrder
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "NotOwner",
+			body: `[]`,
+			reorderFunc: func(username string, listID uuid.UUID, movieIDs []uuid.UUID) error {
+				return service.ErrNotListOwner
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			listHandler := NewListHandler(&mockListService{ReorderFunc: tc.reorderFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /lists/{id}/order", listHandler.Reorder)
+
+			req := httptest.NewRequest(http.MethodPut, "/lists/"+uuid.New().String()+"/order", bytes.NewBufferString(tc.body))
+			req = req.WithContext(middleware.WithUsername(req.Context(), "forrest"))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestListHandler_SetEntryWithoutBody(t *testing.T) {
+	t.Parallel()
+
+	movieID := uuid.New()
+	listHandler := NewListHandler(&mockListService{
+		SetEntryFunc: func(username string, listID uuid.UUID, entry *model.ListEntry) error {
+			if entry.MovieID != movieID {
+				return sql.ErrNoRows
+			}
+			entry.Position = 1
+			return nil
+		},
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /lists/{id}/entries/{movieId}", listHandler.SetEntry)
+
+	req := httptest.NewRequest(http.MethodPut, "/lists/"+uuid.New().String()+"/entries/"+movieID.String(), nil)
+	req = req.WithContext(middleware.WithUsername(req.Context(), "forrest"))
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
@@ -1,26 +1,36 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
 	"github.com/EgMeln/filmLibraryPrivate/internal/service"
 )
 
 // MovieHandler handles HTTP requests related to movies.
 type MovieHandler struct {
-	movieService service.MovieService
+	movieService       service.MovieService
+	watchService       service.WatchService
+	translationService service.TranslationService
 }
 
 // NewMovieHandler creates a new MovieHandler instance.
-func NewMovieHandler(movieService service.MovieService) *MovieHandler {
+func NewMovieHandler(movieService service.MovieService, watchService service.WatchService,
+	translationService service.TranslationService) *MovieHandler {
 	return &MovieHandler{
-		movieService: movieService,
+		movieService:       movieService,
+		watchService:       watchService,
+		translationService: translationService,
 	}
 }
 
@@ -33,6 +43,7 @@ func NewMovieHandler(movieService service.MovieService) *MovieHandler {
 // @Param movie body model.Movie true "Movie object to be created"
 // @Success 200 {string} string "Movie created successfully"
 // @Failure 400 {string} string "Failed to decode request body"
+// @Failure 422 {object} service.ValidationError "Invalid movie fields, e.g. a title longer than 150 characters, a rating outside 0..10, an unknown cast actor or country code"
 // @Failure 500 {string} string "Failed to create movie"
 // @Router /movies/create [post]
 func (mh *MovieHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -45,7 +56,11 @@ func (mh *MovieHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := mh.movieService.Create(&movie); err != nil {
+	username, _ := middleware.UsernameFromContext(r.Context())
+	if err := mh.movieService.Create(&movie, username); err != nil {
+		if writeValidationError(w, err) {
+			return
+		}
 		http.Error(w, "Failed to create movie", http.StatusInternalServerError)
 		log.Printf("Failed to create movie: %v", err)
 		return
@@ -56,16 +71,67 @@ func (mh *MovieHandler) Create(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Create Movie request handled successfully.")
 }
 
+// GetByID handles the HTTP request to retrieve a movie by its ID.
+// @Summary Get a movie
+// @Description Retrieve a movie by its ID, its version is returned as the ETag to send in the If-Match header of updates and deletes
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Param movie_id query string true "ID of the movie"
+// @Param Accept-Language header string false "Preferred languages of the title and description"
+// @Success 200 {object} model.Movie "Movie retrieved successfully"
+// @Header 200 {string} ETag "Version of the movie"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Failed to fetch movie"
+// @Router /movies/getByID [get]
+func (mh *MovieHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetByID Movie request...")
+
+	movieIDStr := r.URL.Query().Get("movie_id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	movie, err := mh.movieService.GetByID(movieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Movie not found", http.StatusNotFound)
+			log.Printf("Movie not found: %s", movieID)
+			return
+		}
+		http.Error(w, "Failed to fetch movie", http.StatusInternalServerError)
+		log.Printf("Failed to fetch movie: %v", err)
+		return
+	}
+	movies := []*model.Movie{movie}
+	mh.markWatchStatus(r, movies)
+	mh.localize(w, r, movies)
+
+	setETag(w, movie.Version)
+	writeJSON(w, http.StatusOK, movie)
+
+	log.Printf("GetByID Movie request handled successfully.")
+}
+
 // Update handles the HTTP request to update an existing movie.
 // @Summary Update a movie
-// @Description Update an existing movie with the provided details
+// @Description Update an existing movie with the provided details. The version of the movie is checked against the If-Match header, which may be required by the server
 // @Tags movies
 // @Accept json
 // @Produce json
 // @Param movie_id query string true "ID of the movie to be updated"
+// @Param If-Match header string false "ETag of the movie as last read"
 // @Param movie body model.Movie true "Updated movie object"
 // @Success 200 {string} string "Movie updated successfully"
 // @Failure 400 {string} string "Invalid movie ID or failed to decode request body"
+// @Failure 422 {object} service.ValidationError "Invalid movie fields, e.g. a title longer than 150 characters, a rating outside 0..10, an unknown cast actor or country code"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 412 {string} string "Movie changed since it was read"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Failed to update movie"
 // @Router /movies/update [put]
 func (mh *MovieHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -85,8 +151,21 @@ func (mh *MovieHandler) Update(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Failed to decode request body: %v", err)
 		return
 	}
-
-	if err := mh.movieService.Update(movieID, updatedMovie); err != nil {
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	updatedMovie.Version = version
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	if err := mh.movieService.Update(movieID, updatedMovie, username); err != nil {
+		if writeValidationError(w, err) {
+			return
+		}
+		if writeVersionError(w, err, "Movie not found", "Movie changed since it was read") {
+			log.Printf("Failed to update movie: %v", err)
+			return
+		}
 		http.Error(w, "Failed to update movie", http.StatusInternalServerError)
 		log.Printf("Failed to update movie: %v", err)
 		return
@@ -97,15 +176,85 @@ func (mh *MovieHandler) Update(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Update Movie request handled successfully.")
 }
 
+// Patch handles the HTTP request to partially update an existing movie.
+// @Summary Patch a movie
+// @Description Partially update an existing movie with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) applied to the movie as returned by its reads. Unlike updates, fields set to null, zero or empty are cleared, e.g. an empty Actors list removes the cast. The version of the movie is checked against the If-Match header, which may be required by the server
+// @Tags movies
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Param movie_id query string true "ID of the movie to be patched"
+// @Param If-Match header string false "ETag of the movie as last read"
+// @Param patch body object true "JSON Merge Patch object or JSON Patch operations"
+// @Success 200 {object} model.Movie "Movie patched successfully"
+// @Header 200 {string} ETag "Version of the movie"
+// @Failure 400 {string} string "Invalid movie ID or invalid patch"
+// @Failure 422 {object} service.ValidationError "Invalid movie fields, e.g. a title longer than 150 characters, a rating outside 0..10, an unknown cast actor or country code"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 409 {string} string "Patch cannot be applied to the movie, e.g. a failed test operation"
+// @Failure 412 {string} string "Movie changed since it was read"
+// @Failure 415 {string} string "Unsupported patch media type"
+// @Failure 428 {string} string "If-Match header required"
+// @Failure 500 {string} string "Failed to patch movie"
+// @Router /movies/patch [patch]
+func (mh *MovieHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Patch Movie request...")
+
+	movieIDStr := r.URL.Query().Get("movie_id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		log.Printf("Failed to read request body: %v", err)
+		return
+	}
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	movie, err := mh.movieService.Patch(movieID, patchMediaType(r), patch, version, username)
+	if err != nil {
+		if writeValidationError(w, err) {
+			return
+		}
+		if writePatchError(w, err) {
+			return
+		}
+		if writeVersionError(w, err, "Movie not found", "Movie changed since it was read") {
+			log.Printf("Failed to patch movie: %v", err)
+			return
+		}
+		http.Error(w, "Failed to patch movie", http.StatusInternalServerError)
+		log.Printf("Failed to patch movie: %v", err)
+		return
+	}
+
+	setETag(w, movie.Version)
+	writeJSON(w, http.StatusOK, movie)
+
+	log.Printf("Patch Movie request handled successfully.")
+}
+
 // Delete handles the HTTP request to delete an existing movie.
 // @Summary Delete a movie
-// @Description Delete an existing movie by its ID
+// @Description Move an existing movie to the trash by its ID, it can be restored until it is purged. The version of the movie is checked against the If-Match header, which may be required by the server
 // @Tags movies
 // @Accept json
 // @Produce json
 // @Param movie_id query string true "ID of the movie to be deleted"
+// @Param If-Match header string false "ETag of the movie as last read"
 // @Success 200 {string} string "Movie deleted successfully"
 // @Failure 400 {string} string "Invalid movie ID"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 412 {string} string "Movie changed since it was read"
+// @Failure 428 {string} string "If-Match header required"
 // @Failure 500 {string} string "Failed to delete movie"
 // @Router /movies/delete [delete]
 func (mh *MovieHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -119,7 +268,17 @@ func (mh *MovieHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := mh.movieService.Delete(movieID); err != nil {
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	if err := mh.movieService.Delete(movieID, version, username); err != nil {
+		if writeVersionError(w, err, "Movie not found", "Movie changed since it was read") {
+			log.Printf("Failed to delete movie: %v", err)
+			return
+		}
 		http.Error(w, "Failed to delete movie", http.StatusInternalServerError)
 		log.Printf("Failed to delete movie: %v", err)
 		return
@@ -136,9 +295,24 @@ func (mh *MovieHandler) Delete(w http.ResponseWriter, r *http.Request) {
 // @Tags movies
 // @Accept json
 // @Produce json
-// @Param flag query int true "Sorting flag"
+// @Param flag query int true "Sorting flag: 1 - title, 2 - release date, 3 - weighted user score, other - rating"
+// @Param country query string false "ISO 3166-1 alpha-2 code of a production country"
+// @Param language query string false "ISO 639-1 code of a spoken language"
+// @Param original_language query string false "ISO 639-1 code of the original language"
+// @Param certification_country query string false "ISO 3166-1 alpha-2 code of a country the movie is certified in"
+// @Param certification query string false "Age certification in certification_country"
+// @Param min_runtime query int false "Minimum running time in minutes"
+// @Param max_runtime query int false "Maximum running time in minutes"
+// @Param release_country query string false "ISO 3166-1 alpha-2 code of a country the movie is released in, sorting by release date follows the dates in it"
+// @Param release_type query string false "Type of the release in release_country: premiere, theatrical_limited, theatrical, digital, physical or tv"
+// @Param won_award query string false "ID of an award the movie has won in any category"
+// @Param company query string false "ID of a company that worked on the movie"
+// @Param company_role query string false "Role of the company in the movie: production or distribution"
+// @Param tags query string false "Comma-separated names of tags of the movie"
+// @Param tag_mode query string false "Whether movies must have all of the tags or any of them: all (default) or any"
+// @Param Accept-Language header string false "Preferred languages of the titles and descriptions"
 // @Success 200 {string} string "Movies retrieved successfully"
-// @Failure 400 {string} string "Invalid sorting flag"
+// @Failure 400 {string} string "Invalid sorting flag or filter"
 // @Failure 500 {string} string "Failed to fetch movies with sorting"
 // @Router /movies/getAllWithSorting [get]
 func (mh *MovieHandler) GetAllWithSorting(w http.ResponseWriter, r *http.Request) {
@@ -152,12 +326,26 @@ func (mh *MovieHandler) GetAllWithSorting(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	movies, err := mh.movieService.GetAllWithSorting(flag)
+	filter, err := parseMovieFilter(r)
 	if err != nil {
+		http.Error(w, "Invalid filter", http.StatusBadRequest)
+		log.Printf("Invalid filter: %v", err)
+		return
+	}
+
+	movies, err := mh.movieService.GetAllWithSorting(flag, filter)
+	if err != nil {
+		if isInvalidFilter(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			log.Printf("Invalid filter: %v", err)
+			return
+		}
 		http.Error(w, "Failed to fetch movies with sorting", http.StatusInternalServerError)
 		log.Printf("Failed to fetch movies with sorting: %v", err)
 		return
 	}
+	mh.markWatchStatus(r, movies)
+	mh.localize(w, r, movies)
 
 	jsonResponse, err := json.Marshal(movies)
 	if err != nil {
@@ -175,11 +363,12 @@ func (mh *MovieHandler) GetAllWithSorting(w http.ResponseWriter, r *http.Request
 
 // GetByTitleFragment handles the HTTP request to retrieve movies by title fragment.
 // @Summary Get movies by title fragment
-// @Description Retrieve movies that match the provided title fragment
+// @Description Retrieve movies whose original or translated title matches the provided title fragment
 // @Tags movies
 // @Accept json
 // @Produce json
 // @Param title_fragment query string true "Title fragment"
+// @Param Accept-Language header string false "Preferred languages of the titles and descriptions"
 // @Success 200 {string} string "Movies retrieved successfully"
 // @Failure 500 {string} string "Failed to fetch movies by title fragment"
 // @Router /movies/getByTitleFragment [get]
@@ -194,6 +383,8 @@ func (mh *MovieHandler) GetByTitleFragment(w http.ResponseWriter, r *http.Reques
 		log.Printf("Failed to fetch movies by title fragment: %v", err)
 		return
 	}
+	mh.markWatchStatus(r, movies)
+	mh.localize(w, r, movies)
 
 	jsonResponse, err := json.Marshal(movies)
 	if err != nil {
@@ -216,6 +407,7 @@ func (mh *MovieHandler) GetByTitleFragment(w http.ResponseWriter, r *http.Reques
 // @Accept json
 // @Produce json
 // @Param actor_name_fragment query string true "Actor name fragment"
+// @Param Accept-Language header string false "Preferred languages of the titles and descriptions"
 // @Success 200 {string} string "Movies retrieved successfully"
 // @Failure 500 {string} string "Failed to fetch movies by actor name fragment"
 // @Router /movies/getByActorNameFragment [get]
@@ -230,6 +422,8 @@ func (mh *MovieHandler) GetByActorNameFragment(w http.ResponseWriter, r *http.Re
 		log.Printf("Failed to fetch movies by actor name fragment: %v", err)
 		return
 	}
+	mh.markWatchStatus(r, movies)
+	mh.localize(w, r, movies)
 
 	jsonResponse, err := json.Marshal(movies)
 	if err != nil {
@@ -244,3 +438,82 @@ func (mh *MovieHandler) GetByActorNameFragment(w http.ResponseWriter, r *http.Re
 
 	log.Printf("GetByActorNameFragment Movie request handled successfully.")
 }
+
+// parseMovieFilter reads the optional movie filter from the query parameters of a request.
+func parseMovieFilter(r *http.Request) (model.MovieFilter, error) {
+	query := r.URL.Query()
+	filter := model.MovieFilter{
+		Country:              query.Get("country"),
+		Language:             query.Get("language"),
+		OriginalLanguage:     query.Get("original_language"),
+		CertificationCountry: query.Get("certification_country"),
+		Certification:        query.Get("certification"),
+		ReleaseCountry:       query.Get("release_country"),
+		ReleaseType:          query.Get("release_type"),
+		CompanyRole:          query.Get("company_role"),
+		TagMode:              query.Get("tag_mode"),
+	}
+	if tags := query.Get("tags"); tags != "" {
+		filter.Tags = strings.Split(tags, ",")
+	}
+
+	var err error
+	if minRuntime := query.Get("min_runtime"); minRuntime != "" {
+		if filter.MinRuntime, err = strconv.Atoi(minRuntime); err != nil {
+			return filter, err
+		}
+	}
+	if maxRuntime := query.Get("max_runtime"); maxRuntime != "" {
+		if filter.MaxRuntime, err = strconv.Atoi(maxRuntime); err != nil {
+			return filter, err
+		}
+	}
+	if wonAward := query.Get("won_award"); wonAward != "" {
+		if filter.WonAward, err = uuid.Parse(wonAward); err != nil {
+			return filter, err
+		}
+	}
+	if company := query.Get("company"); company != "" {
+		if filter.Company, err = uuid.Parse(company); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// isInvalidFilter reports whether err is caused by a movie filter that fails validation.
+func isInvalidFilter(err error) bool {
+	return errors.Is(err, service.ErrInvalidRuntime) ||
+		errors.Is(err, service.ErrInvalidCountry) ||
+		errors.Is(err, service.ErrInvalidLanguage) ||
+		errors.Is(err, service.ErrInvalidCertification) ||
+		errors.Is(err, service.ErrInvalidRelease) ||
+		errors.Is(err, service.ErrInvalidCompanyRole) ||
+		errors.Is(err, service.ErrInvalidTag) ||
+		errors.Is(err, service.ErrInvalidTagMode)
+}
+
+// localize translates the titles and descriptions of the movies into the languages preferred by the
+// Accept-Language header of the request. The movies are still returned untranslated if the translations
+// cannot be loaded.
+func (mh *MovieHandler) localize(w http.ResponseWriter, r *http.Request, movies []*model.Movie) {
+	w.Header().Add("Vary", "Accept-Language")
+
+	languages := parseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err := mh.translationService.Localize(languages, movies); err != nil {
+		log.Printf("Failed to localize movies: %v", err)
+	}
+}
+
+// markWatchStatus flags the movies the current user has on their watchlist or has already watched.
+// The movies are still returned without the flags if the statuses cannot be loaded.
+func (mh *MovieHandler) markWatchStatus(r *http.Request, movies []*model.Movie) {
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		return
+	}
+
+	if err := mh.watchService.MarkWatchStatus(username, movies); err != nil {
+		log.Printf("Failed to mark watch status: %v", err)
+	}
+}
@@ -2,40 +2,54 @@ package handler
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strconv"
 	"testing"
 
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
 	"github.com/google/uuid"
 )
 
 type mockMovieService struct {
 	CreateFunc                 func(movie *model.Movie) error
+	GetByIDFunc                func(movieID uuid.UUID) (*model.Movie, error)
 	UpdateFunc                 func(movieID uuid.UUID, updatedMovie model.Movie) error
-	DeleteFunc                 func(movieID uuid.UUID) error
-	GetAllWithSortingFunc      func(flag int) ([]*model.Movie, error)
+	PatchFunc                  func(movieID uuid.UUID, mediaType string, patch []byte, version int) (*model.Movie, error)
+	DeleteFunc                 func(movieID uuid.UUID, version int) error
+	GetAllWithSortingFunc      func(flag int, filter model.MovieFilter) ([]*model.Movie, error)
 	GetByTitleFragmentFunc     func(titleFragment string) ([]*model.Movie, error)
 	GetByActorNameFragmentFunc func(actorNameFragment string) ([]*model.Movie, error)
 }
 
-func (m *mockMovieService) Create(movie *model.Movie) error {
+func (m *mockMovieService) Create(movie *model.Movie, username string) error {
 	return m.CreateFunc(movie)
 }
 
-func (m *mockMovieService) Update(movieID uuid.UUID, updatedMovie model.Movie) error {
+func (m *mockMovieService) GetByID(movieID uuid.UUID) (*model.Movie, error) {
+	return m.GetByIDFunc(movieID)
+}
+
+func (m *mockMovieService) Update(movieID uuid.UUID, updatedMovie model.Movie, username string) error {
 	return m.UpdateFunc(movieID, updatedMovie)
 }
 
-func (m *mockMovieService) Delete(movieID uuid.UUID) error {
-	return m.DeleteFunc(movieID)
+func (m *mockMovieService) Patch(movieID uuid.UUID, mediaType string, patch []byte, version int,
+	username string) (*model.Movie, error) {
+	return m.PatchFunc(movieID, mediaType, patch, version)
+}
+
+func (m *mockMovieService) Delete(movieID uuid.UUID, version int, username string) error {
+	return m.DeleteFunc(movieID, version)
 }
 
-func (m *mockMovieService) GetAllWithSorting(flag int) ([]*model.Movie, error) {
-	return m.GetAllWithSortingFunc(flag)
+func (m *mockMovieService) GetAllWithSorting(flag int, filter model.MovieFilter) ([]*model.Movie, error) {
+	return m.GetAllWithSortingFunc(flag, filter)
 }
 
 func (m *mockMovieService) GetByTitleFragment(titleFragment string) ([]*model.Movie, error) {
@@ -75,6 +89,18 @@ func TestMovieHandler_Create(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "InvalidFields",
+			movie: model.Movie{
+				Title: "Test Movie",
+			},
+			createFunc: func(movie *model.Movie) error {
+				return &service.ValidationError{Fields: []service.FieldError{
+					{Field: "Rating", Rule: service.RuleRange, Message: "must be between 0 and 10"},
+				}}
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, tc := range tests {
@@ -82,7 +108,7 @@ func TestMovieHandler_Create(t *testing.T) {
 			mockService := &mockMovieService{
 				CreateFunc: tc.createFunc,
 			}
-			handler := NewMovieHandler(mockService)
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
 
 			jsonData, err := json.Marshal(tc.movie)
 			if err != nil {
@@ -112,6 +138,7 @@ func TestMovieHandler_Update(t *testing.T) {
 		name               string
 		movieID            uuid.UUID
 		updatedMovie       model.Movie
+		ifMatch            string
 		updateFunc         func(movieID uuid.UUID, updatedMovie model.Movie) error
 		expectedStatusCode int
 	}{
@@ -126,6 +153,54 @@ func TestMovieHandler_Update(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:    "IfMatch",
+			movieID: uuid.New(),
+			updatedMovie: model.Movie{
+				Title:   "Updated Movie",
+				Version: 7,
+			},
+			ifMatch: `"3"`,
+			updateFunc: func(movieID uuid.UUID, updatedMovie model.Movie) error {
+				if updatedMovie.Version != 3 {
+					return errors.New("unexpected version")
+				}
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "VersionMismatch",
+			movieID: uuid.New(),
+			updatedMovie: model.Movie{
+				Title: "Updated Movie",
+			},
+			ifMatch: `"2"`,
+			updateFunc: func(movieID uuid.UUID, updatedMovie model.Movie) error {
+				return service.ErrVersionMismatch
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "WeakETag",
+			movieID: uuid.New(),
+			updatedMovie: model.Movie{
+				Title: "Updated Movie",
+			},
+			ifMatch:            `W/"2"`,
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "MovieNotFound",
+			movieID: uuid.New(),
+			updatedMovie: model.Movie{
+				Title: "Updated Movie",
+			},
+			updateFunc: func(movieID uuid.UUID, updatedMovie model.Movie) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
 		{
 			name:    "ServiceError",
 			movieID: uuid.New(),
@@ -144,7 +219,7 @@ func TestMovieHandler_Update(t *testing.T) {
 			mockService := &mockMovieService{
 				UpdateFunc: tc.updateFunc,
 			}
-			handler := NewMovieHandler(mockService)
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
 
 			jsonData, err := json.Marshal(tc.updatedMovie)
 			if err != nil {
@@ -156,6 +231,9 @@ func TestMovieHandler_Update(t *testing.T) {
 				t.Fatal(err)
 			}
 			req.Header.Set("Content-Type", "application/json")
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			recorder := httptest.NewRecorder()
 			handler.Update(recorder, req)
@@ -167,27 +245,138 @@ func TestMovieHandler_Update(t *testing.T) {
 	}
 }
 
+func TestMovieHandler_Patch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		contentType        string
+		patchFunc          func(movieID uuid.UUID, mediaType string, patch []byte, version int) (*model.Movie, error)
+		expectedStatusCode int
+		expectedETag       string
+	}{
+		{
+			name:        "Success",
+			contentType: "application/merge-patch+json; charset=utf-8",
+			patchFunc: func(movieID uuid.UUID, mediaType string, patch []byte, version int) (*model.Movie, error) {
+				if mediaType != model.PatchMerge || string(patch) != `{"Rating":0}` || version != 2 {
+					return nil, errors.New("unexpected patch")
+				}
+				return &model.Movie{ID: movieID, Title: "Heat", Version: 3}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       `"3"`,
+		},
+		{
+			name:        "UnsupportedMediaType",
+			contentType: "application/json",
+			patchFunc: func(movieID uuid.UUID, mediaType string, patch []byte, version int) (*model.Movie, error) {
+				return nil, service.ErrUnsupportedPatch
+			},
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "InvalidPatch",
+			contentType: model.PatchJSON,
+			patchFunc: func(movieID uuid.UUID, mediaType string, patch []byte, version int) (*model.Movie, error) {
+				return nil, service.ErrInvalidPatch
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "TestFailed",
+			contentType: model.PatchJSON,
+			patchFunc: func(movieID uuid.UUID, mediaType string, patch []byte, version int) (*model.Movie, error) {
+				return nil, service.ErrPatchConflict
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:        "VersionMismatch",
+			contentType: model.PatchMerge,
+			patchFunc: func(movieID uuid.UUID, mediaType string, patch []byte, version int) (*model.Movie, error) {
+				return nil, service.ErrVersionMismatch
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &mockMovieService{
+				PatchFunc: tc.patchFunc,
+			}
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
+
+			req := httptest.NewRequest(http.MethodPatch, "/movies/patch?movie_id="+uuid.New().String(),
+				bytes.NewReader([]byte(`{"Rating":0}`)))
+			req.Header.Set("Content-Type", tc.contentType)
+			req.Header.Set("If-Match", `"2"`)
+
+			recorder := httptest.NewRecorder()
+			handler.Patch(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+			if etag := recorder.Header().Get("ETag"); etag != tc.expectedETag {
+				t.Errorf("Expected ETag %q, got %q", tc.expectedETag, etag)
+			}
+		})
+	}
+}
+
 func TestMovieHandler_Delete(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name               string
 		movieID            uuid.UUID
-		deleteFunc         func(movieID uuid.UUID) error
+		ifMatch            string
+		deleteFunc         func(movieID uuid.UUID, version int) error
 		expectedStatusCode int
 	}{
 		{
 			name:    "Success",
 			movieID: uuid.New(),
-			deleteFunc: func(movieID uuid.UUID) error {
+			deleteFunc: func(movieID uuid.UUID, version int) error {
 				return nil
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:    "IfMatch",
+			movieID: uuid.New(),
+			ifMatch: `"4"`,
+			deleteFunc: func(movieID uuid.UUID, version int) error {
+				if version != 4 {
+					return errors.New("unexpected version")
+				}
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "VersionMismatch",
+			movieID: uuid.New(),
+			ifMatch: `"4"`,
+			deleteFunc: func(movieID uuid.UUID, version int) error {
+				return service.ErrVersionMismatch
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "MovieNotFound",
+			movieID: uuid.New(),
+			deleteFunc: func(movieID uuid.UUID, version int) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
 		{
 			name:    "ServiceError",
 			movieID: uuid.New(),
-			deleteFunc: func(movieID uuid.UUID) error {
+			deleteFunc: func(movieID uuid.UUID, version int) error {
 				return errors.New("service error")
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -199,12 +388,15 @@ func TestMovieHandler_Delete(t *testing.T) {
 			mockService := &mockMovieService{
 				DeleteFunc: tc.deleteFunc,
 			}
-			handler := NewMovieHandler(mockService)
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
 
 			req, err := http.NewRequest(http.MethodDelete, "/movies/delete?movie_id="+tc.movieID.String(), nil)
 			if err != nil {
 				t.Fatal(err)
 			}
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			recorder := httptest.NewRecorder()
 			handler.Delete(recorder, req)
@@ -216,19 +408,72 @@ func TestMovieHandler_Delete(t *testing.T) {
 	}
 }
 
+func TestMovieHandler_GetByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		movieID            string
+		expectedStatusCode int
+		expectedETag       string
+	}{
+		{
+			name:               "Success",
+			movieID:            uuid.New().String(),
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       `"5"`,
+		},
+		{
+			name:               "InvalidMovieID",
+			movieID:            "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "MovieNotFound",
+			movieID:            uuid.Nil.String(),
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &mockMovieService{
+				GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+					if movieID == uuid.Nil {
+						return nil, sql.ErrNoRows
+					}
+					return &model.Movie{ID: movieID, Title: "Heat", Version: 5}, nil
+				},
+			}
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
+
+			req := httptest.NewRequest(http.MethodGet, "/movies/getByID?movie_id="+tc.movieID, nil)
+			recorder := httptest.NewRecorder()
+			handler.GetByID(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+			if etag := recorder.Header().Get("ETag"); etag != tc.expectedETag {
+				t.Errorf("Expected ETag %q, got %q", tc.expectedETag, etag)
+			}
+		})
+	}
+}
+
 func TestMovieHandler_GetAllWithSorting(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name                  string
 		flag                  int
-		getAllWithSortingFunc func(flag int) ([]*model.Movie, error)
+		getAllWithSortingFunc func(flag int, filter model.MovieFilter) ([]*model.Movie, error)
 		expectedStatusCode    int
 	}{
 		{
 			name: "Success",
 			flag: 1,
-			getAllWithSortingFunc: func(flag int) ([]*model.Movie, error) {
+			getAllWithSortingFunc: func(flag int, filter model.MovieFilter) ([]*model.Movie, error) {
 				return nil, nil
 			},
 			expectedStatusCode: http.StatusOK,
@@ -236,7 +481,7 @@ func TestMovieHandler_GetAllWithSorting(t *testing.T) {
 		{
 			name: "ServiceError",
 			flag: 1,
-			getAllWithSortingFunc: func(flag int) ([]*model.Movie, error) {
+			getAllWithSortingFunc: func(flag int, filter model.MovieFilter) ([]*model.Movie, error) {
 				return nil, errors.New("service error")
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -248,7 +493,7 @@ func TestMovieHandler_GetAllWithSorting(t *testing.T) {
 			mockService := &mockMovieService{
 				GetAllWithSortingFunc: tc.getAllWithSortingFunc,
 			}
-			handler := NewMovieHandler(mockService)
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
 
 			req, err := http.NewRequest(http.MethodGet, "/movies/getAllWithSorting?flag="+strconv.Itoa(tc.flag), nil)
 			if err != nil {
@@ -265,6 +510,65 @@ func TestMovieHandler_GetAllWithSorting(t *testing.T) {
 	}
 }
 
+func TestMovieHandler_GetAllWithSortingFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		query                 string
+		getAllWithSortingFunc func(flag int, filter model.MovieFilter) ([]*model.Movie, error)
+		expectedStatusCode    int
+	}{
+		{
+			name:  "Success",
+			query: "flag=2&country=FR&language=fr&certification_country=US&certification=R&min_runtime=90&max_runtime=150&release_country=DE&release_type=digital&tags=heist,time%20travel&tag_mode=any",
+			getAllWithSortingFunc: func(flag int, filter model.MovieFilter) ([]*model.Movie, error) {
+				expected := model.MovieFilter{Country: "FR", Language: "fr", CertificationCountry: "US", Certification: "R", MinRuntime: 90, MaxRuntime: 150,
+					ReleaseCountry: "DE", ReleaseType: "digital", Tags: []string{"heist", "time travel"}, TagMode: "any"}
+				if !reflect.DeepEqual(filter, expected) {
+					return nil, errors.New("unexpected filter")
+				}
+				return nil, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidRuntime",
+			query:              "flag=1&min_runtime=long",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "InvalidCountry",
+			query: "flag=1&country=XX",
+			getAllWithSortingFunc: func(flag int, filter model.MovieFilter) ([]*model.Movie, error) {
+				return nil, service.ErrInvalidCountry
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &mockMovieService{
+				GetAllWithSortingFunc: tc.getAllWithSortingFunc,
+			}
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
+
+			req, err := http.NewRequest(http.MethodGet, "/movies/getAllWithSorting?"+tc.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.GetAllWithSorting(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
 func TestMovieHandler_GetByTitleFragment(t *testing.T) {
 	t.Parallel()
 
@@ -297,7 +601,7 @@ func TestMovieHandler_GetByTitleFragment(t *testing.T) {
 			mockService := &mockMovieService{
 				GetByTitleFragmentFunc: tc.getByTitleFragmentFunc,
 			}
-			handler := NewMovieHandler(mockService)
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
 
 			req, err := http.NewRequest(http.MethodGet, "/movies/getByTitleFragment?title_fragment="+tc.titleFragment, nil)
 			if err != nil {
@@ -346,7 +650,7 @@ func TestMovieHandler_GetByActorNameFragment(t *testing.T) {
 			mockService := &mockMovieService{
 				GetByActorNameFragmentFunc: tc.getByActorNameFragmentFunc,
 			}
-			handler := NewMovieHandler(mockService)
+			handler := NewMovieHandler(mockService, &mockWatchService{}, &mockTranslationService{})
 
 			req, err := http.NewRequest(http.MethodGet, "/movies/getByActorNameFragment?actor_name_fragment="+tc.actorNameFragment, nil)
 			if err != nil {
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// patchMediaType returns the media type of the patch document in the body of the request, empty when the
+// Content-Type header is missing or invalid.
+func patchMediaType(r *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// writePatchError writes the response of a patch document that is not supported, not valid or cannot be applied,
+// reporting whether err is one of them.
+func writePatchError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrUnsupportedPatch):
+		w.Header().Set("Accept-Patch", model.PatchMerge+", "+model.PatchJSON)
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, service.ErrInvalidPatch):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrPatchConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		return false
+	}
+	log.Printf("Failed to apply patch: %v", err)
+	return true
+}
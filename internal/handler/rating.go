@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// RatingHandler handles HTTP requests related to user ratings of movies.
+type RatingHandler struct {
+	ratingService service.RatingService
+}
+
+// NewRatingHandler creates a new RatingHandler instance.
+func NewRatingHandler(ratingService service.RatingService) *RatingHandler {
+	return &RatingHandler{
+		ratingService: ratingService,
+	}
+}
+
+// PutMyRating handles the HTTP request to set the score the current user gives to a movie.
+// @Summary Rate a movie
+// @Description Create or replace the score (1-10) the authenticated user gives to a movie
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Param rating body model.UserRating true "Rating object, only Score is read"
+// @Success 200 {object} model.UserRating "Rating saved"
+// @Failure 400 {string} string "Invalid movie ID, failed to decode request body or invalid score"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Failed to rate movie"
+// @Router /movies/{id}/my-rating [put]
+func (rh *RatingHandler) PutMyRating(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling PutMyRating request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	var rating model.UserRating
+	if err := json.NewDecoder(r.Body).Decode(&rating); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	saved, err := rh.ratingService.SetMyRating(username, movieID, rating.Score)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidScore):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrUserNotFound):
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		case errors.Is(err, sql.ErrNoRows):
+			http.Error(w, "Movie not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to rate movie", http.StatusInternalServerError)
+		}
+		log.Printf("Failed to rate movie: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, saved)
+
+	log.Printf("PutMyRating request handled successfully.")
+}
+
+// DeleteMyRating handles the HTTP request to remove the score the current user gave to a movie.
+// @Summary Remove a movie rating
+// @Description Remove the score the authenticated user gave to a movie
+// @Tags ratings
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Success 200 {string} string "Rating removed"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Rating not found"
+// @Failure 500 {string} string "Failed to remove rating"
+// @Router /movies/{id}/my-rating [delete]
+func (rh *RatingHandler) DeleteMyRating(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling DeleteMyRating request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	if err := rh.ratingService.DeleteMyRating(username, movieID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		case errors.Is(err, sql.ErrNoRows):
+			http.Error(w, "Rating not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to remove rating", http.StatusInternalServerError)
+		}
+		log.Printf("Failed to remove rating: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("DeleteMyRating request handled successfully.")
+}
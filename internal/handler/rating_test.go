@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockRatingService struct {
+	SetMyRatingFunc    func(username string, movieID uuid.UUID, score int) (*model.UserRating, error)
+	DeleteMyRatingFunc func(username string, movieID uuid.UUID) error
+}
+
+func (m *mockRatingService) SetMyRating(username string, movieID uuid.UUID, score int) (*model.UserRating, error) {
+	return m.SetMyRatingFunc(username, movieID, score)
+}
+
+func (m *mockRatingService) DeleteMyRating(username string, movieID uuid.UUID) error {
+	return m.DeleteMyRatingFunc(username, movieID)
+}
+
+func TestRatingHandler_PutMyRating(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		username           string
+		movieID            string
+		body               string
+		setMyRatingFunc    func(username string, movieID uuid.UUID, score int) (*model.UserRating, error)
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			body:     `{"Score": 9}`,
+			setMyRatingFunc: func(username string, movieID uuid.UUID, score int) (*model.UserRating, error) {
+				return &model.UserRating{MovieID: movieID, Score: score}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Unauthorized",
+			movieID:            uuid.New().String(),
+			body:               `{"Score": 9}`,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "InvalidMovieID",
+			username:           "forrest",
+			movieID:            "invalid",
+			body:               `{"Score": 9}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "InvalidBody",
+			username:           "forrest",
+			movieID:            uuid.New().String(),
+			body:               `{"Score":`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "InvalidScore",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			body:     `{"Score": 42}`,
+			setMyRatingFunc: func(username string, movieID uuid.UUID, score int) (*model.UserRating, error) {
+				return nil, service.ErrInvalidScore
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "MovieNotFound",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			body:     `{"Score": 9}`,
+			setMyRatingFunc: func(username string, movieID uuid.UUID, score int) (*model.UserRating, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:     "ServiceError",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			body:     `{"Score": 9}`,
+			setMyRatingFunc: func(username string, movieID uuid.UUID, score int) (*model.UserRating, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ratingHandler := NewRatingHandler(&mockRatingService{SetMyRatingFunc: tc.setMyRatingFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /movies/{id}/my-rating", ratingHandler.PutMyRating)
+
+			req := httptest.NewRequest(http.MethodPut, "/movies/"+tc.movieID+"/my-rating", bytes.NewBufferString(tc.body))
+			req = req.WithContext(middleware.WithUsername(req.Context(), tc.username))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestRatingHandler_DeleteMyRating(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		username           string
+		movieID            string
+		deleteMyRatingFunc func(username string, movieID uuid.UUID) error
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			deleteMyRatingFunc: func(username string, movieID uuid.UUID) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidMovieID",
+			username:           "forrest",
+			movieID:            "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "RatingNotFound",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			deleteMyRatingFunc: func(username string, movieID uuid.UUID) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:     "UserNotFound",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			deleteMyRatingFunc: func(username string, movieID uuid.UUID) error {
+				return service.ErrUserNotFound
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ratingHandler := NewRatingHandler(&mockRatingService{DeleteMyRatingFunc: tc.deleteMyRatingFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /movies/{id}/my-rating", ratingHandler.DeleteMyRating)
+
+			req := httptest.NewRequest(http.MethodDelete, "/movies/"+tc.movieID+"/my-rating", nil)
+			req = req.WithContext(middleware.WithUsername(req.Context(), tc.username))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
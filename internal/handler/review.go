@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// ReviewHandler handles HTTP requests related to movie reviews.
+type ReviewHandler struct {
+	reviewService service.ReviewService
+}
+
+// NewReviewHandler creates a new ReviewHandler instance.
+func NewReviewHandler(reviewService service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// Create handles the HTTP request to submit a review of a movie.
+// @Summary Review a movie
+// @Description Submit a review of a movie as the authenticated user, the review is published once approved by an admin
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Param review body model.Review true "Review object, Title, Body and Spoiler are read"
+// @Success 201 {object} model.Review "Review submitted"
+// @Failure 400 {string} string "Invalid movie ID, failed to decode request body or invalid review"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 409 {string} string "The user has already reviewed this movie"
+// @Failure 500 {string} string "Failed to create review"
+// @Router /movies/{id}/reviews [post]
+func (rh *ReviewHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Create Review request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := rh.reviewService.Create(username, movieID, &review); err != nil {
+		writeReviewError(w, err, "Movie not found", "Failed to create review")
+		log.Printf("Failed to create review: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, review)
+
+	log.Printf("Create Review request handled successfully.")
+}
+
+// Update handles the HTTP request to edit a review.
+// @Summary Edit a review
+// @Description Edit a review written by the authenticated user, the review goes back to moderation
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the review"
+// @Param review body model.ReviewUpdate true "Edit of the review, the fields left out are kept"
+// @Success 200 {string} string "Review updated"
+// @Failure 400 {string} string "Invalid review ID, failed to decode request body or invalid review"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Only the author can change the review"
+// @Failure 404 {string} string "Review not found"
+// @Failure 500 {string} string "Failed to update review"
+// @Router /reviews/{id} [put]
+func (rh *ReviewHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Update Review request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	reviewIDStr := r.PathValue("id")
+	reviewID, err := uuid.Parse(reviewIDStr)
+	if err != nil {
+		http.Error(w, "Invalid review ID", http.StatusBadRequest)
+		log.Printf("Invalid review ID: %s", reviewIDStr)
+		return
+	}
+
+	var update model.ReviewUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := rh.reviewService.Update(username, reviewID, &update); err != nil {
+		writeReviewError(w, err, "Review not found", "Failed to update review")
+		log.Printf("Failed to update review: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Update Review request handled successfully.")
+}
+
+// Delete handles the HTTP request to delete a review.
+// @Summary Delete a review
+// @Description Delete a review written by the authenticated user
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the review"
+// @Success 200 {string} string "Review deleted"
+// @Failure 400 {string} string "Invalid review ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Only the author can change the review"
+// @Failure 404 {string} string "Review not found"
+// @Failure 500 {string} string "Failed to delete review"
+// @Router /reviews/{id} [delete]
+func (rh *ReviewHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Delete Review request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	reviewIDStr := r.PathValue("id")
+	reviewID, err := uuid.Parse(reviewIDStr)
+	if err != nil {
+		http.Error(w, "Invalid review ID", http.StatusBadRequest)
+		log.Printf("Invalid review ID: %s", reviewIDStr)
+		return
+	}
+
+	if err := rh.reviewService.Delete(username, reviewID); err != nil {
+		writeReviewError(w, err, "Review not found", "Failed to delete review")
+		log.Printf("Failed to delete review: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Delete Review request handled successfully.")
+}
+
+// GetByMovie handles the HTTP request to list the published reviews of a movie.
+// @Summary List movie reviews
+// @Description Retrieve the approved reviews of a movie, newest first
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Success 200 {object} []model.Review "Reviews retrieved successfully"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 500 {string} string "Failed to fetch reviews"
+// @Router /movies/{id}/reviews [get]
+func (rh *ReviewHandler) GetByMovie(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetByMovie Reviews request...")
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	reviews, err := rh.reviewService.GetByMovie(movieID)
+	if err != nil {
+		http.Error(w, "Failed to fetch reviews", http.StatusInternalServerError)
+		log.Printf("Failed to fetch reviews: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reviews)
+
+	log.Printf("GetByMovie Reviews request handled successfully.")
+}
+
+// GetModerationQueue handles the HTTP request to list the reviews waiting for moderation.
+// @Summary List reviews waiting for moderation
+// @Description Retrieve the pending reviews, oldest first
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.Review "Reviews retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch moderation queue"
+// @Router /reviews/moderation [get]
+func (rh *ReviewHandler) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetModerationQueue Reviews request...")
+
+	reviews, err := rh.reviewService.GetModerationQueue()
+	if err != nil {
+		http.Error(w, "Failed to fetch moderation queue", http.StatusInternalServerError)
+		log.Printf("Failed to fetch moderation queue: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reviews)
+
+	log.Printf("GetModerationQueue Reviews request handled successfully.")
+}
+
+// Moderate handles the HTTP request to approve or hide a review.
+// @Summary Moderate a review
+// @Description Approve or hide a review
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the review"
+// @Param review body model.Review true "Review object, only Status (approved or hidden) is read"
+// @Success 200 {string} string "Review moderated"
+// @Failure 400 {string} string "Invalid review ID, failed to decode request body or invalid status"
+// @Failure 404 {string} string "Review not found"
+// @Failure 500 {string} string "Failed to moderate review"
+// @Router /reviews/{id}/moderate [post]
+func (rh *ReviewHandler) Moderate(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Moderate Review request...")
+
+	reviewIDStr := r.PathValue("id")
+	reviewID, err := uuid.Parse(reviewIDStr)
+	if err != nil {
+		http.Error(w, "Invalid review ID", http.StatusBadRequest)
+		log.Printf("Invalid review ID: %s", reviewIDStr)
+		return
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := rh.reviewService.Moderate(reviewID, review.Status); err != nil {
+		writeReviewError(w, err, "Review not found", "Failed to moderate review")
+		log.Printf("Failed to moderate review: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Moderate Review request handled successfully.")
+}
+
+// writeReviewError maps the errors of the review service to HTTP responses.
+func writeReviewError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidReview), errors.Is(err, service.ErrInvalidReviewStatus):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrUserNotFound):
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	case errors.Is(err, service.ErrNotReviewAuthor):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrReviewExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
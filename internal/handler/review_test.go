@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockReviewService struct {
+	CreateFunc             func(username string, movieID uuid.UUID, review *model.Review) error
+	UpdateFunc             func(username string, reviewID uuid.UUID, update *model.ReviewUpdate) error
+	DeleteFunc             func(username string, reviewID uuid.UUID) error
+	GetByMovieFunc         func(movieID uuid.UUID) ([]*model.Review, error)
+	GetModerationQueueFunc func() ([]*model.Review, error)
+	ModerateFunc           func(reviewID uuid.UUID, status string) error
+}
+
+func (m *mockReviewService) Create(username string, movieID uuid.UUID, review *model.Review) error {
+	return m.CreateFunc(username, movieID, review)
+}
+
+func (m *mockReviewService) Update(username string, reviewID uuid.UUID, update *model.ReviewUpdate) error {
+	return m.UpdateFunc(username, reviewID, update)
+}
+
+func (m *mockReviewService) Delete(username string, reviewID uuid.UUID) error {
+	return m.DeleteFunc(username, reviewID)
+}
+
+func (m *mockReviewService) GetByMovie(movieID uuid.UUID) ([]*model.Review, error) {
+	return m.GetByMovieFunc(movieID)
+}
+
+func (m *mockReviewService) GetModerationQueue() ([]*model.Review, error) {
+	return m.GetModerationQueueFunc()
+}
+
+func (m *mockReviewService) Moderate(reviewID uuid.UUID, status string) error {
+	return m.ModerateFunc(reviewID, status)
+}
+
+func TestReviewHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		username           string
+		movieID            string
+		body               string
+		createFunc         func(username string, movieID uuid.UUID, review *model.Review) error
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			body:     `{"Title": "Run", "Body": "Chocolates"}`,
+			createFunc: func(username string, movieID uuid.UUID, review *model.Review) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Unauthorized",
+			movieID:            uuid.New().String(),
+			body:               `{"Title": "Run", "Body": "Chocolates"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "InvalidReview",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			body:     `{"Title": "", "Body": ""}`,
+			createFunc: func(username string, movieID uuid.UUID, review *model.Review) error {
+				return service.ErrInvalidReview
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "AlreadyReviewed",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			body:     `{"Title": "Run", "Body": "Chocolates"}`,
+			createFunc: func(username string, movieID uuid.UUID, review *model.Review) error {
+				return service.ErrReviewExists
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:     "MovieNotFound",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			body:     `{"Title": "Run", "Body": "Chocolates"}`,
+			createFunc: func(username string, movieID uuid.UUID, review *model.Review) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewHandler := NewReviewHandler(&mockReviewService{CreateFunc: tc.createFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /movies/{id}/reviews", reviewHandler.Create)
+
+			req := httptest.NewRequest(http.MethodPost, "/movies/"+tc.movieID+"/reviews", bytes.NewBufferString(tc.body))
+			req = req.WithContext(middleware.WithUsername(req.Context(), tc.username))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestReviewHandler_Delete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		reviewID           string
+		deleteFunc         func(username string, reviewID uuid.UUID) error
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			reviewID: uuid.New().String(),
+			deleteFunc: func(username string, reviewID uuid.UUID) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidReviewID",
+			reviewID:           "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "NotAuthor",
+			reviewID: uuid.New().String(),
+			deleteFunc: func(username string, reviewID uuid.UUID) error {
+				return service.ErrNotReviewAuthor
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewHandler := NewReviewHandler(&mockReviewService{DeleteFunc: tc.deleteFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /reviews/{id}", reviewHandler.Delete)
+
+			req := httptest.NewRequest(http.MethodDelete, "/reviews/"+tc.reviewID, nil)
+			req = req.WithContext(middleware.WithUsername(req.Context(), "forrest"))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestReviewHandler_GetByMovie(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		movieID            string
+		getByMovieFunc     func(movieID uuid.UUID) ([]*model.Review, error)
+		expectedStatusCode int
+	}{
+		{
+			name:    "Success",
+			movieID: uuid.New().String(),
+			getByMovieFunc: func(movieID uuid.UUID) ([]*model.Review, error) {
+				return []*model.Review{{MovieID: movieID, Title: "Run"}}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "ServiceError",
+			movieID: uuid.New().String(),
+			getByMovieFunc: func(movieID uuid.UUID) ([]*model.Review, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewHandler := NewReviewHandler(&mockReviewService{GetByMovieFunc: tc.getByMovieFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /movies/{id}/reviews", reviewHandler.GetByMovie)
+
+			req := httptest.NewRequest(http.MethodGet, "/movies/"+tc.movieID+"/reviews", nil)
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestReviewHandler_Moderate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		body               string
+		moderateFunc       func(reviewID uuid.UUID, status string) error
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			body: `{"Status": "approved"}`,
+			moderateFunc: func(reviewID uuid.UUID, status string) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "InvalidStatus",
+			body: `{"Status": "burned"}`,
+			moderateFunc: func(reviewID uuid.UUID, status string) error {
+				return service.ErrInvalidReviewStatus
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "ReviewNotFound",
+			body: `{"Status": "hidden"}`,
+			moderateFunc: func(reviewID uuid.UUID, status string) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewHandler := NewReviewHandler(&mockReviewService{ModerateFunc: tc.moderateFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /reviews/{id}/moderate", reviewHandler.Moderate)
+
+			req := httptest.NewRequest(http.MethodPost, "/reviews/"+uuid.New().String()+"/moderate", bytes.NewBufferString(tc.body))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,461 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// SeriesHandler handles HTTP requests related to TV series, their seasons and episodes.
+type SeriesHandler struct {
+	seriesService service.SeriesService
+}
+
+// NewSeriesHandler creates a new SeriesHandler instance.
+func NewSeriesHandler(seriesService service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+// Create handles the HTTP request to create a series.
+// @Summary Create a series
+// @Description Create a TV series without seasons
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param series body model.Series true "Series object, Title and Description are read"
+// @Success 201 {object} model.Series "Series created"
+// @Failure 400 {string} string "Failed to decode request body or invalid series"
+// @Failure 500 {string} string "Failed to create series"
+// @Router /series [post]
+func (sh *SeriesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Create Series request...")
+
+	var series model.Series
+	if err := json.NewDecoder(r.Body).Decode(&series); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := sh.seriesService.Create(&series); err != nil {
+		writeSeriesError(w, err, "Series not found", "Failed to create series")
+		log.Printf("Failed to create series: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, series)
+
+	log.Printf("Create Series request handled successfully.")
+}
+
+// GetByID handles the HTTP request to retrieve a series with its seasons and episodes.
+// @Summary Get a series
+// @Description Retrieve a TV series with its seasons and episodes in order, including the cast of each episode
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the series"
+// @Success 200 {object} model.Series "Series retrieved successfully"
+// @Failure 400 {string} string "Invalid series ID"
+// @Failure 404 {string} string "Series not found"
+// @Failure 500 {string} string "Failed to fetch series"
+// @Router /series/{id} [get]
+func (sh *SeriesHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetByID Series request...")
+
+	seriesIDStr := r.PathValue("id")
+	seriesID, err := uuid.Parse(seriesIDStr)
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		log.Printf("Invalid series ID: %s", seriesIDStr)
+		return
+	}
+
+	series, err := sh.seriesService.GetByID(seriesID)
+	if err != nil {
+		writeSeriesError(w, err, "Series not found", "Failed to fetch series")
+		log.Printf("Failed to fetch series: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, series)
+
+	log.Printf("GetByID Series request handled successfully.")
+}
+
+// Search handles the HTTP request to list and search series.
+// @Summary Search series
+// @Description Retrieve the series ordered by title, optionally filtered by a title fragment and an actor name fragment
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param title query string false "Fragment of the title of the series"
+// @Param actor query string false "Fragment of the name of an actor appearing in an episode"
+// @Success 200 {object} []model.Series "Series retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch series"
+// @Router /series [get]
+func (sh *SeriesHandler) Search(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Search Series request...")
+
+	query := r.URL.Query()
+
+	seriesList, err := sh.seriesService.Search(query.Get("title"), query.Get("actor"))
+	if err != nil {
+		http.Error(w, "Failed to fetch series", http.StatusInternalServerError)
+		log.Printf("Failed to fetch series: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, seriesList)
+
+	log.Printf("Search Series request handled successfully.")
+}
+
+// Update handles the HTTP request to update a series.
+// @Summary Update a series
+// @Description Change the title and description of a TV series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the series"
+// @Param series body model.Series true "Series object, Title and Description are read"
+// @Success 200 {string} string "Series updated"
+// @Failure 400 {string} string "Invalid series ID, failed to decode request body or invalid series"
+// @Failure 404 {string} string "Series not found"
+// @Failure 500 {string} string "Failed to update series"
+// @Router /series/{id} [put]
+func (sh *SeriesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Update Series request...")
+
+	seriesIDStr := r.PathValue("id")
+	seriesID, err := uuid.Parse(seriesIDStr)
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		log.Printf("Invalid series ID: %s", seriesIDStr)
+		return
+	}
+
+	var series model.Series
+	if err := json.NewDecoder(r.Body).Decode(&series); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := sh.seriesService.Update(seriesID, series); err != nil {
+		writeSeriesError(w, err, "Series not found", "Failed to update series")
+		log.Printf("Failed to update series: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Update Series request handled successfully.")
+}
+
+// Delete handles the HTTP request to delete a series.
+// @Summary Delete a series
+// @Description Delete a TV series along with its seasons and episodes
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the series"
+// @Success 200 {string} string "Series deleted"
+// @Failure 400 {string} string "Invalid series ID"
+// @Failure 500 {string} string "Failed to delete series"
+// @Router /series/{id} [delete]
+func (sh *SeriesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Delete Series request...")
+
+	seriesIDStr := r.PathValue("id")
+	seriesID, err := uuid.Parse(seriesIDStr)
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		log.Printf("Invalid series ID: %s", seriesIDStr)
+		return
+	}
+
+	if err := sh.seriesService.Delete(seriesID); err != nil {
+		http.Error(w, "Failed to delete series", http.StatusInternalServerError)
+		log.Printf("Failed to delete series: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Delete Series request handled successfully.")
+}
+
+// CreateSeason handles the HTTP request to add a season to a series.
+// @Summary Add a season
+// @Description Add a season to a TV series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the series"
+// @Param season body model.Season true "Season object, Number and Title are read"
+// @Success 201 {object} model.Season "Season created"
+// @Failure 400 {string} string "Invalid series ID, failed to decode request body or invalid season"
+// @Failure 404 {string} string "Series not found"
+// @Failure 409 {string} string "The series already has a season with this number"
+// @Failure 500 {string} string "Failed to create season"
+// @Router /series/{id}/seasons [post]
+func (sh *SeriesHandler) CreateSeason(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling CreateSeason Series request...")
+
+	seriesIDStr := r.PathValue("id")
+	seriesID, err := uuid.Parse(seriesIDStr)
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		log.Printf("Invalid series ID: %s", seriesIDStr)
+		return
+	}
+
+	var season model.Season
+	if err := json.NewDecoder(r.Body).Decode(&season); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := sh.seriesService.CreateSeason(seriesID, &season); err != nil {
+		writeSeriesError(w, err, "Series not found", "Failed to create season")
+		log.Printf("Failed to create season: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, season)
+
+	log.Printf("CreateSeason Series request handled successfully.")
+}
+
+// DeleteSeason handles the HTTP request to delete a season of a series.
+// @Summary Delete a season
+// @Description Delete a season of a TV series along with its episodes
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the series"
+// @Param number path int true "Number of the season"
+// @Success 200 {string} string "Season deleted"
+// @Failure 400 {string} string "Invalid series ID or season number"
+// @Failure 404 {string} string "Series or season not found"
+// @Failure 500 {string} string "Failed to delete season"
+// @Router /series/{id}/seasons/{number} [delete]
+func (sh *SeriesHandler) DeleteSeason(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling DeleteSeason Series request...")
+
+	seriesIDStr := r.PathValue("id")
+	seriesID, err := uuid.Parse(seriesIDStr)
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		log.Printf("Invalid series ID: %s", seriesIDStr)
+		return
+	}
+
+	numberStr := r.PathValue("number")
+	number, err := strconv.Atoi(numberStr)
+	if err != nil {
+		http.Error(w, "Invalid season number", http.StatusBadRequest)
+		log.Printf("Invalid season number: %s", numberStr)
+		return
+	}
+
+	if err := sh.seriesService.DeleteSeason(seriesID, number); err != nil {
+		writeSeriesError(w, err, "Series or season not found", "Failed to delete season")
+		log.Printf("Failed to delete season: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("DeleteSeason Series request handled successfully.")
+}
+
+// CreateEpisode handles the HTTP request to add an episode to a season.
+// @Summary Add an episode
+// @Description Add an episode with its cast to a season of a TV series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the series"
+// @Param number path int true "Number of the season"
+// @Param episode body model.Episode true "Episode object, Number, Title, Description, AirDate and Cast are read"
+// @Success 201 {object} model.Episode "Episode created"
+// @Failure 400 {string} string "Invalid series ID or season number, failed to decode request body or invalid episode"
+// @Failure 404 {string} string "Series or season not found"
+// @Failure 409 {string} string "The season already has an episode with this number"
+// @Failure 500 {string} string "Failed to create episode"
+// @Router /series/{id}/seasons/{number}/episodes [post]
+func (sh *SeriesHandler) CreateEpisode(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling CreateEpisode Series request...")
+
+	seriesIDStr := r.PathValue("id")
+	seriesID, err := uuid.Parse(seriesIDStr)
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		log.Printf("Invalid series ID: %s", seriesIDStr)
+		return
+	}
+
+	numberStr := r.PathValue("number")
+	number, err := strconv.Atoi(numberStr)
+	if err != nil {
+		http.Error(w, "Invalid season number", http.StatusBadRequest)
+		log.Printf("Invalid season number: %s", numberStr)
+		return
+	}
+
+	var episode model.Episode
+	if err := json.NewDecoder(r.Body).Decode(&episode); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := sh.seriesService.CreateEpisode(seriesID, number, &episode); err != nil {
+		writeSeriesError(w, err, "Series or season not found", "Failed to create episode")
+		log.Printf("Failed to create episode: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, episode)
+
+	log.Printf("CreateEpisode Series request handled successfully.")
+}
+
+// GetEpisodeByID handles the HTTP request to retrieve an episode.
+// @Summary Get an episode
+// @Description Retrieve an episode of a TV series with its cast
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the episode"
+// @Success 200 {object} model.Episode "Episode retrieved successfully"
+// @Failure 400 {string} string "Invalid episode ID"
+// @Failure 404 {string} string "Episode not found"
+// @Failure 500 {string} string "Failed to fetch episode"
+// @Router /episodes/{id} [get]
+func (sh *SeriesHandler) GetEpisodeByID(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetEpisodeByID Series request...")
+
+	episodeIDStr := r.PathValue("id")
+	episodeID, err := uuid.Parse(episodeIDStr)
+	if err != nil {
+		http.Error(w, "Invalid episode ID", http.StatusBadRequest)
+		log.Printf("Invalid episode ID: %s", episodeIDStr)
+		return
+	}
+
+	episode, err := sh.seriesService.GetEpisodeByID(episodeID)
+	if err != nil {
+		writeSeriesError(w, err, "Episode not found", "Failed to fetch episode")
+		log.Printf("Failed to fetch episode: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, episode)
+
+	log.Printf("GetEpisodeByID Series request handled successfully.")
+}
+
+// UpdateEpisode handles the HTTP request to update an episode.
+// @Summary Update an episode
+// @Description Change an episode of a TV series, the cast is replaced when given
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the episode"
+// @Param episode body model.Episode true "Episode object, Number, Title, Description, AirDate and Cast are read"
+// @Success 200 {string} string "Episode updated"
+// @Failure 400 {string} string "Invalid episode ID, failed to decode request body or invalid episode"
+// @Failure 404 {string} string "Episode not found"
+// @Failure 409 {string} string "The season already has an episode with this number"
+// @Failure 500 {string} string "Failed to update episode"
+// @Router /episodes/{id} [put]
+func (sh *SeriesHandler) UpdateEpisode(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling UpdateEpisode Series request...")
+
+	episodeIDStr := r.PathValue("id")
+	episodeID, err := uuid.Parse(episodeIDStr)
+	if err != nil {
+		http.Error(w, "Invalid episode ID", http.StatusBadRequest)
+		log.Printf("Invalid episode ID: %s", episodeIDStr)
+		return
+	}
+
+	var episode model.Episode
+	if err := json.NewDecoder(r.Body).Decode(&episode); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := sh.seriesService.UpdateEpisode(episodeID, episode); err != nil {
+		writeSeriesError(w, err, "Episode not found", "Failed to update episode")
+		log.Printf("Failed to update episode: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("UpdateEpisode Series request handled successfully.")
+}
+
+// DeleteEpisode handles the HTTP request to delete an episode.
+// @Summary Delete an episode
+// @Description Delete an episode of a TV series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the episode"
+// @Success 200 {string} string "Episode deleted"
+// @Failure 400 {string} string "Invalid episode ID"
+// @Failure 500 {string} string "Failed to delete episode"
+// @Router /episodes/{id} [delete]
+func (sh *SeriesHandler) DeleteEpisode(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling DeleteEpisode Series request...")
+
+	episodeIDStr := r.PathValue("id")
+	episodeID, err := uuid.Parse(episodeIDStr)
+	if err != nil {
+		http.Error(w, "Invalid episode ID", http.StatusBadRequest)
+		log.Printf("Invalid episode ID: %s", episodeIDStr)
+		return
+	}
+
+	if err := sh.seriesService.DeleteEpisode(episodeID); err != nil {
+		http.Error(w, "Failed to delete episode", http.StatusInternalServerError)
+		log.Printf("Failed to delete episode: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("DeleteEpisode Series request handled successfully.")
+}
+
+// writeSeriesError maps the errors of the series service to HTTP responses.
+func writeSeriesError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidSeries), errors.Is(err, service.ErrInvalidSeason),
+		errors.Is(err, service.ErrInvalidEpisode):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrSeasonExists), errors.Is(err, service.ErrEpisodeExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
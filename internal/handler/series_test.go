@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockSeriesService struct {
+	CreateFunc         func(series *model.Series) error
+	GetByIDFunc        func(seriesID uuid.UUID) (*model.Series, error)
+	UpdateFunc         func(seriesID uuid.UUID, series model.Series) error
+	DeleteFunc         func(seriesID uuid.UUID) error
+	SearchFunc         func(titleFragment, actorNameFragment string) ([]*model.Series, error)
+	CreateSeasonFunc   func(seriesID uuid.UUID, season *model.Season) error
+	DeleteSeasonFunc   func(seriesID uuid.UUID, seasonNumber int) error
+	CreateEpisodeFunc  func(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error
+	GetEpisodeByIDFunc func(episodeID uuid.UUID) (*model.Episode, error)
+	UpdateEpisodeFunc  func(episodeID uuid.UUID, episode model.Episode) error
+	DeleteEpisodeFunc  func(episodeID uuid.UUID) error
+}
+
+func (m *mockSeriesService) Create(series *model.Series) error {
+	return m.CreateFunc(series)
+}
+
+func (m *mockSeriesService) GetByID(seriesID uuid.UUID) (*model.Series, error) {
+	return m.GetByIDFunc(seriesID)
+}
+
+func (m *mockSeriesService) Update(seriesID uuid.UUID, series model.Series) error {
+	return m.UpdateFunc(seriesID, series)
+}
+
+func (m *mockSeriesService) Delete(seriesID uuid.UUID) error {
+	return m.DeleteFunc(seriesID)
+}
+
+func (m *mockSeriesService) Search(titleFragment, actorNameFragment string) ([]*model.Series, error) {
+	return m.SearchFunc(titleFragment, actorNameFragment)
+}
+
+func (m *mockSeriesService) CreateSeason(seriesID uuid.UUID, season *model.Season) error {
+	return m.CreateSeasonFunc(seriesID, season)
+}
+
+func (m *mockSeriesService) DeleteSeason(seriesID uuid.UUID, seasonNumber int) error {
+	return m.DeleteSeasonFunc(seriesID, seasonNumber)
+}
+
+func (m *mockSeriesService) CreateEpisode(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error {
+	return m.CreateEpisodeFunc(seriesID, seasonNumber, episode)
+}
+
+func (m *mockSeriesService) GetEpisodeByID(episodeID uuid.UUID) (*model.Episode, error) {
+	return m.GetEpisodeByIDFunc(episodeID)
+}
+
+func (m *mockSeriesService) UpdateEpisode(episodeID uuid.UUID, episode model.Episode) error {
+	return m.UpdateEpisodeFunc(episodeID, episode)
+}
+
+func (m *mockSeriesService) DeleteEpisode(episodeID uuid.UUID) error {
+	return m.DeleteEpisodeFunc(episodeID)
+}
+
+func TestSeriesHandler_CreateEpisode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		seasonNumber       string
+		body               string
+		createEpisodeFunc  func(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error
+		expectedStatusCode int
+	}{
+		{
+			name:         "Success",
+			seasonNumber: "1",
+			body:         `{"Number": 1, "Title": "Pilot", "AirDate": "1990-04-08T00:00:00Z"}`,
+			createEpisodeFunc: func(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error {
+				if seasonNumber != 1 {
+					return sql.ErrNoRows
+				}
+				return nil
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "InvalidSeasonNumber",
+			seasonNumber:       "first",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:         "InvalidEpisode",
+			seasonNumber: "1",
+			body:         `{"Number": 0}`,
+			createEpisodeFunc: func(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error {
+				return service.ErrInvalidEpisode
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:         "EpisodeExists",
+			seasonNumber: "1",
+			body:         `{"Number": 1, "Title": "Pilot", "AirDate": "1990-04-08T00:00:00Z"}`,
+			createEpisodeFunc: func(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error {
+				return service.ErrEpisodeExists
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:         "SeasonNotFound",
+			seasonNumber: "9",
+			body:         `{"Number": 1, "Title": "Pilot", "AirDate": "1990-04-08T00:00:00Z"}`,
+			createEpisodeFunc: func(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			seriesHandler := NewSeriesHandler(&mockSeriesService{CreateEpisodeFunc: tc.createEpisodeFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /series/{id}/seasons/{number}/episodes", seriesHandler.CreateEpisode)
+
+			url := "/series/" + uuid.New().String() + "/seasons/" + tc.seasonNumber + "/episodes"
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewBufferString(tc.body))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestSeriesHandler_Search(t *testing.T) {
+	t.Parallel()
+
+	seriesHandler := NewSeriesHandler(&mockSeriesService{
+		SearchFunc: func(titleFragment, actorNameFragment string) ([]*model.Series, error) {
+			if titleFragment != "Peaks" || actorNameFragment != "MacLachlan" {
+				return nil, sql.ErrConnDone
+			}
+			return []*model.Series{{Title: "Twin Peaks"}}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/series?title=Peaks&actor=MacLachlan", nil)
+
+	recorder := httptest.NewRecorder()
+	seriesHandler.Search(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
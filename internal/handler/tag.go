@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// TagHandler handles HTTP requests related to the tags of movies.
+type TagHandler struct {
+	tagService service.TagService
+}
+
+// NewTagHandler creates a new TagHandler instance.
+func NewTagHandler(tagService service.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// GetAll handles the HTTP request to retrieve all tags.
+// @Summary Get tags
+// @Description Retrieve all tags in alphabetical order with the number of movies tagged with them
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.Tag "Tags retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch tags"
+// @Router /tags [get]
+func (th *TagHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetAll Tags request...")
+
+	tags, err := th.tagService.GetAll()
+	if err != nil {
+		http.Error(w, "Failed to fetch tags", http.StatusInternalServerError)
+		log.Printf("Failed to fetch tags: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tags)
+
+	log.Printf("GetAll Tags request handled successfully.")
+}
+
+// GetStats handles the HTTP request to retrieve the usage statistics of the tags.
+// @Summary Get tag statistics
+// @Description Retrieve the most used tags with the number of movies tagged with them, e.g. to draw a tag cloud
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param limit query int false "Maximum number of tags, 50 by default and 500 at most"
+// @Success 200 {object} []model.Tag "Tag statistics retrieved successfully"
+// @Failure 400 {string} string "Invalid limit"
+// @Failure 500 {string} string "Failed to fetch tag statistics"
+// @Router /tags/stats [get]
+func (th *TagHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetStats Tags request...")
+
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			log.Printf("Invalid limit: %s", limitStr)
+			return
+		}
+	}
+
+	tags, err := th.tagService.GetStats(limit)
+	if err != nil {
+		writeTagError(w, err, "Tag not found", "Failed to fetch tag statistics")
+		log.Printf("Failed to fetch tag statistics: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tags)
+
+	log.Printf("GetStats Tags request handled successfully.")
+}
+
+// Rename handles the HTTP request to rename a tag.
+// @Summary Rename a tag
+// @Description Change the name of a tag, names are stored in lower case
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the tag"
+// @Param tag body model.Tag true "Tag object, only Name is read"
+// @Success 200 {object} model.Tag "Tag renamed"
+// @Failure 400 {string} string "Invalid tag ID, failed to decode request body or invalid tag name"
+// @Failure 404 {string} string "Tag not found"
+// @Failure 409 {string} string "Another tag already has the name"
+// @Failure 500 {string} string "Failed to rename tag"
+// @Router /tags/{id} [put]
+func (th *TagHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Rename Tag request...")
+
+	tagIDStr := r.PathValue("id")
+	tagID, err := uuid.Parse(tagIDStr)
+	if err != nil {
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		log.Printf("Invalid tag ID: %s", tagIDStr)
+		return
+	}
+
+	var tag model.Tag
+	if err := json.NewDecoder(r.Body).Decode(&tag); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	renamed, err := th.tagService.Rename(tagID, tag.Name)
+	if err != nil {
+		writeTagError(w, err, "Tag not found", "Failed to rename tag")
+		log.Printf("Failed to rename tag: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, renamed)
+
+	log.Printf("Rename Tag request handled successfully.")
+}
+
+// Merge handles the HTTP request to merge a tag into another one.
+// @Summary Merge tags
+// @Description Move the movies of a tag to another tag and delete the merged tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the tag to merge"
+// @Param targetId path string true "ID of the tag to merge into"
+// @Success 200 {object} model.Tag "Tags merged, the target tag is returned"
+// @Failure 400 {string} string "Invalid tag ID or tag merged into itself"
+// @Failure 404 {string} string "Tag not found"
+// @Failure 500 {string} string "Failed to merge tags"
+// @Router /tags/{id}/merge/{targetId} [post]
+func (th *TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Merge Tag request...")
+
+	sourceIDStr := r.PathValue("id")
+	sourceID, err := uuid.Parse(sourceIDStr)
+	if err != nil {
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		log.Printf("Invalid tag ID: %s", sourceIDStr)
+		return
+	}
+
+	targetIDStr := r.PathValue("targetId")
+	targetID, err := uuid.Parse(targetIDStr)
+	if err != nil {
+		http.Error(w, "Invalid target tag ID", http.StatusBadRequest)
+		log.Printf("Invalid target tag ID: %s", targetIDStr)
+		return
+	}
+
+	target, err := th.tagService.Merge(sourceID, targetID)
+	if err != nil {
+		writeTagError(w, err, "Tag not found", "Failed to merge tags")
+		log.Printf("Failed to merge tags: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, target)
+
+	log.Printf("Merge Tag request handled successfully.")
+}
+
+// Delete handles the HTTP request to delete a tag.
+// @Summary Delete a tag
+// @Description Delete a tag, removing it from all movies
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the tag"
+// @Success 200 {string} string "Tag deleted"
+// @Failure 400 {string} string "Invalid tag ID"
+// @Failure 500 {string} string "Failed to delete tag"
+// @Router /tags/{id} [delete]
+func (th *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Delete Tag request...")
+
+	tagIDStr := r.PathValue("id")
+	tagID, err := uuid.Parse(tagIDStr)
+	if err != nil {
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		log.Printf("Invalid tag ID: %s", tagIDStr)
+		return
+	}
+
+	if err := th.tagService.Delete(tagID); err != nil {
+		http.Error(w, "Failed to delete tag", http.StatusInternalServerError)
+		log.Printf("Failed to delete tag: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Delete Tag request handled successfully.")
+}
+
+// writeTagError maps the errors of the tag service to HTTP responses.
+func writeTagError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidTag), errors.Is(err, service.ErrInvalidTagMerge),
+		errors.Is(err, service.ErrInvalidTagLimit):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrTagExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
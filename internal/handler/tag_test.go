@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockTagService struct {
+	GetAllFunc   func() ([]*model.Tag, error)
+	GetStatsFunc func(limit int) ([]*model.Tag, error)
+	RenameFunc   func(tagID uuid.UUID, name string) (*model.Tag, error)
+	MergeFunc    func(sourceID, targetID uuid.UUID) (*model.Tag, error)
+	DeleteFunc   func(tagID uuid.UUID) error
+}
+
+func (m *mockTagService) GetAll() ([]*model.Tag, error) {
+	return m.GetAllFunc()
+}
+
+func (m *mockTagService) GetStats(limit int) ([]*model.Tag, error) {
+	return m.GetStatsFunc(limit)
+}
+
+func (m *mockTagService) Rename(tagID uuid.UUID, name string) (*model.Tag, error) {
+	return m.RenameFunc(tagID, name)
+}
+
+func (m *mockTagService) Merge(sourceID, targetID uuid.UUID) (*model.Tag, error) {
+	return m.MergeFunc(sourceID, targetID)
+}
+
+func (m *mockTagService) Delete(tagID uuid.UUID) error {
+	return m.DeleteFunc(tagID)
+}
+
+func TestTagHandler_Rename(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		tagID              string
+		body               string
+		renameFunc         func(tagID uuid.UUID, name string) (*model.Tag, error)
+		expectedStatusCode int
+	}{
+		{
+			name:  "Success",
+			tagID: uuid.New().String(),
+			body:  `{"Name": "Time Travel"}`,
+			renameFunc: func(tagID uuid.UUID, name string) (*model.Tag, error) {
+				return &model.Tag{ID: tagID, Name: "time travel"}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidTagID",
+			tagID:              "invalid",
+			body:               `{"Name": "heist"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "NameTaken",
+			tagID: uuid.New().String(),
+			body:  `{"Name": "heist"}`,
+			renameFunc: func(tagID uuid.UUID, name string) (*model.Tag, error) {
+				return nil, service.ErrTagExists
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:  "TagNotFound",
+			tagID: uuid.New().String(),
+			body:  `{"Name": "heist"}`,
+			renameFunc: func(tagID uuid.UUID, name string) (*model.Tag, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tagHandler := NewTagHandler(&mockTagService{RenameFunc: tc.renameFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /tags/{id}", tagHandler.Rename)
+
+			req := httptest.NewRequest(http.MethodPut, "/tags/"+tc.tagID, bytes.NewBufferString(tc.body))
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestTagHandler_GetStats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		query              string
+		getStatsFunc       func(limit int) ([]*model.Tag, error)
+		expectedStatusCode int
+	}{
+		{
+			name:  "Success",
+			query: "?limit=10",
+			getStatsFunc: func(limit int) ([]*model.Tag, error) {
+				return []*model.Tag{{Name: "heist", MovieCount: 3}}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidLimit",
+			query:              "?limit=many",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "LimitTooLarge",
+			query: "?limit=1000",
+			getStatsFunc: func(limit int) ([]*model.Tag, error) {
+				return nil, service.ErrInvalidTagLimit
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tagHandler := NewTagHandler(&mockTagService{GetStatsFunc: tc.getStatsFunc})
+
+			req := httptest.NewRequest(http.MethodGet, "/tags/stats"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+			tagHandler.GetStats(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
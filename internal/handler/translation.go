@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// TranslationHandler handles HTTP requests related to the translated titles and descriptions of movies.
+type TranslationHandler struct {
+	translationService service.TranslationService
+}
+
+// NewTranslationHandler creates a new TranslationHandler instance.
+func NewTranslationHandler(translationService service.TranslationService) *TranslationHandler {
+	return &TranslationHandler{
+		translationService: translationService,
+	}
+}
+
+// GetByMovie handles the HTTP request to retrieve the translations of a movie.
+// @Summary Get the translations of a movie
+// @Description Retrieve the translated titles and descriptions of a movie ordered by language
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Success 200 {object} []model.MovieTranslation "Translations retrieved successfully"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Failed to fetch translations"
+// @Router /movies/{id}/translations [get]
+func (th *TranslationHandler) GetByMovie(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetByMovie Translation request...")
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	translations, err := th.translationService.GetTranslations(movieID)
+	if err != nil {
+		writeTranslationError(w, err, "Movie not found", "Failed to fetch translations")
+		log.Printf("Failed to fetch translations: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, translations)
+
+	log.Printf("GetByMovie Translation request handled successfully.")
+}
+
+// Put handles the HTTP request to set the translation of a movie into a language.
+// @Summary Translate a movie
+// @Description Create or replace the title and description of a movie in a language
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Param language path string true "BCP 47 language tag, e.g. de or pt-BR"
+// @Param translation body model.MovieTranslation true "Translation object, Title and Description are read"
+// @Success 200 {object} model.MovieTranslation "Translation saved"
+// @Failure 400 {string} string "Invalid movie ID, failed to decode request body, invalid language tag or invalid translation"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Failed to save translation"
+// @Router /movies/{id}/translations/{language} [put]
+func (th *TranslationHandler) Put(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Put Translation request...")
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	var translation model.MovieTranslation
+	if err := json.NewDecoder(r.Body).Decode(&translation); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+	translation.MovieID = movieID
+	translation.Language = r.PathValue("language")
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	if err := th.translationService.SetTranslation(&translation, username); err != nil {
+		writeTranslationError(w, err, "Movie not found", "Failed to save translation")
+		log.Printf("Failed to save translation: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, translation)
+
+	log.Printf("Put Translation request handled successfully.")
+}
+
+// Delete handles the HTTP request to remove the translation of a movie into a language.
+// @Summary Remove a translation
+// @Description Remove the title and description of a movie in a language
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Param language path string true "BCP 47 language tag, e.g. de or pt-BR"
+// @Success 200 {string} string "Translation removed"
+// @Failure 400 {string} string "Invalid movie ID or language tag"
+// @Failure 404 {string} string "Translation not found"
+// @Failure 500 {string} string "Failed to remove translation"
+// @Router /movies/{id}/translations/{language} [delete]
+func (th *TranslationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling Delete Translation request...")
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	if err := th.translationService.DeleteTranslation(movieID, r.PathValue("language"), username); err != nil {
+		writeTranslationError(w, err, "Translation not found", "Failed to remove translation")
+		log.Printf("Failed to remove translation: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("Delete Translation request handled successfully.")
+}
+
+// writeTranslationError maps an error of the TranslationService to an HTTP error response.
+func writeTranslationError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidLanguageTag), errors.Is(err, service.ErrInvalidTranslation):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
+
+// parseAcceptLanguage returns the language ranges of an Accept-Language header ordered by decreasing quality.
+// Ranges with a zero quality and the "*" wildcard are left out.
+func parseAcceptLanguage(header string) []string {
+	type languageRange struct {
+		tag     string
+		quality float64
+	}
+
+	var ranges []languageRange
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		ranges = append(ranges, languageRange{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	languages := make([]string, 0, len(ranges))
+	for _, languageRange := range ranges {
+		languages = append(languages, languageRange.tag)
+	}
+	return languages
+}
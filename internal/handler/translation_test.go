@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockTranslationService struct {
+	SetTranslationFunc    func(translation *model.MovieTranslation) error
+	DeleteTranslationFunc func(movieID uuid.UUID, language string) error
+	GetTranslationsFunc   func(movieID uuid.UUID) ([]*model.MovieTranslation, error)
+	LocalizeFunc          func(languages []string, movies []*model.Movie) error
+}
+
+func (m *mockTranslationService) SetTranslation(translation *model.MovieTranslation, username string) error {
+	return m.SetTranslationFunc(translation)
+}
+
+func (m *mockTranslationService) DeleteTranslation(movieID uuid.UUID, language, username string) error {
+	return m.DeleteTranslationFunc(movieID, language)
+}
+
+func (m *mockTranslationService) GetTranslations(movieID uuid.UUID) ([]*model.MovieTranslation, error) {
+	return m.GetTranslationsFunc(movieID)
+}
+
+func (m *mockTranslationService) Localize(languages []string, movies []*model.Movie) error {
+	if m.LocalizeFunc == nil {
+		return nil
+	}
+	return m.LocalizeFunc(languages, movies)
+}
+
+func TestTranslationHandler_Put(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		movieID            string
+		language           string
+		setTranslationFunc func(translation *model.MovieTranslation) error
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			movieID:  uuid.New().String(),
+			language: "de",
+			setTranslationFunc: func(translation *model.MovieTranslation) error {
+				if translation.Language != "de" || translation.Title != "Die Verurteilten" {
+					return service.ErrInvalidTranslation
+				}
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidMovieID",
+			movieID:            "invalid",
+			language:           "de",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "InvalidLanguageTag",
+			movieID:  uuid.New().String(),
+			language: "deutsch",
+			setTranslationFunc: func(translation *model.MovieTranslation) error {
+				return service.ErrInvalidLanguageTag
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "MovieNotFound",
+			movieID:  uuid.New().String(),
+			language: "de",
+			setTranslationFunc: func(translation *model.MovieTranslation) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewTranslationHandler(&mockTranslationService{SetTranslationFunc: tc.setTranslationFunc})
+
+			body, err := json.Marshal(model.MovieTranslation{Title: "Die Verurteilten"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/movies/"+tc.movieID+"/translations/"+tc.language, bytes.NewReader(body))
+			req.SetPathValue("id", tc.movieID)
+			req.SetPathValue("language", tc.language)
+
+			recorder := httptest.NewRecorder()
+			handler.Put(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestTranslationHandler_Delete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		deleteTranslationFunc func(movieID uuid.UUID, language string) error
+		expectedStatusCode    int
+	}{
+		{
+			name: "Success",
+			deleteTranslationFunc: func(movieID uuid.UUID, language string) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "TranslationNotFound",
+			deleteTranslationFunc: func(movieID uuid.UUID, language string) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewTranslationHandler(&mockTranslationService{DeleteTranslationFunc: tc.deleteTranslationFunc})
+
+			movieID := uuid.New().String()
+			req := httptest.NewRequest(http.MethodDelete, "/movies/"+movieID+"/translations/de", nil)
+			req.SetPathValue("id", movieID)
+			req.SetPathValue("language", "de")
+
+			recorder := httptest.NewRecorder()
+			handler.Delete(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header   string
+		expected []string
+	}{
+		{header: "", expected: []string{}},
+		{header: "de", expected: []string{"de"}},
+		{header: "fr;q=0.5, de-CH, en;q=0.8, *;q=0.1", expected: []string{"de-CH", "en", "fr"}},
+		{header: "ru;q=0, pt-BR;q=0.9, es;q=invalid", expected: []string{"pt-BR"}},
+	}
+
+	for _, tc := range tests {
+		if languages := parseAcceptLanguage(tc.header); !reflect.DeepEqual(languages, tc.expected) {
+			t.Errorf("Expected %v for %q, got %v", tc.expected, tc.header, languages)
+		}
+	}
+}
+
+func TestMovieHandler_GetByTitleFragmentLocalizes(t *testing.T) {
+	t.Parallel()
+
+	movieService := &mockMovieService{
+		GetByTitleFragmentFunc: func(titleFragment string) ([]*model.Movie, error) {
+			return []*model.Movie{{Title: "The Shawshank Redemption"}}, nil
+		},
+	}
+	translationService := &mockTranslationService{
+		LocalizeFunc: func(languages []string, movies []*model.Movie) error {
+			if reflect.DeepEqual(languages, []string{"de", "en"}) {
+				movies[0].Title = "Die Verurteilten"
+				movies[0].Language = "de"
+			}
+			return nil
+		},
+	}
+	movieHandler := NewMovieHandler(movieService, &mockWatchService{}, translationService)
+
+	req := httptest.NewRequest(http.MethodGet, "/movies/getByTitleFragment?title_fragment=Shaw", nil)
+	req.Header.Set("Accept-Language", "en;q=0.5, de")
+
+	recorder := httptest.NewRecorder()
+	movieHandler.GetByTitleFragment(recorder, req)
+
+	var movies []model.Movie
+	if err := json.Unmarshal(recorder.Body.Bytes(), &movies); err != nil {
+		t.Fatal(err)
+	}
+	if len(movies) != 1 || movies[0].Title != "Die Verurteilten" {
+		t.Errorf("Expected the movie to be localized, got: %+v", movies)
+	}
+	if vary := recorder.Header().Get("Vary"); vary != "Accept-Language" {
+		t.Errorf("Expected Vary: Accept-Language, got: %q", vary)
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// TrashHandler handles HTTP requests related to the deleted actors and movies.
+type TrashHandler struct {
+	trashService service.TrashService
+}
+
+// NewTrashHandler creates a new TrashHandler instance.
+func NewTrashHandler(trashService service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// GetTrash handles the HTTP request to list the deleted actors and movies.
+// @Summary Get the trash
+// @Description Retrieve the actors and movies in the trash, most recently deleted first
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.Trash "Trash retrieved successfully"
+// @Failure 500 {string} string "Failed to fetch the trash"
+// @Router /trash [get]
+func (th *TrashHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetTrash request...")
+
+	trash, err := th.trashService.GetTrash()
+	if err != nil {
+		http.Error(w, "Failed to fetch the trash", http.StatusInternalServerError)
+		log.Printf("Failed to fetch the trash: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, trash)
+
+	log.Printf("GetTrash request handled successfully.")
+}
+
+// RestoreActor handles the HTTP request to restore a deleted actor.
+// @Summary Restore an actor
+// @Description Take an actor out of the trash, along with the credits kept while they were in it
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the actor"
+// @Success 200 {object} model.Actor "Actor restored successfully"
+// @Failure 400 {string} string "Invalid actor ID"
+// @Failure 404 {string} string "Actor not in the trash"
+// @Failure 500 {string} string "Failed to restore actor"
+// @Router /trash/actors/{id}/restore [post]
+func (th *TrashHandler) RestoreActor(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling RestoreActor request...")
+
+	actorIDStr := r.PathValue("id")
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		http.Error(w, "Invalid actor ID", http.StatusBadRequest)
+		log.Printf("Invalid actor ID: %s", actorIDStr)
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	actor, err := th.trashService.RestoreActor(actorID, username)
+	if err != nil {
+		writeTrashError(w, err, "Actor not in the trash", "Failed to restore actor")
+		log.Printf("Failed to restore actor: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, actor)
+
+	log.Printf("RestoreActor request handled successfully.")
+}
+
+// RestoreMovie handles the HTTP request to restore a deleted movie.
+// @Summary Restore a movie
+// @Description Take a movie out of the trash, along with its cast, crew and the rest of its data
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the movie"
+// @Success 200 {object} model.Movie "Movie restored successfully"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 404 {string} string "Movie not in the trash"
+// @Failure 500 {string} string "Failed to restore movie"
+// @Router /trash/movies/{id}/restore [post]
+func (th *TrashHandler) RestoreMovie(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling RestoreMovie request...")
+
+	movieIDStr := r.PathValue("id")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	username, _ := middleware.UsernameFromContext(r.Context())
+	movie, err := th.trashService.RestoreMovie(movieID, username)
+	if err != nil {
+		writeTrashError(w, err, "Movie not in the trash", "Failed to restore movie")
+		log.Printf("Failed to restore movie: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, movie)
+
+	log.Printf("RestoreMovie request handled successfully.")
+}
+
+// writeTrashError maps the errors of the trash service to HTTP responses.
+func writeTrashError(w http.ResponseWriter, err error, notFoundMessage, failureMessage string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+		return
+	}
+	http.Error(w, failureMessage, http.StatusInternalServerError)
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockTrashService struct {
+	GetTrashFunc     func() (*model.Trash, error)
+	RestoreActorFunc func(actorID uuid.UUID) (*model.Actor, error)
+	RestoreMovieFunc func(movieID uuid.UUID) (*model.Movie, error)
+	PurgeFunc        func(retention time.Duration) (int, error)
+}
+
+func (m *mockTrashService) GetTrash() (*model.Trash, error) {
+	return m.GetTrashFunc()
+}
+
+func (m *mockTrashService) RestoreActor(actorID uuid.UUID, username string) (*model.Actor, error) {
+	return m.RestoreActorFunc(actorID)
+}
+
+func (m *mockTrashService) RestoreMovie(movieID uuid.UUID, username string) (*model.Movie, error) {
+	return m.RestoreMovieFunc(movieID)
+}
+
+func (m *mockTrashService) Purge(retention time.Duration) (int, error) {
+	return m.PurgeFunc(retention)
+}
+
+func TestTrashHandler_GetTrash(t *testing.T) {
+	t.Parallel()
+
+	trashHandler := NewTrashHandler(&mockTrashService{
+		GetTrashFunc: func() (*model.Trash, error) {
+			return &model.Trash{
+				Actors: []model.TrashItem{},
+				Movies: []model.TrashItem{{ID: uuid.New(), Name: "Speed", DeletedAt: time.Now()}},
+			}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/trash", nil)
+	recorder := httptest.NewRecorder()
+	trashHandler.GetTrash(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func TestTrashHandler_RestoreActor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		actorID            string
+		restoreFunc        func(actorID uuid.UUID) (*model.Actor, error)
+		expectedStatusCode int
+	}{
+		{
+			name:    "Success",
+			actorID: uuid.New().String(),
+			restoreFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+				return &model.Actor{ID: actorID, Name: "Tom Hanks"}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidActorID",
+			actorID:            "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "ActorNotInTrash",
+			actorID: uuid.New().String(),
+			restoreFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trashHandler := NewTrashHandler(&mockTrashService{RestoreActorFunc: tc.restoreFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /trash/actors/{id}/restore", trashHandler.RestoreActor)
+
+			req := httptest.NewRequest(http.MethodPost, "/trash/actors/"+tc.actorID+"/restore", nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestTrashHandler_RestoreMovie(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		movieID            string
+		restoreFunc        func(movieID uuid.UUID) (*model.Movie, error)
+		expectedStatusCode int
+	}{
+		{
+			name:    "Success",
+			movieID: uuid.New().String(),
+			restoreFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+				return &model.Movie{ID: movieID, Title: "Speed"}, nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "MovieNotInTrash",
+			movieID: uuid.New().String(),
+			restoreFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+				return nil, sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trashHandler := NewTrashHandler(&mockTrashService{RestoreMovieFunc: tc.restoreFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /trash/movies/{id}/restore", trashHandler.RestoreMovie)
+
+			req := httptest.NewRequest(http.MethodPost, "/trash/movies/"+tc.movieID+"/restore", nil)
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -37,6 +37,7 @@ func NewUserHandler(userService service.UserService) *UserHandler {
 // @Param password formData string true "Password"
 // @Success 201 {string} string "User created successfully"
 // @Failure 400 {string} string "Failed to parse form or username and password are required"
+// @Failure 422 {object} service.ValidationError "Username longer than 30 characters or password longer than 72 bytes"
 // @Failure 500 {string} string "Failed to create user"
 // @Router /register [post]
 func (uh *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
@@ -63,6 +64,9 @@ func (uh *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 	err = uh.userService.Register(user)
 	if err != nil {
+		if writeValidationError(w, err) {
+			return
+		}
 		http.Error(w, "Failed to create user", http.StatusInternalServerError)
 		log.Printf("Failed to create user: %v", err)
 		return
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
 )
 
 type mockUserService struct {
@@ -64,6 +65,19 @@ func TestUserHandler_Register(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "InvalidFields",
+			formData: map[string]string{
+				"username": "testuser",
+				"password": "testpassword",
+			},
+			registerFunc: func(user *model.User) error {
+				return &service.ValidationError{Fields: []service.FieldError{
+					{Field: "Username", Rule: service.RuleMaxLength, Message: "must not exceed 30 characters"},
+				}}
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, tc := range tests {
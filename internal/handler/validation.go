@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// writeValidationError writes the fields of an input breaking their rules as an unprocessable entity response,
+// reporting whether err is a validation error.
+func writeValidationError(w http.ResponseWriter, err error) bool {
+	var validationErr *service.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+
+	writeJSON(w, http.StatusUnprocessableEntity, validationErr)
+	log.Printf("Invalid input: %v", err)
+	return true
+}
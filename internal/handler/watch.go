@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+// WatchHandler handles HTTP requests related to the watchlist and watch diary of the current user.
+type WatchHandler struct {
+	watchService service.WatchService
+}
+
+// NewWatchHandler creates a new WatchHandler instance.
+func NewWatchHandler(watchService service.WatchService) *WatchHandler {
+	return &WatchHandler{
+		watchService: watchService,
+	}
+}
+
+// GetWatchlist handles the HTTP request to retrieve the watchlist of the current user.
+// @Summary Get my watchlist
+// @Description Retrieve the movies the authenticated user plans to watch, most recently added first
+// @Tags me
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.WatchlistEntry "Watchlist retrieved successfully"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Failed to fetch watchlist"
+// @Router /me/watchlist [get]
+func (wh *WatchHandler) GetWatchlist(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetWatchlist request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	entries, err := wh.watchService.GetWatchlist(username)
+	if err != nil {
+		writeWatchError(w, err, "Failed to fetch watchlist")
+		log.Printf("Failed to fetch watchlist: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+
+	log.Printf("GetWatchlist request handled successfully.")
+}
+
+// AddToWatchlist handles the HTTP request to add a movie to the watchlist of the current user.
+// @Summary Add a movie to my watchlist
+// @Description Add a movie to the watchlist of the authenticated user
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param movieId path string true "ID of the movie"
+// @Success 200 {string} string "Movie added to the watchlist"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Failed to add movie to watchlist"
+// @Router /me/watchlist/{movieId} [put]
+func (wh *WatchHandler) AddToWatchlist(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling AddToWatchlist request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	movieIDStr := r.PathValue("movieId")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	if err := wh.watchService.AddToWatchlist(username, movieID); err != nil {
+		writeWatchError(w, err, "Failed to add movie to watchlist")
+		log.Printf("Failed to add movie to watchlist: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("AddToWatchlist request handled successfully.")
+}
+
+// RemoveFromWatchlist handles the HTTP request to remove a movie from the watchlist of the current user.
+// @Summary Remove a movie from my watchlist
+// @Description Remove a movie from the watchlist of the authenticated user
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param movieId path string true "ID of the movie"
+// @Success 200 {string} string "Movie removed from the watchlist"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Movie is not on the watchlist"
+// @Failure 500 {string} string "Failed to remove movie from watchlist"
+// @Router /me/watchlist/{movieId} [delete]
+func (wh *WatchHandler) RemoveFromWatchlist(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling RemoveFromWatchlist request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	movieIDStr := r.PathValue("movieId")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	if err := wh.watchService.RemoveFromWatchlist(username, movieID); err != nil {
+		writeWatchError(w, err, "Failed to remove movie from watchlist")
+		log.Printf("Failed to remove movie from watchlist: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("RemoveFromWatchlist request handled successfully.")
+}
+
+// GetDiary handles the HTTP request to retrieve the watch diary of the current user.
+// @Summary Get my diary
+// @Description Retrieve the movies the authenticated user has watched, most recently watched first
+// @Tags me
+// @Accept json
+// @Produce json
+// @Success 200 {object} []model.DiaryEntry "Diary retrieved successfully"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Failed to fetch diary"
+// @Router /me/diary [get]
+func (wh *WatchHandler) GetDiary(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling GetDiary request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	entries, err := wh.watchService.GetDiary(username)
+	if err != nil {
+		writeWatchError(w, err, "Failed to fetch diary")
+		log.Printf("Failed to fetch diary: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+
+	log.Printf("GetDiary request handled successfully.")
+}
+
+// LogWatch handles the HTTP request to record that the current user has watched a movie.
+// @Summary Log a watched movie
+// @Description Record a viewing of a movie in the diary of the authenticated user, logging it again counts as a rewatch.
+// @Description The movie is removed from the watchlist.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param entry body model.DiaryEntry true "Diary entry, MovieID, WatchedAt (defaults to now) and Note are read"
+// @Success 200 {object} model.DiaryEntry "Viewing logged"
+// @Failure 400 {string} string "Failed to decode request body, invalid date or note"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Movie not found"
+// @Failure 500 {string} string "Failed to log viewing"
+// @Router /me/diary [post]
+func (wh *WatchHandler) LogWatch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling LogWatch request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	var entry model.DiaryEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		http.Error(w, "Failed to decode request body", http.StatusBadRequest)
+		log.Printf("Failed to decode request body: %v", err)
+		return
+	}
+
+	if err := wh.watchService.LogWatch(username, &entry); err != nil {
+		writeWatchError(w, err, "Failed to log viewing")
+		log.Printf("Failed to log viewing: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entry)
+
+	log.Printf("LogWatch request handled successfully.")
+}
+
+// RemoveFromDiary handles the HTTP request to remove a movie from the watch diary of the current user.
+// @Summary Remove a movie from my diary
+// @Description Remove a movie from the diary of the authenticated user
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param movieId path string true "ID of the movie"
+// @Success 200 {string} string "Movie removed from the diary"
+// @Failure 400 {string} string "Invalid movie ID"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Movie is not in the diary"
+// @Failure 500 {string} string "Failed to remove movie from diary"
+// @Router /me/diary/{movieId} [delete]
+func (wh *WatchHandler) RemoveFromDiary(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handling RemoveFromDiary request...")
+
+	username, ok := middleware.UsernameFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		log.Printf("Missing username in request context")
+		return
+	}
+
+	movieIDStr := r.PathValue("movieId")
+	movieID, err := uuid.Parse(movieIDStr)
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		log.Printf("Invalid movie ID: %s", movieIDStr)
+		return
+	}
+
+	if err := wh.watchService.RemoveFromDiary(username, movieID); err != nil {
+		writeWatchError(w, err, "Failed to remove movie from diary")
+		log.Printf("Failed to remove movie from diary: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Printf("RemoveFromDiary request handled successfully.")
+}
+
+// writeWatchError maps the errors of the watch service to HTTP responses.
+func writeWatchError(w http.ResponseWriter, err error, failureMessage string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidWatchDate), errors.Is(err, service.ErrInvalidDiaryNote):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrUserNotFound):
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Movie not found", http.StatusNotFound)
+	default:
+		http.Error(w, failureMessage, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/middleware"
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/service"
+)
+
+type mockWatchService struct {
+	AddToWatchlistFunc      func(username string, movieID uuid.UUID) error
+	RemoveFromWatchlistFunc func(username string, movieID uuid.UUID) error
+	GetWatchlistFunc        func(username string) ([]*model.WatchlistEntry, error)
+	LogWatchFunc            func(username string, entry *model.DiaryEntry) error
+	RemoveFromDiaryFunc     func(username string, movieID uuid.UUID) error
+	GetDiaryFunc            func(username string) ([]*model.DiaryEntry, error)
+	MarkWatchStatusFunc     func(username string, movies []*model.Movie) error
+}
+
+func (m *mockWatchService) AddToWatchlist(username string, movieID uuid.UUID) error {
+	return m.AddToWatchlistFunc(username, movieID)
+}
+
+func (m *mockWatchService) RemoveFromWatchlist(username string, movieID uuid.UUID) error {
+	return m.RemoveFromWatchlistFunc(username, movieID)
+}
+
+func (m *mockWatchService) GetWatchlist(username string) ([]*model.WatchlistEntry, error) {
+	return m.GetWatchlistFunc(username)
+}
+
+func (m *mockWatchService) LogWatch(username string, entry *model.DiaryEntry) error {
+	return m.LogWatchFunc(username, entry)
+}
+
+func (m *mockWatchService) RemoveFromDiary(username string, movieID uuid.UUID) error {
+	return m.RemoveFromDiaryFunc(username, movieID)
+}
+
+func (m *mockWatchService) GetDiary(username string) ([]*model.DiaryEntry, error) {
+	return m.GetDiaryFunc(username)
+}
+
+func (m *mockWatchService) MarkWatchStatus(username string, movies []*model.Movie) error {
+	return m.MarkWatchStatusFunc(username, movies)
+}
+
+func TestWatchHandler_AddToWatchlist(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		username           string
+		movieID            string
+		addToWatchlistFunc func(username string, movieID uuid.UUID) error
+		expectedStatusCode int
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			addToWatchlistFunc: func(username string, movieID uuid.UUID) error {
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Unauthorized",
+			movieID:            uuid.New().String(),
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "InvalidMovieID",
+			username:           "forrest",
+			movieID:            "invalid",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "MovieNotFound",
+			username: "forrest",
+			movieID:  uuid.New().String(),
+			addToWatchlistFunc: func(username string, movieID uuid.UUID) error {
+				return sql.ErrNoRows
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			watchHandler := NewWatchHandler(&mockWatchService{AddToWatchlistFunc: tc.addToWatchlistFunc})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /me/watchlist/{movieId}", watchHandler.AddToWatchlist)
+
+			req := httptest.NewRequest(http.MethodPut, "/me/watchlist/"+tc.movieID, nil)
+			req = req.WithContext(middleware.WithUsername(req.Context(), tc.username))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestWatchHandler_LogWatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		body               string
+		logWatchFunc       func(username string, entry *model.DiaryEntry) error
+		expectedStatusCode int
+	}{
+		{
+			name: "Success",
+			body: `{"MovieID": "` + uuid.New().String() + `", "Note": "Cried again"}`,
+			logWatchFunc: func(username string, entry *model.DiaryEntry) error {
+				entry.RewatchCount = 1
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "InvalidBody",
+			body:               `{"MovieID": 42}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "FutureDate",
+			body: `{"MovieID": "` + uuid.New().String() + `", "WatchedAt": "2999-01-01T00:00:00Z"}`,
+			logWatchFunc: func(username string, entry *model.DiaryEntry) error {
+				return service.ErrInvalidWatchDate
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			watchHandler := NewWatchHandler(&mockWatchService{LogWatchFunc: tc.logWatchFunc})
+
+			req := httptest.NewRequest(http.MethodPost, "/me/diary", bytes.NewBufferString(tc.body))
+			req = req.WithContext(middleware.WithUsername(req.Context(), "forrest"))
+
+			recorder := httptest.NewRecorder()
+			watchHandler.LogWatch(recorder, req)
+
+			if recorder.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestWatchHandler_GetDiary(t *testing.T) {
+	t.Parallel()
+
+	watchHandler := NewWatchHandler(&mockWatchService{
+		GetDiaryFunc: func(username string) ([]*model.DiaryEntry, error) {
+			return []*model.DiaryEntry{{Movie: &model.Movie{Title: "Forrest Gump", Watched: true}, RewatchCount: 2}}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/me/diary", nil)
+	req = req.WithContext(middleware.WithUsername(req.Context(), "forrest"))
+
+	recorder := httptest.NewRecorder()
+	watchHandler.GetDiary(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	var entries []model.DiaryEntry
+	if err := json.Unmarshal(recorder.Body.Bytes(), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].RewatchCount != 2 {
+		t.Errorf("Unexpected diary: %+v", entries)
+	}
+}
+
+func TestMovieHandler_GetAllWithSortingMarksWatchStatus(t *testing.T) {
+	t.Parallel()
+
+	movieID := uuid.New()
+	movieService := &mockMovieService{
+		GetAllWithSortingFunc: func(flag int, filter model.MovieFilter) ([]*model.Movie, error) {
+			return []*model.Movie{{ID: movieID, Title: "Forrest Gump"}}, nil
+		},
+	}
+	watchService := &mockWatchService{
+		MarkWatchStatusFunc: func(username string, movies []*model.Movie) error {
+			for _, movie := range movies {
+				movie.OnWatchlist = username == "forrest" && movie.ID == movieID
+			}
+			return nil
+		},
+	}
+	movieHandler := NewMovieHandler(movieService, watchService, &mockTranslationService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/movies/getAllWithSorting?flag=1", nil)
+	req = req.WithContext(middleware.WithUsername(req.Context(), "forrest"))
+
+	recorder := httptest.NewRecorder()
+	movieHandler.GetAllWithSorting(recorder, req)
+
+	var movies []model.Movie
+	if err := json.Unmarshal(recorder.Body.Bytes(), &movies); err != nil {
+		t.Fatal(err)
+	}
+	if len(movies) != 1 || !movies[0].OnWatchlist {
+		t.Errorf("Expected the movie to be flagged as on the watchlist, got: %+v", movies)
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import "net/http"
+
+// RequireIfMatch returns a middleware rejecting the requests without an If-Match header with a precondition required
+// response when required is set, so that a movie or an actor cannot be changed without checking its version.
+func RequireIfMatch(required bool) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		if !required {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-Match") == "" {
+				http.Error(w, "If-Match header required", http.StatusPreconditionRequired)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
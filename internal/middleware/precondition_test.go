@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireIfMatch(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		required       bool
+		ifMatch        string
+		expectedStatus int
+	}{
+		{
+			name:           "NotRequired",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing",
+			required:       true,
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:           "Present",
+			required:       true,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			RequireIfMatch(tt.required)(handler).ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, recorder.Code)
+			}
+		})
+	}
+}
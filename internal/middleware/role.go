@@ -1,12 +1,29 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt"
 )
 
+type contextKey string
+
+// usernameKey is the context key of the username taken from the "sub" claim of the token.
+const usernameKey contextKey = "username"
+
+// WithUsername returns a copy of ctx carrying the username of the authenticated user.
+func WithUsername(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, usernameKey, username)
+}
+
+// UsernameFromContext returns the username of the authenticated user stored by the auth middlewares.
+func UsernameFromContext(ctx context.Context) (string, bool) {
+	username, ok := ctx.Value(usernameKey).(string)
+	return username, ok && username != ""
+}
+
 func AuthAdminMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
@@ -48,7 +65,8 @@ func AuthAdminMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		username, _ := claims["sub"].(string)
+		next.ServeHTTP(w, r.WithContext(WithUsername(r.Context(), username)))
 	})
 }
 
@@ -92,6 +110,7 @@ func AuthUserMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		username, _ := claims["sub"].(string)
+		next.ServeHTTP(w, r.WithContext(WithUsername(r.Context(), username)))
 	})
 }
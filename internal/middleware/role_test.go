@@ -142,3 +142,33 @@ func TestAuthUserMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthUserMiddleware_StoresUsername(t *testing.T) {
+	t.Parallel()
+
+	var username string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, _ = UsernameFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	payload := jwt.MapClaims{
+		"role": "user",
+		"sub":  "forrest",
+		"exp":  time.Now().Add(time.Hour * 72).Unix(),
+	}
+	tk, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, payload).SignedString([]byte("your-secret-key"))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+tk)
+
+	recorder := httptest.NewRecorder()
+	AuthUserMiddleware(handler).ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if username != "forrest" {
+		t.Errorf("Expected username %q, got %q", "forrest", username)
+	}
+}
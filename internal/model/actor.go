@@ -8,12 +8,8 @@ import (
 )
 
 // Actor represents information about an actor.
-type Actor struct {
-	ID        uuid.UUID // Unique identifier of the actor
-	Name      string    // Name of the actor
-	Gender    string    // Gender of the actor
-	BirthDate time.Time // Birth date of the actor
-}
+// Actors are stored as people, so the type is kept as an alias of Person.
+type Actor = Person
 
 // ActorMovies information about an actor and a list of films with his participation.
 type ActorMovies struct {
@@ -21,5 +17,70 @@ type ActorMovies struct {
 	Name      string    // Name of the actor
 	Gender    string    // Gender of the actor
 	BirthDate time.Time // Birth date of the actor
+	FilmCount int       // Number of movies the actor starred in
 	Movies    []*Movie
 }
+
+// Sort orders of actor listings.
+const (
+	ActorSortName      = "name"
+	ActorSortBirthDate = "birth_date"
+	ActorSortFilmCount = "film_count"
+)
+
+// Delete policies of actors, telling what happens to the credits of an actor moved to the trash.
+const (
+	ActorDeleteReject = "reject" // Refuse to delete an actor credited on movies
+	ActorDeleteDetach = "detach" // Remove the credits of the actor along with the actor
+	ActorDeleteSoft   = "soft"   // Keep the credits of the actor, hidden while the actor is in the trash
+)
+
+// ActorQuery holds the criteria of an actor listing, zero values match every actor.
+type ActorQuery struct {
+	Name          string // Fragment of the name or of an alias of the actor, case-insensitive
+	Gender        string // Gender of the actor, case-insensitive
+	MinBirthYear  int    // Earliest birth year of the actor
+	MaxBirthYear  int    // Latest birth year of the actor
+	Sort          string // Sort order of the actors, by name when empty
+	Descending    bool   // Whether the actors are listed in descending order
+	IncludeMovies bool   // Whether the movies of the listed actors are loaded
+}
+
+// ActorPage represents a page of an actor listing.
+type ActorPage struct {
+	Actors      []*ActorMovies
+	Page        int // Number of the page, starting at 1
+	PageSize    int // Maximum number of actors of the page
+	TotalActors int // Number of actors matching the query across all pages
+}
+
+// Collaborator represents an actor who starred in movies with another actor.
+type Collaborator struct {
+	ID           uuid.UUID // Unique identifier of the collaborator
+	Name         string    // Name of the collaborator
+	SharedMovies int       // Number of movies both actors starred in
+	Movies       []string  // Titles of the shared movies, oldest first
+}
+
+// CoStarLink represents two actors starring in the same movie.
+type CoStarLink struct {
+	ActorID    uuid.UUID // Unique identifier of the actor
+	CoStarID   uuid.UUID // Unique identifier of the co-star
+	CoStarName string    // Name of the co-star
+	MovieID    uuid.UUID // Unique identifier of the shared movie
+	MovieTitle string    // Title of the shared movie
+}
+
+// Connection represents the shortest chain of movies connecting two actors.
+type Connection struct {
+	Degrees int              // Number of movies of the chain, 0 when both actors are the same
+	Steps   []ConnectionStep // Actors of the chain, from the first actor to the second one
+}
+
+// ConnectionStep represents an actor of a chain along with the movie they share with the previous actor.
+type ConnectionStep struct {
+	ActorID    uuid.UUID // Unique identifier of the actor
+	ActorName  string    // Name of the actor
+	MovieID    uuid.UUID // Unique identifier of the movie shared with the previous actor, zero for the first actor
+	MovieTitle string    // Title of the movie shared with the previous actor
+}
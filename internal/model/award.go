@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Award represents an award handed out at recurring ceremonies, e.g. "Academy Awards" or "Cannes Film Festival".
+type Award struct {
+	ID          uuid.UUID       // Unique identifier of the award
+	Name        string          // Name of the award
+	Description string          // Optional description of the award
+	Categories  []AwardCategory // Categories of the award ordered by name, only filled when a single award is fetched
+	Ceremonies  []Ceremony      // Ceremonies of the award, most recent first, only filled when a single award is fetched
+}
+
+// AwardCategory represents a category of an award, e.g. "Best Picture".
+type AwardCategory struct {
+	ID      uuid.UUID // Unique identifier of the category
+	AwardID uuid.UUID // Identifier of the award
+	Name    string    // Name of the category
+}
+
+// Ceremony represents the edition of an award held in a given year.
+type Ceremony struct {
+	ID          uuid.UUID    // Unique identifier of the ceremony
+	AwardID     uuid.UUID    // Identifier of the award
+	Year        int          // Year of the ceremony, unique for an award
+	Date        time.Time    // Date the ceremony was held, zero if unknown
+	Nominations []Nomination // Nominations of the ceremony by category, only filled when a single ceremony is fetched
+}
+
+// Nomination represents a movie, or a person for their work on a movie, nominated in a category of a ceremony.
+type Nomination struct {
+	ID           uuid.UUID  // Unique identifier of the nomination
+	CeremonyID   uuid.UUID  // Identifier of the ceremony
+	CategoryID   uuid.UUID  // Identifier of the category
+	MovieID      uuid.UUID  // Identifier of the nominated movie
+	PersonID     *uuid.UUID // Identifier of the nominated person, nil when the movie itself is nominated
+	Won          bool       // Whether the nomination won the category
+	Note         string     // Optional note, e.g. the song of a Best Original Song nomination
+	AwardID      uuid.UUID  // Identifier of the award, read-only
+	AwardName    string     // Name of the award, read-only
+	CategoryName string     // Name of the category, read-only
+	Year         int        // Year of the ceremony, read-only
+	MovieTitle   string     // Title of the nominated movie, read-only
+	PersonName   string     // Name of the nominated person, read-only
+}
+
+// AwardSummary counts the nominations and wins of a person at an award.
+type AwardSummary struct {
+	AwardID     uuid.UUID // Identifier of the award
+	AwardName   string    // Name of the award
+	Nominations int       // Number of nominations, won or not
+	Wins        int       // Number of won nominations
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Orderings of the movies of a collection.
+const (
+	CollectionOrderRelease       = "release"       // Movies are ordered by release date
+	CollectionOrderChronological = "chronological" // Movies are ordered by the chronology of their story
+)
+
+// Collection represents a franchise or shared universe grouping movies, e.g. "The Lord of the Rings".
+type Collection struct {
+	ID          uuid.UUID         // Unique identifier of the collection
+	Name        string            // Name of the collection
+	Description string            // Optional description of the collection
+	Ordering    string            // Default ordering of the movies of the collection
+	Movies      []CollectionMovie // Movies of the collection in order
+}
+
+// CollectionMovie represents a movie belonging to a collection.
+type CollectionMovie struct {
+	MovieID            uuid.UUID // Identifier of the movie
+	Title              string    // Title of the movie
+	ReleaseDate        time.Time // Release date of the movie
+	ChronologicalOrder int       // Position of the movie in the chronology of the collection, starting at 1
+}
+
+// MovieCollection represents the collection a movie belongs to along with its neighbours in the collection,
+// following the default ordering of the collection.
+type MovieCollection struct {
+	ID       uuid.UUID        // Identifier of the collection
+	Name     string           // Name of the collection
+	Previous *CollectionMovie // Previous movie of the collection, nil for the first one
+	Next     *CollectionMovie // Next movie of the collection, nil for the last one
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Roles a company can have on a movie.
+const (
+	CompanyRoleProduction   = "production"   // The company produced the movie
+	CompanyRoleDistribution = "distribution" // The company distributed the movie
+)
+
+// Company represents a studio, production or distribution company.
+type Company struct {
+	ID          uuid.UUID // Unique identifier of the company
+	Name        string    // Name of the company
+	Country     string    // ISO 3166-1 alpha-2 code of the country of the company, empty if unknown
+	Description string    // Optional description of the company
+}
+
+// MovieCompany represents the participation of a company in a movie.
+type MovieCompany struct {
+	CompanyID uuid.UUID // Identifier of the company
+	Name      string    // Name of the company, read-only
+	Role      string    // Role of the company in the movie
+}
+
+// CompanyMovie represents a movie a company worked on.
+type CompanyMovie struct {
+	MovieID     uuid.UUID // Identifier of the movie
+	Title       string    // Title of the movie
+	ReleaseDate time.Time // Release date of the movie
+	Roles       []string  // Roles of the company in the movie
+}
+
+// CompanyPage represents a company along with a page of the movies it worked on, most recent first.
+type CompanyPage struct {
+	Company
+	Movies      []CompanyMovie // Movies of the page
+	Page        int            // Number of the page, starting at 1
+	PageSize    int            // Maximum number of movies of a page
+	TotalMovies int            // Number of movies of the company across all pages
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateRecord represents an actor or a movie as compared when looking for duplicates.
+type DuplicateRecord struct {
+	ID   uuid.UUID // Unique identifier of the actor or the movie
+	Name string    // Name of the actor or title of the movie
+	Date time.Time // Birth date of the actor or release date of the movie
+}
+
+// DuplicateGroup represents records that look like duplicates of each other.
+type DuplicateGroup struct {
+	Key     string            // Normalized name or title shared by the records
+	Records []DuplicateRecord // Records of the group, earliest date first
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of records tracked by the change history.
+const (
+	HistoryMovie       = "movie"       // Movie details, crew, companies and tags
+	HistoryActor       = "actor"       // Actor profile
+	HistoryCast        = "cast"        // Actors starring in a movie, recorded under the ID of the movie
+	HistoryTranslation = "translation" // Translation of a movie into a language, recorded under the ID of the movie
+)
+
+// Actions recorded in the change history.
+const (
+	HistoryCreate  = "create"
+	HistoryUpdate  = "update"
+	HistoryDelete  = "delete"
+	HistoryRevert  = "revert"  // Update bringing a record back to one of its previous versions
+	HistoryRestore = "restore" // Record taken out of the trash
+	HistoryMerge   = "merge"   // Duplicate record merged into another one, recorded for both records
+)
+
+// HistoryEntry represents a change made to a movie, an actor or the cast of a movie.
+type HistoryEntry struct {
+	ID         int64                  // Version number of the change, increasing across all records
+	EntityType string                 // Kind of the changed record
+	EntityID   uuid.UUID              // Identifier of the changed movie or actor
+	Action     string                 // Action of the change
+	Username   string                 // Name of the user who made the change
+	ChangedAt  time.Time              // Time of the change
+	Before     json.RawMessage        // State of the record before the change, null for creations
+	After      json.RawMessage        // State of the record after the change, null for deletions
+	Diff       map[string]FieldChange // Changed fields keyed by name
+}
+
+// FieldChange represents the values of a field before and after a change, null when the field was absent.
+type FieldChange struct {
+	Before json.RawMessage
+	After  json.RawMessage
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Visibilities of a movie list.
+const (
+	ListPrivate  = "private"  // Only the owner can see the list
+	ListUnlisted = "unlisted" // Anyone with the link can see the list, but it is not shown among public lists
+	ListPublic   = "public"   // Anyone can see the list
+)
+
+// MovieList represents a named, ordered list of movies curated by a user.
+type MovieList struct {
+	ID          uuid.UUID   // Unique identifier of the list
+	OwnerID     uuid.UUID   // Identifier of the user owning the list
+	Owner       string      // Name of the user owning the list
+	Name        string      // Name of the list
+	Description string      // Optional description of the list
+	Visibility  string      // Who can see the list
+	EntryCount  int         // Number of movies on the list
+	Entries     []ListEntry // Movies on the list in order, only filled when a single list is fetched
+	CreatedAt   time.Time   // Creation time of the list
+	UpdatedAt   time.Time   // Time of the last change of the list
+}
+
+// ListEntry represents a movie on a movie list.
+type ListEntry struct {
+	MovieID  uuid.UUID // Identifier of the movie
+	Position int       // Position of the movie on the list, starting at 1
+	Note     string    // Optional note of the owner about the movie
+	Movie    *Movie    // Summary of the movie
+}
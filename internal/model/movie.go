@@ -8,10 +8,70 @@ import (
 
 // Movie represents information about a movie.
 type Movie struct {
-    ID          uuid.UUID // Unique identifier of the movie
-    Title       string    // Title of the movie
-    Description string    // Description of the movie
-    ReleaseDate time.Time // Release date of the movie
-    Rating      int       // Rating of the movie
-    Actors      []Actor   // List of actors starring in the movie
+	ID               uuid.UUID         // Unique identifier of the movie
+	Title            string            // Title of the movie
+	Description      string            // Description of the movie
+	ReleaseDate      time.Time         // Release date of the movie
+	Rating           int               // Rating of the movie
+	RuntimeMinutes   int               // Running time of the movie in minutes, 0 if unknown
+	OriginalTitle    string            // Title of the movie in its original language, empty if it is the same as Title
+	OriginalLanguage string            // ISO 639-1 code of the original language of the movie
+	Countries        []string          // ISO 3166-1 alpha-2 codes of the production countries
+	Languages        []string          // ISO 639-1 codes of the spoken languages
+	Certifications   map[string]string // Age certifications of the movie by ISO 3166-1 alpha-2 country code
+	Language         string            // BCP 47 tag of the language of Title and Description, set when the movie is localized
+	Releases         []ReleaseEvent    // Regional releases of the movie ordered by date, ReleaseDate is derived from them
+	Actors           []CastMember      // List of actors starring in the movie, ordered by billing
+	Crew             []Credit          // List of crew credits of the movie
+	Companies        []MovieCompany    // Production and distribution companies of the movie, ordered by role and name
+	Tags             []string          // Names of the tags of the movie in alphabetical order
+	UserRating       RatingSummary     // Aggregated scores given by the users
+	ReviewCount      int               // Number of approved reviews of the movie
+	OnWatchlist      bool              // Whether the movie is on the watchlist of the current user
+	Watched          bool              // Whether the current user has watched the movie
+	Collection       *MovieCollection  // Collection the movie belongs to, nil if it belongs to none
+	Version          int               // Version of the movie, increased by every update and returned as its ETag
+}
+
+// MovieFilter restricts movie listings. Zero fields do not restrict anything.
+type MovieFilter struct {
+	Country              string    // ISO 3166-1 alpha-2 code of a production country
+	Language             string    // ISO 639-1 code of a spoken language
+	OriginalLanguage     string    // ISO 639-1 code of the original language
+	CertificationCountry string    // ISO 3166-1 alpha-2 code of a country the movie is certified in
+	Certification        string    // Age certification in CertificationCountry
+	MinRuntime           int       // Minimum running time in minutes
+	MaxRuntime           int       // Maximum running time in minutes, movies with an unknown running time are excluded
+	ReleaseCountry       string    // ISO 3166-1 alpha-2 code of a country the movie is released in, release date listings follow the dates in it
+	ReleaseType          string    // Type of the release in ReleaseCountry, any type when empty
+	WonAward             uuid.UUID // Identifier of an award the movie has won in any category
+	Company              uuid.UUID // Identifier of a company that worked on the movie
+	CompanyRole          string    // Role of Company in the movie, any role when empty
+	Tags                 []string  // Names of tags of the movie
+	TagMode              string    // Whether movies must have all of the Tags or any of them, all when empty
+}
+
+// Types of release events.
+const (
+	ReleasePremiere          = "premiere"           // Festival or gala premiere
+	ReleaseTheatricalLimited = "theatrical_limited" // Theatrical release in a limited number of venues
+	ReleaseTheatrical        = "theatrical"         // Wide theatrical release
+	ReleaseDigital           = "digital"            // Release on streaming or download platforms
+	ReleasePhysical          = "physical"           // Release on DVD, Blu-ray or other media
+	ReleaseTV                = "tv"                 // Broadcast on television
+)
+
+// ReleaseEvent represents the release of a movie in a country.
+type ReleaseEvent struct {
+	Country string    // ISO 3166-1 alpha-2 code of the country of the release
+	Type    string    // Type of the release
+	Date    time.Time // Date of the release
+	Note    string    // Optional note, e.g. the festival of a premiere
+}
+
+// CastMember represents an actor appearing in a movie together with the part they play.
+type CastMember struct {
+	Actor
+	CharacterName string // Name of the character played by the actor
+	BillingOrder  int    // Position of the actor in the cast billing, leads first
 }
@@ -0,0 +1,7 @@
+package model
+
+// Media types of the documents accepted to partially update movies and actors.
+const (
+	PatchMerge = "application/merge-patch+json" // JSON Merge Patch (RFC 7396)
+	PatchJSON  = "application/json-patch+json"  // JSON Patch (RFC 6902)
+)
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Credit roles a person can have on a movie.
+const (
+	RoleActor    = "actor"
+	RoleDirector = "director"
+	RoleWriter   = "writer"
+	RoleProducer = "producer"
+	RoleComposer = "composer"
+)
+
+// Person represents anyone credited on a movie, in front of or behind the camera.
+// The profile fields after BirthDate are only filled when a single person is fetched.
+type Person struct {
+	ID          uuid.UUID     // Unique identifier of the person
+	Name        string        // Name of the person
+	Gender      string        // Gender of the person
+	BirthDate   time.Time     // Birth date of the person
+	DeathDate   time.Time     // Death date of the person, zero if alive or unknown
+	BirthPlace  string        // Place of birth of the person, e.g. "Concord, California, USA"
+	Nationality string        // ISO 3166-1 alpha-2 code of the nationality of the person
+	Biography   string        // Biography of the person
+	Aliases     []string      // Alternate names of the person, e.g. birth or stage names
+	Links       []ProfileLink // Links to external profiles of the person, ordered by site
+	Version     int           // Version of the person, increased by every update and returned as their ETag
+}
+
+// ProfileLink represents a link to an external profile of a person, e.g. on IMDb or Wikipedia.
+type ProfileLink struct {
+	Site string // Name of the site, e.g. "imdb"
+	URL  string // Absolute http or https URL of the profile
+}
+
+// Credit represents the participation of a person in a movie crew.
+type Credit struct {
+	PersonID     uuid.UUID // Unique identifier of the credited person
+	Name         string    // Name of the credited person
+	Role         string    // Role of the person in the movie
+	BillingOrder int       // Position of the credit in the billing
+}
+
+// Filmography information about a person and the movies they worked on, grouped by role.
+type Filmography struct {
+	Person      Person
+	Credits     map[string][]*FilmographyMovie // Movies keyed by the credit role, most recent first
+	Awards      []AwardSummary                 // Nominations and wins of the person by award, ordered by award name
+	Nominations []Nomination                   // Nominations of the person, most recent first
+}
+
+// FilmographyMovie represents a movie of a filmography along with the age of the person at its release.
+type FilmographyMovie struct {
+	Movie
+	AgeAtRelease int // Age of the person in whole years at the release of the movie, 0 if their birth date is unknown
+}
+
+// CareerTimeline summarizes the career of a person over the years, from the movies they worked on in any role.
+type CareerTimeline struct {
+	Person        Person
+	FirstMovie    *FilmographyMovie // Earliest released movie of the person, nil if they have none
+	LastMovie     *FilmographyMovie // Latest released movie of the person, nil if they have none
+	TotalMovies   int               // Number of movies the person worked on
+	ActiveYears   int               // Number of years with at least one movie released
+	CareerSpan    int               // Number of years from the first to the last movie, both included
+	AverageRating float64           // Average rating of the movies, 0 if there are none
+	Years         []CareerYear      // Every year from the first to the last movie, including the years without movies
+}
+
+// CareerYear summarizes the movies of a person released during a year.
+type CareerYear struct {
+	Year          int     // Calendar year
+	Movies        int     // Number of movies released during the year
+	AverageRating float64 // Average rating of the movies released during the year, 0 if there are none
+}
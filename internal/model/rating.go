@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserRating represents the score a user gave to a movie.
+type UserRating struct {
+	UserID  uuid.UUID // Identifier of the user who rated the movie
+	MovieID uuid.UUID // Identifier of the rated movie
+	Score   int       // Score from 1 to 10
+	RatedAt time.Time // Time of the last change of the score
+}
+
+// RatingSummary aggregates the scores users gave to a movie.
+type RatingSummary struct {
+	Average  float64 // Average user score
+	Count    int     // Number of user scores
+	Weighted float64 // Bayesian-weighted score, pulled towards the global average for movies with few scores
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Moderation statuses of a review.
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewHidden   = "hidden"
+)
+
+// Review represents a written review of a movie by a user.
+type Review struct {
+	ID        uuid.UUID // Unique identifier of the review
+	MovieID   uuid.UUID // Identifier of the reviewed movie
+	UserID    uuid.UUID // Identifier of the author
+	Username  string    // Name of the author
+	Title     string    // Title of the review
+	Body      string    // Text of the review
+	Spoiler   bool      // Whether the review reveals the plot
+	Score     int       // Score the author gave to the movie, 0 if the author has not rated it
+	Status    string    // Moderation status of the review
+	CreatedAt time.Time // Creation time of the review
+	UpdatedAt time.Time // Time of the last edit of the review
+}
+
+// ReviewUpdate represents an edit of a review by its author. Empty fields and a nil Spoiler are left unchanged.
+type ReviewUpdate struct {
+	Title   string // New title of the review
+	Body    string // New text of the review
+	Spoiler *bool  // Whether the review reveals the plot
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Series represents a TV series made of seasons of episodes.
+type Series struct {
+	ID           uuid.UUID // Unique identifier of the series
+	Title        string    // Title of the series
+	Description  string    // Description of the series
+	FirstAirDate time.Time // Air date of the first episode, zero if the series has no episode
+	LastAirDate  time.Time // Air date of the latest episode, zero if the series has no episode
+	SeasonCount  int       // Number of seasons of the series
+	EpisodeCount int       // Number of episodes of the series
+	Seasons      []Season  // Seasons of the series in order, only filled when a single series is fetched
+}
+
+// Season represents a season of a TV series.
+type Season struct {
+	ID       uuid.UUID // Unique identifier of the season
+	SeriesID uuid.UUID // Identifier of the series
+	Number   int       // Number of the season within the series, starting at 1
+	Title    string    // Optional title of the season
+	Episodes []Episode // Episodes of the season in order
+}
+
+// Episode represents an episode of a season of a TV series.
+type Episode struct {
+	ID           uuid.UUID    // Unique identifier of the episode
+	SeriesID     uuid.UUID    // Identifier of the series
+	SeasonID     uuid.UUID    // Identifier of the season
+	SeasonNumber int          // Number of the season within the series
+	Number       int          // Number of the episode within the season, starting at 1
+	Title        string       // Title of the episode
+	Description  string       // Description of the episode
+	AirDate      time.Time    // Date the episode was first aired
+	Cast         []CastMember // Actors appearing in the episode, ordered by billing
+}
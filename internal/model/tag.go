@@ -0,0 +1,16 @@
+package model
+
+import "github.com/google/uuid"
+
+// Modes combining the tags of a movie filter.
+const (
+	TagModeAll = "all" // Movies must have every tag of the filter
+	TagModeAny = "any" // Movies must have at least one tag of the filter
+)
+
+// Tag represents a free-form keyword attached to movies, e.g. "time travel" or "heist".
+type Tag struct {
+	ID         uuid.UUID // Unique identifier of the tag
+	Name       string    // Name of the tag in lower case, unique
+	MovieCount int       // Number of movies tagged with the tag, read-only
+}
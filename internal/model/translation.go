@@ -0,0 +1,11 @@
+package model
+
+import "github.com/google/uuid"
+
+// MovieTranslation represents the title and description of a movie in another language.
+type MovieTranslation struct {
+	MovieID     uuid.UUID // Identifier of the translated movie
+	Language    string    // BCP 47 language tag of the translation, e.g. "de" or "pt-BR"
+	Title       string    // Translated title of the movie
+	Description string    // Translated description of the movie, empty to keep the original one
+}
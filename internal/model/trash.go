@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashItem represents a deleted actor or movie waiting in the trash to be restored or purged.
+type TrashItem struct {
+	ID        uuid.UUID // Unique identifier of the actor or the movie
+	Name      string    // Name of the actor or title of the movie
+	DeletedAt time.Time // Time the actor or the movie was moved to the trash
+}
+
+// Trash represents the actors and movies in the trash, most recently deleted first.
+type Trash struct {
+	Actors []TrashItem
+	Movies []TrashItem
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WatchlistEntry represents a movie a user plans to watch.
+type WatchlistEntry struct {
+	Movie   *Movie    // Summary of the movie, without cast and crew
+	AddedAt time.Time // Time the movie was added to the watchlist
+}
+
+// DiaryEntry represents a movie a user has watched.
+type DiaryEntry struct {
+	MovieID      uuid.UUID // Identifier of the watched movie
+	Movie        *Movie    // Summary of the movie, without cast and crew
+	WatchedAt    time.Time // Date of the last viewing
+	RewatchCount int       // Number of viewings after the first one
+	Note         string    // Optional personal note
+}
+
+// WatchStatus tells whether a movie is on the watchlist of a user and whether the user has watched it.
+type WatchStatus struct {
+	OnWatchlist bool
+	Watched     bool
+}
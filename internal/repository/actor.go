@@ -3,19 +3,28 @@ package repository
 
 import (
 	"database/sql"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
 )
 
 // ActorManager represents an interface for managing actors in the system.
 type ActorManager interface {
-	Create(actor *model.Actor) error
+	Create(actor *model.Actor, history ...*model.HistoryEntry) error
 	GetByID(actorID uuid.UUID) (*model.Actor, error)
-	Update(actorID uuid.UUID, actor *model.Actor) error
-	Delete(actorID uuid.UUID) error
+	Update(actorID uuid.UUID, actor *model.Actor, history ...*model.HistoryEntry) error
+	Delete(actorID uuid.UUID, version int, detach bool, history ...*model.HistoryEntry) error
+	GetCreditedMovies(actorID uuid.UUID) ([]*model.Movie, error)
 	GetAllWithMovies() ([]*model.ActorMovies, error)
+	GetAll(query model.ActorQuery, limit, offset int) ([]*model.ActorMovies, int, error)
+	LoadMovies(actors []*model.ActorMovies) error
+	GetCollaborators(actorID uuid.UUID, limit int) ([]model.Collaborator, error)
+	GetCoStars(actorIDs []uuid.UUID) ([]model.CoStarLink, error)
+	GetFilmography(actorID uuid.UUID) (*model.Filmography, error)
 }
 
 // NewActorStorage returns new repository instance for actors
@@ -30,56 +39,219 @@ type actorManager struct {
 	db *sql.DB
 }
 
-// Create inserts a new actor record into the database.
-func (am *actorManager) Create(actor *model.Actor) error {
+// Create inserts a new actor record along with the links to their external profiles into the database, recording the
+// history entries of the change in the same transaction.
+func (am *actorManager) Create(actor *model.Actor, history ...*model.HistoryEntry) error {
+	tx, err := am.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
 	query := `
-		INSERT INTO actors (id, name, gender, birth_date) VALUES ($1, $2, $3, $4)`
+		INSERT INTO actors (id, name, gender, birth_date, death_date, birth_place, nationality, biography, aliases)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
-	_, err := am.db.Query(query, actor.ID, actor.Name, actor.Gender, actor.BirthDate)
+	_, err = tx.Exec(query, actor.ID, actor.Name, actor.Gender, actor.BirthDate, nullTime(actor.DeathDate),
+		actor.BirthPlace, actor.Nationality, actor.Biography, pq.Array(nonNilStrings(actor.Aliases)))
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if err = insertProfileLinks(tx, actor.ID, actor.Links); err != nil {
+		return err
+	}
+
+	err = insertHistory(tx, history)
+	return err
 }
 
-// GetByID retrieves actor information from the database based on the provided actor ID.
+// GetByID retrieves actor information from the database based on the provided actor ID, including their profile.
+// The ID of an actor merged into another one retrieves the actor it was merged into,
+// actors in the trash are not retrieved.
 func (am *actorManager) GetByID(actorID uuid.UUID) (*model.Actor, error) {
+	return getActor(am.db, actorID)
+}
+
+// getActor retrieves an actor along with their profile as GetByID does, possibly within a transaction.
+func getActor(db queryer, actorID uuid.UUID) (*model.Actor, error) {
 	query := `
-		SELECT id, name, gender, birth_date AT TIME ZONE 'UTC' AS birth_date_utc 
+		SELECT id, name, gender, birth_date AT TIME ZONE 'UTC' AS birth_date_utc,
+			death_date AT TIME ZONE 'UTC' AS death_date_utc, birth_place, nationality, biography, aliases, version
 		FROM actors 
-		WHERE id = $1`
+		WHERE id = COALESCE((SELECT actor_id FROM actor_redirects WHERE old_id = $1), $1) AND deleted_at IS NULL`
 
 	var actor model.Actor
+	var deathDate sql.NullTime
 
-	err := am.db.QueryRow(query, actorID).Scan(&actor.ID, &actor.Name, &actor.Gender, &actor.BirthDate)
+	err := db.QueryRow(query, actorID).Scan(&actor.ID, &actor.Name, &actor.Gender, &actor.BirthDate,
+		&deathDate, &actor.BirthPlace, &actor.Nationality, &actor.Biography, pq.Array(&actor.Aliases), &actor.Version)
 	if err != nil {
 		return nil, err
 	}
+	actor.DeathDate = deathDate.Time
+	if len(actor.Aliases) == 0 {
+		actor.Aliases = nil
+	}
+
+	linksQuery := `
+		SELECT site, url
+		FROM actor_links
+		WHERE actor_id = $1
+		ORDER BY site`
+
+	rows, err := db.Query(linksQuery, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link model.ProfileLink
+		if err := rows.Scan(&link.Site, &link.URL); err != nil {
+			return nil, err
+		}
+		actor.Links = append(actor.Links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return &actor, nil
 }
 
-// Update updates the information of an actor in the database.
-func (am *actorManager) Update(actorID uuid.UUID, actor *model.Actor) error {
+// Update updates the information of an actor in the database, replacing the links to their external profiles,
+// provided the actor still has the version they were read with, and sets the new version of the actor. The history
+// entries of the change are recorded in the same transaction.
+// sql.ErrNoRows is returned when the actor has another version, does not exist or is in the trash.
+func (am *actorManager) Update(actorID uuid.UUID, actor *model.Actor, history ...*model.HistoryEntry) error {
+	tx, err := am.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
 	query := `
-		UPDATE actors SET name = COALESCE($2,name), gender = COALESCE($3,gender), birth_date = COALESCE($4,birth_date) 
-		WHERE id = $1`
+		UPDATE actors SET name = COALESCE($2,name), gender = COALESCE($3,gender), birth_date = COALESCE($4,birth_date),
+			death_date = $5, birth_place = $6, nationality = $7, biography = $8, aliases = $9, version = version + 1
+		WHERE id = $1 AND version = $10 AND deleted_at IS NULL
+		RETURNING version`
 
-	_, err := am.db.Exec(query, actorID, actor.Name, actor.Gender, actor.BirthDate)
+	err = tx.QueryRow(query, actorID, actor.Name, actor.Gender, actor.BirthDate, nullTime(actor.DeathDate),
+		actor.BirthPlace, actor.Nationality, actor.Biography, pq.Array(nonNilStrings(actor.Aliases)), actor.Version).
+		Scan(&actor.Version)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	deleteLinksQuery := `
+		DELETE FROM actor_links WHERE actor_id = $1`
+
+	_, err = tx.Exec(deleteLinksQuery, actorID)
+	if err != nil {
+		return err
+	}
+
+	if err = insertProfileLinks(tx, actorID, actor.Links); err != nil {
+		return err
+	}
+
+	err = insertHistory(tx, history)
+	return err
 }
 
-// Delete removes actor information from the database based on the provided actor ID.
-func (am *actorManager) Delete(actorID uuid.UUID) error {
-	query := `DELETE FROM actors WHERE id = $1`
+// Delete moves an actor to the trash, hiding them from the actor listings and the casts and crews of movies until
+// they are restored or purged, provided the actor still has the given version, any version when it is zero.
+// When detach is set, the cast and crew credits of the actor are removed in the same transaction, where the history
+// entries of the change are recorded as well.
+// sql.ErrNoRows is returned when the actor has another version, does not exist or is already in the trash.
+func (am *actorManager) Delete(actorID uuid.UUID, version int, detach bool, history ...*model.HistoryEntry) error {
+	tx, err := am.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if detach {
+		creditsQuery := `
+		DELETE FROM credits WHERE person_id = $1`
 
-	_, err := am.db.Exec(query, actorID)
+		_, err = tx.Exec(creditsQuery, actorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `
+		UPDATE actors SET deleted_at = NOW()
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND deleted_at IS NULL`
+
+	result, err := tx.Exec(query, actorID, version)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		err = sql.ErrNoRows
+		return err
+	}
+
+	err = insertHistory(tx, history)
+	return err
+}
+
+// GetCreditedMovies retrieves the movies an actor is credited on, in the cast or in the crew, most recent first.
+func (am *actorManager) GetCreditedMovies(actorID uuid.UUID) ([]*model.Movie, error) {
+	query := `
+	SELECT m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating
+	FROM movies m
+	WHERE m.deleted_at IS NULL
+		AND EXISTS (SELECT 1 FROM credits c WHERE c.movie_id = m.id AND c.person_id = $1)
+	ORDER BY release_date_utc DESC, m.title`
+
+	rows, err := am.db.Query(query, actorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := make([]*model.Movie, 0)
+	for rows.Next() {
+		var movie model.Movie
+
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating); err != nil {
+			return nil, err
+		}
+
+		movies = append(movies, &movie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
 }
 
 // GetAllWithMovies retrieves a list of actors from the database along with information about the movies they starred in.
@@ -89,8 +261,9 @@ func (am *actorManager) GetAllWithMovies() ([]*model.ActorMovies, error) {
 		   m.id AS movie_id, m.title AS movie_title, m.description AS movie_description, m.release_date AT TIME ZONE 'UTC' AS movie_release_date, 
 		   m.rating AS movie_rating
 	FROM actors a
-	JOIN movie_actor ma ON a.id = ma.actor_id
-	JOIN movies m ON ma.movie_id = m.id`
+	JOIN credits c ON a.id = c.person_id AND c.role = 'actor'
+	JOIN movies m ON c.movie_id = m.id AND m.deleted_at IS NULL
+	WHERE a.deleted_at IS NULL`
 
 	rows, err := am.db.Query(query)
 	if err != nil {
@@ -118,6 +291,7 @@ func (am *actorManager) GetAllWithMovies() ([]*model.ActorMovies, error) {
 		}
 
 		actor.Movies = append(actor.Movies, &nextMovie)
+		actor.FilmCount++
 	}
 
 	if err := rows.Err(); err != nil {
@@ -125,4 +299,295 @@ func (am *actorManager) GetAllWithMovies() ([]*model.ActorMovies, error) {
 	}
 
 	return actors, nil
-}
\ No newline at end of file
+}
+
+// actorSortColumns maps the sort orders of actor listings to the columns they sort on.
+var actorSortColumns = map[string]string{
+	model.ActorSortName:      "a.name",
+	model.ActorSortBirthDate: "a.birth_date",
+	model.ActorSortFilmCount: "film_count",
+}
+
+// actorQueryClause selects the actors aliased a matching the name fragment $1, the gender $2
+// and the birth year range $3 to $4, zero years leaving the range open. Actors in the trash are left out.
+const actorQueryClause = `
+	WHERE a.deleted_at IS NULL
+		AND ($1 = '' OR a.name ILIKE '%' || $1 || '%'
+			OR EXISTS (SELECT 1 FROM UNNEST(a.aliases) alias WHERE alias ILIKE '%' || $1 || '%'))
+		AND ($2 = '' OR LOWER(a.gender) = LOWER($2))
+		AND ($3 = 0 OR EXTRACT(YEAR FROM a.birth_date) >= $3)
+		AND ($4 = 0 OR EXTRACT(YEAR FROM a.birth_date) <= $4)`
+
+// GetAll retrieves a page of the actors matching the query, including the actors without movies, along with the
+// number of matching actors across all pages. The movies of the actors are not loaded, see LoadMovies.
+func (am *actorManager) GetAll(query model.ActorQuery, limit, offset int) ([]*model.ActorMovies, int, error) {
+	sortColumn, ok := actorSortColumns[query.Sort]
+	if !ok {
+		sortColumn = actorSortColumns[model.ActorSortName]
+	}
+	direction := "ASC"
+	if query.Descending {
+		direction = "DESC"
+	}
+
+	listQuery := `
+	SELECT a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc,
+		(SELECT COUNT(*) FROM credits c JOIN movies m ON c.movie_id = m.id AND m.deleted_at IS NULL
+			WHERE c.person_id = a.id AND c.role = 'actor') AS film_count, COUNT(*) OVER ()
+	FROM actors a` + actorQueryClause + `
+	ORDER BY ` + sortColumn + ` ` + direction + `, a.name, a.id
+	LIMIT $5 OFFSET $6`
+
+	args := []interface{}{query.Name, query.Gender, query.MinBirthYear, query.MaxBirthYear}
+
+	rows, err := am.db.Query(listQuery, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	actors := make([]*model.ActorMovies, 0)
+	total := 0
+	for rows.Next() {
+		var actor model.ActorMovies
+
+		err := rows.Scan(&actor.ID, &actor.Name, &actor.Gender, &actor.BirthDate, &actor.FilmCount, &total)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		actors = append(actors, &actor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(actors) == 0 && offset > 0 {
+		countQuery := `
+		SELECT COUNT(*)
+		FROM actors a` + actorQueryClause
+
+		if err := am.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return actors, total, nil
+}
+
+// LoadMovies fills the movies the given actors starred in with a single query, most recent first.
+func (am *actorManager) LoadMovies(actors []*model.ActorMovies) error {
+	if len(actors) == 0 {
+		return nil
+	}
+
+	actorIDs := make([]string, 0, len(actors))
+	actorMap := make(map[uuid.UUID]*model.ActorMovies, len(actors))
+	for _, actor := range actors {
+		actorIDs = append(actorIDs, actor.ID.String())
+		actorMap[actor.ID] = actor
+	}
+
+	query := `
+	SELECT c.person_id, m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating
+	FROM credits c
+	JOIN movies m ON c.movie_id = m.id AND m.deleted_at IS NULL
+	WHERE c.person_id = ANY($1::uuid[]) AND c.role = 'actor'
+	ORDER BY release_date_utc DESC, m.title`
+
+	rows, err := am.db.Query(query, pq.Array(actorIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var actorID uuid.UUID
+		var movie model.Movie
+
+		if err := rows.Scan(&actorID, &movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating); err != nil {
+			return err
+		}
+
+		actorMap[actorID].Movies = append(actorMap[actorID].Movies, &movie)
+	}
+
+	return rows.Err()
+}
+
+// GetCollaborators retrieves the actors who starred in the most movies with the given actor, limited to the given
+// number of actors.
+func (am *actorManager) GetCollaborators(actorID uuid.UUID, limit int) ([]model.Collaborator, error) {
+	query := `
+	SELECT a.id, a.name, COUNT(*) AS shared_movies, ARRAY_AGG(m.title ORDER BY m.release_date, m.title)
+	FROM credits c
+	JOIN credits co ON co.movie_id = c.movie_id AND co.role = 'actor' AND co.person_id <> c.person_id
+	JOIN actors a ON co.person_id = a.id AND a.deleted_at IS NULL
+	JOIN movies m ON c.movie_id = m.id AND m.deleted_at IS NULL
+	WHERE c.person_id = $1 AND c.role = 'actor'
+	GROUP BY a.id, a.name
+	ORDER BY shared_movies DESC, a.name
+	LIMIT $2`
+
+	rows, err := am.db.Query(query, actorID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collaborators := make([]model.Collaborator, 0)
+	for rows.Next() {
+		var collaborator model.Collaborator
+
+		err := rows.Scan(&collaborator.ID, &collaborator.Name, &collaborator.SharedMovies,
+			pq.Array(&collaborator.Movies))
+		if err != nil {
+			return nil, err
+		}
+
+		collaborators = append(collaborators, collaborator)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collaborators, nil
+}
+
+// GetCoStars retrieves the co-stars of the given actors with a single query, one link per shared movie,
+// oldest movies first.
+func (am *actorManager) GetCoStars(actorIDs []uuid.UUID) ([]model.CoStarLink, error) {
+	if len(actorIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(actorIDs))
+	for _, actorID := range actorIDs {
+		ids = append(ids, actorID.String())
+	}
+
+	query := `
+	SELECT c.person_id, co.person_id, a.name, m.id, m.title
+	FROM credits c
+	JOIN credits co ON co.movie_id = c.movie_id AND co.role = 'actor' AND co.person_id <> c.person_id
+	JOIN actors a ON co.person_id = a.id AND a.deleted_at IS NULL
+	JOIN movies m ON c.movie_id = m.id AND m.deleted_at IS NULL
+	WHERE c.person_id = ANY($1::uuid[]) AND c.role = 'actor'
+	ORDER BY m.release_date, m.title, a.name`
+
+	rows, err := am.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []model.CoStarLink
+	for rows.Next() {
+		var link model.CoStarLink
+
+		if err := rows.Scan(&link.ActorID, &link.CoStarID, &link.CoStarName, &link.MovieID, &link.MovieTitle); err != nil {
+			return nil, err
+		}
+
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// GetFilmography retrieves the movies a person worked on, grouped by the role of their credit.
+func (am *actorManager) GetFilmography(actorID uuid.UUID) (*model.Filmography, error) {
+	person, err := am.GetByID(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT c.role, m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating
+	FROM credits c
+	JOIN movies m ON c.movie_id = m.id AND m.deleted_at IS NULL
+	WHERE c.person_id = $1
+	ORDER BY release_date_utc DESC`
+
+	rows, err := am.db.Query(query, person.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filmography := &model.Filmography{
+		Person:  *person,
+		Credits: make(map[string][]*model.FilmographyMovie),
+	}
+
+	for rows.Next() {
+		var role string
+		var movie model.FilmographyMovie
+
+		if err := rows.Scan(&role, &movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating); err != nil {
+			return nil, err
+		}
+
+		filmography.Credits[role] = append(filmography.Credits[role], &movie)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	nominations, err := getNominationsByQuery(am.db, nominationQuery+`
+	WHERE n.person_id = $1
+	ORDER BY ce.year DESC, aw.name, ca.name`, person.ID)
+	if err != nil {
+		return nil, err
+	}
+	filmography.Nominations = nominations
+	filmography.Awards = summarizeAwards(nominations)
+
+	return filmography, nil
+}
+
+// summarizeAwards counts nominations and wins by award, ordered by award name.
+func summarizeAwards(nominations []model.Nomination) []model.AwardSummary {
+	summaries := make([]model.AwardSummary, 0)
+	index := make(map[uuid.UUID]int)
+	for _, nomination := range nominations {
+		i, ok := index[nomination.AwardID]
+		if !ok {
+			i = len(summaries)
+			index[nomination.AwardID] = i
+			summaries = append(summaries, model.AwardSummary{AwardID: nomination.AwardID, AwardName: nomination.AwardName})
+		}
+
+		summaries[i].Nominations++
+		if nomination.Won {
+			summaries[i].Wins++
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].AwardName < summaries[j].AwardName
+	})
+	return summaries
+}
+
+// insertProfileLinks inserts the links to the external profiles of an actor.
+func insertProfileLinks(tx *sql.Tx, actorID uuid.UUID, links []model.ProfileLink) error {
+	query := `
+		INSERT INTO actor_links (actor_id, site, url) VALUES ($1, $2, $3)`
+
+	for _, link := range links {
+		if _, err := tx.Exec(query, actorID, link.Site, link.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nullTime returns NULL for a zero time so that optional TIMESTAMP columns are left empty.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
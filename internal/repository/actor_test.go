@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"testing"
 	"time"
 
@@ -39,6 +40,7 @@ func TestActorManager_GetByID(t *testing.T) {
 
 	err := actorRep.Create(Ken)
 	require.NoError(t, err)
+	Ken.Version = 1
 
 	getActor, err := actorRep.GetByID(Ken.ID)
 
@@ -65,16 +67,78 @@ func TestActorManager_Update(t *testing.T) {
 		Name:      "Cillian Murphy",
 		Gender:    "Shelby",
 		BirthDate: time.Date(1976, 5, 25, 0, 0, 0, 0, time.UTC),
+		Version:   1,
 	}
 
 	err = actorRep.Update(Ken.ID, updatedActor)
 	require.NoError(t, err)
+	require.Equal(t, 2, updatedActor.Version)
+
+	staleActor := *updatedActor
+	staleActor.Version = 1
+	require.ErrorIs(t, actorRep.Update(Ken.ID, &staleActor), sql.ErrNoRows)
 
 	getActor, err := actorRep.GetByID(Ken.ID)
 	require.NoError(t, err)
 	require.Equal(t, updatedActor, getActor)
 }
 
+func TestActorManager_Profile(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+	Marilyn := &model.Actor{
+		ID:          uuid.New(),
+		Name:        "Marilyn Monroe",
+		Gender:      "female",
+		BirthDate:   time.Date(1926, 6, 1, 0, 0, 0, 0, time.UTC),
+		DeathDate:   time.Date(1962, 8, 4, 0, 0, 0, 0, time.UTC),
+		BirthPlace:  "Los Angeles, California, USA",
+		Nationality: "US",
+		Biography:   "American actress and model.",
+		Aliases:     []string{"Norma Jeane Mortenson"},
+		Links: []model.ProfileLink{
+			{Site: "imdb", URL: "https://www.imdb.com/name/nm0000054/"},
+			{Site: "wikipedia", URL: "https://en.wikipedia.org/wiki/Marilyn_Monroe"},
+		},
+	}
+	err := actorRep.Create(Marilyn)
+	require.NoError(t, err)
+	Marilyn.Version = 1
+
+	getActor, err := actorRep.GetByID(Marilyn.ID)
+	require.NoError(t, err)
+	require.Equal(t, Marilyn, getActor)
+
+	Marilyn.DeathDate = time.Time{}
+	Marilyn.Links = Marilyn.Links[1:]
+	err = actorRep.Update(Marilyn.ID, Marilyn)
+	require.NoError(t, err)
+
+	getActor, err = actorRep.GetByID(Marilyn.ID)
+	require.NoError(t, err)
+	require.Equal(t, Marilyn, getActor)
+
+	SomeLikeItHot := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Some Like It Hot",
+		Description: "Two musicians disguise themselves as women.",
+		ReleaseDate: time.Date(1959, 3, 29, 0, 0, 0, 0, time.UTC),
+		Rating:      8,
+		Actors:      []model.CastMember{{Actor: *Marilyn, BillingOrder: 1}},
+	}
+	err = movieRep.Create(SomeLikeItHot)
+	require.NoError(t, err)
+
+	movies, err := movieRep.GetByActorNameFragment("Norma Jeane")
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	require.Equal(t, SomeLikeItHot.ID, movies[0].ID)
+}
+
 func TestActorManager_Delete(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
@@ -89,7 +153,10 @@ func TestActorManager_Delete(t *testing.T) {
 	err := actorRep.Create(Ken)
 	require.NoError(t, err)
 
-	err = actorRep.Delete(Ken.ID)
+	err = actorRep.Delete(Ken.ID, 2, false)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = actorRep.Delete(Ken.ID, 1, false)
 	require.NoError(t, err)
 
 	getActor, err := actorRep.GetByID(Ken.ID)
@@ -97,11 +164,79 @@ func TestActorManager_Delete(t *testing.T) {
 	require.Empty(t, getActor)
 }
 
+func TestActorManager_DeletePolicies(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+	Tom := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Tom Hanks",
+		Gender:    "male",
+		BirthDate: time.Date(1956, 7, 9, 0, 0, 0, 0, time.UTC),
+	}
+	Helen := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Helen Hunt",
+		Gender:    "female",
+		BirthDate: time.Date(1963, 6, 15, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(Tom))
+	require.NoError(t, actorRep.Create(Helen))
+
+	CastAway := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Cast Away",
+		Description: "A FedEx employee is stranded on an island.",
+		ReleaseDate: time.Date(2000, 12, 22, 0, 0, 0, 0, time.UTC),
+		Rating:      8,
+		Actors:      []model.CastMember{{Actor: *Tom, BillingOrder: 1}, {Actor: *Helen, BillingOrder: 2}},
+		Crew:        []model.Credit{{PersonID: Tom.ID, Role: model.RoleProducer}},
+	}
+	require.NoError(t, movieRep.Create(CastAway))
+
+	movies, err := actorRep.GetCreditedMovies(Tom.ID)
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	require.Equal(t, CastAway.ID, movies[0].ID)
+
+	require.NoError(t, actorRep.Delete(Tom.ID, 0, true))
+	require.ErrorIs(t, actorRep.Delete(Tom.ID, 0, true), sql.ErrNoRows)
+
+	movies, err = actorRep.GetCreditedMovies(Tom.ID)
+	require.NoError(t, err)
+	require.Empty(t, movies)
+
+	require.NoError(t, actorRep.Delete(Helen.ID, 0, false))
+
+	_, err = actorRep.GetByID(Helen.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	actors, total, err := actorRep.GetAll(model.ActorQuery{}, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, actors)
+	require.Zero(t, total)
+
+	movie, err := movieRep.GetByID(CastAway.ID)
+	require.NoError(t, err)
+	require.Empty(t, movie.Actors)
+	require.Empty(t, movie.Crew)
+
+	require.NoError(t, trashRep.RestoreActor(Helen.ID, nil))
+
+	movie, err = movieRep.GetByID(CastAway.ID)
+	require.NoError(t, err)
+	require.Len(t, movie.Actors, 1)
+	require.Equal(t, Helen.ID, movie.Actors[0].ID)
+}
+
 func TestActorManager_GetAllWithMovies(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -131,7 +266,7 @@ func TestActorManager_GetAllWithMovies(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      9,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -141,7 +276,7 @@ func TestActorManager_GetAllWithMovies(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Drive)
 	require.NoError(t, err)
@@ -152,7 +287,7 @@ func TestActorManager_GetAllWithMovies(t *testing.T) {
 		Description: "Atomic bomb",
 		ReleaseDate: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Deadpool},
+		Actors:      []model.CastMember{{Actor: *Deadpool}},
 	}
 	err = movieRep.Create(Oppenheimer)
 	require.NoError(t, err)
@@ -167,6 +302,7 @@ func TestActorManager_GetAllWithMovies(t *testing.T) {
 				Name:      "Ryan Gosling",
 				Gender:    "Drive",
 				BirthDate: time.Date(1980, 11, 12, 0, 0, 0, 0, time.UTC),
+				FilmCount: 2,
 				Movies: []*model.Movie{
 					{
 						ID:          Barbi.ID,
@@ -189,6 +325,7 @@ func TestActorManager_GetAllWithMovies(t *testing.T) {
 				Name:      "Ryan Reynolds ",
 				Gender:    "Deadpool",
 				BirthDate: time.Date(1980, 11, 12, 0, 0, 0, 0, time.UTC),
+				FilmCount: 1,
 				Movies: []*model.Movie{
 					{
 						ID:          Oppenheimer.ID,
@@ -202,3 +339,198 @@ func TestActorManager_GetAllWithMovies(t *testing.T) {
 
 	require.Equal(t, expectedActorMovies, actorsMovies)
 }
+
+func TestActorManager_GetFilmography(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Clint := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Clint Eastwood",
+		Gender:    "Male",
+		BirthDate: time.Date(1930, 5, 31, 0, 0, 0, 0, time.UTC),
+	}
+	err := actorRep.Create(Clint)
+	require.NoError(t, err)
+
+	GranTorino := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Gran Torino",
+		Description: "Walt Kowalski",
+		ReleaseDate: time.Date(2008, 12, 12, 0, 0, 0, 0, time.UTC),
+		Rating:      8,
+		Actors:      []model.CastMember{{Actor: *Clint}},
+		Crew: []model.Credit{
+			{PersonID: Clint.ID, Name: Clint.Name, Role: model.RoleDirector},
+			{PersonID: Clint.ID, Name: Clint.Name, Role: model.RoleProducer, BillingOrder: 1},
+		},
+	}
+	err = movieRep.Create(GranTorino)
+	require.NoError(t, err)
+
+	filmography, err := actorRep.GetFilmography(Clint.ID)
+	require.NoError(t, err)
+
+	expectedMovie := &model.FilmographyMovie{Movie: model.Movie{
+		ID:          GranTorino.ID,
+		Title:       GranTorino.Title,
+		Description: GranTorino.Description,
+		ReleaseDate: GranTorino.ReleaseDate,
+		Rating:      GranTorino.Rating,
+	}}
+
+	require.Equal(t, *Clint, filmography.Person)
+	require.Equal(t, map[string][]*model.FilmographyMovie{
+		model.RoleActor:    {expectedMovie},
+		model.RoleDirector: {expectedMovie},
+		model.RoleProducer: {expectedMovie},
+	}, filmography.Credits)
+
+	getMovie, err := movieRep.GetByID(GranTorino.ID)
+	require.NoError(t, err)
+	require.Equal(t, GranTorino.Crew, getMovie.Crew)
+}
+
+func TestActorManager_GetAll(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Keanu := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Keanu Reeves",
+		Gender:    "male",
+		BirthDate: time.Date(1964, 9, 2, 0, 0, 0, 0, time.UTC),
+	}
+	Carrie := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Carrie-Anne Moss",
+		Gender:    "female",
+		BirthDate: time.Date(1967, 8, 21, 0, 0, 0, 0, time.UTC),
+	}
+	Newcomer := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Jane Newcomer",
+		Gender:    "female",
+		BirthDate: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+		Aliases:   []string{"Janie Reeves"},
+	}
+	for _, actor := range []*model.Actor{Keanu, Carrie, Newcomer} {
+		require.NoError(t, actorRep.Create(actor))
+	}
+
+	Matrix := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "The Matrix",
+		Description: "A hacker learns the nature of his reality.",
+		ReleaseDate: time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+		Actors:      []model.CastMember{{Actor: *Keanu, BillingOrder: 1}, {Actor: *Carrie, BillingOrder: 2}},
+	}
+	require.NoError(t, movieRep.Create(Matrix))
+	JohnWick := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "John Wick",
+		Description: "A retired hitman seeks vengeance.",
+		ReleaseDate: time.Date(2014, 10, 24, 0, 0, 0, 0, time.UTC),
+		Rating:      7,
+		Actors:      []model.CastMember{{Actor: *Keanu, BillingOrder: 1}},
+	}
+	require.NoError(t, movieRep.Create(JohnWick))
+
+	actors, total, err := actorRep.GetAll(model.ActorQuery{}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, []string{"Carrie-Anne Moss", "Jane Newcomer", "Keanu Reeves"},
+		[]string{actors[0].Name, actors[1].Name, actors[2].Name})
+	require.Equal(t, 0, actors[1].FilmCount)
+
+	actors, total, err = actorRep.GetAll(model.ActorQuery{Sort: model.ActorSortFilmCount, Descending: true}, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, actors, 1)
+	require.Equal(t, Keanu.ID, actors[0].ID)
+	require.Equal(t, 2, actors[0].FilmCount)
+
+	actors, total, err = actorRep.GetAll(model.ActorQuery{Name: "reeves"}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, actors, 2)
+
+	actors, total, err = actorRep.GetAll(model.ActorQuery{Gender: "Female", MaxBirthYear: 1990}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, Carrie.ID, actors[0].ID)
+
+	actors, total, err = actorRep.GetAll(model.ActorQuery{}, 10, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Empty(t, actors)
+
+	actors, _, err = actorRep.GetAll(model.ActorQuery{Name: "Keanu"}, 10, 0)
+	require.NoError(t, err)
+	require.NoError(t, actorRep.LoadMovies(actors))
+	require.Len(t, actors[0].Movies, 2)
+	require.Equal(t, "John Wick", actors[0].Movies[0].Title)
+}
+
+func TestActorManager_CoStars(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Pacino := &model.Actor{ID: uuid.New(), Name: "Al Pacino", BirthDate: time.Date(1940, 4, 25, 0, 0, 0, 0, time.UTC)}
+	DeNiro := &model.Actor{ID: uuid.New(), Name: "Robert De Niro", BirthDate: time.Date(1943, 8, 17, 0, 0, 0, 0, time.UTC)}
+	Keaton := &model.Actor{ID: uuid.New(), Name: "Diane Keaton", BirthDate: time.Date(1946, 1, 5, 0, 0, 0, 0, time.UTC)}
+	for _, actor := range []*model.Actor{Pacino, DeNiro, Keaton} {
+		require.NoError(t, actorRep.Create(actor))
+	}
+
+	GodfatherII := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "The Godfather Part II",
+		Description: "The early life and career of Vito Corleone.",
+		ReleaseDate: time.Date(1974, 12, 20, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+		Actors: []model.CastMember{
+			{Actor: *Pacino, BillingOrder: 1}, {Actor: *DeNiro, BillingOrder: 2}, {Actor: *Keaton, BillingOrder: 3},
+		},
+	}
+	Heat := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Heat",
+		Description: "A group of bank robbers is tracked by a detective.",
+		ReleaseDate: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC),
+		Rating:      8,
+		Actors:      []model.CastMember{{Actor: *Pacino, BillingOrder: 1}, {Actor: *DeNiro, BillingOrder: 2}},
+	}
+	for _, movie := range []*model.Movie{GodfatherII, Heat} {
+		require.NoError(t, movieRep.Create(movie))
+	}
+
+	collaborators, err := actorRep.GetCollaborators(Pacino.ID, 10)
+	require.NoError(t, err)
+	require.Equal(t, []model.Collaborator{
+		{ID: DeNiro.ID, Name: "Robert De Niro", SharedMovies: 2, Movies: []string{"The Godfather Part II", "Heat"}},
+		{ID: Keaton.ID, Name: "Diane Keaton", SharedMovies: 1, Movies: []string{"The Godfather Part II"}},
+	}, collaborators)
+
+	links, err := actorRep.GetCoStars([]uuid.UUID{Keaton.ID})
+	require.NoError(t, err)
+	require.Equal(t, []model.CoStarLink{
+		{ActorID: Keaton.ID, CoStarID: Pacino.ID, CoStarName: "Al Pacino", MovieID: GodfatherII.ID, MovieTitle: GodfatherII.Title},
+		{ActorID: Keaton.ID, CoStarID: DeNiro.ID, CoStarName: "Robert De Niro", MovieID: GodfatherII.ID, MovieTitle: GodfatherII.Title},
+	}, links)
+}
@@ -0,0 +1,372 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// AwardManager represents an interface for managing awards, their categories, ceremonies and nominations in the system.
+type AwardManager interface {
+	Create(award *model.Award) error
+	GetAll() ([]*model.Award, error)
+	GetByID(awardID uuid.UUID) (*model.Award, error)
+	Update(award *model.Award) error
+	Delete(awardID uuid.UUID) error
+	CreateCategory(category *model.AwardCategory) error
+	GetCategoryByID(categoryID uuid.UUID) (*model.AwardCategory, error)
+	DeleteCategory(categoryID uuid.UUID) error
+	CreateCeremony(ceremony *model.Ceremony) error
+	GetCeremonyByID(ceremonyID uuid.UUID) (*model.Ceremony, error)
+	DeleteCeremony(ceremonyID uuid.UUID) error
+	CreateNomination(nomination *model.Nomination) error
+	GetNominationByID(nominationID uuid.UUID) (*model.Nomination, error)
+	UpdateNomination(nomination *model.Nomination) error
+	DeleteNomination(nominationID uuid.UUID) error
+}
+
+// NewAwardManager returns new repository instance for awards
+func NewAwardManager(db *sql.DB) AwardManager {
+	return &awardManager{
+		db: db,
+	}
+}
+
+type awardManager struct {
+	db *sql.DB
+}
+
+// nominationQuery selects nominations along with the names of their award, category, movie and person,
+// in the order read by scanNomination.
+const nominationQuery = `
+	SELECT n.id, n.ceremony_id, n.category_id, n.movie_id, n.person_id, n.won, n.note,
+		ce.award_id, aw.name, ca.name, ce.year, m.title, COALESCE(p.name, '')
+	FROM nominations n
+	INNER JOIN award_ceremonies ce ON n.ceremony_id = ce.id
+	INNER JOIN awards aw ON ce.award_id = aw.id
+	INNER JOIN award_categories ca ON n.category_id = ca.id
+	INNER JOIN movies m ON n.movie_id = m.id AND m.deleted_at IS NULL
+	LEFT JOIN actors p ON n.person_id = p.id AND p.deleted_at IS NULL`
+
+// Create inserts a new award record into the database.
+func (am *awardManager) Create(award *model.Award) error {
+	query := `
+		INSERT INTO awards (id, name, description) VALUES ($1, $2, $3)`
+
+	_, err := am.db.Exec(query, award.ID, award.Name, award.Description)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetAll retrieves the awards ordered by name, without their categories and ceremonies.
+func (am *awardManager) GetAll() ([]*model.Award, error) {
+	query := `
+		SELECT id, name, description
+		FROM awards
+		ORDER BY name`
+
+	rows, err := am.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	awards := make([]*model.Award, 0)
+	for rows.Next() {
+		var award model.Award
+		if err := rows.Scan(&award.ID, &award.Name, &award.Description); err != nil {
+			return nil, err
+		}
+		awards = append(awards, &award)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return awards, nil
+}
+
+// GetByID retrieves an award from the database along with its categories and ceremonies.
+func (am *awardManager) GetByID(awardID uuid.UUID) (*model.Award, error) {
+	query := `
+		SELECT id, name, description
+		FROM awards
+		WHERE id = $1`
+
+	var award model.Award
+
+	err := am.db.QueryRow(query, awardID).Scan(&award.ID, &award.Name, &award.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	categoriesQuery := `
+		SELECT id, award_id, name
+		FROM award_categories
+		WHERE award_id = $1
+		ORDER BY name`
+
+	rows, err := am.db.Query(categoriesQuery, awardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	award.Categories = make([]model.AwardCategory, 0)
+	for rows.Next() {
+		var category model.AwardCategory
+		if err := rows.Scan(&category.ID, &category.AwardID, &category.Name); err != nil {
+			return nil, err
+		}
+		award.Categories = append(award.Categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ceremoniesQuery := `
+		SELECT id, award_id, year, ceremony_date AT TIME ZONE 'UTC' AS ceremony_date_utc
+		FROM award_ceremonies
+		WHERE award_id = $1
+		ORDER BY year DESC`
+
+	ceremonyRows, err := am.db.Query(ceremoniesQuery, awardID)
+	if err != nil {
+		return nil, err
+	}
+	defer ceremonyRows.Close()
+
+	award.Ceremonies = make([]model.Ceremony, 0)
+	for ceremonyRows.Next() {
+		var ceremony model.Ceremony
+		if err := scanCeremony(ceremonyRows, &ceremony); err != nil {
+			return nil, err
+		}
+		award.Ceremonies = append(award.Ceremonies, ceremony)
+	}
+	if err := ceremonyRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &award, nil
+}
+
+// Update updates the name and description of an award in the database.
+func (am *awardManager) Update(award *model.Award) error {
+	query := `
+		UPDATE awards SET name = $2, description = $3
+		WHERE id = $1`
+
+	_, err := am.db.Exec(query, award.ID, award.Name, award.Description)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete removes an award along with its categories, ceremonies and nominations from the database.
+func (am *awardManager) Delete(awardID uuid.UUID) error {
+	query := `DELETE FROM awards WHERE id = $1`
+
+	_, err := am.db.Exec(query, awardID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateCategory inserts a new award category record into the database.
+func (am *awardManager) CreateCategory(category *model.AwardCategory) error {
+	query := `
+		INSERT INTO award_categories (id, award_id, name) VALUES ($1, $2, $3)`
+
+	_, err := am.db.Exec(query, category.ID, category.AwardID, category.Name)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetCategoryByID retrieves an award category from the database.
+func (am *awardManager) GetCategoryByID(categoryID uuid.UUID) (*model.AwardCategory, error) {
+	query := `
+		SELECT id, award_id, name
+		FROM award_categories
+		WHERE id = $1`
+
+	var category model.AwardCategory
+
+	err := am.db.QueryRow(query, categoryID).Scan(&category.ID, &category.AwardID, &category.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// DeleteCategory removes an award category along with its nominations from the database.
+func (am *awardManager) DeleteCategory(categoryID uuid.UUID) error {
+	query := `DELETE FROM award_categories WHERE id = $1`
+
+	_, err := am.db.Exec(query, categoryID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateCeremony inserts a new ceremony record into the database.
+func (am *awardManager) CreateCeremony(ceremony *model.Ceremony) error {
+	query := `
+		INSERT INTO award_ceremonies (id, award_id, year, ceremony_date) VALUES ($1, $2, $3, $4)`
+
+	var date sql.NullTime
+	if !ceremony.Date.IsZero() {
+		date = sql.NullTime{Time: ceremony.Date, Valid: true}
+	}
+
+	_, err := am.db.Exec(query, ceremony.ID, ceremony.AwardID, ceremony.Year, date)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetCeremonyByID retrieves a ceremony from the database along with its nominations ordered by category.
+func (am *awardManager) GetCeremonyByID(ceremonyID uuid.UUID) (*model.Ceremony, error) {
+	query := `
+		SELECT id, award_id, year, ceremony_date AT TIME ZONE 'UTC' AS ceremony_date_utc
+		FROM award_ceremonies
+		WHERE id = $1`
+
+	var ceremony model.Ceremony
+
+	if err := scanCeremony(am.db.QueryRow(query, ceremonyID), &ceremony); err != nil {
+		return nil, err
+	}
+
+	nominations, err := getNominationsByQuery(am.db, nominationQuery+`
+	WHERE n.ceremony_id = $1
+	ORDER BY ca.name, n.won DESC, m.title`, ceremonyID)
+	if err != nil {
+		return nil, err
+	}
+	ceremony.Nominations = nominations
+
+	return &ceremony, nil
+}
+
+// DeleteCeremony removes a ceremony along with its nominations from the database.
+func (am *awardManager) DeleteCeremony(ceremonyID uuid.UUID) error {
+	query := `DELETE FROM award_ceremonies WHERE id = $1`
+
+	_, err := am.db.Exec(query, ceremonyID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateNomination inserts a new nomination record into the database.
+func (am *awardManager) CreateNomination(nomination *model.Nomination) error {
+	query := `
+		INSERT INTO nominations (id, ceremony_id, category_id, movie_id, person_id, won, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := am.db.Exec(query, nomination.ID, nomination.CeremonyID, nomination.CategoryID, nomination.MovieID,
+		nomination.PersonID, nomination.Won, nomination.Note)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetNominationByID retrieves a nomination from the database.
+func (am *awardManager) GetNominationByID(nominationID uuid.UUID) (*model.Nomination, error) {
+	var nomination model.Nomination
+
+	err := scanNomination(am.db.QueryRow(nominationQuery+`
+	WHERE n.id = $1`, nominationID), &nomination)
+	if err != nil {
+		return nil, err
+	}
+	return &nomination, nil
+}
+
+// UpdateNomination updates the category, nominees, outcome and note of a nomination in the database.
+func (am *awardManager) UpdateNomination(nomination *model.Nomination) error {
+	query := `
+		UPDATE nominations SET category_id = $2, movie_id = $3, person_id = $4, won = $5, note = $6
+		WHERE id = $1`
+
+	_, err := am.db.Exec(query, nomination.ID, nomination.CategoryID, nomination.MovieID, nomination.PersonID,
+		nomination.Won, nomination.Note)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteNomination removes a nomination from the database.
+func (am *awardManager) DeleteNomination(nominationID uuid.UUID) error {
+	query := `DELETE FROM nominations WHERE id = $1`
+
+	_, err := am.db.Exec(query, nominationID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getNominationsByQuery runs a query built on nominationQuery and returns the nominations it selects.
+func getNominationsByQuery(db *sql.DB, query string, args ...interface{}) ([]model.Nomination, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nominations := make([]model.Nomination, 0)
+	for rows.Next() {
+		var nomination model.Nomination
+		if err := scanNomination(rows, &nomination); err != nil {
+			return nil, err
+		}
+		nominations = append(nominations, nomination)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return nominations, nil
+}
+
+func scanCeremony(row rowScanner, ceremony *model.Ceremony) error {
+	var date sql.NullTime
+
+	if err := row.Scan(&ceremony.ID, &ceremony.AwardID, &ceremony.Year, &date); err != nil {
+		return err
+	}
+
+	ceremony.Date = date.Time
+	return nil
+}
+
+func scanNomination(row rowScanner, nomination *model.Nomination) error {
+	var personID uuid.NullUUID
+
+	err := row.Scan(&nomination.ID, &nomination.CeremonyID, &nomination.CategoryID, &nomination.MovieID, &personID,
+		&nomination.Won, &nomination.Note, &nomination.AwardID, &nomination.AwardName, &nomination.CategoryName,
+		&nomination.Year, &nomination.MovieTitle, &nomination.PersonName)
+	if err != nil {
+		return err
+	}
+
+	if personID.Valid {
+		nomination.PersonID = &personID.UUID
+	}
+	return nil
+}
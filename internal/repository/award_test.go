@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestAwardManager_Nominations(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE awards CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+	}()
+
+	actor := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Tom Hanks",
+		Gender:    "male",
+		BirthDate: time.Date(1956, 7, 9, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(actor))
+
+	movie := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Forrest Gump",
+		Description: "Life is like a box of chocolates",
+		ReleaseDate: time.Date(1994, 7, 6, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+		Actors:      []model.CastMember{{Actor: *actor, BillingOrder: 1}},
+	}
+	require.NoError(t, movieRep.Create(movie))
+
+	award := &model.Award{ID: uuid.New(), Name: "Academy Awards"}
+	require.NoError(t, awardRep.Create(award))
+
+	bestPicture := &model.AwardCategory{ID: uuid.New(), AwardID: award.ID, Name: "Best Picture"}
+	require.NoError(t, awardRep.CreateCategory(bestPicture))
+	bestActor := &model.AwardCategory{ID: uuid.New(), AwardID: award.ID, Name: "Best Actor"}
+	require.NoError(t, awardRep.CreateCategory(bestActor))
+
+	ceremony := &model.Ceremony{ID: uuid.New(), AwardID: award.ID, Year: 1995, Date: time.Date(1995, 3, 27, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, awardRep.CreateCeremony(ceremony))
+
+	require.NoError(t, awardRep.CreateNomination(&model.Nomination{
+		ID: uuid.New(), CeremonyID: ceremony.ID, CategoryID: bestPicture.ID, MovieID: movie.ID, Won: true,
+	}))
+	require.NoError(t, awardRep.CreateNomination(&model.Nomination{
+		ID: uuid.New(), CeremonyID: ceremony.ID, CategoryID: bestActor.ID, MovieID: movie.ID, PersonID: &actor.ID, Won: true,
+	}))
+
+	fetched, err := awardRep.GetCeremonyByID(ceremony.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1995, fetched.Year)
+	require.Len(t, fetched.Nominations, 2)
+
+	filmography, err := actorRep.GetFilmography(actor.ID)
+	require.NoError(t, err)
+	require.Equal(t, []model.AwardSummary{{AwardID: award.ID, AwardName: "Academy Awards", Nominations: 1, Wins: 1}}, filmography.Awards)
+	require.Len(t, filmography.Nominations, 1)
+	require.Equal(t, "Best Actor", filmography.Nominations[0].CategoryName)
+	require.Equal(t, "Forrest Gump", filmography.Nominations[0].MovieTitle)
+
+	winners, err := movieRep.GetByTitle(model.MovieFilter{WonAward: award.ID})
+	require.NoError(t, err)
+	require.Len(t, winners, 1)
+	require.Equal(t, movie.ID, winners[0].ID)
+
+	require.NoError(t, awardRep.Delete(award.ID))
+	_, err = awardRep.GetCeremonyByID(ceremony.ID)
+	require.Error(t, err)
+}
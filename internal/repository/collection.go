@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// CollectionManager represents an interface for managing movie collections in the system.
+type CollectionManager interface {
+	Create(collection *model.Collection) error
+	GetByID(collectionID uuid.UUID, ordering string) (*model.Collection, error)
+	GetAll() ([]*model.Collection, error)
+	Update(collection *model.Collection) error
+	Delete(collectionID uuid.UUID) error
+	SetMovie(collectionID uuid.UUID, movie *model.CollectionMovie) error
+	RemoveMovie(collectionID, movieID uuid.UUID) error
+}
+
+// NewCollectionManager returns new repository instance for collections
+func NewCollectionManager(db *sql.DB) CollectionManager {
+	return &collectionManager{
+		db: db,
+	}
+}
+
+type collectionManager struct {
+	db *sql.DB
+}
+
+// Create inserts a new collection record into the database.
+func (cm *collectionManager) Create(collection *model.Collection) error {
+	query := `
+		INSERT INTO collections (id, name, description, ordering) VALUES ($1, $2, $3, $4)`
+
+	_, err := cm.db.Exec(query, collection.ID, collection.Name, collection.Description, collection.Ordering)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByID retrieves a collection from the database along with its movies in the given ordering.
+// An empty ordering stands for the default ordering of the collection.
+func (cm *collectionManager) GetByID(collectionID uuid.UUID, ordering string) (*model.Collection, error) {
+	query := `
+		SELECT id, name, description, ordering
+		FROM collections
+		WHERE id = $1`
+
+	var collection model.Collection
+
+	err := cm.db.QueryRow(query, collectionID).
+		Scan(&collection.ID, &collection.Name, &collection.Description, &collection.Ordering)
+	if err != nil {
+		return nil, err
+	}
+
+	moviesQuery := `
+		SELECT m.id, m.title, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, cm.chronological_order
+		FROM collection_movies cm
+		INNER JOIN movies m ON cm.movie_id = m.id AND m.deleted_at IS NULL
+		WHERE cm.collection_id = $1
+		ORDER BY CASE WHEN $2 = 'chronological' THEN cm.chronological_order END, m.release_date, cm.chronological_order`
+
+	if ordering == "" {
+		ordering = collection.Ordering
+	}
+
+	rows, err := cm.db.Query(moviesQuery, collectionID, ordering)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collection.Movies = make([]model.CollectionMovie, 0)
+	for rows.Next() {
+		var movie model.CollectionMovie
+
+		err := rows.Scan(&movie.MovieID, &movie.Title, &movie.ReleaseDate, &movie.ChronologicalOrder)
+		if err != nil {
+			return nil, err
+		}
+
+		collection.Movies = append(collection.Movies, movie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+// GetAll retrieves all collections ordered by name. Movies are not loaded.
+func (cm *collectionManager) GetAll() ([]*model.Collection, error) {
+	query := `
+		SELECT id, name, description, ordering
+		FROM collections
+		ORDER BY name`
+
+	rows, err := cm.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := make([]*model.Collection, 0)
+	for rows.Next() {
+		var collection model.Collection
+
+		err := rows.Scan(&collection.ID, &collection.Name, &collection.Description, &collection.Ordering)
+		if err != nil {
+			return nil, err
+		}
+
+		collections = append(collections, &collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// Update updates the name, description and ordering of a collection in the database.
+func (cm *collectionManager) Update(collection *model.Collection) error {
+	query := `
+		UPDATE collections SET name = $2, description = $3, ordering = $4
+		WHERE id = $1`
+
+	_, err := cm.db.Exec(query, collection.ID, collection.Name, collection.Description, collection.Ordering)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete removes a collection from the database. Its movies are kept.
+func (cm *collectionManager) Delete(collectionID uuid.UUID) error {
+	query := `DELETE FROM collections WHERE id = $1`
+
+	_, err := cm.db.Exec(query, collectionID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetMovie adds a movie to a collection, moving it out of the collection it belonged to.
+// A movie without chronological order is placed last in the chronology; the order actually stored is set on movie.
+func (cm *collectionManager) SetMovie(collectionID uuid.UUID, movie *model.CollectionMovie) error {
+	query := `
+		INSERT INTO collection_movies (movie_id, collection_id, chronological_order)
+		VALUES ($1, $2, COALESCE(NULLIF($3::integer, 0),
+			(SELECT COALESCE(MAX(chronological_order), 0) + 1 FROM collection_movies WHERE collection_id = $2)))
+		ON CONFLICT (movie_id) DO UPDATE SET
+			collection_id = EXCLUDED.collection_id,
+			chronological_order = EXCLUDED.chronological_order
+		RETURNING chronological_order`
+
+	return cm.db.QueryRow(query, movie.MovieID, collectionID, movie.ChronologicalOrder).Scan(&movie.ChronologicalOrder)
+}
+
+// RemoveMovie removes a movie from a collection.
+// sql.ErrNoRows is returned when the movie does not belong to the collection.
+func (cm *collectionManager) RemoveMovie(collectionID, movieID uuid.UUID) error {
+	query := `DELETE FROM collection_movies WHERE collection_id = $1 AND movie_id = $2`
+
+	return execAffectingRow(cm.db, query, collectionID, movieID)
+}
+
+// loadCollections fills the collection blocks of the given movies with a single query.
+// Previous and next movies follow the default ordering of each collection.
+func loadCollections(db queryer, movies []*model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	movieIDs := make([]string, 0, len(movies))
+	movieMap := make(map[uuid.UUID]*model.Movie, len(movies))
+	for _, movie := range movies {
+		movieIDs = append(movieIDs, movie.ID.String())
+		movieMap[movie.ID] = movie
+	}
+
+	query := `
+		WITH ordered AS (
+			SELECT cm.movie_id, cm.collection_id, c.name, m.title,
+				m.release_date AT TIME ZONE 'UTC' AS release_date_utc, cm.chronological_order,
+				ROW_NUMBER() OVER (
+					PARTITION BY cm.collection_id
+					ORDER BY CASE WHEN c.ordering = 'chronological' THEN cm.chronological_order END,
+						m.release_date, cm.chronological_order
+				) AS position
+			FROM collection_movies cm
+			INNER JOIN collections c ON cm.collection_id = c.id
+			INNER JOIN movies m ON cm.movie_id = m.id AND m.deleted_at IS NULL
+			WHERE cm.collection_id IN (SELECT collection_id FROM collection_movies WHERE movie_id = ANY($1::uuid[]))
+		)
+		SELECT o.movie_id, o.collection_id, o.name,
+			p.movie_id, p.title, p.release_date_utc, p.chronological_order,
+			n.movie_id, n.title, n.release_date_utc, n.chronological_order
+		FROM ordered o
+		LEFT JOIN ordered p ON p.collection_id = o.collection_id AND p.position = o.position - 1
+		LEFT JOIN ordered n ON n.collection_id = o.collection_id AND n.position = o.position + 1
+		WHERE o.movie_id = ANY($1::uuid[])
+	`
+	rows, err := db.Query(query, pq.Array(movieIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movieID uuid.UUID
+		var collection model.MovieCollection
+		var previous, next nullCollectionMovie
+
+		err := rows.Scan(&movieID, &collection.ID, &collection.Name,
+			&previous.MovieID, &previous.Title, &previous.ReleaseDate, &previous.ChronologicalOrder,
+			&next.MovieID, &next.Title, &next.ReleaseDate, &next.ChronologicalOrder)
+		if err != nil {
+			return err
+		}
+
+		collection.Previous = previous.toModel()
+		collection.Next = next.toModel()
+		movieMap[movieID].Collection = &collection
+	}
+
+	return rows.Err()
+}
+
+// nullCollectionMovie holds the columns of a neighbouring movie read from an outer join.
+type nullCollectionMovie struct {
+	MovieID            uuid.NullUUID
+	Title              sql.NullString
+	ReleaseDate        sql.NullTime
+	ChronologicalOrder sql.NullInt64
+}
+
+func (n nullCollectionMovie) toModel() *model.CollectionMovie {
+	if !n.MovieID.Valid {
+		return nil
+	}
+	return &model.CollectionMovie{
+		MovieID:            n.MovieID.UUID,
+		Title:              n.Title.String,
+		ReleaseDate:        n.ReleaseDate.Time,
+		ChronologicalOrder: int(n.ChronologicalOrder.Int64),
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestCollectionManager_PreviousAndNext(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE collections CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	collection := &model.Collection{ID: uuid.New(), Name: "Star Wars", Ordering: model.CollectionOrderRelease}
+	require.NoError(t, collectionRep.Create(collection))
+
+	titles := []string{"A New Hope", "The Phantom Menace", "Attack of the Clones"}
+	chronology := []int{3, 1, 2}
+	movies := make([]*model.Movie, len(titles))
+	for i, title := range titles {
+		movies[i] = &model.Movie{
+			ID:          uuid.New(),
+			Title:       title,
+			Description: "A long time ago",
+			ReleaseDate: time.Date(1977+i*22, 5, 25, 0, 0, 0, 0, time.UTC),
+			Rating:      8,
+		}
+		require.NoError(t, movieRep.Create(movies[i]))
+		require.NoError(t, collectionRep.SetMovie(collection.ID,
+			&model.CollectionMovie{MovieID: movies[i].ID, ChronologicalOrder: chronology[i]}))
+	}
+
+	movie, err := movieRep.GetByID(movies[1].ID)
+	require.NoError(t, err)
+	require.NotNil(t, movie.Collection)
+	require.Equal(t, collection.ID, movie.Collection.ID)
+	require.Equal(t, movies[0].ID, movie.Collection.Previous.MovieID)
+	require.Equal(t, movies[2].ID, movie.Collection.Next.MovieID)
+
+	collection.Ordering = model.CollectionOrderChronological
+	require.NoError(t, collectionRep.Update(collection))
+
+	listed, err := movieRep.GetByTitle(model.MovieFilter{})
+	require.NoError(t, err)
+	for _, movie := range listed {
+		if movie.ID == movies[1].ID {
+			require.Nil(t, movie.Collection.Previous)
+			require.Equal(t, movies[2].ID, movie.Collection.Next.MovieID)
+		}
+	}
+
+	byRelease, err := collectionRep.GetByID(collection.ID, model.CollectionOrderRelease)
+	require.NoError(t, err)
+	require.Len(t, byRelease.Movies, 3)
+	require.Equal(t, movies[0].ID, byRelease.Movies[0].MovieID)
+
+	require.NoError(t, collectionRep.RemoveMovie(collection.ID, movies[0].ID))
+	movie, err = movieRep.GetByID(movies[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, movie.Collection)
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// CompanyManager represents an interface for managing studios and production companies in the system.
+type CompanyManager interface {
+	Create(company *model.Company) error
+	GetByID(companyID uuid.UUID) (*model.Company, error)
+	GetAll() ([]*model.Company, error)
+	Update(company *model.Company) error
+	Delete(companyID uuid.UUID) error
+	GetMovies(companyID uuid.UUID, role string, limit, offset int) ([]model.CompanyMovie, int, error)
+}
+
+// NewCompanyManager returns new repository instance for companies
+func NewCompanyManager(db *sql.DB) CompanyManager {
+	return &companyManager{
+		db: db,
+	}
+}
+
+type companyManager struct {
+	db *sql.DB
+}
+
+// Create inserts a new company record into the database.
+func (cm *companyManager) Create(company *model.Company) error {
+	query := `
+		INSERT INTO companies (id, name, country, description) VALUES ($1, $2, $3, $4)`
+
+	_, err := cm.db.Exec(query, company.ID, company.Name, company.Country, company.Description)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByID retrieves a company from the database.
+func (cm *companyManager) GetByID(companyID uuid.UUID) (*model.Company, error) {
+	query := `
+		SELECT id, name, country, description
+		FROM companies
+		WHERE id = $1`
+
+	var company model.Company
+
+	err := cm.db.QueryRow(query, companyID).
+		Scan(&company.ID, &company.Name, &company.Country, &company.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	return &company, nil
+}
+
+// GetAll retrieves all companies ordered by name.
+func (cm *companyManager) GetAll() ([]*model.Company, error) {
+	query := `
+		SELECT id, name, country, description
+		FROM companies
+		ORDER BY name`
+
+	rows, err := cm.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	companies := make([]*model.Company, 0)
+	for rows.Next() {
+		var company model.Company
+
+		err := rows.Scan(&company.ID, &company.Name, &company.Country, &company.Description)
+		if err != nil {
+			return nil, err
+		}
+
+		companies = append(companies, &company)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return companies, nil
+}
+
+// Update updates the name, country and description of a company in the database.
+func (cm *companyManager) Update(company *model.Company) error {
+	query := `
+		UPDATE companies SET name = $2, country = $3, description = $4
+		WHERE id = $1`
+
+	_, err := cm.db.Exec(query, company.ID, company.Name, company.Country, company.Description)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete removes a company from the database along with its links to movies. The movies are kept.
+func (cm *companyManager) Delete(companyID uuid.UUID) error {
+	query := `DELETE FROM companies WHERE id = $1`
+
+	_, err := cm.db.Exec(query, companyID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetMovies retrieves a page of the movies a company worked on in the given role, or in any role when it is empty,
+// most recent first, along with the number of such movies across all pages.
+func (cm *companyManager) GetMovies(companyID uuid.UUID, role string, limit, offset int) ([]model.CompanyMovie, int, error) {
+	query := `
+		SELECT m.id, m.title, m.release_date AT TIME ZONE 'UTC' AS release_date_utc,
+			ARRAY_AGG(mc.role ORDER BY mc.role), COUNT(*) OVER ()
+		FROM movie_company mc
+		INNER JOIN movies m ON mc.movie_id = m.id AND m.deleted_at IS NULL
+		WHERE mc.company_id = $1 AND ($2 = '' OR mc.role = $2)
+		GROUP BY m.id, m.title, m.release_date
+		ORDER BY m.release_date DESC, m.title
+		LIMIT $3 OFFSET $4`
+
+	rows, err := cm.db.Query(query, companyID, role, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	movies := make([]model.CompanyMovie, 0)
+	total := 0
+	for rows.Next() {
+		var movie model.CompanyMovie
+
+		err := rows.Scan(&movie.MovieID, &movie.Title, &movie.ReleaseDate, pq.Array(&movie.Roles), &total)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		movies = append(movies, movie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(movies) == 0 && offset > 0 {
+		countQuery := `
+			SELECT COUNT(DISTINCT mc.movie_id)
+			FROM movie_company mc
+			INNER JOIN movies m ON mc.movie_id = m.id AND m.deleted_at IS NULL
+			WHERE mc.company_id = $1 AND ($2 = '' OR mc.role = $2)`
+
+		if err := cm.db.QueryRow(countQuery, companyID, role).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return movies, total, nil
+}
+
+// loadCompanies fills the companies of the given movies with a single query, ordered by role and name.
+func loadCompanies(db queryer, movies []*model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	movieIDs := make([]string, 0, len(movies))
+	movieMap := make(map[uuid.UUID]*model.Movie, len(movies))
+	for _, movie := range movies {
+		movieIDs = append(movieIDs, movie.ID.String())
+		movieMap[movie.ID] = movie
+	}
+
+	query := `
+		SELECT mc.movie_id, c.id, c.name, mc.role
+		FROM movie_company mc
+		INNER JOIN companies c ON mc.company_id = c.id
+		WHERE mc.movie_id = ANY($1::uuid[])
+		ORDER BY mc.role DESC, c.name
+	`
+	rows, err := db.Query(query, pq.Array(movieIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movieID uuid.UUID
+		var company model.MovieCompany
+
+		if err := rows.Scan(&movieID, &company.CompanyID, &company.Name, &company.Role); err != nil {
+			return err
+		}
+
+		movieMap[movieID].Companies = append(movieMap[movieID].Companies, company)
+	}
+
+	return rows.Err()
+}
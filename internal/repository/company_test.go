@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestCompanyManager_GetMovies(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE companies CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	pixar := &model.Company{ID: uuid.New(), Name: "Pixar", Country: "US"}
+	require.NoError(t, companyRep.Create(pixar))
+	disney := &model.Company{ID: uuid.New(), Name: "Walt Disney Pictures", Country: "US"}
+	require.NoError(t, companyRep.Create(disney))
+
+	titles := []string{"Toy Story", "A Bug's Life", "Toy Story 2"}
+	movies := make([]*model.Movie, len(titles))
+	for i, title := range titles {
+		movies[i] = &model.Movie{
+			ID:          uuid.New(),
+			Title:       title,
+			Description: "To infinity and beyond",
+			ReleaseDate: time.Date(1995+i*2, 11, 22, 0, 0, 0, 0, time.UTC),
+			Rating:      8,
+			Companies: []model.MovieCompany{
+				{CompanyID: pixar.ID, Name: "Pixar", Role: model.CompanyRoleProduction},
+				{CompanyID: disney.ID, Name: "Walt Disney Pictures", Role: model.CompanyRoleDistribution},
+			},
+		}
+		require.NoError(t, movieRep.Create(movies[i]))
+	}
+
+	movie, err := movieRep.GetByID(movies[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, movies[0].Companies, movie.Companies)
+
+	page, total, err := companyRep.GetMovies(pixar.ID, "", 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, page, 2)
+	require.Equal(t, "Toy Story 2", page[0].Title)
+	require.Equal(t, []string{model.CompanyRoleProduction}, page[0].Roles)
+
+	page, total, err = companyRep.GetMovies(pixar.ID, "", 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Empty(t, page)
+
+	page, total, err = companyRep.GetMovies(pixar.ID, model.CompanyRoleDistribution, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, total)
+	require.Empty(t, page)
+
+	distributed, err := movieRep.GetByTitle(model.MovieFilter{Company: disney.ID, CompanyRole: model.CompanyRoleDistribution})
+	require.NoError(t, err)
+	require.Len(t, distributed, 3)
+
+	require.NoError(t, companyRep.Delete(disney.ID))
+	movie, err = movieRep.GetByID(movies[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, movies[0].Companies[:1], movie.Companies)
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// DuplicateManager represents an interface for finding and merging duplicate actors and movies in the system.
+type DuplicateManager interface {
+	GetActorRecords() ([]model.DuplicateRecord, error)
+	GetMovieRecords() ([]model.DuplicateRecord, error)
+	MergeActors(sourceID, targetID uuid.UUID, history ActorHistory) error
+	MergeMovies(sourceID, targetID uuid.UUID, history MovieHistory) error
+}
+
+// NewDuplicateManager returns new repository instance for duplicates
+func NewDuplicateManager(db *sql.DB) DuplicateManager {
+	return &duplicateManager{
+		db: db,
+	}
+}
+
+type duplicateManager struct {
+	db *sql.DB
+}
+
+// GetActorRecords retrieves the names and birth dates of all actors but the ones in the trash, ordered by name.
+func (dm *duplicateManager) GetActorRecords() ([]model.DuplicateRecord, error) {
+	query := `
+		SELECT id, name, birth_date AT TIME ZONE 'UTC'
+		FROM actors
+		WHERE deleted_at IS NULL
+		ORDER BY name, birth_date`
+
+	return dm.getRecordsByQuery(query)
+}
+
+// GetMovieRecords retrieves the titles and release dates of all movies but the ones in the trash, ordered by title.
+func (dm *duplicateManager) GetMovieRecords() ([]model.DuplicateRecord, error) {
+	query := `
+		SELECT id, title, release_date AT TIME ZONE 'UTC'
+		FROM movies
+		WHERE deleted_at IS NULL
+		ORDER BY title, release_date`
+
+	return dm.getRecordsByQuery(query)
+}
+
+// MergeActors merges the source actor into the target actor: the credits, episode appearances, nominations and
+// profile links of the source actor are moved to the target actor, the empty profile fields of the target actor
+// are filled from the source actor, whose name becomes an alias. The source actor is removed and its ID, as well
+// as the IDs previously merged into it, redirect to the target actor. The history entries built from the merged actor
+// are recorded in the same transaction.
+func (dm *duplicateManager) MergeActors(sourceID, targetID uuid.UUID, history ActorHistory) error {
+	tx, err := dm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	profileQuery := `
+		UPDATE actors t SET
+			death_date = COALESCE(t.death_date, s.death_date),
+			birth_place = COALESCE(NULLIF(t.birth_place, ''), s.birth_place),
+			nationality = COALESCE(NULLIF(t.nationality, ''), s.nationality),
+			biography = COALESCE(NULLIF(t.biography, ''), s.biography),
+			aliases = ARRAY(
+				SELECT alias
+				FROM UNNEST(t.aliases || s.name::VARCHAR(255) || s.aliases) WITH ORDINALITY AS a(alias, position)
+				WHERE alias <> t.name
+				GROUP BY alias
+				ORDER BY MIN(position)),
+			version = t.version + 1
+		FROM actors s
+		WHERE t.id = $2 AND s.id = $1`
+
+	_, err = tx.Exec(profileQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	repoints := []struct {
+		table string
+		keys  []string
+	}{
+		{"actor_links", []string{"site"}},
+		{"credits", []string{"movie_id", "role"}},
+		{"episode_actor", []string{"episode_id"}},
+	}
+	for _, repoint := range repoints {
+		column := "actor_id"
+		if repoint.table == "credits" {
+			column = "person_id"
+		}
+		if err = repointRows(tx, repoint.table, column, repoint.keys, sourceID, targetID); err != nil {
+			return err
+		}
+	}
+
+	nominationsQuery := `UPDATE nominations SET person_id = $2 WHERE person_id = $1`
+
+	_, err = tx.Exec(nominationsQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	if err = redirect(tx, "actor_redirects", "actor_id", "actors", sourceID, targetID); err != nil {
+		return err
+	}
+
+	err = insertActorHistory(tx, targetID, history)
+	return err
+}
+
+// MergeMovies merges the source movie into the target movie: the cast, crew, user data, list and collection
+// entries, metadata, translations, releases, nominations, companies and tags of the source movie are moved to the
+// target movie, keeping the rows of the target movie on conflicts, and the empty fields of the target movie are
+// filled from the source movie. The source movie is removed and its ID, as well as the IDs previously merged into
+// it, redirect to the target movie. The user scores of the target movie are refreshed from the merged ratings.
+// The history entries built from the merged movie are recorded in the same transaction.
+func (dm *duplicateManager) MergeMovies(sourceID, targetID uuid.UUID, history MovieHistory) error {
+	tx, err := dm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// The scores of the source movie move to the target movie, so the rating statistics are locked before any movie
+	// like for any change of scores.
+	if err = lockRatingStats(tx); err != nil {
+		return err
+	}
+
+	movieQuery := `
+		UPDATE movies t SET
+			description = COALESCE(NULLIF(t.description, ''), s.description),
+			rating = CASE WHEN t.rating = 0 THEN s.rating ELSE t.rating END,
+			runtime_minutes = CASE WHEN t.runtime_minutes = 0 THEN s.runtime_minutes ELSE t.runtime_minutes END,
+			original_title = COALESCE(NULLIF(t.original_title, ''), s.original_title),
+			original_language = COALESCE(NULLIF(t.original_language, ''), s.original_language),
+			production_countries = ARRAY(
+				SELECT DISTINCT UNNEST(t.production_countries || s.production_countries) ORDER BY 1),
+			spoken_languages = ARRAY(
+				SELECT DISTINCT UNNEST(t.spoken_languages || s.spoken_languages) ORDER BY 1),
+			version = t.version + 1
+		FROM movies s
+		WHERE t.id = $2 AND s.id = $1`
+
+	_, err = tx.Exec(movieQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	repoints := []struct {
+		table string
+		keys  []string
+	}{
+		{"credits", []string{"person_id", "role"}},
+		{"user_ratings", []string{"user_id"}},
+		{"reviews", []string{"user_id"}},
+		{"watchlist", []string{"user_id"}},
+		{"watch_log", []string{"user_id"}},
+		{"list_entries", []string{"list_id"}},
+		{"collection_movies", nil},
+		{"movie_certifications", []string{"country_code"}},
+		{"movie_translations", []string{"language"}},
+		{"movie_releases", []string{"country_code", "release_type"}},
+		{"movie_company", []string{"company_id", "role"}},
+		{"movie_tags", []string{"tag_id"}},
+	}
+	for _, repoint := range repoints {
+		if err = repointRows(tx, repoint.table, "movie_id", repoint.keys, sourceID, targetID); err != nil {
+			return err
+		}
+	}
+
+	nominationsQuery := `UPDATE nominations SET movie_id = $2 WHERE movie_id = $1`
+
+	_, err = tx.Exec(nominationsQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	// The primary release date is derived from the merged releases the same way as when a movie is saved:
+	// the earliest theatrical release, or the earliest release of any type.
+	releaseDateQuery := `
+		UPDATE movies SET release_date = COALESCE(
+			(SELECT MIN(release_date) FROM movie_releases
+				WHERE movie_id = $1 AND release_type IN ('theatrical', 'theatrical_limited')),
+			(SELECT MIN(release_date) FROM movie_releases WHERE movie_id = $1),
+			release_date)
+		WHERE id = $1`
+
+	_, err = tx.Exec(releaseDateQuery, targetID)
+	if err != nil {
+		return err
+	}
+
+	// The scores of the users who rated both movies are dropped in favour of their scores of the target movie.
+	if err = refreshScores(tx, targetID); err != nil {
+		return err
+	}
+	if err = recountRatingStats(tx); err != nil {
+		return err
+	}
+
+	if err = redirect(tx, "movie_redirects", "movie_id", "movies", sourceID, targetID); err != nil {
+		return err
+	}
+
+	err = insertMovieHistory(tx, targetID, history)
+	return err
+}
+
+func (dm *duplicateManager) getRecordsByQuery(query string) ([]model.DuplicateRecord, error) {
+	rows, err := dm.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]model.DuplicateRecord, 0)
+	for rows.Next() {
+		var record model.DuplicateRecord
+
+		if err := rows.Scan(&record.ID, &record.Name, &record.Date); err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// repointRows moves the rows of a table from the source record to the target record. The rows that would collide
+// with a row of the target record, i.e. that have the same values in the key columns, are removed instead.
+func repointRows(tx *sql.Tx, table, column string, keys []string, sourceID, targetID uuid.UUID) error {
+	conditions := []string{"o." + column + " = $2"}
+	for _, key := range keys {
+		conditions = append(conditions, "o."+key+" = r."+key)
+	}
+
+	updateQuery := `
+		UPDATE ` + table + ` r SET ` + column + ` = $2
+		WHERE r.` + column + ` = $1
+			AND NOT EXISTS (SELECT 1 FROM ` + table + ` o WHERE ` + strings.Join(conditions, " AND ") + `)`
+
+	if _, err := tx.Exec(updateQuery, sourceID, targetID); err != nil {
+		return err
+	}
+
+	deleteQuery := `DELETE FROM ` + table + ` WHERE ` + column + ` = $1`
+
+	_, err := tx.Exec(deleteQuery, sourceID)
+	return err
+}
+
+// redirect removes the source record from its table and makes its ID, as well as the IDs redirecting to it,
+// redirect to the target record.
+func redirect(tx *sql.Tx, redirectTable, column, table string, sourceID, targetID uuid.UUID) error {
+	updateQuery := `UPDATE ` + redirectTable + ` SET ` + column + ` = $2 WHERE ` + column + ` = $1`
+
+	if _, err := tx.Exec(updateQuery, sourceID, targetID); err != nil {
+		return err
+	}
+
+	insertQuery := `INSERT INTO ` + redirectTable + ` (old_id, ` + column + `) VALUES ($1, $2)`
+
+	if _, err := tx.Exec(insertQuery, sourceID, targetID); err != nil {
+		return err
+	}
+
+	deleteQuery := `DELETE FROM ` + table + ` WHERE id = $1`
+
+	_, err := tx.Exec(deleteQuery, sourceID)
+	return err
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestDuplicateManager_MergeActors(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+	Marilyn := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Marilyn Monroe",
+		Gender:    "female",
+		BirthDate: time.Date(1926, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	Norma := &model.Actor{
+		ID:         uuid.New(),
+		Name:       "Norma Jeane Mortenson",
+		Gender:     "female",
+		BirthDate:  time.Date(1926, 6, 1, 0, 0, 0, 0, time.UTC),
+		DeathDate:  time.Date(1962, 8, 4, 0, 0, 0, 0, time.UTC),
+		BirthPlace: "Los Angeles, California, USA",
+	}
+	require.NoError(t, actorRep.Create(Marilyn))
+	require.NoError(t, actorRep.Create(Norma))
+
+	SomeLikeItHot := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Some Like It Hot",
+		Description: "Two musicians disguise themselves as women.",
+		ReleaseDate: time.Date(1959, 3, 29, 0, 0, 0, 0, time.UTC),
+		Rating:      8,
+		Actors:      []model.CastMember{{Actor: *Norma, BillingOrder: 1}},
+	}
+	require.NoError(t, movieRep.Create(SomeLikeItHot))
+
+	records, err := duplicateRep.GetActorRecords()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	require.NoError(t, duplicateRep.MergeActors(Norma.ID, Marilyn.ID, nil))
+
+	merged, err := actorRep.GetByID(Norma.ID)
+	require.NoError(t, err)
+	require.Equal(t, Marilyn.ID, merged.ID)
+	require.Equal(t, "Marilyn Monroe", merged.Name)
+	require.Equal(t, []string{"Norma Jeane Mortenson"}, merged.Aliases)
+	require.Equal(t, Norma.DeathDate, merged.DeathDate)
+	require.Equal(t, Norma.BirthPlace, merged.BirthPlace)
+
+	movie, err := movieRep.GetByID(SomeLikeItHot.ID)
+	require.NoError(t, err)
+	require.Len(t, movie.Actors, 1)
+	require.Equal(t, Marilyn.ID, movie.Actors[0].ID)
+
+	records, err = duplicateRep.GetActorRecords()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
+
+func TestDuplicateManager_MergeMovies(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE users CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("UPDATE rating_stats SET score_sum = 0, score_count = 0")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE history")
+		require.NoError(t, err)
+	}()
+	Keanu := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Keanu Reeves",
+		Gender:    "male",
+		BirthDate: time.Date(1964, 9, 2, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(Keanu))
+
+	Matrix := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "The Matrix",
+		Description: "A hacker learns the truth about his reality.",
+		ReleaseDate: time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+	}
+	MatrixCopy := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "the matrix",
+		ReleaseDate: time.Date(1999, 6, 11, 0, 0, 0, 0, time.UTC),
+		Actors:      []model.CastMember{{Actor: *Keanu, BillingOrder: 1}},
+	}
+	older := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Before The Matrix",
+		ReleaseDate: time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, movieRep.Create(Matrix))
+	require.NoError(t, movieRep.Create(MatrixCopy))
+	require.NoError(t, movieRep.Create(older))
+
+	trinity := &model.User{ID: uuid.New(), Username: "trinity", Password: "hash"}
+	require.NoError(t, userRep.Create(trinity))
+	neo := &model.User{ID: uuid.New(), Username: "neo", Password: "hash"}
+	require.NoError(t, userRep.Create(neo))
+	ratedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, ratingRep.Set(&model.UserRating{UserID: trinity.ID, MovieID: Matrix.ID, Score: 10, RatedAt: ratedAt}))
+	require.NoError(t, ratingRep.Set(&model.UserRating{UserID: trinity.ID, MovieID: MatrixCopy.ID, Score: 2, RatedAt: ratedAt}))
+	require.NoError(t, ratingRep.Set(&model.UserRating{UserID: neo.ID, MovieID: MatrixCopy.ID, Score: 8, RatedAt: ratedAt}))
+
+	require.NoError(t, duplicateRep.MergeMovies(older.ID, MatrixCopy.ID, nil))
+	// The history is built from the merged movie as read within the merge.
+	require.NoError(t, duplicateRep.MergeMovies(MatrixCopy.ID, Matrix.ID, func(merged *model.Movie) ([]*model.HistoryEntry, error) {
+		require.Equal(t, 2, merged.Version)
+		require.Len(t, merged.Actors, 1)
+		return []*model.HistoryEntry{{
+			EntityType: model.HistoryCast,
+			EntityID:   merged.ID,
+			Action:     model.HistoryMerge,
+			Username:   "admin",
+			Before:     json.RawMessage(`{"Actors":null}`),
+			After:      json.RawMessage(`{"Actors":[{"ActorID":"` + merged.Actors[0].ID.String() + `"}]}`),
+		}}, nil
+	}))
+	entries, err := historyRep.GetByEntity(Matrix.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, model.HistoryMerge, entries[0].Action)
+
+	for _, movieID := range []uuid.UUID{Matrix.ID, MatrixCopy.ID, older.ID} {
+		merged, err := movieRep.GetByID(movieID)
+		require.NoError(t, err)
+		require.Equal(t, Matrix.ID, merged.ID)
+		require.Equal(t, "The Matrix", merged.Title)
+		require.Equal(t, 2, merged.Version)
+		require.Len(t, merged.Actors, 1)
+		require.Equal(t, Keanu.ID, merged.Actors[0].ID)
+		require.Equal(t, 2, merged.UserRating.Count)
+		require.InDelta(t, 9.0, merged.UserRating.Average, 0.001)
+		require.InDelta(t, 9.0, merged.UserRating.Weighted, 0.001)
+	}
+
+	records, err := duplicateRep.GetMovieRecords()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// HistoryManager represents an interface for browsing the change history of movies, actors and casts. The changes are
+// recorded by the writes making them, in the same transaction.
+type HistoryManager interface {
+	GetByEntity(entityID uuid.UUID) ([]model.HistoryEntry, error)
+}
+
+// MovieHistory builds the history entries of a change from the state the change leaves a movie in, read within the
+// transaction of the change.
+type MovieHistory func(movie *model.Movie) ([]*model.HistoryEntry, error)
+
+// ActorHistory builds the history entries of a change from the state the change leaves an actor in, read within the
+// transaction of the change.
+type ActorHistory func(actor *model.Actor) ([]*model.HistoryEntry, error)
+
+// NewHistoryManager returns new repository instance for the change history
+func NewHistoryManager(db *sql.DB) HistoryManager {
+	return &historyManager{
+		db: db,
+	}
+}
+
+type historyManager struct {
+	db *sql.DB
+}
+
+// GetByEntity retrieves the changes made to a movie and its cast or to an actor, most recent first.
+func (hm *historyManager) GetByEntity(entityID uuid.UUID) ([]model.HistoryEntry, error) {
+	query := `
+		SELECT id, entity_type, entity_id, action, username, changed_at AT TIME ZONE 'UTC' AS changed_at_utc,
+			before, after, diff
+		FROM history
+		WHERE entity_id = $1
+		ORDER BY id DESC`
+
+	rows, err := hm.db.Query(query, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]model.HistoryEntry, 0)
+	for rows.Next() {
+		var entry model.HistoryEntry
+		var before, after, diff []byte
+
+		if err := rows.Scan(&entry.ID, &entry.EntityType, &entry.EntityID, &entry.Action, &entry.Username,
+			&entry.ChangedAt, &before, &after, &diff); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(diff, &entry.Diff); err != nil {
+			return nil, err
+		}
+		entry.Before = before
+		entry.After = after
+
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// insertHistory records changes in the history within the transaction of the write making them, setting the IDs and
+// the times of the entries.
+func insertHistory(tx *sql.Tx, entries []*model.HistoryEntry) error {
+	query := `
+		INSERT INTO history (entity_type, entity_id, action, username, before, after, diff)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, changed_at AT TIME ZONE 'UTC'`
+
+	for _, entry := range entries {
+		diff, err := json.Marshal(entry.Diff)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(query, entry.EntityType, entry.EntityID, entry.Action, entry.Username,
+			nullableJSON(entry.Before), nullableJSON(entry.After), string(diff)).Scan(&entry.ID, &entry.ChangedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertMovieHistory records the history entries built from the state of a movie read within the transaction of
+// the change, nothing when no history is given.
+func insertMovieHistory(tx *sql.Tx, movieID uuid.UUID, history MovieHistory) error {
+	if history == nil {
+		return nil
+	}
+
+	movie, err := getMovie(tx, movieID)
+	if err != nil {
+		return err
+	}
+	entries, err := history(movie)
+	if err != nil {
+		return err
+	}
+	return insertHistory(tx, entries)
+}
+
+// insertActorHistory records the history entries built from the state of an actor read within the transaction of
+// the change, nothing when no history is given.
+func insertActorHistory(tx *sql.Tx, actorID uuid.UUID, history ActorHistory) error {
+	if history == nil {
+		return nil
+	}
+
+	actor, err := getActor(tx, actorID)
+	if err != nil {
+		return err
+	}
+	entries, err := history(actor)
+	if err != nil {
+		return err
+	}
+	return insertHistory(tx, entries)
+}
+
+// nullableJSON stores an absent state of a record as NULL rather than as a JSON null. The states are passed as strings
+// since byte slices are sent as bytea.
+func nullableJSON(state json.RawMessage) interface{} {
+	if len(state) == 0 {
+		return nil
+	}
+	return string(state)
+}
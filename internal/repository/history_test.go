@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestHistoryManager_InsertAndGetByEntity(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE history")
+		require.NoError(t, err)
+	}()
+	movieID := uuid.New()
+
+	created := &model.HistoryEntry{
+		EntityType: model.HistoryMovie,
+		EntityID:   movieID,
+		Action:     model.HistoryCreate,
+		Username:   "admin",
+		After:      json.RawMessage(`{"Title":"Heat","Rating":7}`),
+		Diff: map[string]model.FieldChange{
+			"Rating": {Before: json.RawMessage("null"), After: json.RawMessage("7")},
+			"Title":  {Before: json.RawMessage("null"), After: json.RawMessage(`"Heat"`)},
+		},
+	}
+	insert := func(entries ...*model.HistoryEntry) {
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		require.NoError(t, insertHistory(tx, entries))
+		require.NoError(t, tx.Commit())
+	}
+	insert(created)
+	require.NotZero(t, created.ID)
+	require.False(t, created.ChangedAt.IsZero())
+
+	updated := &model.HistoryEntry{
+		EntityType: model.HistoryMovie,
+		EntityID:   movieID,
+		Action:     model.HistoryUpdate,
+		Username:   "editor",
+		Before:     json.RawMessage(`{"Title":"Heat","Rating":7}`),
+		After:      json.RawMessage(`{"Title":"Heat","Rating":9}`),
+		Diff:       map[string]model.FieldChange{"Rating": {Before: json.RawMessage("7"), After: json.RawMessage("9")}},
+	}
+	actorCreated := &model.HistoryEntry{
+		EntityType: model.HistoryActor,
+		EntityID:   uuid.New(),
+		Action:     model.HistoryCreate,
+		Username:   "admin",
+		After:      json.RawMessage(`{"Name":"Al Pacino"}`),
+		Diff:       map[string]model.FieldChange{"Name": {Before: json.RawMessage("null"), After: json.RawMessage(`"Al Pacino"`)}},
+	}
+	insert(updated, actorCreated)
+	require.Greater(t, updated.ID, created.ID)
+	require.Greater(t, actorCreated.ID, updated.ID)
+
+	entries, err := historyRep.GetByEntity(movieID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, updated.ID, entries[0].ID)
+	require.Equal(t, "editor", entries[0].Username)
+	require.JSONEq(t, `{"Title":"Heat","Rating":9}`, string(entries[0].After))
+	require.JSONEq(t, "9", string(entries[0].Diff["Rating"].After))
+	require.Equal(t, created.ID, entries[1].ID)
+	require.Nil(t, entries[1].Before)
+
+	entries, err = historyRep.GetByEntity(uuid.New())
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestHistoryManager_RecordedWithTheChange(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE history")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+	Heat := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Heat",
+		Description: "A group of professional bank robbers",
+		ReleaseDate: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC),
+		Rating:      7,
+	}
+	require.NoError(t, movieRep.Create(Heat, &model.HistoryEntry{
+		EntityType: model.HistoryMovie,
+		EntityID:   Heat.ID,
+		Action:     model.HistoryCreate,
+		Username:   "admin",
+		After:      json.RawMessage(`{"Title":"Heat"}`),
+		Diff:       map[string]model.FieldChange{"Title": {Before: json.RawMessage("null"), After: json.RawMessage(`"Heat"`)}},
+	}))
+
+	// An update losing to a concurrent one leaves no trace in the history.
+	stale := *Heat
+	stale.Version = 0
+	stale.Rating = 9
+	require.ErrorIs(t, movieRep.Update(&stale, &model.HistoryEntry{
+		EntityType: model.HistoryMovie,
+		EntityID:   Heat.ID,
+		Action:     model.HistoryUpdate,
+		Username:   "editor",
+		Before:     json.RawMessage(`{"Rating":7}`),
+		After:      json.RawMessage(`{"Rating":9}`),
+		Diff:       map[string]model.FieldChange{"Rating": {Before: json.RawMessage("7"), After: json.RawMessage("9")}},
+	}), sql.ErrNoRows)
+
+	entries, err := historyRep.GetByEntity(Heat.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, model.HistoryCreate, entries[0].Action)
+}
@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// ListManager represents an interface for managing user-curated movie lists in the system.
+type ListManager interface {
+	Create(list *model.MovieList) error
+	GetByID(listID uuid.UUID) (*model.MovieList, error)
+	Update(list *model.MovieList) error
+	Delete(listID uuid.UUID) error
+	GetByOwner(userID uuid.UUID) ([]*model.MovieList, error)
+	GetByVisibility(visibility string) ([]*model.MovieList, error)
+	SetEntry(listID uuid.UUID, entry *model.ListEntry) error
+	RemoveEntry(listID, movieID uuid.UUID) error
+	Reorder(listID uuid.UUID, movieIDs []uuid.UUID) error
+}
+
+// NewListManager returns new repository instance for movie lists
+func NewListManager(db *sql.DB) ListManager {
+	return &listManager{
+		db: db,
+	}
+}
+
+type listManager struct {
+	db *sql.DB
+}
+
+// listQuery selects lists along with the name of their owner and the number of movies on them.
+const listQuery = `
+	SELECT l.id, l.user_id, u.username, l.name, l.description, l.visibility,
+		(SELECT COUNT(*) FROM list_entries le INNER JOIN movies m ON le.movie_id = m.id AND m.deleted_at IS NULL
+			WHERE le.list_id = l.id) AS entry_count,
+		l.created_at AT TIME ZONE 'UTC' AS created_at_utc, l.updated_at AT TIME ZONE 'UTC' AS updated_at_utc
+	FROM lists l
+	INNER JOIN users u ON l.user_id = u.id`
+
+// Create inserts a new list record along with its entries into the database.
+func (lm *listManager) Create(list *model.MovieList) error {
+	tx, err := lm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	insertQuery := `
+		INSERT INTO lists (id, user_id, name, description, visibility, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = tx.Exec(insertQuery, list.ID, list.OwnerID, list.Name, list.Description, list.Visibility,
+		list.CreatedAt, list.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	entryQuery := `
+		INSERT INTO list_entries (list_id, movie_id, position, note) VALUES ($1, $2, $3, $4)`
+
+	for _, entry := range list.Entries {
+		_, err = tx.Exec(entryQuery, list.ID, entry.MovieID, entry.Position, entry.Note)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByID retrieves a list from the database along with its entries in order.
+// The movies of the entries are loaded with their casts.
+func (lm *listManager) GetByID(listID uuid.UUID) (*model.MovieList, error) {
+	query := listQuery + `
+	WHERE l.id = $1`
+
+	var list model.MovieList
+
+	err := scanList(lm.db.QueryRow(query, listID), &list)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := lm.getEntries(listID)
+	if err != nil {
+		return nil, err
+	}
+	list.Entries = entries
+
+	return &list, nil
+}
+
+// getEntries retrieves the entries of a list in order and batch-loads the casts and collections of their movies.
+func (lm *listManager) getEntries(listID uuid.UUID) ([]model.ListEntry, error) {
+	query := `
+		SELECT ` + movieColumns + `, le.position, le.note
+		FROM list_entries le
+		INNER JOIN movies m ON le.movie_id = m.id AND m.deleted_at IS NULL
+		WHERE le.list_id = $1
+		ORDER BY le.position`
+
+	rows, err := lm.db.Query(query, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]model.ListEntry, 0)
+	movies := make([]*model.Movie, 0)
+	for rows.Next() {
+		var movie model.Movie
+		var entry model.ListEntry
+
+		if err := scanMovie(rows, &movie, &entry.Position, &entry.Note); err != nil {
+			return nil, err
+		}
+
+		entry.MovieID = movie.ID
+		entry.Movie = &movie
+		entries = append(entries, entry)
+		movies = append(movies, &movie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadMovieDetails(lm.db, movies); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Update updates the name, description and visibility of a list in the database.
+func (lm *listManager) Update(list *model.MovieList) error {
+	query := `
+		UPDATE lists SET name = $2, description = $3, visibility = $4, updated_at = $5
+		WHERE id = $1`
+
+	_, err := lm.db.Exec(query, list.ID, list.Name, list.Description, list.Visibility, list.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete removes a list along with its entries from the database.
+func (lm *listManager) Delete(listID uuid.UUID) error {
+	query := `DELETE FROM lists WHERE id = $1`
+
+	_, err := lm.db.Exec(query, listID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByOwner retrieves all lists of a user, most recently changed first. Entries are not loaded.
+func (lm *listManager) GetByOwner(userID uuid.UUID) ([]*model.MovieList, error) {
+	query := listQuery + `
+	WHERE l.user_id = $1
+	ORDER BY l.updated_at DESC`
+
+	return lm.getListsByQuery(query, userID)
+}
+
+// GetByVisibility retrieves all lists with the given visibility, most recently changed first. Entries are not loaded.
+func (lm *listManager) GetByVisibility(visibility string) ([]*model.MovieList, error) {
+	query := listQuery + `
+	WHERE l.visibility = $1
+	ORDER BY l.updated_at DESC`
+
+	return lm.getListsByQuery(query, visibility)
+}
+
+// SetEntry appends a movie to the end of a list, or updates its note if the movie is already on the list.
+// The position of the entry is set from the database.
+func (lm *listManager) SetEntry(listID uuid.UUID, entry *model.ListEntry) error {
+	tx, err := lm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = touchList(tx, listID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO list_entries (list_id, movie_id, position, note)
+		VALUES ($1, $2, (SELECT COALESCE(MAX(position), 0) + 1 FROM list_entries WHERE list_id = $1), $3)
+		ON CONFLICT (list_id, movie_id) DO UPDATE SET note = EXCLUDED.note
+		RETURNING position`
+
+	err = tx.QueryRow(query, listID, entry.MovieID, entry.Note).Scan(&entry.Position)
+	return err
+}
+
+// RemoveEntry removes a movie from a list and closes the gap in the positions of the following entries.
+// sql.ErrNoRows is returned when the movie is not on the list.
+func (lm *listManager) RemoveEntry(listID, movieID uuid.UUID) error {
+	tx, err := lm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = touchList(tx, listID); err != nil {
+		return err
+	}
+
+	var position int
+	deleteQuery := `
+		DELETE FROM list_entries WHERE list_id = $1 AND movie_id = $2
+		RETURNING position`
+
+	err = tx.QueryRow(deleteQuery, listID, movieID).Scan(&position)
+	if err != nil {
+		return err
+	}
+
+	shiftQuery := `
+		UPDATE list_entries SET position = position - 1
+		WHERE list_id = $1 AND position > $2`
+
+	_, err = tx.Exec(shiftQuery, listID, position)
+	return err
+}
+
+// Reorder sets the positions of the entries of a list to the order of the given movie IDs.
+// The IDs are expected to be exactly the movies shown on the list; the entries of the movies in the trash are hidden
+// from it, so they are moved after the given ones, keeping their order, and come back there when restored.
+func (lm *listManager) Reorder(listID uuid.UUID, movieIDs []uuid.UUID) error {
+	tx, err := lm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = touchList(tx, listID); err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(movieIDs))
+	for _, id := range movieIDs {
+		ids = append(ids, id.String())
+	}
+
+	// Every entry is renumbered, so that the positions stay unique once the deferred constraint is checked.
+	query := `
+		UPDATE list_entries le SET position = n.position
+		FROM (
+			SELECT e.movie_id, ROW_NUMBER() OVER (ORDER BY o.position NULLS LAST, e.position) AS position
+			FROM list_entries e
+			LEFT JOIN unnest($2::uuid[]) WITH ORDINALITY AS o(movie_id, position) ON o.movie_id = e.movie_id
+			WHERE e.list_id = $1
+		) n
+		WHERE le.list_id = $1 AND le.movie_id = n.movie_id`
+
+	_, err = tx.Exec(query, listID, pq.Array(ids))
+	return err
+}
+
+// touchList locks a list for the rest of the transaction and sets its update time.
+// sql.ErrNoRows is returned when the list does not exist.
+func touchList(tx *sql.Tx, listID uuid.UUID) error {
+	query := `UPDATE lists SET updated_at = NOW() AT TIME ZONE 'UTC' WHERE id = $1`
+
+	result, err := tx.Exec(query, listID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (lm *listManager) getListsByQuery(query string, args ...interface{}) ([]*model.MovieList, error) {
+	rows, err := lm.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := make([]*model.MovieList, 0)
+	for rows.Next() {
+		var list model.MovieList
+		if err := scanList(rows, &list); err != nil {
+			return nil, err
+		}
+		lists = append(lists, &list)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+func scanList(row rowScanner, list *model.MovieList) error {
+	return row.Scan(&list.ID, &list.OwnerID, &list.Owner, &list.Name, &list.Description, &list.Visibility,
+		&list.EntryCount, &list.CreatedAt, &list.UpdatedAt)
+}
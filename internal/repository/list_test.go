@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestListManager_Entries(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE lists CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE users CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+	}()
+
+	forrest := &model.User{ID: uuid.New(), Username: "forrest", Password: "hash"}
+	require.NoError(t, userRep.Create(forrest))
+
+	Ken := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Ryan Gosling",
+		Gender:    "Male",
+		BirthDate: time.Date(1980, 11, 12, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(Ken))
+
+	movies := make([]*model.Movie, 3)
+	for i, title := range []string{"Drive", "Barbi", "La La Land"} {
+		movies[i] = &model.Movie{
+			ID:          uuid.New(),
+			Title:       title,
+			Description: "Ryan Gosling",
+			ReleaseDate: time.Date(2011+i, 1, 1, 0, 0, 0, 0, time.UTC),
+			Rating:      8,
+			Actors:      []model.CastMember{{Actor: *Ken}},
+		}
+		require.NoError(t, movieRep.Create(movies[i]))
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	list := &model.MovieList{
+		ID:         uuid.New(),
+		OwnerID:    forrest.ID,
+		Name:       "Gosling",
+		Visibility: model.ListPublic,
+		Entries:    []model.ListEntry{{MovieID: movies[0].ID, Position: 1, Note: "Best one"}},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	require.NoError(t, listRep.Create(list))
+
+	for _, movie := range movies[1:] {
+		entry := &model.ListEntry{MovieID: movie.ID}
+		require.NoError(t, listRep.SetEntry(list.ID, entry))
+	}
+
+	entry := &model.ListEntry{MovieID: movies[0].ID, Note: "Still the best one"}
+	require.NoError(t, listRep.SetEntry(list.ID, entry))
+	require.Equal(t, 1, entry.Position)
+
+	require.NoError(t, listRep.Reorder(list.ID, []uuid.UUID{movies[2].ID, movies[0].ID, movies[1].ID}))
+	require.NoError(t, listRep.RemoveEntry(list.ID, movies[0].ID))
+	require.ErrorIs(t, listRep.RemoveEntry(list.ID, movies[0].ID), sql.ErrNoRows)
+
+	fetched, err := listRep.GetByID(list.ID)
+	require.NoError(t, err)
+	require.Equal(t, "forrest", fetched.Owner)
+	require.Equal(t, 2, fetched.EntryCount)
+	require.Len(t, fetched.Entries, 2)
+	require.Equal(t, movies[2].ID, fetched.Entries[0].MovieID)
+	require.Equal(t, 1, fetched.Entries[0].Position)
+	require.Equal(t, movies[1].ID, fetched.Entries[1].MovieID)
+	require.Equal(t, 2, fetched.Entries[1].Position)
+	require.Len(t, fetched.Entries[0].Movie.Actors, 1)
+
+	public, err := listRep.GetByVisibility(model.ListPublic)
+	require.NoError(t, err)
+	require.Len(t, public, 1)
+	require.Nil(t, public[0].Entries)
+}
+
+func TestListManager_ReorderWithTrashedMovie(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE lists CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE users CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	forrest := &model.User{ID: uuid.New(), Username: "forrest", Password: "hash"}
+	require.NoError(t, userRep.Create(forrest))
+
+	movies := make([]*model.Movie, 3)
+	for i, title := range []string{"Drive", "Barbi", "La La Land"} {
+		movies[i] = &model.Movie{
+			ID:          uuid.New(),
+			Title:       title,
+			Description: "Ryan Gosling",
+			ReleaseDate: time.Date(2011+i, 1, 1, 0, 0, 0, 0, time.UTC),
+			Rating:      8,
+		}
+		require.NoError(t, movieRep.Create(movies[i]))
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	list := &model.MovieList{
+		ID:         uuid.New(),
+		OwnerID:    forrest.ID,
+		Name:       "Gosling",
+		Visibility: model.ListPrivate,
+		Entries: []model.ListEntry{
+			{MovieID: movies[0].ID, Position: 1},
+			{MovieID: movies[1].ID, Position: 2},
+			{MovieID: movies[2].ID, Position: 3},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	require.NoError(t, listRep.Create(list))
+
+	// The hidden entry of the trashed movie moves after the reordered ones instead of colliding with them.
+	require.NoError(t, movieRep.Delete(movies[0].ID, 0))
+	require.NoError(t, listRep.Reorder(list.ID, []uuid.UUID{movies[2].ID, movies[1].ID}))
+	require.NoError(t, trashRep.RestoreMovie(movies[0].ID, nil))
+
+	fetched, err := listRep.GetByID(list.ID)
+	require.NoError(t, err)
+	require.Len(t, fetched.Entries, 3)
+	for i, movie := range []*model.Movie{movies[2], movies[1], movies[0]} {
+		require.Equal(t, movie.ID, fetched.Entries[i].MovieID)
+		require.Equal(t, i+1, fetched.Entries[i].Position)
+	}
+}
@@ -2,23 +2,28 @@ package repository
 
 import (
 	"database/sql"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
 )
 
 // MovieManager represents an interface for managing movies in the system.
 type MovieManager interface {
-	Create(movie *model.Movie) error
+	Create(movie *model.Movie, history ...*model.HistoryEntry) error
 	GetByID(movieID uuid.UUID) (*model.Movie, error)
-	Update(movie *model.Movie) error
-	Delete(movieID uuid.UUID) error
-	GetByTitle() ([]*model.Movie, error)
-	GetByRatingDesc() ([]*model.Movie, error)
-	GetByReleaseDate() ([]*model.Movie, error)
+	Update(movie *model.Movie, history ...*model.HistoryEntry) error
+	Delete(movieID uuid.UUID, version int, history ...*model.HistoryEntry) error
+	GetByTitle(filter model.MovieFilter) ([]*model.Movie, error)
+	GetByRatingDesc(filter model.MovieFilter) ([]*model.Movie, error)
+	GetByWeightedRatingDesc(filter model.MovieFilter) ([]*model.Movie, error)
+	GetByReleaseDate(filter model.MovieFilter) ([]*model.Movie, error)
 	GetByTitleFragment(fragment string) ([]*model.Movie, error)
 	GetByActorNameFragment(fragment string) ([]*model.Movie, error)
+	GetMissingPeople(personIDs []uuid.UUID) ([]uuid.UUID, error)
 }
 
 // NewMovieManager returns new repository instance for movies
@@ -32,8 +37,46 @@ type movieManager struct {
 	db *sql.DB
 }
 
-// Create inserts a new movie record along with its associated actors into the database.
-func (mm *movieManager) Create(movie *model.Movie) error {
+// movieColumns lists the movie columns selected by the queries of the repository, in the order read by scanMovie.
+const movieColumns = `m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating,
+			m.runtime_minutes, m.original_title, m.original_language, m.production_countries, m.spoken_languages,
+			m.user_rating_avg, m.user_rating_count, ` + weightedRating + ` AS weighted_rating,
+			(SELECT COUNT(*) FROM reviews r WHERE r.movie_id = m.id AND r.status = 'approved') AS review_count, m.version`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx, so that records can also be read within a transaction.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// scanMovie reads the movieColumns of a row into movie, followed by any extra columns selected after them.
+func scanMovie(row rowScanner, movie *model.Movie, extra ...interface{}) error {
+	dest := []interface{}{&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating,
+		&movie.RuntimeMinutes, &movie.OriginalTitle, &movie.OriginalLanguage,
+		pq.Array(&movie.Countries), pq.Array(&movie.Languages), &movie.UserRating.Average, &movie.UserRating.Count, &movie.UserRating.Weighted, &movie.ReviewCount,
+		&movie.Version}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	if len(movie.Countries) == 0 {
+		movie.Countries = nil
+	}
+	if len(movie.Languages) == 0 {
+		movie.Languages = nil
+	}
+	return nil
+}
+
+// Create inserts a new movie record along with its cast and crew credits into the database, recording the history
+// entries of the change in the same transaction.
+func (mm *movieManager) Create(movie *model.Movie, history ...*model.HistoryEntry) error {
 	tx, err := mm.db.Begin()
 	if err != nil {
 		return err
@@ -47,74 +90,108 @@ func (mm *movieManager) Create(movie *model.Movie) error {
 	}()
 
 	movieQuery := `
-		INSERT INTO movies (id, title, description, release_date, rating) VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO movies (id, title, description, release_date, rating, runtime_minutes, original_title,
+			original_language, production_countries, spoken_languages)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
-	_, err = tx.Exec(movieQuery, movie.ID, movie.Title, movie.Description, movie.ReleaseDate, movie.Rating)
+	_, err = tx.Exec(movieQuery, movie.ID, movie.Title, movie.Description, movie.ReleaseDate, movie.Rating,
+		movie.RuntimeMinutes, movie.OriginalTitle, movie.OriginalLanguage,
+		pq.Array(nonNilStrings(movie.Countries)), pq.Array(nonNilStrings(movie.Languages)))
 	if err != nil {
 		return err
 	}
 
-	actorQuery := `
-		INSERT INTO movie_actor (movie_id, actor_id) VALUES ($1, $2)`
+	movie.Version = 1
 
-	for _, actor := range movie.Actors {
-		_, err := tx.Exec(actorQuery, movie.ID, actor.ID)
-		if err != nil {
-			return err
-		}
+	if err = insertCertifications(tx, movie); err != nil {
+		return err
+	}
+	if err = insertReleases(tx, movie); err != nil {
+		return err
+	}
+	if err = insertCompanies(tx, movie); err != nil {
+		return err
+	}
+	if err = insertTags(tx, movie); err != nil {
+		return err
+	}
+	if err = insertCredits(tx, movie); err != nil {
+		return err
 	}
 
-	return nil
+	err = insertHistory(tx, history)
+	return err
 }
 
 // GetByID retrieves movie information from the database based on the provided movie ID.
+// The ID of a movie merged into another one retrieves the movie it was merged into,
+// movies in the trash are not retrieved.
 func (mm *movieManager) GetByID(movieID uuid.UUID) (*model.Movie, error) {
+	return getMovie(mm.db, movieID)
+}
+
+// getMovie retrieves a movie along with its details as GetByID does, possibly within a transaction.
+func getMovie(db queryer, movieID uuid.UUID) (*model.Movie, error) {
 	movieQuery := `
-        SELECT id, title, description, 
-			release_date AT TIME ZONE 'UTC' AS release_date_utc, rating
-        FROM movies
-        WHERE id = $1
-    `
-	row := mm.db.QueryRow(movieQuery, movieID)
+		SELECT ` + movieColumns + `
+		FROM movies m
+		WHERE m.id = COALESCE((SELECT movie_id FROM movie_redirects WHERE old_id = $1), $1) AND m.deleted_at IS NULL
+	`
+	row := db.QueryRow(movieQuery, movieID)
 
 	var movie model.Movie
-	err := row.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating)
+	if err := scanMovie(row, &movie); err != nil {
+		return nil, err
+	}
+
+	if err := loadMovieDetails(db, []*model.Movie{&movie}); err != nil {
+		return nil, err
+	}
+
+	crew, err := getCrew(db, movie.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
-		}
 		return nil, err
 	}
+	movie.Crew = crew
+
+	return &movie, nil
+}
 
-	actorQuery := `
-        SELECT a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc
-        FROM actors a
-        INNER JOIN movie_actor ma ON a.id = ma.actor_id
-        WHERE ma.movie_id = $1
-    `
-	rows, err := mm.db.Query(actorQuery, movieID)
+func getCrew(db queryer, movieID uuid.UUID) ([]model.Credit, error) {
+	query := `
+		SELECT a.id, a.name, c.role, c.billing_order
+		FROM credits c
+		INNER JOIN actors a ON a.id = c.person_id AND a.deleted_at IS NULL
+		WHERE c.movie_id = $1 AND c.role <> 'actor'
+		ORDER BY c.billing_order, a.name
+	`
+	rows, err := db.Query(query, movieID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var crew []model.Credit
 	for rows.Next() {
-		var actor model.Actor
-		err := rows.Scan(&actor.ID, &actor.Name, &actor.Gender, &actor.BirthDate)
-		if err != nil {
+		var credit model.Credit
+		if err := rows.Scan(&credit.PersonID, &credit.Name, &credit.Role, &credit.BillingOrder); err != nil {
 			return nil, err
 		}
-		movie.Actors = append(movie.Actors, actor)
+		crew = append(crew, credit)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return &movie, nil
+	return crew, nil
 }
 
-// Update updates the information of a movie in the database based on the provided movie ID.
-func (mm *movieManager) Update(movie *model.Movie) error {
+// Update updates the information of a movie in the database based on the provided movie ID, provided the movie
+// still has the version it was read with, and sets the new version of the movie. The history entries of the change
+// are recorded in the same transaction. The credits of the people in the trash are left untouched, so that they come
+// back with them when restored.
+// sql.ErrNoRows is returned when the movie has another version, does not exist or is in the trash.
+func (mm *movieManager) Update(movie *model.Movie, history ...*model.HistoryEntry) error {
 	tx, err := mm.db.Begin()
 	if err != nil {
 		return err
@@ -132,41 +209,99 @@ func (mm *movieManager) Update(movie *model.Movie) error {
 		SET title = COALESCE($2, title), 
 			description = COALESCE($3,description), 
 			release_date = COALESCE($4,release_date), 
-			rating = COALESCE($5,rating) 
-		WHERE id = $1`
-	_, err = tx.Exec(updateQuery, movie.ID, movie.Title, movie.Description, movie.ReleaseDate, movie.Rating)
+			rating = COALESCE($5,rating),
+			runtime_minutes = $6,
+			original_title = $7,
+			original_language = $8,
+			production_countries = $9,
+			spoken_languages = $10,
+			version = version + 1
+		WHERE id = $1 AND version = $11 AND deleted_at IS NULL
+		RETURNING version`
+	err = tx.QueryRow(updateQuery, movie.ID, movie.Title, movie.Description, movie.ReleaseDate, movie.Rating,
+		movie.RuntimeMinutes, movie.OriginalTitle, movie.OriginalLanguage,
+		pq.Array(nonNilStrings(movie.Countries)), pq.Array(nonNilStrings(movie.Languages)), movie.Version).
+		Scan(&movie.Version)
 	if err != nil {
 		return err
 	}
 
-	deleteQuery := `
-		DELETE FROM movie_actor WHERE movie_id = $1`
+	deleteCertificationsQuery := `
+		DELETE FROM movie_certifications WHERE movie_id = $1`
 
-	_, err = tx.Exec(deleteQuery, movie.ID)
+	_, err = tx.Exec(deleteCertificationsQuery, movie.ID)
 	if err != nil {
 		return err
 	}
 
-	actorQuery := `
-		INSERT INTO movie_actor (movie_id, actor_id) VALUES ($1, $2)`
+	if err = insertCertifications(tx, movie); err != nil {
+		return err
+	}
 
-	for _, actor := range movie.Actors {
-		_, err := tx.Exec(actorQuery, movie.ID, actor.ID)
-		if err != nil {
-			return err
-		}
+	deleteReleasesQuery := `
+		DELETE FROM movie_releases WHERE movie_id = $1`
+
+	_, err = tx.Exec(deleteReleasesQuery, movie.ID)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	if err = insertReleases(tx, movie); err != nil {
+		return err
+	}
+
+	deleteCompaniesQuery := `
+		DELETE FROM movie_company WHERE movie_id = $1`
+
+	_, err = tx.Exec(deleteCompaniesQuery, movie.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = insertCompanies(tx, movie); err != nil {
+		return err
+	}
+
+	deleteTagsQuery := `
+		DELETE FROM movie_tags WHERE movie_id = $1`
+
+	_, err = tx.Exec(deleteTagsQuery, movie.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = insertTags(tx, movie); err != nil {
+		return err
+	}
+
+	// The credits of the people in the trash are hidden from the movie, so they are kept for their restore.
+	deleteCreditsQuery := `
+		DELETE FROM credits c
+		USING actors a
+		WHERE c.movie_id = $1 AND c.person_id = a.id AND a.deleted_at IS NULL`
+
+	_, err = tx.Exec(deleteCreditsQuery, movie.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = insertCredits(tx, movie); err != nil {
+		return err
+	}
+
+	err = insertHistory(tx, history)
+	return err
 }
 
-// Delete removes movie information from the database based on the provided movie ID.
-func (mm *movieManager) Delete(movieID uuid.UUID) error {
+// Delete moves a movie to the trash, hiding it from every listing until it is restored or purged, provided the movie
+// still has the given version, any version when it is zero. The history entries of the change are recorded in the
+// same transaction.
+// sql.ErrNoRows is returned when the movie has another version, does not exist or is already in the trash.
+func (mm *movieManager) Delete(movieID uuid.UUID, version int, history ...*model.HistoryEntry) error {
 	tx, err := mm.db.Begin()
 	if err != nil {
 		return err
 	}
-
 	defer func() {
 		if err != nil {
 			tx.Rollback()
@@ -175,168 +310,460 @@ func (mm *movieManager) Delete(movieID uuid.UUID) error {
 		err = tx.Commit()
 	}()
 
-	q1 := `
-		DELETE FROM movie_actor WHERE movie_id = $1`
-
-	_, err = tx.Exec(q1, movieID)
-	if err != nil {
-		return err
-	}
-
-	q2 := `
-		DELETE FROM movies WHERE id = $1`
+	query := `
+		UPDATE movies SET deleted_at = NOW()
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND deleted_at IS NULL`
 
-	_, err = tx.Exec(q2, movieID)
-	if err != nil {
+	if err = execAffectingRow(tx, query, movieID, version); err != nil {
 		return err
 	}
 
-	return nil
+	err = insertHistory(tx, history)
+	return err
 }
 
 // GetByTitle retrieves a list of movies from the database sorted by title,
-func (mm *movieManager) GetByTitle() ([]*model.Movie, error) {
+func (mm *movieManager) GetByTitle(filter model.MovieFilter) ([]*model.Movie, error) {
+	where, args := movieFilterClause(filter)
 	query := `
-		SELECT m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating,
-			a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc
+		SELECT ` + movieColumns + `
 		FROM movies m
-		LEFT JOIN movie_actor ma ON m.id = ma.movie_id
-		LEFT JOIN actors a ON ma.actor_id = a.id
+		` + where + `
 		ORDER BY m.title
 `
-	return mm.getMoviesByQuery(query)
+	return mm.getMoviesByQuery(query, args...)
 }
 
 // GetByRatingDesc retrieves a list of movies from the database sorted by rating
-func (mm *movieManager) GetByRatingDesc() ([]*model.Movie, error) {
+func (mm *movieManager) GetByRatingDesc(filter model.MovieFilter) ([]*model.Movie, error) {
+	where, args := movieFilterClause(filter)
 	query := `
-		SELECT m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating,
-			a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc 
+		SELECT ` + movieColumns + `
 		FROM movies m
-		LEFT JOIN movie_actor ma ON m.id = ma.movie_id
-		LEFT JOIN actors a ON ma.actor_id = a.id
+		` + where + `
 		ORDER BY rating DESC
 `
-	return mm.getMoviesByQuery(query)
+	return mm.getMoviesByQuery(query, args...)
+}
+
+// GetByWeightedRatingDesc retrieves a list of movies from the database sorted by the Bayesian-weighted user score.
+func (mm *movieManager) GetByWeightedRatingDesc(filter model.MovieFilter) ([]*model.Movie, error) {
+	where, args := movieFilterClause(filter)
+	query := `
+		SELECT ` + movieColumns + `
+		FROM movies m
+		` + where + `
+		ORDER BY weighted_rating DESC, m.user_rating_count DESC
+`
+	return mm.getMoviesByQuery(query, args...)
 }
 
 // GetByReleaseDate retrieves a list of movies from the database sorted by release date,
-func (mm *movieManager) GetByReleaseDate() ([]*model.Movie, error) {
+// or by the earliest release date in the release country of the filter when it has one.
+func (mm *movieManager) GetByReleaseDate(filter model.MovieFilter) ([]*model.Movie, error) {
+	where, args := movieFilterClause(filter)
+	orderBy := "m.release_date"
+	if filter.ReleaseCountry != "" {
+		args = append(args, filter.ReleaseCountry, filter.ReleaseType)
+		orderBy = `(
+			SELECT MIN(r.release_date)
+			FROM movie_releases r
+			WHERE r.movie_id = m.id AND r.country_code = $` + strconv.Itoa(len(args)-1) + `
+				AND ($` + strconv.Itoa(len(args)) + ` = '' OR r.release_type = $` + strconv.Itoa(len(args)) + `)
+		)`
+	}
+
 	query := `
-		SELECT m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating,
-			a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc
+		SELECT ` + movieColumns + `
 		FROM movies m
-		LEFT JOIN movie_actor ma ON m.id = ma.movie_id
-		LEFT JOIN actors a ON ma.actor_id = a.id
-		ORDER BY m.release_date DESC
+		` + where + `
+		ORDER BY ` + orderBy + ` DESC
 `
-	return mm.getMoviesByQuery(query)
+	return mm.getMoviesByQuery(query, args...)
+}
+
+// GetByTitleFragment retrieves a list of movies from the database filtered by title fragment,
+// matching the original title as well as the translated titles of the movies.
+func (mm *movieManager) GetByTitleFragment(fragment string) ([]*model.Movie, error) {
+	query := `
+		SELECT ` + movieColumns + `
+		FROM movies m
+		WHERE m.deleted_at IS NULL
+			AND (m.title LIKE '%' || $1 || '%'
+				OR EXISTS (
+					SELECT 1
+					FROM movie_translations t
+					WHERE t.movie_id = m.id AND t.title LIKE '%' || $1 || '%'
+				))`
+
+	return mm.getMoviesByQuery(query, fragment)
+}
+
+// GetByActorNameFragment retrieves a list of movies from the database filtered by actor name fragment,
+// matching the names as well as the aliases of the actors.
+func (mm *movieManager) GetByActorNameFragment(fragment string) ([]*model.Movie, error) {
+	query := `SELECT ` + movieColumns + `
+		FROM movies m
+		WHERE m.deleted_at IS NULL AND EXISTS (
+			SELECT 1
+			FROM credits c
+			INNER JOIN actors a ON c.person_id = a.id AND a.deleted_at IS NULL
+			WHERE c.movie_id = m.id AND c.role = 'actor' AND (a.name LIKE '%' || $1 || '%'
+				OR EXISTS (SELECT 1 FROM UNNEST(a.aliases) alias WHERE alias LIKE '%' || $1 || '%'))
+		)`
+	return mm.getMoviesByQuery(query, fragment)
 }
 
-func (mm *movieManager) getMoviesByQuery(query string) ([]*model.Movie, error) {
-	rows, err := mm.db.Query(query)
+// getMoviesByQuery runs a query selecting movie columns and batch-loads the details of the returned movies.
+func (mm *movieManager) getMoviesByQuery(query string, args ...interface{}) ([]*model.Movie, error) {
+	rows, err := mm.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var movieIDs []uuid.UUID
-	movieMap := make(map[uuid.UUID]*model.Movie)
+	var movies []*model.Movie
 
 	for rows.Next() {
 		var movie model.Movie
-		var actor model.Actor
 
-		err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating,
-			&actor.ID, &actor.Name, &actor.Gender, &actor.BirthDate)
-		if err != nil {
+		if err := scanMovie(rows, &movie); err != nil {
 			return nil, err
 		}
 
-		if _, ok := movieMap[movie.ID]; !ok {
-			movie.Actors = make([]model.Actor, 0)
-			movieMap[movie.ID] = &movie
-			movieIDs = append(movieIDs, movie.ID)
-		} else {
-			movie = *movieMap[movie.ID]
-		}
-
-		movie.Actors = append(movie.Actors, actor)
+		movies = append(movies, &movie)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	var movies []*model.Movie
-	for _, id := range movieIDs {
-		movies = append(movies, movieMap[id])
+	if err := loadMovieDetails(mm.db, movies); err != nil {
+		return nil, err
 	}
 
 	return movies, nil
 }
 
-// GetByTitleFragment retrieves a list of movies from the database filtered by title fragment.
-func (mm *movieManager) GetByTitleFragment(fragment string) ([]*model.Movie, error) {
+// movieFilterClause builds the WHERE clause selecting the movies matching the filter from the movies aliased m.
+// The parameters of the clause are numbered after the given arguments, which are returned extended with their values.
+// Movies in the trash are never selected.
+func movieFilterClause(filter model.MovieFilter, args ...interface{}) (string, []interface{}) {
+	conditions := []string{"m.deleted_at IS NULL"}
+	param := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.Country != "" {
+		conditions = append(conditions, param(filter.Country)+" = ANY(m.production_countries)")
+	}
+	if filter.Language != "" {
+		conditions = append(conditions, param(filter.Language)+" = ANY(m.spoken_languages)")
+	}
+	if filter.OriginalLanguage != "" {
+		conditions = append(conditions, "m.original_language = "+param(filter.OriginalLanguage))
+	}
+	if filter.MinRuntime > 0 {
+		conditions = append(conditions, "m.runtime_minutes >= "+param(filter.MinRuntime))
+	}
+	if filter.MaxRuntime > 0 {
+		conditions = append(conditions, "m.runtime_minutes BETWEEN 1 AND "+param(filter.MaxRuntime))
+	}
+	if filter.CertificationCountry != "" {
+		condition := `EXISTS (
+			SELECT 1 FROM movie_certifications mc
+			WHERE mc.movie_id = m.id AND mc.country_code = ` + param(filter.CertificationCountry)
+		if filter.Certification != "" {
+			condition += " AND mc.certification = " + param(filter.Certification)
+		}
+		conditions = append(conditions, condition+")")
+	}
+
+	if filter.ReleaseCountry != "" {
+		condition := `EXISTS (
+			SELECT 1 FROM movie_releases r
+			WHERE r.movie_id = m.id AND r.country_code = ` + param(filter.ReleaseCountry)
+		if filter.ReleaseType != "" {
+			condition += " AND r.release_type = " + param(filter.ReleaseType)
+		}
+		conditions = append(conditions, condition+")")
+	}
+
+	if filter.WonAward != uuid.Nil {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM nominations n
+			INNER JOIN award_ceremonies ce ON n.ceremony_id = ce.id
+			WHERE n.movie_id = m.id AND n.won AND ce.award_id = `+param(filter.WonAward)+`)`)
+	}
+
+	if filter.Company != uuid.Nil {
+		condition := `EXISTS (
+			SELECT 1 FROM movie_company mco
+			WHERE mco.movie_id = m.id AND mco.company_id = ` + param(filter.Company)
+		if filter.CompanyRole != "" {
+			condition += " AND mco.role = " + param(filter.CompanyRole)
+		}
+		conditions = append(conditions, condition+")")
+	}
+
+	if len(filter.Tags) > 0 {
+		tagged := `
+			SELECT COUNT(DISTINCT t.id) FROM movie_tags mt
+			INNER JOIN tags t ON mt.tag_id = t.id
+			WHERE mt.movie_id = m.id AND t.name = ANY(` + param(pq.Array(filter.Tags)) + `)`
+		if filter.TagMode == model.TagModeAny {
+			conditions = append(conditions, "("+tagged+") > 0")
+		} else {
+			conditions = append(conditions, "("+tagged+") = "+param(len(filter.Tags)))
+		}
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// loadMovieDetails fills the casts, companies, tags, collections, certifications and releases of the given movies.
+func loadMovieDetails(db queryer, movies []*model.Movie) error {
+	if err := loadCasts(db, movies); err != nil {
+		return err
+	}
+	if err := loadCompanies(db, movies); err != nil {
+		return err
+	}
+	if err := loadTags(db, movies); err != nil {
+		return err
+	}
+	if err := loadCollections(db, movies); err != nil {
+		return err
+	}
+	if err := loadCertifications(db, movies); err != nil {
+		return err
+	}
+	return loadReleases(db, movies)
+}
+
+// insertCertifications inserts the age certifications of a movie.
+func insertCertifications(tx *sql.Tx, movie *model.Movie) error {
 	query := `
-		SELECT m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating,
-			a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc
-		FROM movies m
-		LEFT JOIN movie_actor ma ON m.id = ma.movie_id
-		LEFT JOIN actors a ON ma.actor_id = a.id
-		WHERE m.title LIKE '%' || $1 || '%'`
+		INSERT INTO movie_certifications (movie_id, country_code, certification) VALUES ($1, $2, $3)`
 
-	return mm.getMoviesByQueryFragment(query, fragment)
+	for country, certification := range movie.Certifications {
+		if _, err := tx.Exec(query, movie.ID, country, certification); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// GetByActorNameFragment retrieves a list of movies from the database filtered by actor name fragment.
-func (mm *movieManager) GetByActorNameFragment(fragment string) ([]*model.Movie, error) {
-	query := `SELECT m.id, m.title, m.description, m.release_date AT TIME ZONE 'UTC' AS release_date_utc, m.rating,
-		a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc
-		FROM movies m
-		LEFT JOIN movie_actor ma ON m.id = ma.movie_id
-		LEFT JOIN actors a ON ma.actor_id = a.id
-		WHERE a.name LIKE '%' || $1 || '%'`
-	return mm.getMoviesByQueryFragment(query, fragment)
+// loadCertifications fills the age certifications of the given movies with a single query.
+// Movies without certifications are left with a nil map.
+func loadCertifications(db queryer, movies []*model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	movieIDs := make([]string, 0, len(movies))
+	movieMap := make(map[uuid.UUID]*model.Movie, len(movies))
+	for _, movie := range movies {
+		movieIDs = append(movieIDs, movie.ID.String())
+		movieMap[movie.ID] = movie
+	}
+
+	query := `
+		SELECT movie_id, country_code, certification
+		FROM movie_certifications
+		WHERE movie_id = ANY($1::uuid[])
+	`
+	rows, err := db.Query(query, pq.Array(movieIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movieID uuid.UUID
+		var country, certification string
+
+		if err := rows.Scan(&movieID, &country, &certification); err != nil {
+			return err
+		}
+
+		movie := movieMap[movieID]
+		if movie.Certifications == nil {
+			movie.Certifications = make(map[string]string)
+		}
+		movie.Certifications[country] = certification
+	}
+
+	return rows.Err()
+}
+
+// insertReleases inserts the release events of a movie.
+func insertReleases(tx *sql.Tx, movie *model.Movie) error {
+	query := `
+		INSERT INTO movie_releases (movie_id, country_code, release_type, release_date, note) VALUES ($1, $2, $3, $4, $5)`
+
+	for _, release := range movie.Releases {
+		if _, err := tx.Exec(query, movie.ID, release.Country, release.Type, release.Date, release.Note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertCredits inserts the cast of a movie as acting credits, followed by its crew credits.
+// A credit kept for a person in the trash is overwritten by the given one.
+func insertCredits(tx *sql.Tx, movie *model.Movie) error {
+	query := `
+		INSERT INTO credits (movie_id, person_id, role, character_name, billing_order) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (movie_id, person_id, role)
+		DO UPDATE SET character_name = EXCLUDED.character_name, billing_order = EXCLUDED.billing_order`
+
+	for _, actor := range movie.Actors {
+		_, err := tx.Exec(query, movie.ID, actor.ID, model.RoleActor, actor.CharacterName, actor.BillingOrder)
+		if err != nil {
+			return err
+		}
+	}
+	for _, credit := range movie.Crew {
+		if _, err := tx.Exec(query, movie.ID, credit.PersonID, credit.Role, "", credit.BillingOrder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertCompanies inserts the production and distribution companies of a movie.
+func insertCompanies(tx *sql.Tx, movie *model.Movie) error {
+	query := `
+		INSERT INTO movie_company (movie_id, company_id, role) VALUES ($1, $2, $3)`
+
+	for _, company := range movie.Companies {
+		if _, err := tx.Exec(query, movie.ID, company.CompanyID, company.Role); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (mm *movieManager) getMoviesByQueryFragment(query string, fragment string) ([]*model.Movie, error) {
-	rows, err := mm.db.Query(query, fragment)
+// loadReleases fills the release events of the given movies with a single query, ordered by date.
+func loadReleases(db queryer, movies []*model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	movieIDs := make([]string, 0, len(movies))
+	movieMap := make(map[uuid.UUID]*model.Movie, len(movies))
+	for _, movie := range movies {
+		movieIDs = append(movieIDs, movie.ID.String())
+		movieMap[movie.ID] = movie
+	}
+
+	query := `
+		SELECT movie_id, country_code, release_type, release_date AT TIME ZONE 'UTC' AS release_date_utc, note
+		FROM movie_releases
+		WHERE movie_id = ANY($1::uuid[])
+		ORDER BY release_date, country_code, release_type
+	`
+	rows, err := db.Query(query, pq.Array(movieIDs))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	var movieIDs []uuid.UUID
-	movieMap := make(map[uuid.UUID]*model.Movie)
+	for rows.Next() {
+		var movieID uuid.UUID
+		var release model.ReleaseEvent
+
+		if err := rows.Scan(&movieID, &release.Country, &release.Type, &release.Date, &release.Note); err != nil {
+			return err
+		}
+
+		movie := movieMap[movieID]
+		movie.Releases = append(movie.Releases, release)
+	}
+
+	return rows.Err()
+}
+
+// nonNilStrings returns an empty slice instead of nil so that NOT NULL array columns receive an empty array.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// loadCasts fills the casts of the given movies with a single query, ordered by billing order.
+func loadCasts(db queryer, movies []*model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	movieIDs := make([]string, 0, len(movies))
+	movieMap := make(map[uuid.UUID]*model.Movie, len(movies))
+	for _, movie := range movies {
+		movie.Actors = make([]model.CastMember, 0)
+		movieIDs = append(movieIDs, movie.ID.String())
+		movieMap[movie.ID] = movie
+	}
+
+	query := `
+		SELECT c.movie_id, a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc,
+			c.character_name, c.billing_order
+		FROM credits c
+		INNER JOIN actors a ON c.person_id = a.id AND a.deleted_at IS NULL
+		WHERE c.movie_id = ANY($1::uuid[]) AND c.role = 'actor'
+		ORDER BY c.billing_order, a.name
+	`
+	rows, err := db.Query(query, pq.Array(movieIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
-		var movie model.Movie
-		var actor model.Actor
+		var movieID uuid.UUID
+		var member model.CastMember
 
-		err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating,
-			&actor.ID, &actor.Name, &actor.Gender, &actor.BirthDate)
+		err := rows.Scan(&movieID, &member.ID, &member.Name, &member.Gender, &member.BirthDate,
+			&member.CharacterName, &member.BillingOrder)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		if _, ok := movieMap[movie.ID]; !ok {
-			movie.Actors = make([]model.Actor, 0)
-			movieMap[movie.ID] = &movie
-			movieIDs = append(movieIDs, movie.ID)
-		} else {
-			movie = *movieMap[movie.ID]
-		}
+		movie := movieMap[movieID]
+		movie.Actors = append(movie.Actors, member)
+	}
+
+	return rows.Err()
+}
 
-		movie.Actors = append(movie.Actors, actor)
+// GetMissingPeople returns the IDs among personIDs that do not belong to any person, the people in the trash
+// being counted as missing, so that a cast or a crew is only made of existing people.
+func (mm *movieManager) GetMissingPeople(personIDs []uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]string, 0, len(personIDs))
+	for _, personID := range personIDs {
+		ids = append(ids, personID.String())
 	}
-	if err := rows.Err(); err != nil {
+
+	query := `
+		SELECT DISTINCT ids.id
+		FROM unnest($1::uuid[]) AS ids(id)
+		WHERE NOT EXISTS (SELECT 1 FROM actors a WHERE a.id = ids.id AND a.deleted_at IS NULL)`
+
+	rows, err := mm.db.Query(query, pq.Array(ids))
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	var movies []*model.Movie
-	for _, id := range movieIDs {
-		movies = append(movies, movieMap[id])
+	var missing []uuid.UUID
+	for rows.Next() {
+		var personID uuid.UUID
+		if err := rows.Scan(&personID); err != nil {
+			return nil, err
+		}
+		missing = append(missing, personID)
 	}
-
-	return movies, nil
+	return missing, rows.Err()
 }
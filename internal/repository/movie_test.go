@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"testing"
 	"time"
 
@@ -14,7 +15,7 @@ func TestMovieManager_Create(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -36,7 +37,7 @@ func TestMovieManager_Create(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -46,7 +47,7 @@ func TestMovieManager_GetByID(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -68,7 +69,7 @@ func TestMovieManager_GetByID(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -78,11 +79,68 @@ func TestMovieManager_GetByID(t *testing.T) {
 	require.Equal(t, Barbi, getMovie)
 }
 
+func TestMovieManager_GetByIDCastOrder(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Hanks := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Tom Hanks",
+		Gender:    "Male",
+		BirthDate: time.Date(1956, 7, 9, 0, 0, 0, 0, time.UTC),
+	}
+	err := actorRep.Create(Hanks)
+	require.NoError(t, err)
+
+	Wright := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Robin Wright",
+		Gender:    "Female",
+		BirthDate: time.Date(1966, 4, 8, 0, 0, 0, 0, time.UTC),
+	}
+	err = actorRep.Create(Wright)
+	require.NoError(t, err)
+
+	ForrestGump := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Forrest Gump",
+		Description: "Life is like a box of chocolates",
+		ReleaseDate: time.Date(1994, 7, 6, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+		Actors: []model.CastMember{
+			{Actor: *Wright, CharacterName: "Jenny Curran", BillingOrder: 2},
+			{Actor: *Hanks, CharacterName: "Forrest Gump", BillingOrder: 1},
+		},
+	}
+	err = movieRep.Create(ForrestGump)
+	require.NoError(t, err)
+
+	expectedCast := []model.CastMember{
+		{Actor: *Hanks, CharacterName: "Forrest Gump", BillingOrder: 1},
+		{Actor: *Wright, CharacterName: "Jenny Curran", BillingOrder: 2},
+	}
+
+	getMovie, err := movieRep.GetByID(ForrestGump.ID)
+	require.NoError(t, err)
+	require.Equal(t, expectedCast, getMovie.Actors)
+
+	movies, err := movieRep.GetByTitle(model.MovieFilter{})
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	require.Equal(t, expectedCast, movies[0].Actors)
+}
+
 func TestMovieManager_Update(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -104,7 +162,7 @@ func TestMovieManager_Update(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -115,11 +173,17 @@ func TestMovieManager_Update(t *testing.T) {
 		Description: "Atomic bomb",
 		ReleaseDate: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
+		Version:     Barbi.Version,
 	}
 
 	err = movieRep.Update(updatedMovie)
 	require.NoError(t, err)
+	require.Equal(t, 2, updatedMovie.Version)
+
+	staleMovie := *updatedMovie
+	staleMovie.Version = 1
+	require.ErrorIs(t, movieRep.Update(&staleMovie), sql.ErrNoRows)
 
 	getMovie, err := movieRep.GetByID(Barbi.ID)
 	require.NoError(t, err)
@@ -130,7 +194,7 @@ func TestMovieManager_Delete(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -152,7 +216,7 @@ func TestMovieManager_Delete(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -163,12 +227,15 @@ func TestMovieManager_Delete(t *testing.T) {
 		Description: "Atomic bomb",
 		ReleaseDate: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Oppenheimer)
 	require.NoError(t, err)
 
-	err = movieRep.Delete(Barbi.ID)
+	err = movieRep.Delete(Barbi.ID, 2)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = movieRep.Delete(Barbi.ID, 1)
 	require.NoError(t, err)
 
 	getMovie, err := movieRep.GetByID(Barbi.ID)
@@ -180,7 +247,7 @@ func TestMovieManager_GetByTitle(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -202,7 +269,7 @@ func TestMovieManager_GetByTitle(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -213,21 +280,75 @@ func TestMovieManager_GetByTitle(t *testing.T) {
 		Description: "Atomic bomb",
 		ReleaseDate: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Oppenheimer)
 	require.NoError(t, err)
 
-	movies, err := movieRep.GetByTitle()
+	movies, err := movieRep.GetByTitle(model.MovieFilter{})
 	require.NoError(t, err)
 	require.Equal(t, []*model.Movie{Barbi, Oppenheimer}, movies)
 }
 
+func TestMovieManager_GetByTitleFilter(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Amelie := &model.Movie{
+		ID:               uuid.New(),
+		Title:            "Amelie",
+		Description:      "Paris",
+		ReleaseDate:      time.Date(2001, 4, 25, 0, 0, 0, 0, time.UTC),
+		Rating:           9,
+		RuntimeMinutes:   122,
+		OriginalTitle:    "Le Fabuleux Destin d'Amelie Poulain",
+		OriginalLanguage: "fr",
+		Countries:        []string{"FR", "DE"},
+		Languages:        []string{"fr"},
+		Certifications:   map[string]string{"US": "R", "GB": "15"},
+	}
+	err := movieRep.Create(Amelie)
+	require.NoError(t, err)
+
+	Barbi := &model.Movie{
+		ID:               uuid.New(),
+		Title:            "Barbi",
+		Description:      "Ryan Gosling",
+		ReleaseDate:      time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
+		Rating:           10,
+		RuntimeMinutes:   114,
+		OriginalLanguage: "en",
+		Countries:        []string{"US", "GB"},
+		Languages:        []string{"en"},
+		Certifications:   map[string]string{"US": "PG-13"},
+	}
+	err = movieRep.Create(Barbi)
+	require.NoError(t, err)
+
+	movies, err := movieRep.GetByTitle(model.MovieFilter{Country: "DE"})
+	require.NoError(t, err)
+	require.Equal(t, []*model.Movie{Amelie}, movies)
+
+	movies, err = movieRep.GetByTitle(model.MovieFilter{Language: "en", MaxRuntime: 120})
+	require.NoError(t, err)
+	require.Equal(t, []*model.Movie{Barbi}, movies)
+
+	movies, err = movieRep.GetByTitle(model.MovieFilter{CertificationCountry: "US", Certification: "R"})
+	require.NoError(t, err)
+	require.Equal(t, []*model.Movie{Amelie}, movies)
+
+	movies, err = movieRep.GetByTitle(model.MovieFilter{CertificationCountry: "GB", MinRuntime: 120})
+	require.NoError(t, err)
+	require.Equal(t, []*model.Movie{Amelie}, movies)
+}
+
 func TestMovieManager_GetByRatingDesc(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -249,7 +370,7 @@ func TestMovieManager_GetByRatingDesc(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      9,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -260,12 +381,12 @@ func TestMovieManager_GetByRatingDesc(t *testing.T) {
 		Description: "Atomic bomb",
 		ReleaseDate: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Oppenheimer)
 	require.NoError(t, err)
 
-	movies, err := movieRep.GetByRatingDesc()
+	movies, err := movieRep.GetByRatingDesc(model.MovieFilter{})
 	require.NoError(t, err)
 	require.Equal(t, []*model.Movie{Oppenheimer, Barbi}, movies)
 }
@@ -273,7 +394,7 @@ func TestMovieManager_GetByReleaseDate(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -295,7 +416,7 @@ func TestMovieManager_GetByReleaseDate(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      9,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -306,21 +427,70 @@ func TestMovieManager_GetByReleaseDate(t *testing.T) {
 		Description: "Atomic bomb",
 		ReleaseDate: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Oppenheimer)
 	require.NoError(t, err)
 
-	movies, err := movieRep.GetByReleaseDate()
+	movies, err := movieRep.GetByReleaseDate(model.MovieFilter{})
 	require.NoError(t, err)
 	require.Equal(t, []*model.Movie{Oppenheimer, Barbi}, movies)
 }
 
+func TestMovieManager_GetByReleaseDateRegion(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Amelie := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Amelie",
+		Description: "Paris",
+		ReleaseDate: time.Date(2001, 4, 25, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+		Releases: []model.ReleaseEvent{
+			{Country: "FR", Type: model.ReleaseTheatrical, Date: time.Date(2001, 4, 25, 0, 0, 0, 0, time.UTC)},
+			{Country: "US", Type: model.ReleaseTheatricalLimited, Date: time.Date(2001, 11, 2, 0, 0, 0, 0, time.UTC)},
+			{Country: "US", Type: model.ReleaseDigital, Date: time.Date(2002, 7, 16, 0, 0, 0, 0, time.UTC), Note: "Streaming"},
+		},
+	}
+	require.NoError(t, movieRep.Create(Amelie))
+
+	Oppenheimer := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Oppenheimer",
+		Description: "Atomic bomb",
+		ReleaseDate: time.Date(2023, 7, 21, 0, 0, 0, 0, time.UTC),
+		Rating:      10,
+		Releases: []model.ReleaseEvent{
+			{Country: "US", Type: model.ReleaseTheatrical, Date: time.Date(2023, 7, 21, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	require.NoError(t, movieRep.Create(Oppenheimer))
+
+	movie, err := movieRep.GetByID(Amelie.ID)
+	require.NoError(t, err)
+	require.Equal(t, Amelie.Releases, movie.Releases)
+
+	movies, err := movieRep.GetByReleaseDate(model.MovieFilter{ReleaseCountry: "US"})
+	require.NoError(t, err)
+	require.Equal(t, []*model.Movie{Oppenheimer, Amelie}, movies)
+
+	movies, err = movieRep.GetByReleaseDate(model.MovieFilter{ReleaseCountry: "FR"})
+	require.NoError(t, err)
+	require.Equal(t, []*model.Movie{Amelie}, movies)
+
+	movies, err = movieRep.GetByReleaseDate(model.MovieFilter{ReleaseCountry: "US", ReleaseType: model.ReleaseDigital})
+	require.NoError(t, err)
+	require.Equal(t, []*model.Movie{Amelie}, movies)
+}
+
 func TestMovieManager_GetByTitleFragment(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -342,7 +512,7 @@ func TestMovieManager_GetByTitleFragment(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      9,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -353,7 +523,7 @@ func TestMovieManager_GetByTitleFragment(t *testing.T) {
 		Description: "bomb",
 		ReleaseDate: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Oppenheimer)
 	require.NoError(t, err)
@@ -367,7 +537,7 @@ func TestMovieManager_GetByActorNameFragment(t *testing.T) {
 	defer func() {
 		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
 		require.NoError(t, err)
-		_, err = db.Exec("TRUNCATE TABLE movie_actor CASCADE")
+		_, err = db.Exec("TRUNCATE TABLE credits CASCADE")
 		require.NoError(t, err)
 		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
 		require.NoError(t, err)
@@ -397,7 +567,7 @@ func TestMovieManager_GetByActorNameFragment(t *testing.T) {
 		Description: "Ryan Gosling",
 		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      9,
-		Actors:      []model.Actor{*Ken},
+		Actors:      []model.CastMember{{Actor: *Ken}},
 	}
 	err = movieRep.Create(Barbi)
 	require.NoError(t, err)
@@ -408,7 +578,7 @@ func TestMovieManager_GetByActorNameFragment(t *testing.T) {
 		Description: "Atomic bomb",
 		ReleaseDate: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC),
 		Rating:      10,
-		Actors:      []model.Actor{*Deadpool},
+		Actors:      []model.CastMember{{Actor: *Deadpool}},
 	}
 	err = movieRep.Create(Oppenheimer)
 	require.NoError(t, err)
@@ -417,3 +587,39 @@ func TestMovieManager_GetByActorNameFragment(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []*model.Movie{Barbi, Oppenheimer}, movies)
 }
+
+func TestMovieManager_GetMissingPeople(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Ken := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Ryan Gosling",
+		Gender:    "Drive",
+		BirthDate: time.Date(1980, 11, 12, 0, 0, 0, 0, time.UTC),
+	}
+	err := actorRep.Create(Ken)
+	require.NoError(t, err)
+
+	Deadpool := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Ryan Reynolds",
+		Gender:    "Deadpool",
+		BirthDate: time.Date(1976, 10, 23, 0, 0, 0, 0, time.UTC),
+	}
+	err = actorRep.Create(Deadpool)
+	require.NoError(t, err)
+	err = actorRep.Delete(Deadpool.ID, 0, false)
+	require.NoError(t, err)
+
+	unknownID := uuid.New()
+	missing, err := movieRep.GetMissingPeople([]uuid.UUID{Ken.ID, Deadpool.ID, unknownID, unknownID})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{Deadpool.ID, unknownID}, missing)
+
+	missing, err = movieRep.GetMissingPeople([]uuid.UUID{Ken.ID})
+	require.NoError(t, err)
+	require.Empty(t, missing)
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// ratingPriorVotes is the number of virtual votes at the global average added to every movie
+// when computing the Bayesian-weighted score, so that a few enthusiastic votes do not top the charts.
+const ratingPriorVotes = "10"
+
+// weightedRating computes the Bayesian-weighted score of the movie aliased m from its own scores and the global
+// average kept in rating_stats, 0 for a movie without scores.
+const weightedRating = `COALESCE((
+	SELECT (m.user_rating_count * m.user_rating_avg + ` + ratingPriorVotes + ` * s.score_sum::DOUBLE PRECISION / s.score_count)
+		/ (m.user_rating_count + ` + ratingPriorVotes + `)
+	FROM rating_stats s
+	WHERE m.user_rating_count > 0 AND s.score_count > 0), 0)`
+
+// RatingManager represents an interface for managing user ratings of movies in the system.
+type RatingManager interface {
+	Set(rating *model.UserRating) error
+	Get(userID, movieID uuid.UUID) (*model.UserRating, error)
+	Delete(userID, movieID uuid.UUID) error
+}
+
+// NewRatingManager returns new repository instance for user ratings
+func NewRatingManager(db *sql.DB) RatingManager {
+	return &ratingManager{
+		db: db,
+	}
+}
+
+type ratingManager struct {
+	db *sql.DB
+}
+
+// Set inserts or replaces the rating of a user for a movie and refreshes the movie scores in the same transaction.
+func (rm *ratingManager) Set(rating *model.UserRating) error {
+	tx, err := rm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = lockRatingStats(tx); err != nil {
+		return err
+	}
+
+	previousQuery := `
+		SELECT score FROM user_ratings WHERE user_id = $1 AND movie_id = $2`
+
+	var previous sql.NullInt64
+	err = tx.QueryRow(previousQuery, rating.UserID, rating.MovieID).Scan(&previous)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_ratings (user_id, movie_id, score, rated_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, movie_id) DO UPDATE SET score = EXCLUDED.score, rated_at = EXCLUDED.rated_at`
+
+	_, err = tx.Exec(query, rating.UserID, rating.MovieID, rating.Score, rating.RatedAt)
+	if err != nil {
+		return err
+	}
+
+	added := 1
+	if previous.Valid {
+		added = 0
+	}
+	if err = adjustRatingStats(tx, rating.Score-int(previous.Int64), added); err != nil {
+		return err
+	}
+
+	err = refreshScores(tx, rating.MovieID)
+	return err
+}
+
+// Get retrieves the rating a user gave to a movie.
+func (rm *ratingManager) Get(userID, movieID uuid.UUID) (*model.UserRating, error) {
+	query := `
+		SELECT user_id, movie_id, score, rated_at AT TIME ZONE 'UTC' AS rated_at_utc
+		FROM user_ratings
+		WHERE user_id = $1 AND movie_id = $2`
+
+	var rating model.UserRating
+
+	err := rm.db.QueryRow(query, userID, movieID).Scan(&rating.UserID, &rating.MovieID, &rating.Score, &rating.RatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rating, nil
+}
+
+// Delete removes the rating of a user for a movie and refreshes the movie scores in the same transaction.
+// sql.ErrNoRows is returned when the user has not rated the movie.
+func (rm *ratingManager) Delete(userID, movieID uuid.UUID) error {
+	tx, err := rm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = lockRatingStats(tx); err != nil {
+		return err
+	}
+
+	query := `
+		DELETE FROM user_ratings WHERE user_id = $1 AND movie_id = $2
+		RETURNING score`
+
+	var score int
+	err = tx.QueryRow(query, userID, movieID).Scan(&score)
+	if err != nil {
+		return err
+	}
+
+	if err = adjustRatingStats(tx, -score, -1); err != nil {
+		return err
+	}
+
+	err = refreshScores(tx, movieID)
+	return err
+}
+
+// lockRatingStats locks the global rating statistics. Every transaction changing scores takes this lock before
+// touching any movie, so that concurrent ratings are serialized instead of locking movies in different orders.
+func lockRatingStats(tx *sql.Tx) error {
+	_, err := tx.Exec(`SELECT 1 FROM rating_stats FOR UPDATE`)
+	return err
+}
+
+// adjustRatingStats adds the changes of the sum and the number of all scores to the global rating statistics.
+func adjustRatingStats(tx *sql.Tx, sum, count int) error {
+	query := `
+		UPDATE rating_stats SET score_sum = score_sum + $1, score_count = score_count + $2`
+
+	_, err := tx.Exec(query, sum, count)
+	return err
+}
+
+// recountRatingStats recomputes the global rating statistics from all scores, after scores were moved or removed
+// in bulk.
+func recountRatingStats(tx *sql.Tx) error {
+	query := `
+		UPDATE rating_stats
+		SET score_sum = (SELECT COALESCE(SUM(score), 0) FROM user_ratings),
+			score_count = (SELECT COUNT(*) FROM user_ratings)`
+
+	_, err := tx.Exec(query)
+	return err
+}
+
+// refreshScores recomputes the average and count of the scores of the given movie. The weighted score is derived
+// from them when the movie is read, see weightedRating.
+func refreshScores(tx *sql.Tx, movieID uuid.UUID) error {
+	query := `
+		UPDATE movies m
+		SET user_rating_avg = s.average, user_rating_count = s.count
+		FROM (
+			SELECT COALESCE(AVG(score), 0) AS average, COUNT(*) AS count
+			FROM user_ratings
+			WHERE movie_id = $1
+		) s
+		WHERE m.id = $1`
+
+	_, err := tx.Exec(query, movieID)
+	return err
+}
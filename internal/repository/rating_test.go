@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestRatingManager_SetAndDelete(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE user_ratings CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE users CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("UPDATE rating_stats SET score_sum = 0, score_count = 0")
+		require.NoError(t, err)
+	}()
+	_, err := db.Exec("UPDATE rating_stats SET score_sum = 0, score_count = 0")
+	require.NoError(t, err)
+
+	forrest := &model.User{ID: uuid.New(), Username: "forrest", Password: "hash"}
+	require.NoError(t, userRep.Create(forrest))
+	jenny := &model.User{ID: uuid.New(), Username: "jenny", Password: "hash"}
+	require.NoError(t, userRep.Create(jenny))
+
+	Barbi := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Barbi",
+		Description: "Ryan Gosling",
+		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+	}
+	require.NoError(t, movieRep.Create(Barbi))
+
+	ratedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, ratingRep.Set(&model.UserRating{UserID: forrest.ID, MovieID: Barbi.ID, Score: 6, RatedAt: ratedAt}))
+	require.NoError(t, ratingRep.Set(&model.UserRating{UserID: forrest.ID, MovieID: Barbi.ID, Score: 8, RatedAt: ratedAt}))
+	require.NoError(t, ratingRep.Set(&model.UserRating{UserID: jenny.ID, MovieID: Barbi.ID, Score: 10, RatedAt: ratedAt}))
+
+	rating, err := ratingRep.Get(forrest.ID, Barbi.ID)
+	require.NoError(t, err)
+	require.Equal(t, &model.UserRating{UserID: forrest.ID, MovieID: Barbi.ID, Score: 8, RatedAt: ratedAt}, rating)
+
+	movie, err := movieRep.GetByID(Barbi.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, movie.UserRating.Count)
+	require.InDelta(t, 9.0, movie.UserRating.Average, 0.001)
+	require.InDelta(t, 9.0, movie.UserRating.Weighted, 0.001)
+
+	Ken := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Ken",
+		Description: "Barbie's boyfriend",
+		ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, movieRep.Create(Ken))
+	require.NoError(t, ratingRep.Set(&model.UserRating{UserID: forrest.ID, MovieID: Ken.ID, Score: 3, RatedAt: ratedAt}))
+
+	// The global average of 7 pulls both movies towards it without rewriting Barbi.
+	movie, err = movieRep.GetByID(Barbi.ID)
+	require.NoError(t, err)
+	require.InDelta(t, (2*9.0+10*7.0)/12, movie.UserRating.Weighted, 0.001)
+	movie, err = movieRep.GetByID(Ken.ID)
+	require.NoError(t, err)
+	require.InDelta(t, (3.0+10*7.0)/11, movie.UserRating.Weighted, 0.001)
+
+	require.NoError(t, ratingRep.Delete(forrest.ID, Ken.ID))
+
+	require.NoError(t, ratingRep.Delete(jenny.ID, Barbi.ID))
+	require.ErrorIs(t, ratingRep.Delete(jenny.ID, Barbi.ID), sql.ErrNoRows)
+
+	movie, err = movieRep.GetByID(Barbi.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, movie.UserRating.Count)
+	require.InDelta(t, 8.0, movie.UserRating.Average, 0.001)
+}
@@ -16,9 +16,22 @@ import (
 var (
 	db *sql.DB
 
-	actorRep ActorManager
-	movieRep MovieManager
-	userRep  UserManager
+	actorRep       ActorManager
+	movieRep       MovieManager
+	userRep        UserManager
+	ratingRep      RatingManager
+	reviewRep      ReviewManager
+	watchRep       WatchManager
+	listRep        ListManager
+	collectionRep  CollectionManager
+	seriesRep      SeriesManager
+	translationRep TranslationManager
+	awardRep       AwardManager
+	companyRep     CompanyManager
+	tagRep         TagManager
+	duplicateRep   DuplicateManager
+	trashRep       TrashManager
+	historyRep     HistoryManager
 )
 
 func TestMain(m *testing.M) {
@@ -77,6 +90,19 @@ func TestMain(m *testing.M) {
 	actorRep = NewActorManager(db)
 	movieRep = NewMovieManager(db)
 	userRep = NewUserManager(db)
+	ratingRep = NewRatingManager(db)
+	reviewRep = NewReviewManager(db)
+	watchRep = NewWatchManager(db)
+	listRep = NewListManager(db)
+	collectionRep = NewCollectionManager(db)
+	seriesRep = NewSeriesManager(db)
+	translationRep = NewTranslationManager(db)
+	awardRep = NewAwardManager(db)
+	companyRep = NewCompanyManager(db)
+	tagRep = NewTagManager(db)
+	duplicateRep = NewDuplicateManager(db)
+	trashRep = NewTrashManager(db)
+	historyRep = NewHistoryManager(db)
 
 	code := m.Run()
 
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// ReviewManager represents an interface for managing movie reviews in the system.
+type ReviewManager interface {
+	Create(review *model.Review) error
+	GetByID(reviewID uuid.UUID) (*model.Review, error)
+	Update(review *model.Review) error
+	Delete(reviewID uuid.UUID) error
+	IfExist(movieID, userID uuid.UUID) (bool, error)
+	GetByMovie(movieID uuid.UUID, status string) ([]*model.Review, error)
+	GetByStatus(status string) ([]*model.Review, error)
+}
+
+// NewReviewManager returns new repository instance for reviews
+func NewReviewManager(db *sql.DB) ReviewManager {
+	return &reviewManager{
+		db: db,
+	}
+}
+
+type reviewManager struct {
+	db *sql.DB
+}
+
+// reviewQuery selects reviews along with the name of their author and the score the author gave to the movie.
+const reviewQuery = `
+	SELECT r.id, r.movie_id, r.user_id, u.username, r.title, r.body, r.spoiler, COALESCE(ur.score, 0), r.status,
+		r.created_at AT TIME ZONE 'UTC' AS created_at_utc, r.updated_at AT TIME ZONE 'UTC' AS updated_at_utc
+	FROM reviews r
+	INNER JOIN users u ON r.user_id = u.id
+	LEFT JOIN user_ratings ur ON ur.user_id = r.user_id AND ur.movie_id = r.movie_id`
+
+// Create inserts a new review record into the database.
+func (rm *reviewManager) Create(review *model.Review) error {
+	query := `
+		INSERT INTO reviews (id, movie_id, user_id, title, body, spoiler, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := rm.db.Exec(query, review.ID, review.MovieID, review.UserID, review.Title, review.Body, review.Spoiler,
+		review.Status, review.CreatedAt, review.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByID retrieves review information from the database based on the provided review ID.
+func (rm *reviewManager) GetByID(reviewID uuid.UUID) (*model.Review, error) {
+	query := reviewQuery + `
+	WHERE r.id = $1`
+
+	var review model.Review
+
+	err := scanReview(rm.db.QueryRow(query, reviewID), &review)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// Update updates the content and moderation status of a review in the database.
+func (rm *reviewManager) Update(review *model.Review) error {
+	query := `
+		UPDATE reviews SET title = $2, body = $3, spoiler = $4, status = $5, updated_at = $6
+		WHERE id = $1`
+
+	_, err := rm.db.Exec(query, review.ID, review.Title, review.Body, review.Spoiler, review.Status, review.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete removes review information from the database based on the provided review ID.
+func (rm *reviewManager) Delete(reviewID uuid.UUID) error {
+	query := `DELETE FROM reviews WHERE id = $1`
+
+	_, err := rm.db.Exec(query, reviewID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// IfExist checks whether the user has already reviewed the movie.
+func (rm *reviewManager) IfExist(movieID, userID uuid.UUID) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM reviews WHERE movie_id = $1 AND user_id = $2)"
+
+	var exists bool
+	err := rm.db.QueryRow(query, movieID, userID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetByMovie retrieves the reviews of a movie with the given moderation status, newest first.
+func (rm *reviewManager) GetByMovie(movieID uuid.UUID, status string) ([]*model.Review, error) {
+	query := reviewQuery + `
+	WHERE r.movie_id = $1 AND r.status = $2
+	ORDER BY r.created_at DESC`
+
+	return rm.getReviewsByQuery(query, movieID, status)
+}
+
+// GetByStatus retrieves all reviews with the given moderation status, oldest first.
+func (rm *reviewManager) GetByStatus(status string) ([]*model.Review, error) {
+	query := reviewQuery + `
+	WHERE r.status = $1
+	ORDER BY r.created_at`
+
+	return rm.getReviewsByQuery(query, status)
+}
+
+func (rm *reviewManager) getReviewsByQuery(query string, args ...interface{}) ([]*model.Review, error) {
+	rows, err := rm.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]*model.Review, 0)
+	for rows.Next() {
+		var review model.Review
+		if err := scanReview(rows, &review); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, &review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func scanReview(row rowScanner, review *model.Review) error {
+	return row.Scan(&review.ID, &review.MovieID, &review.UserID, &review.Username, &review.Title, &review.Body,
+		&review.Spoiler, &review.Score, &review.Status, &review.CreatedAt, &review.UpdatedAt)
+}
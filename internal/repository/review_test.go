@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestReviewManager_Moderation(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE reviews CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE user_ratings CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE users CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	forrest := &model.User{ID: uuid.New(), Username: "forrest", Password: "hash"}
+	require.NoError(t, userRep.Create(forrest))
+
+	Barbi := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Barbi",
+		Description: "Ryan Gosling",
+		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+	}
+	require.NoError(t, movieRep.Create(Barbi))
+	require.NoError(t, ratingRep.Set(&model.UserRating{UserID: forrest.ID, MovieID: Barbi.ID, Score: 7, RatedAt: time.Now().UTC()}))
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	review := &model.Review{
+		ID:        uuid.New(),
+		MovieID:   Barbi.ID,
+		UserID:    forrest.ID,
+		Username:  forrest.Username,
+		Title:     "Pink",
+		Body:      "Very pink",
+		Status:    model.ReviewPending,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	require.NoError(t, reviewRep.Create(review))
+
+	exists, err := reviewRep.IfExist(Barbi.ID, forrest.ID)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	queue, err := reviewRep.GetByStatus(model.ReviewPending)
+	require.NoError(t, err)
+	review.Score = 7
+	require.Equal(t, []*model.Review{review}, queue)
+
+	movie, err := movieRep.GetByID(Barbi.ID)
+	require.NoError(t, err)
+	require.Equal(t, 0, movie.ReviewCount)
+
+	review.Status = model.ReviewApproved
+	require.NoError(t, reviewRep.Update(review))
+
+	published, err := reviewRep.GetByMovie(Barbi.ID, model.ReviewApproved)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Review{review}, published)
+
+	movie, err = movieRep.GetByID(Barbi.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, movie.ReviewCount)
+
+	require.NoError(t, reviewRep.Delete(review.ID))
+	_, err = reviewRep.GetByID(review.ID)
+	require.Error(t, err)
+}
@@ -0,0 +1,403 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// SeriesManager represents an interface for managing TV series, their seasons and episodes in the system.
+type SeriesManager interface {
+	Create(series *model.Series) error
+	GetByID(seriesID uuid.UUID) (*model.Series, error)
+	Update(series *model.Series) error
+	Delete(seriesID uuid.UUID) error
+	Search(titleFragment, actorNameFragment string) ([]*model.Series, error)
+	CreateSeason(season *model.Season) error
+	DeleteSeason(seasonID uuid.UUID) error
+	CreateEpisode(episode *model.Episode) error
+	GetEpisodeByID(episodeID uuid.UUID) (*model.Episode, error)
+	UpdateEpisode(episode *model.Episode) error
+	DeleteEpisode(episodeID uuid.UUID) error
+}
+
+// NewSeriesManager returns new repository instance for series
+func NewSeriesManager(db *sql.DB) SeriesManager {
+	return &seriesManager{
+		db: db,
+	}
+}
+
+type seriesManager struct {
+	db *sql.DB
+}
+
+// seriesQuery selects series along with their air dates and the number of their seasons and episodes.
+const seriesQuery = `
+	SELECT s.id, s.title, s.description, stats.first_air_date, stats.last_air_date,
+		(SELECT COUNT(*) FROM seasons se WHERE se.series_id = s.id) AS season_count, stats.episode_count
+	FROM series s
+	LEFT JOIN LATERAL (
+		SELECT MIN(e.air_date) AT TIME ZONE 'UTC' AS first_air_date, MAX(e.air_date) AT TIME ZONE 'UTC' AS last_air_date,
+			COUNT(*) AS episode_count
+		FROM episodes e
+		INNER JOIN seasons se ON e.season_id = se.id
+		WHERE se.series_id = s.id
+	) stats ON TRUE`
+
+// episodeColumns lists the episode columns selected by the queries of the repository, in the order read by scanEpisode.
+const episodeColumns = `e.id, se.series_id, e.season_id, se.number, e.number, e.title, e.description,
+	e.air_date AT TIME ZONE 'UTC' AS air_date_utc`
+
+// Create inserts a new series record into the database.
+func (sm *seriesManager) Create(series *model.Series) error {
+	query := `
+		INSERT INTO series (id, title, description) VALUES ($1, $2, $3)`
+
+	_, err := sm.db.Exec(query, series.ID, series.Title, series.Description)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByID retrieves a series from the database along with its seasons and episodes in order.
+// The casts of the episodes are batch-loaded.
+func (sm *seriesManager) GetByID(seriesID uuid.UUID) (*model.Series, error) {
+	query := seriesQuery + `
+	WHERE s.id = $1`
+
+	var series model.Series
+
+	if err := scanSeries(sm.db.QueryRow(query, seriesID), &series); err != nil {
+		return nil, err
+	}
+
+	seasonsQuery := `
+		SELECT id, series_id, number, title
+		FROM seasons
+		WHERE series_id = $1
+		ORDER BY number`
+
+	rows, err := sm.db.Query(seasonsQuery, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	series.Seasons = make([]model.Season, 0)
+	seasonIndex := make(map[uuid.UUID]int)
+	for rows.Next() {
+		season := model.Season{Episodes: make([]model.Episode, 0)}
+
+		if err := rows.Scan(&season.ID, &season.SeriesID, &season.Number, &season.Title); err != nil {
+			return nil, err
+		}
+
+		seasonIndex[season.ID] = len(series.Seasons)
+		series.Seasons = append(series.Seasons, season)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	episodesQuery := `
+		SELECT ` + episodeColumns + `
+		FROM episodes e
+		INNER JOIN seasons se ON e.season_id = se.id
+		WHERE se.series_id = $1
+		ORDER BY se.number, e.number`
+
+	episodes, err := sm.getEpisodesByQuery(episodesQuery, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, episode := range episodes {
+		season := &series.Seasons[seasonIndex[episode.SeasonID]]
+		season.Episodes = append(season.Episodes, *episode)
+	}
+
+	return &series, nil
+}
+
+// Update updates the title and description of a series in the database.
+func (sm *seriesManager) Update(series *model.Series) error {
+	query := `
+		UPDATE series SET title = $2, description = $3
+		WHERE id = $1`
+
+	_, err := sm.db.Exec(query, series.ID, series.Title, series.Description)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete removes a series along with its seasons and episodes from the database.
+func (sm *seriesManager) Delete(seriesID uuid.UUID) error {
+	query := `DELETE FROM series WHERE id = $1`
+
+	_, err := sm.db.Exec(query, seriesID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Search retrieves the series whose title contains the title fragment and, when an actor name fragment is given,
+// with an episode featuring an actor whose name contains it. Series are ordered by title, without their seasons.
+func (sm *seriesManager) Search(titleFragment, actorNameFragment string) ([]*model.Series, error) {
+	query := seriesQuery + `
+	WHERE s.title LIKE '%' || $1 || '%'
+	AND ($2 = '' OR EXISTS (
+		SELECT 1
+		FROM episode_actor ea
+		INNER JOIN episodes e ON ea.episode_id = e.id
+		INNER JOIN seasons se ON e.season_id = se.id
+		INNER JOIN actors a ON ea.actor_id = a.id AND a.deleted_at IS NULL
+		WHERE se.series_id = s.id AND a.name LIKE '%' || $2 || '%'
+	))
+	ORDER BY s.title`
+
+	rows, err := sm.db.Query(query, titleFragment, actorNameFragment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seriesList := make([]*model.Series, 0)
+	for rows.Next() {
+		var series model.Series
+		if err := scanSeries(rows, &series); err != nil {
+			return nil, err
+		}
+		seriesList = append(seriesList, &series)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return seriesList, nil
+}
+
+// CreateSeason inserts a new season record into the database.
+func (sm *seriesManager) CreateSeason(season *model.Season) error {
+	query := `
+		INSERT INTO seasons (id, series_id, number, title) VALUES ($1, $2, $3, $4)`
+
+	_, err := sm.db.Exec(query, season.ID, season.SeriesID, season.Number, season.Title)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteSeason removes a season along with its episodes from the database.
+func (sm *seriesManager) DeleteSeason(seasonID uuid.UUID) error {
+	query := `DELETE FROM seasons WHERE id = $1`
+
+	_, err := sm.db.Exec(query, seasonID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateEpisode inserts a new episode record along with its cast into the database.
+func (sm *seriesManager) CreateEpisode(episode *model.Episode) error {
+	tx, err := sm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `
+		INSERT INTO episodes (id, season_id, number, title, description, air_date) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err = tx.Exec(query, episode.ID, episode.SeasonID, episode.Number, episode.Title, episode.Description,
+		episode.AirDate)
+	if err != nil {
+		return err
+	}
+
+	err = insertEpisodeCast(tx, episode)
+	return err
+}
+
+// GetEpisodeByID retrieves an episode from the database along with its cast.
+func (sm *seriesManager) GetEpisodeByID(episodeID uuid.UUID) (*model.Episode, error) {
+	query := `
+		SELECT ` + episodeColumns + `
+		FROM episodes e
+		INNER JOIN seasons se ON e.season_id = se.id
+		WHERE e.id = $1`
+
+	var episode model.Episode
+
+	if err := scanEpisode(sm.db.QueryRow(query, episodeID), &episode); err != nil {
+		return nil, err
+	}
+
+	if err := loadEpisodeCasts(sm.db, []*model.Episode{&episode}); err != nil {
+		return nil, err
+	}
+
+	return &episode, nil
+}
+
+// UpdateEpisode updates an episode in the database and replaces its cast.
+func (sm *seriesManager) UpdateEpisode(episode *model.Episode) error {
+	tx, err := sm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `
+		UPDATE episodes SET number = $2, title = $3, description = $4, air_date = $5
+		WHERE id = $1`
+
+	_, err = tx.Exec(query, episode.ID, episode.Number, episode.Title, episode.Description, episode.AirDate)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM episode_actor WHERE episode_id = $1`, episode.ID)
+	if err != nil {
+		return err
+	}
+
+	err = insertEpisodeCast(tx, episode)
+	return err
+}
+
+// DeleteEpisode removes an episode along with its cast from the database.
+func (sm *seriesManager) DeleteEpisode(episodeID uuid.UUID) error {
+	query := `DELETE FROM episodes WHERE id = $1`
+
+	_, err := sm.db.Exec(query, episodeID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getEpisodesByQuery runs a query selecting episode columns and batch-loads the casts of the returned episodes.
+func (sm *seriesManager) getEpisodesByQuery(query string, args ...interface{}) ([]*model.Episode, error) {
+	rows, err := sm.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	episodes := make([]*model.Episode, 0)
+	for rows.Next() {
+		var episode model.Episode
+		if err := scanEpisode(rows, &episode); err != nil {
+			return nil, err
+		}
+		episodes = append(episodes, &episode)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadEpisodeCasts(sm.db, episodes); err != nil {
+		return nil, err
+	}
+
+	return episodes, nil
+}
+
+// loadEpisodeCasts fills the casts of the given episodes with a single query, ordered by billing order.
+func loadEpisodeCasts(db *sql.DB, episodes []*model.Episode) error {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	episodeIDs := make([]string, 0, len(episodes))
+	episodeMap := make(map[uuid.UUID]*model.Episode, len(episodes))
+	for _, episode := range episodes {
+		episode.Cast = make([]model.CastMember, 0)
+		episodeIDs = append(episodeIDs, episode.ID.String())
+		episodeMap[episode.ID] = episode
+	}
+
+	query := `
+		SELECT ea.episode_id, a.id, a.name, a.gender, a.birth_date AT TIME ZONE 'UTC' AS birth_date_utc,
+			ea.character_name, ea.billing_order
+		FROM episode_actor ea
+		INNER JOIN actors a ON ea.actor_id = a.id AND a.deleted_at IS NULL
+		WHERE ea.episode_id = ANY($1::uuid[])
+		ORDER BY ea.billing_order, a.name
+	`
+	rows, err := db.Query(query, pq.Array(episodeIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var episodeID uuid.UUID
+		var member model.CastMember
+
+		err := rows.Scan(&episodeID, &member.ID, &member.Name, &member.Gender, &member.BirthDate,
+			&member.CharacterName, &member.BillingOrder)
+		if err != nil {
+			return err
+		}
+
+		episode := episodeMap[episodeID]
+		episode.Cast = append(episode.Cast, member)
+	}
+
+	return rows.Err()
+}
+
+func insertEpisodeCast(tx *sql.Tx, episode *model.Episode) error {
+	query := `
+		INSERT INTO episode_actor (episode_id, actor_id, character_name, billing_order) VALUES ($1, $2, $3, $4)`
+
+	for _, member := range episode.Cast {
+		_, err := tx.Exec(query, episode.ID, member.ID, member.CharacterName, member.BillingOrder)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanSeries(row rowScanner, series *model.Series) error {
+	var firstAirDate, lastAirDate sql.NullTime
+
+	err := row.Scan(&series.ID, &series.Title, &series.Description, &firstAirDate, &lastAirDate,
+		&series.SeasonCount, &series.EpisodeCount)
+	if err != nil {
+		return err
+	}
+
+	series.FirstAirDate = firstAirDate.Time
+	series.LastAirDate = lastAirDate.Time
+	return nil
+}
+
+func scanEpisode(row rowScanner, episode *model.Episode) error {
+	return row.Scan(&episode.ID, &episode.SeriesID, &episode.SeasonID, &episode.SeasonNumber, &episode.Number,
+		&episode.Title, &episode.Description, &episode.AirDate)
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestSeriesManager_SeasonsAndEpisodes(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE series CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Kyle := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Kyle MacLachlan",
+		Gender:    "Male",
+		BirthDate: time.Date(1959, 2, 22, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(Kyle))
+
+	series := &model.Series{ID: uuid.New(), Title: "Twin Peaks", Description: "Who killed Laura Palmer?"}
+	require.NoError(t, seriesRep.Create(series))
+
+	seasons := []*model.Season{
+		{ID: uuid.New(), SeriesID: series.ID, Number: 2},
+		{ID: uuid.New(), SeriesID: series.ID, Number: 1},
+	}
+	for _, season := range seasons {
+		require.NoError(t, seriesRep.CreateSeason(season))
+	}
+
+	pilot := &model.Episode{
+		ID:       uuid.New(),
+		SeasonID: seasons[1].ID,
+		Number:   1,
+		Title:    "Pilot",
+		AirDate:  time.Date(1990, 4, 8, 0, 0, 0, 0, time.UTC),
+		Cast:     []model.CastMember{{Actor: *Kyle, CharacterName: "Dale Cooper", BillingOrder: 1}},
+	}
+	require.NoError(t, seriesRep.CreateEpisode(pilot))
+
+	finale := &model.Episode{
+		ID:       uuid.New(),
+		SeasonID: seasons[0].ID,
+		Number:   22,
+		Title:    "Beyond Life and Death",
+		AirDate:  time.Date(1991, 6, 10, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, seriesRep.CreateEpisode(finale))
+
+	fetched, err := seriesRep.GetByID(series.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, fetched.SeasonCount)
+	require.Equal(t, 2, fetched.EpisodeCount)
+	require.Equal(t, pilot.AirDate, fetched.FirstAirDate)
+	require.Equal(t, finale.AirDate, fetched.LastAirDate)
+	require.Len(t, fetched.Seasons, 2)
+	require.Equal(t, 1, fetched.Seasons[0].Number)
+	require.Len(t, fetched.Seasons[0].Episodes, 1)
+	require.Equal(t, "Dale Cooper", fetched.Seasons[0].Episodes[0].Cast[0].CharacterName)
+	require.Empty(t, fetched.Seasons[1].Episodes[0].Cast)
+
+	found, err := seriesRep.Search("Peaks", "MacLachlan")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	found, err = seriesRep.Search("", "Lynch")
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	pilot.Cast = nil
+	pilot.Title = "Northwest Passage"
+	require.NoError(t, seriesRep.UpdateEpisode(pilot))
+
+	episode, err := seriesRep.GetEpisodeByID(pilot.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Northwest Passage", episode.Title)
+	require.Equal(t, 1, episode.SeasonNumber)
+	require.Equal(t, series.ID, episode.SeriesID)
+	require.Empty(t, episode.Cast)
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// TagManager represents an interface for managing the tags of movies in the system.
+type TagManager interface {
+	GetAll() ([]*model.Tag, error)
+	GetStats(limit int) ([]*model.Tag, error)
+	GetByID(tagID uuid.UUID) (*model.Tag, error)
+	GetByName(name string) (*model.Tag, error)
+	Rename(tagID uuid.UUID, name string) error
+	Merge(sourceID, targetID uuid.UUID) error
+	Delete(tagID uuid.UUID) error
+}
+
+// NewTagManager returns new repository instance for tags
+func NewTagManager(db *sql.DB) TagManager {
+	return &tagManager{
+		db: db,
+	}
+}
+
+type tagManager struct {
+	db *sql.DB
+}
+
+// tagColumns lists the tag columns selected by the queries of the repository, in the order read by getTagsByQuery.
+const tagColumns = `t.id, t.name,
+	(SELECT COUNT(*) FROM movie_tags mt INNER JOIN movies m ON mt.movie_id = m.id AND m.deleted_at IS NULL
+		WHERE mt.tag_id = t.id) AS movie_count`
+
+// GetAll retrieves all tags ordered by name along with the number of movies tagged with them.
+func (tm *tagManager) GetAll() ([]*model.Tag, error) {
+	query := `
+		SELECT ` + tagColumns + `
+		FROM tags t
+		ORDER BY t.name`
+
+	return tm.getTagsByQuery(query)
+}
+
+// GetStats retrieves the tags used by at least one movie, most used first, limited to the given number of tags.
+func (tm *tagManager) GetStats(limit int) ([]*model.Tag, error) {
+	query := `
+		SELECT t.id, t.name, COUNT(*) AS movie_count
+		FROM tags t
+		INNER JOIN movie_tags mt ON mt.tag_id = t.id
+		INNER JOIN movies m ON mt.movie_id = m.id AND m.deleted_at IS NULL
+		GROUP BY t.id, t.name
+		ORDER BY movie_count DESC, t.name
+		LIMIT $1`
+
+	return tm.getTagsByQuery(query, limit)
+}
+
+// GetByID retrieves a tag from the database.
+func (tm *tagManager) GetByID(tagID uuid.UUID) (*model.Tag, error) {
+	query := `
+		SELECT ` + tagColumns + `
+		FROM tags t
+		WHERE t.id = $1`
+
+	var tag model.Tag
+	if err := tm.db.QueryRow(query, tagID).Scan(&tag.ID, &tag.Name, &tag.MovieCount); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetByName retrieves a tag from the database by its name.
+func (tm *tagManager) GetByName(name string) (*model.Tag, error) {
+	query := `
+		SELECT ` + tagColumns + `
+		FROM tags t
+		WHERE t.name = $1`
+
+	var tag model.Tag
+	if err := tm.db.QueryRow(query, name).Scan(&tag.ID, &tag.Name, &tag.MovieCount); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Rename changes the name of a tag in the database.
+// sql.ErrNoRows is returned when the tag does not exist.
+func (tm *tagManager) Rename(tagID uuid.UUID, name string) error {
+	query := `UPDATE tags SET name = $2 WHERE id = $1`
+
+	return execAffectingRow(tm.db, query, tagID, name)
+}
+
+// Merge moves the movies of the source tag to the target tag and removes the source tag.
+func (tm *tagManager) Merge(sourceID, targetID uuid.UUID) error {
+	tx, err := tm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	moveQuery := `
+		INSERT INTO movie_tags (movie_id, tag_id)
+		SELECT movie_id, $2 FROM movie_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING`
+
+	_, err = tx.Exec(moveQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	deleteQuery := `DELETE FROM tags WHERE id = $1`
+
+	_, err = tx.Exec(deleteQuery, sourceID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes a tag from the database, untagging its movies.
+func (tm *tagManager) Delete(tagID uuid.UUID) error {
+	query := `DELETE FROM tags WHERE id = $1`
+
+	_, err := tm.db.Exec(query, tagID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tm *tagManager) getTagsByQuery(query string, args ...interface{}) ([]*model.Tag, error) {
+	rows, err := tm.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]*model.Tag, 0)
+	for rows.Next() {
+		var tag model.Tag
+
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.MovieCount); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// insertTags attaches the tags of a movie, creating the tags that do not exist yet.
+func insertTags(tx *sql.Tx, movie *model.Movie) error {
+	tagQuery := `
+		INSERT INTO tags (id, name) VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING`
+
+	movieTagQuery := `
+		INSERT INTO movie_tags (movie_id, tag_id)
+		SELECT $1, id FROM tags WHERE name = $2
+		ON CONFLICT DO NOTHING`
+
+	for _, name := range movie.Tags {
+		if _, err := tx.Exec(tagQuery, uuid.New(), name); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(movieTagQuery, movie.ID, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTags fills the tag names of the given movies with a single query, in alphabetical order.
+func loadTags(db queryer, movies []*model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	movieIDs := make([]string, 0, len(movies))
+	movieMap := make(map[uuid.UUID]*model.Movie, len(movies))
+	for _, movie := range movies {
+		movieIDs = append(movieIDs, movie.ID.String())
+		movieMap[movie.ID] = movie
+	}
+
+	query := `
+		SELECT mt.movie_id, t.name
+		FROM movie_tags mt
+		INNER JOIN tags t ON mt.tag_id = t.id
+		WHERE mt.movie_id = ANY($1::uuid[])
+		ORDER BY t.name
+	`
+	rows, err := db.Query(query, pq.Array(movieIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movieID uuid.UUID
+		var name string
+
+		if err := rows.Scan(&movieID, &name); err != nil {
+			return err
+		}
+
+		movieMap[movieID].Tags = append(movieMap[movieID].Tags, name)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestTagManager_FilterAndMerge(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE tags CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	movieTags := map[string][]string{
+		"Inception":      {"dream", "heist"},
+		"Ocean's Eleven": {"heist"},
+		"Looper":         {"time-travel"},
+		"Primer":         {"time travel"},
+	}
+	for title, tags := range movieTags {
+		require.NoError(t, movieRep.Create(&model.Movie{
+			ID:          uuid.New(),
+			Title:       title,
+			Description: "Keywords",
+			ReleaseDate: time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC),
+			Rating:      8,
+			Tags:        tags,
+		}))
+	}
+
+	all, err := movieRep.GetByTitle(model.MovieFilter{Tags: []string{"dream", "heist"}})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.Equal(t, "Inception", all[0].Title)
+	require.Equal(t, []string{"dream", "heist"}, all[0].Tags)
+
+	tagged, err := movieRep.GetByTitle(model.MovieFilter{Tags: []string{"dream", "heist"}, TagMode: model.TagModeAny})
+	require.NoError(t, err)
+	require.Len(t, tagged, 2)
+
+	source, err := tagRep.GetByName("time-travel")
+	require.NoError(t, err)
+	target, err := tagRep.GetByName("time travel")
+	require.NoError(t, err)
+	require.NoError(t, tagRep.Merge(source.ID, target.ID))
+
+	stats, err := tagRep.GetStats(2)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, "heist", stats[0].Name)
+	require.Equal(t, 2, stats[0].MovieCount)
+	require.Equal(t, "time travel", stats[1].Name)
+	require.Equal(t, 2, stats[1].MovieCount)
+
+	_, err = tagRep.GetByID(source.ID)
+	require.Error(t, err)
+}
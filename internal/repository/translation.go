@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// TranslationManager represents an interface for managing the translated titles and descriptions of movies.
+type TranslationManager interface {
+	Set(translation *model.MovieTranslation, history ...*model.HistoryEntry) error
+	Delete(movieID uuid.UUID, language string, history ...*model.HistoryEntry) error
+	GetByMovie(movieID uuid.UUID) ([]*model.MovieTranslation, error)
+	GetByMovies(movieIDs []uuid.UUID, languages []string) (map[uuid.UUID][]*model.MovieTranslation, error)
+}
+
+// NewTranslationManager returns new repository instance for movie translations
+func NewTranslationManager(db *sql.DB) TranslationManager {
+	return &translationManager{
+		db: db,
+	}
+}
+
+type translationManager struct {
+	db *sql.DB
+}
+
+// Set inserts or replaces the translation of a movie into a language, recording the history entries of the change
+// in the same transaction.
+func (tm *translationManager) Set(translation *model.MovieTranslation, history ...*model.HistoryEntry) error {
+	tx, err := tm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `
+		INSERT INTO movie_translations (movie_id, language, title, description) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (movie_id, language) DO UPDATE SET title = EXCLUDED.title, description = EXCLUDED.description`
+
+	_, err = tx.Exec(query, translation.MovieID, translation.Language, translation.Title, translation.Description)
+	if err != nil {
+		return err
+	}
+
+	err = insertHistory(tx, history)
+	return err
+}
+
+// Delete removes the translation of a movie into a language, recording the history entries of the change in the same
+// transaction. sql.ErrNoRows is returned when the movie has no translation into the language.
+func (tm *translationManager) Delete(movieID uuid.UUID, language string, history ...*model.HistoryEntry) error {
+	tx, err := tm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `DELETE FROM movie_translations WHERE movie_id = $1 AND language = $2`
+
+	if err = execAffectingRow(tx, query, movieID, language); err != nil {
+		return err
+	}
+
+	err = insertHistory(tx, history)
+	return err
+}
+
+// GetByMovie retrieves the translations of a movie ordered by language.
+func (tm *translationManager) GetByMovie(movieID uuid.UUID) ([]*model.MovieTranslation, error) {
+	query := `
+		SELECT movie_id, language, title, description
+		FROM movie_translations
+		WHERE movie_id = $1
+		ORDER BY language`
+
+	rows, err := tm.db.Query(query, movieID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := make([]*model.MovieTranslation, 0)
+	for rows.Next() {
+		var translation model.MovieTranslation
+		if err := rows.Scan(&translation.MovieID, &translation.Language, &translation.Title, &translation.Description); err != nil {
+			return nil, err
+		}
+		translations = append(translations, &translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetByMovies retrieves with a single query the translations of the given movies into any of the given languages,
+// grouped by movie.
+func (tm *translationManager) GetByMovies(movieIDs []uuid.UUID, languages []string) (map[uuid.UUID][]*model.MovieTranslation, error) {
+	translations := make(map[uuid.UUID][]*model.MovieTranslation)
+	if len(movieIDs) == 0 || len(languages) == 0 {
+		return translations, nil
+	}
+
+	ids := make([]string, 0, len(movieIDs))
+	for _, id := range movieIDs {
+		ids = append(ids, id.String())
+	}
+
+	query := `
+		SELECT movie_id, language, title, description
+		FROM movie_translations
+		WHERE movie_id = ANY($1::uuid[]) AND language = ANY($2)`
+
+	rows, err := tm.db.Query(query, pq.Array(ids), pq.Array(languages))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var translation model.MovieTranslation
+		if err := rows.Scan(&translation.MovieID, &translation.Language, &translation.Title, &translation.Description); err != nil {
+			return nil, err
+		}
+		translations[translation.MovieID] = append(translations[translation.MovieID], &translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestTranslationManager_SetGetAndDelete(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+
+	Shawshank := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "The Shawshank Redemption",
+		Description: "Prison drama",
+		ReleaseDate: time.Date(1994, 9, 23, 0, 0, 0, 0, time.UTC),
+		Rating:      10,
+	}
+	require.NoError(t, movieRep.Create(Shawshank))
+
+	german := &model.MovieTranslation{MovieID: Shawshank.ID, Language: "de", Title: "Verurteilten", Description: "Gefängnisdrama"}
+	require.NoError(t, translationRep.Set(german))
+	german.Title = "Die Verurteilten"
+	require.NoError(t, translationRep.Set(german))
+	brazilian := &model.MovieTranslation{MovieID: Shawshank.ID, Language: "pt-BR", Title: "Um Sonho de Liberdade"}
+	require.NoError(t, translationRep.Set(brazilian))
+
+	translations, err := translationRep.GetByMovie(Shawshank.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.MovieTranslation{german, brazilian}, translations)
+
+	byMovie, err := translationRep.GetByMovies([]uuid.UUID{Shawshank.ID}, []string{"pt-BR", "pt"})
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID][]*model.MovieTranslation{Shawshank.ID: {brazilian}}, byMovie)
+
+	movies, err := movieRep.GetByTitleFragment("Sonho")
+	require.NoError(t, err)
+	require.Equal(t, []*model.Movie{Shawshank}, movies)
+
+	require.NoError(t, translationRep.Delete(Shawshank.ID, "pt-BR"))
+	require.ErrorIs(t, translationRep.Delete(Shawshank.ID, "pt-BR"), sql.ErrNoRows)
+
+	movies, err = movieRep.GetByTitleFragment("Sonho")
+	require.NoError(t, err)
+	require.Empty(t, movies)
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// TrashManager represents an interface for managing the deleted actors and movies in the system.
+type TrashManager interface {
+	GetActors() ([]model.TrashItem, error)
+	GetMovies() ([]model.TrashItem, error)
+	RestoreActor(actorID uuid.UUID, history ActorHistory) error
+	RestoreMovie(movieID uuid.UUID, history MovieHistory) error
+	Purge(retention time.Duration) (int, error)
+}
+
+// NewTrashManager returns new repository instance for the trash
+func NewTrashManager(db *sql.DB) TrashManager {
+	return &trashManager{
+		db: db,
+	}
+}
+
+type trashManager struct {
+	db *sql.DB
+}
+
+// GetActors retrieves the actors in the trash, most recently deleted first.
+func (tm *trashManager) GetActors() ([]model.TrashItem, error) {
+	query := `
+		SELECT id, name, deleted_at AT TIME ZONE 'UTC' AS deleted_at_utc
+		FROM actors
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, name`
+
+	return tm.getItemsByQuery(query)
+}
+
+// GetMovies retrieves the movies in the trash, most recently deleted first.
+func (tm *trashManager) GetMovies() ([]model.TrashItem, error) {
+	query := `
+		SELECT id, title, deleted_at AT TIME ZONE 'UTC' AS deleted_at_utc
+		FROM movies
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, title`
+
+	return tm.getItemsByQuery(query)
+}
+
+// RestoreActor takes an actor out of the trash, along with the credits kept while they were in it, and records the
+// history entries built from the restored actor in the same transaction.
+// sql.ErrNoRows is returned when the actor is not in the trash.
+func (tm *trashManager) RestoreActor(actorID uuid.UUID, history ActorHistory) error {
+	tx, err := tm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE actors SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	if err = execAffectingRow(tx, query, actorID); err != nil {
+		return err
+	}
+
+	err = insertActorHistory(tx, actorID, history)
+	return err
+}
+
+// RestoreMovie takes a movie out of the trash, along with its cast, crew and the rest of its data, and records the
+// history entries built from the restored movie in the same transaction.
+// sql.ErrNoRows is returned when the movie is not in the trash.
+func (tm *trashManager) RestoreMovie(movieID uuid.UUID, history MovieHistory) error {
+	tx, err := tm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `UPDATE movies SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	if err = execAffectingRow(tx, query, movieID); err != nil {
+		return err
+	}
+
+	err = insertMovieHistory(tx, movieID, history)
+	return err
+}
+
+// Purge permanently removes the actors and movies that have been in the trash for longer than the retention,
+// along with their credits, and returns the number of removed actors and movies. The other data of the removed actors and movies,
+// e.g. ratings, reviews or nominations, is removed by the cascading foreign keys.
+func (tm *trashManager) Purge(retention time.Duration) (int, error) {
+	tx, err := tm.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// The scores of the removed movies leave the global rating statistics, which are locked first like for any
+	// change of scores.
+	if err = lockRatingStats(tx); err != nil {
+		return 0, err
+	}
+
+	// The expired rows are those deleted more than $1 seconds ago.
+	creditsQuery := `
+		DELETE FROM credits
+		WHERE movie_id IN (SELECT id FROM movies WHERE deleted_at < NOW() - $1 * INTERVAL '1 second')
+			OR person_id IN (SELECT id FROM actors WHERE deleted_at < NOW() - $1 * INTERVAL '1 second')`
+
+	if _, err = tx.Exec(creditsQuery, retention.Seconds()); err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, query := range []string{
+		`DELETE FROM movies WHERE deleted_at < NOW() - $1 * INTERVAL '1 second'`,
+		`DELETE FROM actors WHERE deleted_at < NOW() - $1 * INTERVAL '1 second'`,
+	} {
+		var result sql.Result
+		result, err = tx.Exec(query, retention.Seconds())
+		if err != nil {
+			return 0, err
+		}
+
+		var affected int64
+		affected, err = result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		purged += int(affected)
+	}
+
+	if purged > 0 {
+		err = recountRatingStats(tx)
+	}
+	return purged, err
+}
+
+func (tm *trashManager) getItemsByQuery(query string) ([]model.TrashItem, error) {
+	rows, err := tm.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]model.TrashItem, 0)
+	for rows.Next() {
+		var item model.TrashItem
+
+		if err := rows.Scan(&item.ID, &item.Name, &item.DeletedAt); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
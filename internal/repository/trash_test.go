@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestTrashManager_RestoreAndPurge(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+	Keanu := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Keanu Reeves",
+		Gender:    "male",
+		BirthDate: time.Date(1964, 9, 2, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(Keanu))
+
+	Speed := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Speed",
+		Description: "A bus must stay above 50 miles per hour.",
+		ReleaseDate: time.Date(1994, 6, 10, 0, 0, 0, 0, time.UTC),
+		Rating:      7,
+		Actors:      []model.CastMember{{Actor: *Keanu, BillingOrder: 1}},
+	}
+	require.NoError(t, movieRep.Create(Speed))
+
+	require.NoError(t, movieRep.Delete(Speed.ID, 0))
+	require.ErrorIs(t, movieRep.Delete(Speed.ID, 0), sql.ErrNoRows)
+
+	_, err := movieRep.GetByID(Speed.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	movies, err := movieRep.GetByTitleFragment("Speed")
+	require.NoError(t, err)
+	require.Empty(t, movies)
+
+	trashed, err := trashRep.GetMovies()
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	require.Equal(t, Speed.ID, trashed[0].ID)
+	require.Equal(t, "Speed", trashed[0].Name)
+
+	require.NoError(t, trashRep.RestoreMovie(Speed.ID, nil))
+	require.ErrorIs(t, trashRep.RestoreMovie(Speed.ID, nil), sql.ErrNoRows)
+
+	restored, err := movieRep.GetByID(Speed.ID)
+	require.NoError(t, err)
+	require.Len(t, restored.Actors, 1)
+	require.Equal(t, Keanu.ID, restored.Actors[0].ID)
+
+	require.NoError(t, movieRep.Delete(Speed.ID, 0))
+	require.NoError(t, actorRep.Delete(Keanu.ID, 0, false))
+
+	purged, err := trashRep.Purge(time.Hour)
+	require.NoError(t, err)
+	require.Zero(t, purged)
+
+	purged, err = trashRep.Purge(0)
+	require.NoError(t, err)
+	require.Equal(t, 2, purged)
+
+	trashed, err = trashRep.GetActors()
+	require.NoError(t, err)
+	require.Empty(t, trashed)
+	require.ErrorIs(t, trashRep.RestoreMovie(Speed.ID, nil), sql.ErrNoRows)
+}
+
+func TestTrashManager_RestoreActorAfterMovieUpdate(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+	}()
+	Keanu := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Keanu Reeves",
+		Gender:    "male",
+		BirthDate: time.Date(1964, 9, 2, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(Keanu))
+	Jan := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Jan de Bont",
+		Gender:    "male",
+		BirthDate: time.Date(1943, 10, 22, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(Jan))
+
+	Speed := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Speed",
+		Description: "A bus must stay above 50 miles per hour.",
+		ReleaseDate: time.Date(1994, 6, 10, 0, 0, 0, 0, time.UTC),
+		Rating:      7,
+		Actors:      []model.CastMember{{Actor: *Keanu, CharacterName: "Jack Traven", BillingOrder: 1}},
+		Crew:        []model.Credit{{PersonID: Jan.ID, Role: model.RoleDirector, BillingOrder: 1}},
+	}
+	require.NoError(t, movieRep.Create(Speed))
+
+	require.NoError(t, actorRep.Delete(Keanu.ID, 0, false))
+	require.NoError(t, actorRep.Delete(Jan.ID, 0, false))
+
+	edited, err := movieRep.GetByID(Speed.ID)
+	require.NoError(t, err)
+	require.Empty(t, edited.Actors)
+	require.Empty(t, edited.Crew)
+	edited.Rating = 8
+	require.NoError(t, movieRep.Update(edited))
+
+	require.NoError(t, trashRep.RestoreActor(Keanu.ID, nil))
+	require.NoError(t, trashRep.RestoreActor(Jan.ID, nil))
+
+	restored, err := movieRep.GetByID(Speed.ID)
+	require.NoError(t, err)
+	require.Equal(t, 8, restored.Rating)
+	require.Len(t, restored.Actors, 1)
+	require.Equal(t, Keanu.ID, restored.Actors[0].ID)
+	require.Equal(t, "Jack Traven", restored.Actors[0].CharacterName)
+	require.Len(t, restored.Crew, 1)
+	require.Equal(t, Jan.ID, restored.Crew[0].PersonID)
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// WatchManager represents an interface for managing the watchlists and watch diaries of users.
+type WatchManager interface {
+	AddToWatchlist(userID, movieID uuid.UUID) error
+	RemoveFromWatchlist(userID, movieID uuid.UUID) error
+	GetWatchlist(userID uuid.UUID) ([]*model.WatchlistEntry, error)
+	LogWatch(userID uuid.UUID, entry *model.DiaryEntry) error
+	RemoveFromDiary(userID, movieID uuid.UUID) error
+	GetDiary(userID uuid.UUID) ([]*model.DiaryEntry, error)
+	GetStatuses(userID uuid.UUID, movieIDs []uuid.UUID) (map[uuid.UUID]model.WatchStatus, error)
+}
+
+// NewWatchManager returns new repository instance for watchlists and diaries
+func NewWatchManager(db *sql.DB) WatchManager {
+	return &watchManager{
+		db: db,
+	}
+}
+
+type watchManager struct {
+	db *sql.DB
+}
+
+// AddToWatchlist adds a movie to the watchlist of a user, keeping the original date if it is already there.
+func (wm *watchManager) AddToWatchlist(userID, movieID uuid.UUID) error {
+	query := `
+		INSERT INTO watchlist (user_id, movie_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, movie_id) DO NOTHING`
+
+	_, err := wm.db.Exec(query, userID, movieID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveFromWatchlist removes a movie from the watchlist of a user.
+// sql.ErrNoRows is returned when the movie is not on the watchlist.
+func (wm *watchManager) RemoveFromWatchlist(userID, movieID uuid.UUID) error {
+	query := `DELETE FROM watchlist WHERE user_id = $1 AND movie_id = $2`
+
+	return execAffectingRow(wm.db, query, userID, movieID)
+}
+
+// GetWatchlist retrieves the watchlist of a user, most recently added first.
+// The movies of the entries are loaded with their casts and other details.
+func (wm *watchManager) GetWatchlist(userID uuid.UUID) ([]*model.WatchlistEntry, error) {
+	query := `
+		SELECT ` + movieColumns + `, w.added_at AT TIME ZONE 'UTC' AS added_at_utc
+		FROM watchlist w
+		INNER JOIN movies m ON w.movie_id = m.id AND m.deleted_at IS NULL
+		WHERE w.user_id = $1
+		ORDER BY w.added_at DESC`
+
+	rows, err := wm.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*model.WatchlistEntry, 0)
+	movies := make([]*model.Movie, 0)
+	for rows.Next() {
+		var movie model.Movie
+		var entry model.WatchlistEntry
+
+		if err := scanMovie(rows, &movie, &entry.AddedAt); err != nil {
+			return nil, err
+		}
+
+		movie.OnWatchlist = true
+		entry.Movie = &movie
+		entries = append(entries, &entry)
+		movies = append(movies, &movie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadMovieDetails(wm.db, movies); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// LogWatch records that a user has watched a movie. Logging a movie again counts as a rewatch.
+// The movie is removed from the watchlist of the user in the same transaction.
+func (wm *watchManager) LogWatch(userID uuid.UUID, entry *model.DiaryEntry) error {
+	tx, err := wm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `
+		INSERT INTO watch_log (user_id, movie_id, watched_at, note) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, movie_id) DO UPDATE SET
+			watched_at = GREATEST(watch_log.watched_at, EXCLUDED.watched_at),
+			rewatch_count = watch_log.rewatch_count + 1,
+			note = CASE WHEN EXCLUDED.note <> '' THEN EXCLUDED.note ELSE watch_log.note END
+		RETURNING watched_at AT TIME ZONE 'UTC', rewatch_count, note`
+
+	err = tx.QueryRow(query, userID, entry.MovieID, entry.WatchedAt, entry.Note).
+		Scan(&entry.WatchedAt, &entry.RewatchCount, &entry.Note)
+	if err != nil {
+		return err
+	}
+
+	watchlistQuery := `
+		DELETE FROM watchlist WHERE user_id = $1 AND movie_id = $2`
+
+	_, err = tx.Exec(watchlistQuery, userID, entry.MovieID)
+	return err
+}
+
+// RemoveFromDiary removes a movie from the watch diary of a user.
+// sql.ErrNoRows is returned when the movie is not in the diary.
+func (wm *watchManager) RemoveFromDiary(userID, movieID uuid.UUID) error {
+	query := `DELETE FROM watch_log WHERE user_id = $1 AND movie_id = $2`
+
+	return execAffectingRow(wm.db, query, userID, movieID)
+}
+
+// GetDiary retrieves the watch diary of a user, most recently watched first.
+// The movies of the entries are loaded with their casts and other details.
+func (wm *watchManager) GetDiary(userID uuid.UUID) ([]*model.DiaryEntry, error) {
+	query := `
+		SELECT ` + movieColumns + `, wl.watched_at AT TIME ZONE 'UTC' AS watched_at_utc, wl.rewatch_count, wl.note
+		FROM watch_log wl
+		INNER JOIN movies m ON wl.movie_id = m.id AND m.deleted_at IS NULL
+		WHERE wl.user_id = $1
+		ORDER BY wl.watched_at DESC`
+
+	rows, err := wm.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*model.DiaryEntry, 0)
+	movies := make([]*model.Movie, 0)
+	for rows.Next() {
+		var movie model.Movie
+		var entry model.DiaryEntry
+
+		if err := scanMovie(rows, &movie, &entry.WatchedAt, &entry.RewatchCount, &entry.Note); err != nil {
+			return nil, err
+		}
+
+		movie.Watched = true
+		entry.MovieID = movie.ID
+		entry.Movie = &movie
+		entries = append(entries, &entry)
+		movies = append(movies, &movie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadMovieDetails(wm.db, movies); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetStatuses tells for each of the given movies whether it is on the watchlist of the user and whether the user
+// has watched it. Movies the user has neither listed nor watched are left out of the result.
+func (wm *watchManager) GetStatuses(userID uuid.UUID, movieIDs []uuid.UUID) (map[uuid.UUID]model.WatchStatus, error) {
+	statuses := make(map[uuid.UUID]model.WatchStatus)
+	if len(movieIDs) == 0 {
+		return statuses, nil
+	}
+
+	ids := make([]string, 0, len(movieIDs))
+	for _, id := range movieIDs {
+		ids = append(ids, id.String())
+	}
+
+	query := `
+		SELECT movie_id, TRUE AS on_watchlist, FALSE AS watched
+		FROM watchlist
+		WHERE user_id = $1 AND movie_id = ANY($2::uuid[])
+		UNION ALL
+		SELECT movie_id, FALSE AS on_watchlist, TRUE AS watched
+		FROM watch_log
+		WHERE user_id = $1 AND movie_id = ANY($2::uuid[])`
+
+	rows, err := wm.db.Query(query, userID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movieID uuid.UUID
+		var onWatchlist, watched bool
+
+		if err := rows.Scan(&movieID, &onWatchlist, &watched); err != nil {
+			return nil, err
+		}
+
+		status := statuses[movieID]
+		status.OnWatchlist = status.OnWatchlist || onWatchlist
+		status.Watched = status.Watched || watched
+		statuses[movieID] = status
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// execAffectingRow executes a statement, possibly within a transaction, and returns sql.ErrNoRows when it did not
+// affect any row.
+func execAffectingRow(db execer, query string, args ...interface{}) error {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
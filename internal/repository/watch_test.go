@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestWatchManager_WatchlistAndDiary(t *testing.T) {
+	defer func() {
+		_, err := db.Exec("TRUNCATE TABLE watchlist CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE watch_log CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE users CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE movies CASCADE")
+		require.NoError(t, err)
+		_, err = db.Exec("TRUNCATE TABLE actors CASCADE")
+		require.NoError(t, err)
+	}()
+
+	forrest := &model.User{ID: uuid.New(), Username: "forrest", Password: "hash"}
+	require.NoError(t, userRep.Create(forrest))
+
+	Ryan := &model.Actor{
+		ID:        uuid.New(),
+		Name:      "Ryan Gosling",
+		Gender:    "male",
+		BirthDate: time.Date(1980, 11, 12, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, actorRep.Create(Ryan))
+
+	Barbi := &model.Movie{
+		ID:          uuid.New(),
+		Title:       "Barbi",
+		Description: "Ryan Gosling",
+		ReleaseDate: time.Date(2023, 11, 12, 0, 0, 0, 0, time.UTC),
+		Rating:      9,
+		Actors:      []model.CastMember{{Actor: *Ryan, CharacterName: "Ken", BillingOrder: 1}},
+	}
+	require.NoError(t, movieRep.Create(Barbi))
+
+	require.NoError(t, watchRep.AddToWatchlist(forrest.ID, Barbi.ID))
+	require.NoError(t, watchRep.AddToWatchlist(forrest.ID, Barbi.ID))
+
+	watchlist, err := watchRep.GetWatchlist(forrest.ID)
+	require.NoError(t, err)
+	require.Len(t, watchlist, 1)
+	require.Equal(t, Barbi.ID, watchlist[0].Movie.ID)
+	require.Len(t, watchlist[0].Movie.Actors, 1)
+	require.Equal(t, "Ken", watchlist[0].Movie.Actors[0].CharacterName)
+
+	statuses, err := watchRep.GetStatuses(forrest.ID, []uuid.UUID{Barbi.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]model.WatchStatus{Barbi.ID: {OnWatchlist: true}}, statuses)
+
+	first := &model.DiaryEntry{MovieID: Barbi.ID, WatchedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Note: "Pink"}
+	require.NoError(t, watchRep.LogWatch(forrest.ID, first))
+	require.Equal(t, 0, first.RewatchCount)
+
+	again := &model.DiaryEntry{MovieID: Barbi.ID, WatchedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, watchRep.LogWatch(forrest.ID, again))
+	require.Equal(t, 1, again.RewatchCount)
+	require.Equal(t, "Pink", again.Note)
+
+	diary, err := watchRep.GetDiary(forrest.ID)
+	require.NoError(t, err)
+	require.Len(t, diary, 1)
+	require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), diary[0].WatchedAt)
+	require.Len(t, diary[0].Movie.Actors, 1)
+	require.Equal(t, Ryan.ID, diary[0].Movie.Actors[0].ID)
+
+	statuses, err = watchRep.GetStatuses(forrest.ID, []uuid.UUID{Barbi.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]model.WatchStatus{Barbi.ID: {Watched: true}}, statuses)
+
+	require.ErrorIs(t, watchRep.RemoveFromWatchlist(forrest.ID, Barbi.ID), sql.ErrNoRows)
+	require.NoError(t, watchRep.RemoveFromDiary(forrest.ID, Barbi.ID))
+}
@@ -2,44 +2,146 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
 	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
 )
 
+// Limits of the actor profile fields, matching the constraints of the actors and actor_links tables.
+const (
+	maxBiographyLength  = 5000
+	maxAliasLength      = 255
+	maxBirthPlaceLength = 255
+	maxLinkSiteLength   = 50
+	maxLinkURLLength    = 500
+)
+
+// Page sizes of actor listings.
+const (
+	DefaultActorPageSize = 20
+	MaxActorPageSize     = 100
+)
+
+// Limits of the co-star queries: the number of frequent collaborators listed and the length of the chains of movies
+// searched between two actors.
+const (
+	DefaultCollaboratorsLimit = 10
+	MaxCollaboratorsLimit     = 100
+	MaxConnectionDegrees      = 6
+)
+
+// ErrInvalidLifeDates is returned when merging actors would leave the death date of the merged actor before
+// their birth date.
+var ErrInvalidLifeDates = errors.New("death date must not be before the birth date nor in the future")
+
+// Errors returned when an actor listing query is not valid.
+var (
+	ErrInvalidActorSort  = errors.New("actor sort must be name, birth_date or film_count")
+	ErrInvalidBirthYears = errors.New("birth years must not be negative and the minimum must not exceed the maximum")
+)
+
+// Errors returned by the co-star queries.
+var (
+	ErrInvalidCollaboratorsLimit = errors.New("collaborators limit must be between 1 and 100")
+	ErrNoConnection              = errors.New("no chain of at most 6 movies connects the actors")
+)
+
+// Errors returned when an actor cannot be deleted.
+var (
+	ErrInvalidDeletePolicy = errors.New("delete policy must be reject, detach or soft")
+	ErrActorInUse          = errors.New("actor is credited on movies")
+)
+
+// ActorInUseError is returned when an actor credited on movies is deleted with the reject policy,
+// it lists the movies crediting the actor and matches ErrActorInUse.
+type ActorInUseError struct {
+	Movies []*model.Movie
+}
+
+func (e *ActorInUseError) Error() string {
+	return fmt.Sprintf("%v: %d movies", ErrActorInUse, len(e.Movies))
+}
+
+func (e *ActorInUseError) Unwrap() error {
+	return ErrActorInUse
+}
+
 // ActorService represents a service for managing actors.
 type ActorService interface {
-	Create(actor *model.Actor) error
-	Update(actorID uuid.UUID, actor *model.Actor) error
-	Delete(actorID uuid.UUID) error
+	Create(actor *model.Actor, username string) error
+	GetByID(actorID uuid.UUID) (*model.Actor, error)
+	Update(actorID uuid.UUID, actor *model.Actor, username string) error
+	Patch(actorID uuid.UUID, mediaType string, patch []byte, version int, username string) (*model.Actor, error)
+	Delete(actorID uuid.UUID, version int, policy, username string) error
 	GetAllWithMovies() ([]*model.ActorMovies, error)
+	GetAll(query model.ActorQuery, page, pageSize int) (*model.ActorPage, error)
+	GetFilmography(actorID uuid.UUID) (*model.Filmography, error)
+	GetCareerTimeline(actorID uuid.UUID) (*model.CareerTimeline, error)
+	GetCollaborators(actorID uuid.UUID, limit int) ([]model.Collaborator, error)
+	GetConnection(sourceID, targetID uuid.UUID) (*model.Connection, error)
 }
 
 type actorService struct {
-	actorManager repository.ActorManager
+	actorManager   repository.ActorManager
+	movieManager   repository.MovieManager
+	historyManager repository.HistoryManager
 }
 
 // NewActorService creates a new instance of the ActorService.
-func NewActorService(actorManager repository.ActorManager) ActorService {
+func NewActorService(actorManager repository.ActorManager, movieManager repository.MovieManager,
+	historyManager repository.HistoryManager) ActorService {
 	return &actorService{
-		actorManager: actorManager,
+		actorManager:   actorManager,
+		movieManager:   movieManager,
+		historyManager: historyManager,
 	}
 }
 
-// Create creates a new actor.
-func (as *actorService) Create(actor *model.Actor) error {
+// Create creates a new actor, recording the change made by the user in the history.
+func (as *actorService) Create(actor *model.Actor, username string) error {
+	normalizeProfile(actor)
+	if err := validateActor(nil, actor); err != nil {
+		return err
+	}
+
 	actor.ID = uuid.New()
 
-	return as.actorManager.Create(actor)
+	history, err := changeEntries(model.HistoryActor, actor.ID, model.HistoryCreate, username,
+		nil, newActorVersion(actor))
+	if err != nil {
+		return err
+	}
+
+	return as.actorManager.Create(actor, history...)
+}
+
+// GetByID retrieves an actor by ID along with their profile and current version.
+func (as *actorService) GetByID(actorID uuid.UUID) (*model.Actor, error) {
+	return as.actorManager.GetByID(actorID)
 }
 
-// Update updates an existing actor.
-func (as *actorService) Update(actorID uuid.UUID, actor *model.Actor) error {
+// Update updates an existing actor, recording the change made by the user in the history. The version of the actor
+// is the one the actor is expected to have, any version when it is zero; ErrVersionMismatch is returned when the actor
+// has another version, including when they are changed by someone else during the update.
+func (as *actorService) Update(actorID uuid.UUID, actor *model.Actor, username string) error {
 	existingActor, err := as.actorManager.GetByID(actorID)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(actor.Version, existingActor.Version); err != nil {
+		return err
+	}
+	previousActor := newActorVersion(existingActor)
+	// Only the fields the update changes are validated, the stored actor tells them apart.
+	storedActor := *existingActor
 
 	if actor.Name != "" {
 		existingActor.Name = actor.Name
@@ -50,16 +152,413 @@ func (as *actorService) Update(actorID uuid.UUID, actor *model.Actor) error {
 	if !actor.BirthDate.IsZero() {
 		existingActor.BirthDate = actor.BirthDate
 	}
+	if !actor.DeathDate.IsZero() {
+		existingActor.DeathDate = actor.DeathDate
+	}
+	if actor.BirthPlace != "" {
+		existingActor.BirthPlace = actor.BirthPlace
+	}
+	if actor.Nationality != "" {
+		existingActor.Nationality = actor.Nationality
+	}
+	if actor.Biography != "" {
+		existingActor.Biography = actor.Biography
+	}
+	if actor.Aliases != nil {
+		existingActor.Aliases = actor.Aliases
+	}
+	if actor.Links != nil {
+		existingActor.Links = actor.Links
+	}
+	normalizeProfile(existingActor)
+	if err := validateActor(&storedActor, existingActor); err != nil {
+		return err
+	}
+
+	history, err := changeEntries(model.HistoryActor, existingActor.ID, model.HistoryUpdate, username,
+		previousActor, newActorVersion(existingActor))
+	if err != nil {
+		return err
+	}
+
+	if err := as.actorManager.Update(existingActor.ID, existingActor, history...); err != nil {
+		return versionConflict(err)
+	}
+	return nil
+}
+
+// Patch partially updates an existing actor with a JSON Merge Patch or a JSON Patch document applied to the actor
+// as returned by their reads, recording the change made by the user in the history. Unlike Update, the fields the
+// patch sets to null or empty are cleared. The version of the actor is checked as in Update.
+func (as *actorService) Patch(actorID uuid.UUID, mediaType string, patch []byte, version int,
+	username string) (*model.Actor, error) {
+	existingActor, err := as.actorManager.GetByID(actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(version, existingActor.Version); err != nil {
+		return nil, err
+	}
+
+	var patchedActor model.Actor
+	if err := patchRecord(existingActor, mediaType, patch, &patchedActor); err != nil {
+		return nil, err
+	}
+	updatedActor := *existingActor
+	updatedActor.Name = patchedActor.Name
+	updatedActor.Gender = patchedActor.Gender
+	updatedActor.BirthDate = patchedActor.BirthDate
+	updatedActor.DeathDate = patchedActor.DeathDate
+	updatedActor.BirthPlace = patchedActor.BirthPlace
+	updatedActor.Nationality = patchedActor.Nationality
+	updatedActor.Biography = patchedActor.Biography
+	updatedActor.Aliases = patchedActor.Aliases
+	updatedActor.Links = patchedActor.Links
+	normalizeProfile(&updatedActor)
+	if err := validateActor(existingActor, &updatedActor); err != nil {
+		return nil, err
+	}
+
+	history, err := changeEntries(model.HistoryActor, updatedActor.ID, model.HistoryUpdate, username,
+		newActorVersion(existingActor), newActorVersion(&updatedActor))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.actorManager.Update(updatedActor.ID, &updatedActor, history...); err != nil {
+		return nil, versionConflict(err)
+	}
+	return &updatedActor, nil
+}
+
+// Delete moves an actor to the trash following the delete policy, the reject policy when it is empty:
+// an actor credited on movies is kept with the reject policy, loses their credits with the detach policy
+// and keeps them, hidden until the actor is restored, with the soft policy. The change made by the user is recorded
+// in the history, along with the changes of the casts and crews of the movies the actor is detached from.
+// ErrVersionMismatch is returned when the actor does not have the expected version, any version when it is zero,
+// including when they are changed by someone else during the delete.
+func (as *actorService) Delete(actorID uuid.UUID, version int, policy, username string) error {
+	if policy == "" {
+		policy = model.ActorDeleteReject
+	}
+	if policy != model.ActorDeleteReject && policy != model.ActorDeleteDetach && policy != model.ActorDeleteSoft {
+		return fmt.Errorf("%w: %q", ErrInvalidDeletePolicy, policy)
+	}
+
+	actor, err := as.actorManager.GetByID(actorID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version, actor.Version); err != nil {
+		return err
+	}
+	history, err := changeEntries(model.HistoryActor, actor.ID, model.HistoryDelete, username,
+		newActorVersion(actor), nil)
+	if err != nil {
+		return err
+	}
+
+	detach := policy == model.ActorDeleteDetach
+	if policy == model.ActorDeleteSoft {
+		return as.delete(actor, detach, history)
+	}
+
+	movies, err := as.actorManager.GetCreditedMovies(actor.ID)
+	if err != nil {
+		return err
+	}
+	if len(movies) > 0 && policy == model.ActorDeleteReject {
+		return &ActorInUseError{Movies: movies}
+	}
+
+	// The movies are fetched in full before the detach, to record what their casts and crews lose.
+	for _, movie := range movies {
+		creditedMovie, err := as.movieManager.GetByID(movie.ID)
+		if err != nil {
+			return err
+		}
+		detachedMovie := *creditedMovie
+		detachedMovie.Actors = slices.DeleteFunc(slices.Clone(creditedMovie.Actors), func(member model.CastMember) bool {
+			return member.ID == actor.ID
+		})
+		detachedMovie.Crew = slices.DeleteFunc(slices.Clone(creditedMovie.Crew), func(credit model.Credit) bool {
+			return credit.PersonID == actor.ID
+		})
+		movieHistory, err := movieChangeEntries(model.HistoryUpdate, username, movie.ID, creditedMovie, &detachedMovie)
+		if err != nil {
+			return err
+		}
+		history = append(history, movieHistory...)
+	}
 
-	return as.actorManager.Update(actorID, existingActor)
+	return as.delete(actor, detach, history)
 }
 
-// Delete deletes an actor by its ID.
-func (as *actorService) Delete(actorID uuid.UUID) error {
-	return as.actorManager.Delete(actorID)
+// delete moves an actor to the trash with the history of the change, provided they still have the version they were
+// read with.
+func (as *actorService) delete(actor *model.Actor, detach bool, history []*model.HistoryEntry) error {
+	if err := as.actorManager.Delete(actor.ID, actor.Version, detach, history...); err != nil {
+		return versionConflict(err)
+	}
+	return nil
 }
 
 // GetAllWithMovies retrieves all actors along with their movies.
 func (as *actorService) GetAllWithMovies() ([]*model.ActorMovies, error) {
 	return as.actorManager.GetAllWithMovies()
 }
+
+// GetAll retrieves a page of the actors matching the query, with their movies when the query asks for them.
+// Pages start at 1; zero page and page size stand for the first page and the default page size.
+func (as *actorService) GetAll(query model.ActorQuery, page, pageSize int) (*model.ActorPage, error) {
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = DefaultActorPageSize
+	}
+	if page < 0 || pageSize < 0 || pageSize > MaxActorPageSize {
+		return nil, ErrInvalidPage
+	}
+	query.Name = strings.TrimSpace(query.Name)
+	query.Gender = strings.TrimSpace(query.Gender)
+	query.Sort = strings.ToLower(query.Sort)
+	switch query.Sort {
+	case "":
+		query.Sort = model.ActorSortName
+	case model.ActorSortName, model.ActorSortBirthDate, model.ActorSortFilmCount:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidActorSort, query.Sort)
+	}
+	if query.MinBirthYear < 0 || query.MaxBirthYear < 0 ||
+		(query.MaxBirthYear != 0 && query.MinBirthYear > query.MaxBirthYear) {
+		return nil, ErrInvalidBirthYears
+	}
+
+	actors, total, err := as.actorManager.GetAll(query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if query.IncludeMovies {
+		if err := as.actorManager.LoadMovies(actors); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.ActorPage{
+		Actors:      actors,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalActors: total,
+	}, nil
+}
+
+// GetFilmography retrieves the movies of an actor grouped by credit role, with the age of the actor at their release.
+func (as *actorService) GetFilmography(actorID uuid.UUID) (*model.Filmography, error) {
+	filmography, err := as.actorManager.GetFilmography(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, movies := range filmography.Credits {
+		for _, movie := range movies {
+			movie.AgeAtRelease = ageAt(filmography.Person.BirthDate, movie.ReleaseDate)
+		}
+	}
+
+	return filmography, nil
+}
+
+// GetCareerTimeline summarizes the career of an actor from the movies they worked on in any role:
+// their first and last movies, their active years and the number and average rating of their movies per year.
+func (as *actorService) GetCareerTimeline(actorID uuid.UUID) (*model.CareerTimeline, error) {
+	filmography, err := as.GetFilmography(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A movie is counted once even if the actor has several roles in it.
+	seen := make(map[uuid.UUID]bool)
+	var movies []*model.FilmographyMovie
+	for _, credits := range filmography.Credits {
+		for _, movie := range credits {
+			if !seen[movie.ID] {
+				seen[movie.ID] = true
+				movies = append(movies, movie)
+			}
+		}
+	}
+	sort.Slice(movies, func(i, j int) bool {
+		if !movies[i].ReleaseDate.Equal(movies[j].ReleaseDate) {
+			return movies[i].ReleaseDate.Before(movies[j].ReleaseDate)
+		}
+		return movies[i].Title < movies[j].Title
+	})
+
+	timeline := &model.CareerTimeline{
+		Person:      filmography.Person,
+		TotalMovies: len(movies),
+	}
+	if len(movies) == 0 {
+		return timeline, nil
+	}
+
+	timeline.FirstMovie = movies[0]
+	timeline.LastMovie = movies[len(movies)-1]
+	firstYear := timeline.FirstMovie.ReleaseDate.Year()
+	timeline.CareerSpan = timeline.LastMovie.ReleaseDate.Year() - firstYear + 1
+	timeline.Years = make([]model.CareerYear, timeline.CareerSpan)
+	for i := range timeline.Years {
+		timeline.Years[i].Year = firstYear + i
+	}
+
+	totalRating := 0
+	yearRatings := make([]int, timeline.CareerSpan)
+	for _, movie := range movies {
+		i := movie.ReleaseDate.Year() - firstYear
+		timeline.Years[i].Movies++
+		yearRatings[i] += movie.Rating
+		totalRating += movie.Rating
+	}
+	for i := range timeline.Years {
+		if timeline.Years[i].Movies > 0 {
+			timeline.ActiveYears++
+			timeline.Years[i].AverageRating = float64(yearRatings[i]) / float64(timeline.Years[i].Movies)
+		}
+	}
+	timeline.AverageRating = float64(totalRating) / float64(len(movies))
+
+	return timeline, nil
+}
+
+// GetCollaborators retrieves the actors who starred in the most movies with an actor.
+// A zero limit stands for the default limit.
+func (as *actorService) GetCollaborators(actorID uuid.UUID, limit int) ([]model.Collaborator, error) {
+	if limit == 0 {
+		limit = DefaultCollaboratorsLimit
+	}
+	if limit < 0 || limit > MaxCollaboratorsLimit {
+		return nil, ErrInvalidCollaboratorsLimit
+	}
+	actor, err := as.actorManager.GetByID(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.actorManager.GetCollaborators(actor.ID, limit)
+}
+
+// GetConnection finds the shortest chain of movies connecting two actors, "six degrees of Kevin Bacon" style.
+// The co-star graph is searched breadth-first from both actors at once, always expanding the smaller frontier,
+// so that the search stays small even for well-connected actors.
+func (as *actorService) GetConnection(sourceID, targetID uuid.UUID) (*model.Connection, error) {
+	source, err := as.actorManager.GetByID(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := as.actorManager.GetByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		return &model.Connection{Steps: []model.ConnectionStep{{ActorID: source.ID, ActorName: source.Name}}}, nil
+	}
+
+	names := map[uuid.UUID]string{source.ID: source.Name, target.ID: target.Name}
+	// The links through which the actors were reached from each side, nil for the actor the side starts from.
+	fromSource := map[uuid.UUID]*model.CoStarLink{source.ID: nil}
+	fromTarget := map[uuid.UUID]*model.CoStarLink{target.ID: nil}
+	sourceFrontier := []uuid.UUID{source.ID}
+	targetFrontier := []uuid.UUID{target.ID}
+
+	for degrees := 0; degrees < MaxConnectionDegrees && len(sourceFrontier) > 0 && len(targetFrontier) > 0; degrees++ {
+		forward := len(sourceFrontier) <= len(targetFrontier)
+		frontier, reached, opposite := sourceFrontier, fromSource, fromTarget
+		if !forward {
+			frontier, reached, opposite = targetFrontier, fromTarget, fromSource
+		}
+
+		links, err := as.actorManager.GetCoStars(frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []uuid.UUID
+		for _, link := range links {
+			if _, ok := reached[link.CoStarID]; ok {
+				continue
+			}
+			reached[link.CoStarID] = &link
+			names[link.CoStarID] = link.CoStarName
+			if _, ok := opposite[link.CoStarID]; ok {
+				return buildConnection(link.CoStarID, fromSource, fromTarget, names), nil
+			}
+			next = append(next, link.CoStarID)
+		}
+
+		if forward {
+			sourceFrontier = next
+		} else {
+			targetFrontier = next
+		}
+	}
+
+	return nil, ErrNoConnection
+}
+
+// buildConnection joins the chain from the source actor to the actor where both searches met
+// with the chain from that actor to the target actor.
+func buildConnection(meetingID uuid.UUID, fromSource, fromTarget map[uuid.UUID]*model.CoStarLink,
+	names map[uuid.UUID]string) *model.Connection {
+	var steps []model.ConnectionStep
+	for actorID := meetingID; ; {
+		step := model.ConnectionStep{ActorID: actorID, ActorName: names[actorID]}
+		link := fromSource[actorID]
+		if link != nil {
+			step.MovieID, step.MovieTitle = link.MovieID, link.MovieTitle
+		}
+		steps = append(steps, step)
+		if link == nil {
+			break
+		}
+		actorID = link.ActorID
+	}
+	slices.Reverse(steps)
+
+	for link := fromTarget[meetingID]; link != nil; link = fromTarget[link.ActorID] {
+		steps = append(steps, model.ConnectionStep{
+			ActorID:    link.ActorID,
+			ActorName:  names[link.ActorID],
+			MovieID:    link.MovieID,
+			MovieTitle: link.MovieTitle,
+		})
+	}
+
+	return &model.Connection{Degrees: len(steps) - 1, Steps: steps}
+}
+
+// ageAt returns the age in whole years on the given date of a person born on birthDate,
+// or 0 if the birth date is unknown or after the date.
+func ageAt(birthDate, date time.Time) int {
+	if birthDate.IsZero() || date.Before(birthDate) {
+		return 0
+	}
+
+	age := date.Year() - birthDate.Year()
+	if date.Month() < birthDate.Month() || (date.Month() == birthDate.Month() && date.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// normalizeProfile trims the aliases and lowers the site names of the profile links of an actor,
+// and upper-cases their nationality.
+func normalizeProfile(actor *model.Actor) {
+	actor.Nationality = strings.ToUpper(actor.Nationality)
+	for i := range actor.Aliases {
+		actor.Aliases[i] = strings.TrimSpace(actor.Aliases[i])
+	}
+	for i := range actor.Links {
+		actor.Links[i].Site = strings.ToLower(strings.TrimSpace(actor.Links[i].Site))
+	}
+}
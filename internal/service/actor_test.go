@@ -1,7 +1,10 @@
 package service
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -11,33 +14,63 @@ import (
 )
 
 type mockActorManager struct {
-	CreateFunc           func(actor *model.Actor) error
-	GetByIDFunc          func(actorID uuid.UUID) (*model.Actor, error)
-	UpdateFunc           func(actorID uuid.UUID, actor *model.Actor) error
-	DeleteFunc           func(actorID uuid.UUID) error
-	GetAllWithMoviesFunc func() ([]*model.ActorMovies, error)
+	CreateFunc            func(actor *model.Actor, history []*model.HistoryEntry) error
+	GetByIDFunc           func(actorID uuid.UUID) (*model.Actor, error)
+	UpdateFunc            func(actorID uuid.UUID, actor *model.Actor, history []*model.HistoryEntry) error
+	DeleteFunc            func(actorID uuid.UUID, version int, detach bool, history []*model.HistoryEntry) error
+	GetCreditedMoviesFunc func(actorID uuid.UUID) ([]*model.Movie, error)
+	GetAllWithMoviesFunc  func() ([]*model.ActorMovies, error)
+	GetAllFunc            func(query model.ActorQuery, limit, offset int) ([]*model.ActorMovies, int, error)
+	LoadMoviesFunc        func(actors []*model.ActorMovies) error
+	GetFilmographyFunc    func(actorID uuid.UUID) (*model.Filmography, error)
+	GetCollaboratorsFunc  func(actorID uuid.UUID, limit int) ([]model.Collaborator, error)
+	GetCoStarsFunc        func(actorIDs []uuid.UUID) ([]model.CoStarLink, error)
 }
 
-func (m *mockActorManager) Create(actor *model.Actor) error {
-	return m.CreateFunc(actor)
+func (m *mockActorManager) Create(actor *model.Actor, history ...*model.HistoryEntry) error {
+	return m.CreateFunc(actor, history)
 }
 
 func (m *mockActorManager) GetByID(actorID uuid.UUID) (*model.Actor, error) {
 	return m.GetByIDFunc(actorID)
 }
 
-func (m *mockActorManager) Update(actorID uuid.UUID, actor *model.Actor) error {
-	return m.UpdateFunc(actorID, actor)
+func (m *mockActorManager) Update(actorID uuid.UUID, actor *model.Actor, history ...*model.HistoryEntry) error {
+	return m.UpdateFunc(actorID, actor, history)
 }
 
-func (m *mockActorManager) Delete(actorID uuid.UUID) error {
-	return m.DeleteFunc(actorID)
+func (m *mockActorManager) Delete(actorID uuid.UUID, version int, detach bool, history ...*model.HistoryEntry) error {
+	return m.DeleteFunc(actorID, version, detach, history)
+}
+
+func (m *mockActorManager) GetCreditedMovies(actorID uuid.UUID) ([]*model.Movie, error) {
+	return m.GetCreditedMoviesFunc(actorID)
 }
 
 func (m *mockActorManager) GetAllWithMovies() ([]*model.ActorMovies, error) {
 	return m.GetAllWithMoviesFunc()
 }
 
+func (m *mockActorManager) GetAll(query model.ActorQuery, limit, offset int) ([]*model.ActorMovies, int, error) {
+	return m.GetAllFunc(query, limit, offset)
+}
+
+func (m *mockActorManager) LoadMovies(actors []*model.ActorMovies) error {
+	return m.LoadMoviesFunc(actors)
+}
+
+func (m *mockActorManager) GetFilmography(actorID uuid.UUID) (*model.Filmography, error) {
+	return m.GetFilmographyFunc(actorID)
+}
+
+func (m *mockActorManager) GetCollaborators(actorID uuid.UUID, limit int) ([]model.Collaborator, error) {
+	return m.GetCollaboratorsFunc(actorID, limit)
+}
+
+func (m *mockActorManager) GetCoStars(actorIDs []uuid.UUID) ([]model.CoStarLink, error) {
+	return m.GetCoStarsFunc(actorIDs)
+}
+
 func TestActorService_Create(t *testing.T) {
 	t.Parallel()
 
@@ -46,7 +79,7 @@ func TestActorService_Create(t *testing.T) {
 	)
 
 	mockManager := &mockActorManager{
-		CreateFunc: func(actor *model.Actor) error {
+		CreateFunc: func(actor *model.Actor, history []*model.HistoryEntry) error {
 			if _, exists := actors[actor.Name]; exists {
 				return errors.New("actor already exists")
 			}
@@ -79,9 +112,9 @@ func TestActorService_Create(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actorSvc := NewActorService(mockManager)
+			actorSvc := NewActorService(mockManager, nil, &mockHistoryManager{})
 		
-			err := actorSvc.Create(tt.actor)
+			err := actorSvc.Create(tt.actor, "admin")
 
 			if err != nil && err.Error() != tt.expectedResult.Error() {
 				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
@@ -105,7 +138,7 @@ func TestActorService_Update(t *testing.T) {
 			}
 			return actor, nil
 		},
-		UpdateFunc: func(actorID uuid.UUID, actor *model.Actor) error {
+		UpdateFunc: func(actorID uuid.UUID, actor *model.Actor, history []*model.HistoryEntry) error {
 			if _, exists := actors[actor.Name]; exists && actor.ID != actorID {
 				return errors.New("actor with this name already exists")
 			}
@@ -148,9 +181,9 @@ func TestActorService_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actorSvc := NewActorService(mockManager)
+			actorSvc := NewActorService(mockManager, nil, &mockHistoryManager{})
 
-			err := actorSvc.Update(tt.actorID, tt.actor)
+			err := actorSvc.Update(tt.actorID, tt.actor, "admin")
 
 			if err != nil && err.Error() != tt.expectedResult.Error() {
 				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
@@ -159,55 +192,186 @@ func TestActorService_Update(t *testing.T) {
 	}
 }
 
-func TestActorService_Delete(t *testing.T) {
+func TestActorService_UpdateProfile(t *testing.T) {
 	t.Parallel()
 
-	var (
-		actors = make(map[uuid.UUID]*model.Actor)
-	)
+	actorID := uuid.New()
+	var updated *model.Actor
+	mockManager := &mockActorManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Actor, error) {
+			return &model.Actor{
+				ID:          actorID,
+				Name:        "Marilyn Monroe",
+				BirthDate:   time.Date(1926, 6, 1, 0, 0, 0, 0, time.UTC),
+				Nationality: "US",
+				Biography:   "American actress.",
+				Aliases:     []string{"Norma Jeane Mortenson"},
+			}, nil
+		},
+		UpdateFunc: func(id uuid.UUID, actor *model.Actor, history []*model.HistoryEntry) error {
+			updated = actor
+			return nil
+		},
+	}
 
-	successActorID := uuid.New()
-	actors[successActorID] = &model.Actor{
-		ID:   successActorID,
-		Name: "Tom Hanks",
+	tests := []struct {
+		name           string
+		actor          *model.Actor
+		expectedResult error
+	}{
+		{
+			name: "Success",
+			actor: &model.Actor{
+				DeathDate:  time.Date(1962, 8, 4, 0, 0, 0, 0, time.UTC),
+				BirthPlace: "Los Angeles, California, USA",
+				Links:      []model.ProfileLink{{Site: "IMDb", URL: "https://www.imdb.com/name/nm0000054/"}},
+			},
+		},
+		{
+			name:           "DeathBeforeBirth",
+			actor:          &model.Actor{DeathDate: time.Date(1920, 1, 1, 0, 0, 0, 0, time.UTC)},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "DeathInFuture",
+			actor:          &model.Actor{DeathDate: time.Now().AddDate(1, 0, 0)},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "UnknownNationality",
+			actor:          &model.Actor{Nationality: "xx"},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "RelativeLink",
+			actor:          &model.Actor{Links: []model.ProfileLink{{Site: "wikipedia", URL: "/wiki/Marilyn_Monroe"}}},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "EmptyAlias",
+			actor:          &model.Actor{Aliases: []string{" "}},
+			expectedResult: ErrValidation,
+		},
 	}
 
-	mockManager := &mockActorManager{
-		DeleteFunc: func(actorID uuid.UUID) error {
-			if _, exists := actors[actorID]; !exists {
-				return errors.New("actor not found")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated = nil
+			actorSvc := NewActorService(mockManager, nil, &mockHistoryManager{})
+
+			err := actorSvc.Update(actorID, tt.actor, "admin")
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
 			}
-			delete(actors, actorID)
-			return nil
-		},
+			if err != nil {
+				return
+			}
+			if updated.Biography != "American actress." || len(updated.Aliases) != 1 ||
+				updated.BirthPlace != tt.actor.BirthPlace || updated.Links[0].Site != "imdb" {
+				t.Errorf("Unexpected updated actor: %+v", updated)
+			}
+		})
+	}
+}
+
+func TestActorService_Delete(t *testing.T) {
+	t.Parallel()
+
+	creditedActorID := uuid.New()
+	uncreditedActorID := uuid.New()
+	actors := map[uuid.UUID]*model.Actor{
+		creditedActorID:   {ID: creditedActorID, Name: "Tom Hanks"},
+		uncreditedActorID: {ID: uncreditedActorID, Name: "Tom Hardy"},
 	}
 
 	tests := []struct {
 		name           string
 		actorID        uuid.UUID
+		policy         string
+		expectedAction string
 		expectedResult error
 	}{
 		{
-			name:           "Success",
-			actorID:        successActorID,
-			expectedResult: nil,
+			name:           "UncreditedActor",
+			actorID:        uncreditedActorID,
+			expectedAction: "delete",
 		},
 		{
-			name:           "ErrorActorNotFound",
+			name:           "CreditedActorRejected",
+			actorID:        creditedActorID,
+			policy:         model.ActorDeleteReject,
+			expectedResult: ErrActorInUse,
+		},
+		{
+			name:           "CreditedActorDetached",
+			actorID:        creditedActorID,
+			policy:         model.ActorDeleteDetach,
+			expectedAction: "detach",
+		},
+		{
+			name:           "CreditedActorSoftDeleted",
+			actorID:        creditedActorID,
+			policy:         model.ActorDeleteSoft,
+			expectedAction: "delete",
+		},
+		{
+			name:           "InvalidPolicy",
+			actorID:        creditedActorID,
+			policy:         "cascade",
+			expectedResult: ErrInvalidDeletePolicy,
+		},
+		{
+			name:           "ActorNotFound",
 			actorID:        uuid.New(),
-			expectedResult: errors.New("actor not found"),
+			expectedResult: sql.ErrNoRows,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actorSvc := NewActorService(mockManager)
+			action := ""
+			mockManager := &mockActorManager{
+				GetByIDFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+					if actor, ok := actors[actorID]; ok {
+						return actor, nil
+					}
+					return nil, sql.ErrNoRows
+				},
+				GetCreditedMoviesFunc: func(actorID uuid.UUID) ([]*model.Movie, error) {
+					if actorID == creditedActorID {
+						return []*model.Movie{{ID: uuid.New(), Title: "Cast Away"}}, nil
+					}
+					return []*model.Movie{}, nil
+				},
+				DeleteFunc: func(actorID uuid.UUID, version int, detach bool, history []*model.HistoryEntry) error {
+					action = "delete"
+					if detach {
+						action = "detach"
+					}
+					return nil
+				},
+			}
+			movieManager := &mockMovieManager{
+				GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+					return &model.Movie{ID: movieID, Title: "Cast Away",
+						Actors: []model.CastMember{{Actor: model.Actor{ID: creditedActorID}}}}, nil
+				},
+			}
+			actorSvc := NewActorService(mockManager, movieManager, &mockHistoryManager{})
 
-			err := actorSvc.Delete(tt.actorID)
+			err := actorSvc.Delete(tt.actorID, 0, tt.policy, "admin")
 
-			if err != nil && err.Error() != tt.expectedResult.Error() {
+			if !errors.Is(err, tt.expectedResult) {
 				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
 			}
+			if action != tt.expectedAction {
+				t.Errorf("Expected action: %q, got: %q", tt.expectedAction, action)
+			}
+			var inUse *ActorInUseError
+			if errors.As(err, &inUse) && len(inUse.Movies) != 1 {
+				t.Errorf("Expected 1 referencing movie, got: %d", len(inUse.Movies))
+			}
 		})
 	}
 }
@@ -251,7 +415,7 @@ func TestActorService_GetAllWithMovies(t *testing.T) {
 		},
 	}
 
-	actorSvc := NewActorService(mockManager)
+	actorSvc := NewActorService(mockManager, nil, &mockHistoryManager{})
 
 	expectedActors := []*model.ActorMovies{
 
@@ -304,3 +468,346 @@ func TestActorService_GetAllWithMovies(t *testing.T) {
 		}
 	}
 }
+
+func TestActorService_GetAll(t *testing.T) {
+	t.Parallel()
+
+	actorManager := &mockActorManager{
+		GetAllFunc: func(query model.ActorQuery, limit, offset int) ([]*model.ActorMovies, int, error) {
+			if query.Sort == "" {
+				return nil, 0, errors.New("sort not defaulted")
+			}
+			return []*model.ActorMovies{{Name: "Keanu Reeves", FilmCount: 1}}, limit + offset, nil
+		},
+		LoadMoviesFunc: func(actors []*model.ActorMovies) error {
+			for _, actor := range actors {
+				actor.Movies = []*model.Movie{{Title: "The Matrix"}}
+			}
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name             string
+		query            model.ActorQuery
+		page             int
+		pageSize         int
+		expectedPageSize int
+		expectedTotal    int
+		expectedMovies   int
+		expectedResult   error
+	}{
+		{
+			name:             "SuccessDefaultPage",
+			expectedPageSize: DefaultActorPageSize,
+			expectedTotal:    DefaultActorPageSize,
+		},
+		{
+			name:             "SuccessWithMovies",
+			query:            model.ActorQuery{Sort: "Film_Count", Descending: true, IncludeMovies: true},
+			page:             2,
+			pageSize:         5,
+			expectedPageSize: 5,
+			expectedTotal:    10,
+			expectedMovies:   1,
+		},
+		{
+			name:           "InvalidSort",
+			query:          model.ActorQuery{Sort: "age"},
+			expectedResult: ErrInvalidActorSort,
+		},
+		{
+			name:           "InvertedBirthYears",
+			query:          model.ActorQuery{MinBirthYear: 1990, MaxBirthYear: 1960},
+			expectedResult: ErrInvalidBirthYears,
+		},
+		{
+			name:           "PageSizeTooLarge",
+			pageSize:       MaxActorPageSize + 1,
+			expectedResult: ErrInvalidPage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := NewActorService(actorManager, nil, &mockHistoryManager{})
+
+			page, err := as.GetAll(tt.query, tt.page, tt.pageSize)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && (page.PageSize != tt.expectedPageSize || page.TotalActors != tt.expectedTotal ||
+				len(page.Actors) != 1 || len(page.Actors[0].Movies) != tt.expectedMovies) {
+				t.Errorf("Unexpected actor page: %+v", page)
+			}
+		})
+	}
+}
+
+func TestActorService_GetConnection(t *testing.T) {
+	t.Parallel()
+
+	// The cast of each movie: a chain from Kevin to Harry with a shortcut from Kevin to Eve,
+	// an isolated actor, and a separate chain of eight actors linked by seven movies.
+	actors := make(map[string]uuid.UUID)
+	names := make(map[uuid.UUID]string)
+	movies := make(map[string][]uuid.UUID)
+	cast := func(title string, actorNames ...string) {
+		for _, name := range actorNames {
+			if _, ok := actors[name]; !ok {
+				actors[name] = uuid.New()
+				names[actors[name]] = name
+			}
+			movies[title] = append(movies[title], actors[name])
+		}
+	}
+	cast("Footloose", "Kevin", "Bob")
+	cast("Tremors", "Bob", "Carol")
+	cast("Apollo 13", "Carol", "Dave")
+	cast("Mystic River", "Dave", "Eve")
+	cast("Frost/Nixon", "Eve", "Frank")
+	cast("Flatliners", "Frank", "Grace")
+	cast("Sleepers", "Grace", "Harry")
+	cast("Diner", "Kevin", "Eve")
+	cast("Solo", "Zoe")
+	for i := 1; i < 8; i++ {
+		cast(fmt.Sprintf("Sequel %d", i), fmt.Sprintf("Extra %d", i-1), fmt.Sprintf("Extra %d", i))
+	}
+
+	actorManager := &mockActorManager{
+		GetByIDFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+			name, ok := names[actorID]
+			if !ok {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Actor{ID: actorID, Name: name}, nil
+		},
+		GetCoStarsFunc: func(actorIDs []uuid.UUID) ([]model.CoStarLink, error) {
+			var links []model.CoStarLink
+			for title, movieCast := range movies {
+				for _, actorID := range actorIDs {
+					if !slices.Contains(movieCast, actorID) {
+						continue
+					}
+					for _, coStarID := range movieCast {
+						if coStarID != actorID {
+							links = append(links, model.CoStarLink{
+								ActorID: actorID, CoStarID: coStarID, CoStarName: names[coStarID], MovieTitle: title,
+							})
+						}
+					}
+				}
+			}
+			return links, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		source         uuid.UUID
+		target         uuid.UUID
+		expectedChain  []string
+		expectedResult error
+	}{
+		{
+			name:          "SameActor",
+			source:        actors["Kevin"],
+			target:        actors["Kevin"],
+			expectedChain: []string{"Kevin"},
+		},
+		{
+			name:          "CoStars",
+			source:        actors["Kevin"],
+			target:        actors["Bob"],
+			expectedChain: []string{"Kevin", "Footloose", "Bob"},
+		},
+		{
+			name:          "ThroughShortcut",
+			source:        actors["Harry"],
+			target:        actors["Kevin"],
+			expectedChain: []string{"Harry", "Sleepers", "Grace", "Flatliners", "Frank", "Frost/Nixon", "Eve", "Diner", "Kevin"},
+		},
+		{
+			name:   "SixDegrees",
+			source: actors["Extra 0"],
+			target: actors["Extra 6"],
+			expectedChain: []string{"Extra 0", "Sequel 1", "Extra 1", "Sequel 2", "Extra 2", "Sequel 3", "Extra 3",
+				"Sequel 4", "Extra 4", "Sequel 5", "Extra 5", "Sequel 6", "Extra 6"},
+		},
+		{
+			name:           "TooFar",
+			source:         actors["Extra 0"],
+			target:         actors["Extra 7"],
+			expectedResult: ErrNoConnection,
+		},
+		{
+			name:           "NotConnected",
+			source:         actors["Kevin"],
+			target:         actors["Zoe"],
+			expectedResult: ErrNoConnection,
+		},
+		{
+			name:           "ActorNotFound",
+			source:         actors["Kevin"],
+			target:         uuid.New(),
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := NewActorService(actorManager, nil, &mockHistoryManager{})
+
+			connection, err := as.GetConnection(tt.source, tt.target)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Fatalf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err != nil {
+				return
+			}
+			if connection.Degrees != len(connection.Steps)-1 ||
+				connection.Steps[0].ActorID != tt.source || connection.Steps[connection.Degrees].ActorID != tt.target {
+				t.Fatalf("Unexpected connection: %+v", connection)
+			}
+			chain := []string{connection.Steps[0].ActorName}
+			for _, step := range connection.Steps[1:] {
+				chain = append(chain, step.MovieTitle, step.ActorName)
+			}
+			if !slices.Equal(chain, tt.expectedChain) {
+				t.Errorf("Expected chain: %v, got: %v", tt.expectedChain, chain)
+			}
+		})
+	}
+}
+
+func TestActorService_GetFilmography(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+
+	mockManager := &mockActorManager{
+		GetFilmographyFunc: func(id uuid.UUID) (*model.Filmography, error) {
+			if id != actorID {
+				return nil, errors.New("actor not found")
+			}
+			granTorino := model.Movie{ID: uuid.New(), Title: "Gran Torino", Rating: 8,
+				ReleaseDate: time.Date(2008, 12, 12, 0, 0, 0, 0, time.UTC)}
+			unforgiven := model.Movie{ID: uuid.New(), Title: "Unforgiven", Rating: 8,
+				ReleaseDate: time.Date(1992, 8, 7, 0, 0, 0, 0, time.UTC)}
+			return &model.Filmography{
+				Person: model.Person{ID: actorID, Name: "Clint Eastwood",
+					BirthDate: time.Date(1930, 5, 31, 0, 0, 0, 0, time.UTC)},
+				Credits: map[string][]*model.FilmographyMovie{
+					model.RoleActor:    {{Movie: granTorino}, {Movie: unforgiven}},
+					model.RoleDirector: {{Movie: granTorino}},
+				},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name            string
+		actorID         uuid.UUID
+		expectedCredits map[string]int
+		expectedResult  error
+	}{
+		{
+			name:            "Success",
+			actorID:         actorID,
+			expectedCredits: map[string]int{model.RoleActor: 2, model.RoleDirector: 1},
+		},
+		{
+			name:           "ErrorActorNotFound",
+			actorID:        uuid.New(),
+			expectedResult: errors.New("actor not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actorSvc := NewActorService(mockManager, nil, &mockHistoryManager{})
+
+			filmography, err := actorSvc.GetFilmography(tt.actorID)
+
+			if err != nil {
+				if tt.expectedResult == nil || err.Error() != tt.expectedResult.Error() {
+					t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+				}
+				return
+			}
+
+			for role, count := range tt.expectedCredits {
+				if len(filmography.Credits[role]) != count {
+					t.Errorf("Expected %d %s credits, got %d", count, role, len(filmography.Credits[role]))
+				}
+			}
+			if age := filmography.Credits[model.RoleActor][1].AgeAtRelease; age != 62 {
+				t.Errorf("Expected age 62 at the release of Unforgiven, got %d", age)
+			}
+		})
+	}
+}
+
+func TestActorService_GetCareerTimeline(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+	emptyID := uuid.New()
+	movie := func(title string, year, rating int) *model.FilmographyMovie {
+		return &model.FilmographyMovie{Movie: model.Movie{
+			ID: uuid.New(), Title: title, Rating: rating, ReleaseDate: time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC),
+		}}
+	}
+	sinnerman, trilogy, sequel := movie("Sinnerman", 2001, 6), movie("Trilogy", 2001, 8), movie("Sequel", 2004, 7)
+
+	mockManager := &mockActorManager{
+		GetFilmographyFunc: func(id uuid.UUID) (*model.Filmography, error) {
+			switch id {
+			case actorID:
+				return &model.Filmography{
+					Person: model.Person{ID: actorID, BirthDate: time.Date(1980, 7, 1, 0, 0, 0, 0, time.UTC)},
+					Credits: map[string][]*model.FilmographyMovie{
+						model.RoleActor:    {sequel, trilogy, sinnerman},
+						model.RoleDirector: {sequel},
+					},
+				}, nil
+			case emptyID:
+				return &model.Filmography{Person: model.Person{ID: emptyID}}, nil
+			default:
+				return nil, sql.ErrNoRows
+			}
+		},
+	}
+	actorSvc := NewActorService(mockManager, nil, &mockHistoryManager{})
+
+	timeline, err := actorSvc.GetCareerTimeline(actorID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if timeline.FirstMovie != sinnerman || timeline.LastMovie != sequel || timeline.FirstMovie.AgeAtRelease != 20 {
+		t.Errorf("Unexpected first and last movies: %+v, %+v", timeline.FirstMovie, timeline.LastMovie)
+	}
+	if timeline.TotalMovies != 3 || timeline.ActiveYears != 2 || timeline.CareerSpan != 4 || timeline.AverageRating != 7 {
+		t.Errorf("Unexpected career summary: %+v", timeline)
+	}
+	expectedYears := []model.CareerYear{
+		{Year: 2001, Movies: 2, AverageRating: 7},
+		{Year: 2002},
+		{Year: 2003},
+		{Year: 2004, Movies: 1, AverageRating: 7},
+	}
+	if !slices.Equal(timeline.Years, expectedYears) {
+		t.Errorf("Expected years: %+v, got: %+v", expectedYears, timeline.Years)
+	}
+
+	timeline, err = actorSvc.GetCareerTimeline(emptyID)
+	if err != nil || timeline.TotalMovies != 0 || timeline.FirstMovie != nil || timeline.Years != nil {
+		t.Errorf("Unexpected timeline without movies: %+v, %v", timeline, err)
+	}
+
+	if _, err := actorSvc.GetCareerTimeline(uuid.New()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected error: %v, got: %v", sql.ErrNoRows, err)
+	}
+}
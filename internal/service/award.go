@@ -0,0 +1,291 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Limits of the award fields, matching the constraints of the awards, award_categories and nominations tables.
+const (
+	maxAwardNameLength        = 150
+	maxAwardDescriptionLength = 1000
+	maxNominationNoteLength   = 200
+)
+
+// Errors returned by the AwardService.
+var (
+	ErrInvalidAward         = errors.New("award name is required and must not exceed 150 characters, description must not exceed 1000 characters")
+	ErrAwardExists          = errors.New("an award with this name already exists")
+	ErrInvalidAwardCategory = errors.New("category name is required and must not exceed 150 characters")
+	ErrAwardCategoryExists  = errors.New("the award already has a category with this name")
+	ErrInvalidCeremony      = errors.New("ceremony year must be positive")
+	ErrCeremonyExists       = errors.New("the award already has a ceremony this year")
+	ErrInvalidNomination    = errors.New("nomination needs a movie and a category of the award of the ceremony, note must not exceed 200 characters")
+)
+
+// AwardService represents a service for managing awards, their categories, ceremonies and nominations.
+type AwardService interface {
+	Create(award *model.Award) error
+	GetAll() ([]*model.Award, error)
+	GetByID(awardID uuid.UUID) (*model.Award, error)
+	Update(awardID uuid.UUID, award model.Award) error
+	Delete(awardID uuid.UUID) error
+	CreateCategory(awardID uuid.UUID, category *model.AwardCategory) error
+	DeleteCategory(categoryID uuid.UUID) error
+	CreateCeremony(awardID uuid.UUID, ceremony *model.Ceremony) error
+	GetCeremonyByID(ceremonyID uuid.UUID) (*model.Ceremony, error)
+	DeleteCeremony(ceremonyID uuid.UUID) error
+	CreateNomination(ceremonyID uuid.UUID, nomination *model.Nomination) error
+	UpdateNomination(nominationID uuid.UUID, nomination model.Nomination) (*model.Nomination, error)
+	DeleteNomination(nominationID uuid.UUID) error
+}
+
+type awardService struct {
+	awardManager repository.AwardManager
+	movieManager repository.MovieManager
+	actorManager repository.ActorManager
+}
+
+// NewAwardService creates a new instance of the AwardService.
+func NewAwardService(awardManager repository.AwardManager, movieManager repository.MovieManager,
+	actorManager repository.ActorManager) AwardService {
+	return &awardService{
+		awardManager: awardManager,
+		movieManager: movieManager,
+		actorManager: actorManager,
+	}
+}
+
+// Create creates a new award without categories and ceremonies. Award names are unique.
+func (as *awardService) Create(award *model.Award) error {
+	if err := validateAward(award); err != nil {
+		return err
+	}
+	if err := as.checkAwardName(uuid.Nil, award.Name); err != nil {
+		return err
+	}
+
+	award.ID = uuid.New()
+	award.Categories = make([]model.AwardCategory, 0)
+	award.Ceremonies = make([]model.Ceremony, 0)
+
+	return as.awardManager.Create(award)
+}
+
+// GetAll retrieves all awards.
+func (as *awardService) GetAll() ([]*model.Award, error) {
+	return as.awardManager.GetAll()
+}
+
+// GetByID retrieves an award with its categories and ceremonies.
+func (as *awardService) GetByID(awardID uuid.UUID) (*model.Award, error) {
+	return as.awardManager.GetByID(awardID)
+}
+
+// Update updates the name and description of an existing award.
+func (as *awardService) Update(awardID uuid.UUID, award model.Award) error {
+	existingAward, err := as.awardManager.GetByID(awardID)
+	if err != nil {
+		return err
+	}
+
+	if award.Name != "" {
+		existingAward.Name = award.Name
+	}
+	if award.Description != "" {
+		existingAward.Description = award.Description
+	}
+
+	if err := validateAward(existingAward); err != nil {
+		return err
+	}
+	if err := as.checkAwardName(awardID, existingAward.Name); err != nil {
+		return err
+	}
+
+	return as.awardManager.Update(existingAward)
+}
+
+// Delete removes an award along with its categories, ceremonies and nominations.
+func (as *awardService) Delete(awardID uuid.UUID) error {
+	return as.awardManager.Delete(awardID)
+}
+
+// CreateCategory adds a category to an award. Category names are unique within an award.
+func (as *awardService) CreateCategory(awardID uuid.UUID, category *model.AwardCategory) error {
+	if strings.TrimSpace(category.Name) == "" || utf8.RuneCountInString(category.Name) > maxAwardNameLength {
+		return ErrInvalidAwardCategory
+	}
+
+	award, err := as.awardManager.GetByID(awardID)
+	if err != nil {
+		return err
+	}
+	for _, existingCategory := range award.Categories {
+		if strings.EqualFold(existingCategory.Name, category.Name) {
+			return ErrAwardCategoryExists
+		}
+	}
+
+	category.ID = uuid.New()
+	category.AwardID = awardID
+
+	return as.awardManager.CreateCategory(category)
+}
+
+// DeleteCategory removes an award category along with its nominations.
+func (as *awardService) DeleteCategory(categoryID uuid.UUID) error {
+	return as.awardManager.DeleteCategory(categoryID)
+}
+
+// CreateCeremony adds a ceremony to an award. An award holds at most one ceremony a year.
+func (as *awardService) CreateCeremony(awardID uuid.UUID, ceremony *model.Ceremony) error {
+	if ceremony.Year <= 0 {
+		return ErrInvalidCeremony
+	}
+
+	award, err := as.awardManager.GetByID(awardID)
+	if err != nil {
+		return err
+	}
+	for _, existingCeremony := range award.Ceremonies {
+		if existingCeremony.Year == ceremony.Year {
+			return ErrCeremonyExists
+		}
+	}
+
+	ceremony.ID = uuid.New()
+	ceremony.AwardID = awardID
+	ceremony.Nominations = make([]model.Nomination, 0)
+
+	return as.awardManager.CreateCeremony(ceremony)
+}
+
+// GetCeremonyByID retrieves a ceremony with its nominations.
+func (as *awardService) GetCeremonyByID(ceremonyID uuid.UUID) (*model.Ceremony, error) {
+	return as.awardManager.GetCeremonyByID(ceremonyID)
+}
+
+// DeleteCeremony removes a ceremony along with its nominations.
+func (as *awardService) DeleteCeremony(ceremonyID uuid.UUID) error {
+	return as.awardManager.DeleteCeremony(ceremonyID)
+}
+
+// CreateNomination nominates a movie, or a person for their work on a movie, in a category of a ceremony.
+func (as *awardService) CreateNomination(ceremonyID uuid.UUID, nomination *model.Nomination) error {
+	ceremony, err := as.awardManager.GetCeremonyByID(ceremonyID)
+	if err != nil {
+		return err
+	}
+
+	nomination.ID = uuid.New()
+	nomination.CeremonyID = ceremonyID
+	nomination.AwardID = ceremony.AwardID
+	nomination.Year = ceremony.Year
+
+	if err := as.resolveNomination(nomination); err != nil {
+		return err
+	}
+
+	return as.awardManager.CreateNomination(nomination)
+}
+
+// UpdateNomination updates an existing nomination. The category, movie and person are changed when given,
+// the outcome and note are always replaced.
+func (as *awardService) UpdateNomination(nominationID uuid.UUID, nomination model.Nomination) (*model.Nomination, error) {
+	existingNomination, err := as.awardManager.GetNominationByID(nominationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if nomination.CategoryID != uuid.Nil {
+		existingNomination.CategoryID = nomination.CategoryID
+	}
+	if nomination.MovieID != uuid.Nil {
+		existingNomination.MovieID = nomination.MovieID
+	}
+	if nomination.PersonID != nil {
+		existingNomination.PersonID = nomination.PersonID
+	}
+	existingNomination.Won = nomination.Won
+	existingNomination.Note = nomination.Note
+
+	if err := as.resolveNomination(existingNomination); err != nil {
+		return nil, err
+	}
+
+	if err := as.awardManager.UpdateNomination(existingNomination); err != nil {
+		return nil, err
+	}
+	return existingNomination, nil
+}
+
+// DeleteNomination removes a nomination.
+func (as *awardService) DeleteNomination(nominationID uuid.UUID) error {
+	return as.awardManager.DeleteNomination(nominationID)
+}
+
+// resolveNomination checks that the category of a nomination belongs to the award of its ceremony and that
+// its movie and person exist, filling in their names.
+func (as *awardService) resolveNomination(nomination *model.Nomination) error {
+	if nomination.MovieID == uuid.Nil || nomination.CategoryID == uuid.Nil ||
+		utf8.RuneCountInString(nomination.Note) > maxNominationNoteLength {
+		return ErrInvalidNomination
+	}
+
+	category, err := as.awardManager.GetCategoryByID(nomination.CategoryID)
+	if err != nil {
+		return err
+	}
+	if category.AwardID != nomination.AwardID {
+		return ErrInvalidNomination
+	}
+	nomination.CategoryName = category.Name
+
+	movie, err := as.movieManager.GetByID(nomination.MovieID)
+	if err != nil {
+		return err
+	}
+	nomination.MovieID = movie.ID
+	nomination.MovieTitle = movie.Title
+
+	nomination.PersonName = ""
+	if nomination.PersonID != nil {
+		person, err := as.actorManager.GetByID(*nomination.PersonID)
+		if err != nil {
+			return err
+		}
+		nomination.PersonID = &person.ID
+		nomination.PersonName = person.Name
+	}
+	return nil
+}
+
+// checkAwardName returns ErrAwardExists when an award other than awardID already has the name.
+func (as *awardService) checkAwardName(awardID uuid.UUID, name string) error {
+	awards, err := as.awardManager.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, award := range awards {
+		if award.ID != awardID && strings.EqualFold(award.Name, name) {
+			return ErrAwardExists
+		}
+	}
+	return nil
+}
+
+func validateAward(award *model.Award) error {
+	if strings.TrimSpace(award.Name) == "" ||
+		utf8.RuneCountInString(award.Name) > maxAwardNameLength ||
+		utf8.RuneCountInString(award.Description) > maxAwardDescriptionLength {
+		return ErrInvalidAward
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockAwardManager struct {
+	CreateFunc            func(award *model.Award) error
+	GetAllFunc            func() ([]*model.Award, error)
+	GetByIDFunc           func(awardID uuid.UUID) (*model.Award, error)
+	UpdateFunc            func(award *model.Award) error
+	DeleteFunc            func(awardID uuid.UUID) error
+	CreateCategoryFunc    func(category *model.AwardCategory) error
+	GetCategoryByIDFunc   func(categoryID uuid.UUID) (*model.AwardCategory, error)
+	DeleteCategoryFunc    func(categoryID uuid.UUID) error
+	CreateCeremonyFunc    func(ceremony *model.Ceremony) error
+	GetCeremonyByIDFunc   func(ceremonyID uuid.UUID) (*model.Ceremony, error)
+	DeleteCeremonyFunc    func(ceremonyID uuid.UUID) error
+	CreateNominationFunc  func(nomination *model.Nomination) error
+	GetNominationByIDFunc func(nominationID uuid.UUID) (*model.Nomination, error)
+	UpdateNominationFunc  func(nomination *model.Nomination) error
+	DeleteNominationFunc  func(nominationID uuid.UUID) error
+}
+
+func (m *mockAwardManager) Create(award *model.Award) error {
+	return m.CreateFunc(award)
+}
+
+func (m *mockAwardManager) GetAll() ([]*model.Award, error) {
+	return m.GetAllFunc()
+}
+
+func (m *mockAwardManager) GetByID(awardID uuid.UUID) (*model.Award, error) {
+	return m.GetByIDFunc(awardID)
+}
+
+func (m *mockAwardManager) Update(award *model.Award) error {
+	return m.UpdateFunc(award)
+}
+
+func (m *mockAwardManager) Delete(awardID uuid.UUID) error {
+	return m.DeleteFunc(awardID)
+}
+
+func (m *mockAwardManager) CreateCategory(category *model.AwardCategory) error {
+	return m.CreateCategoryFunc(category)
+}
+
+func (m *mockAwardManager) GetCategoryByID(categoryID uuid.UUID) (*model.AwardCategory, error) {
+	return m.GetCategoryByIDFunc(categoryID)
+}
+
+func (m *mockAwardManager) DeleteCategory(categoryID uuid.UUID) error {
+	return m.DeleteCategoryFunc(categoryID)
+}
+
+func (m *mockAwardManager) CreateCeremony(ceremony *model.Ceremony) error {
+	return m.CreateCeremonyFunc(ceremony)
+}
+
+func (m *mockAwardManager) GetCeremonyByID(ceremonyID uuid.UUID) (*model.Ceremony, error) {
+	return m.GetCeremonyByIDFunc(ceremonyID)
+}
+
+func (m *mockAwardManager) DeleteCeremony(ceremonyID uuid.UUID) error {
+	return m.DeleteCeremonyFunc(ceremonyID)
+}
+
+func (m *mockAwardManager) CreateNomination(nomination *model.Nomination) error {
+	return m.CreateNominationFunc(nomination)
+}
+
+func (m *mockAwardManager) GetNominationByID(nominationID uuid.UUID) (*model.Nomination, error) {
+	return m.GetNominationByIDFunc(nominationID)
+}
+
+func (m *mockAwardManager) UpdateNomination(nomination *model.Nomination) error {
+	return m.UpdateNominationFunc(nomination)
+}
+
+func (m *mockAwardManager) DeleteNomination(nominationID uuid.UUID) error {
+	return m.DeleteNominationFunc(nominationID)
+}
+
+func TestAwardService_Create(t *testing.T) {
+	t.Parallel()
+
+	awardManager := &mockAwardManager{
+		GetAllFunc: func() ([]*model.Award, error) {
+			return []*model.Award{{ID: uuid.New(), Name: "Academy Awards"}}, nil
+		},
+		CreateFunc: func(award *model.Award) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		award          *model.Award
+		expectedResult error
+	}{
+		{
+			name:  "Success",
+			award: &model.Award{Name: "Cannes Film Festival"},
+		},
+		{
+			name:           "MissingName",
+			award:          &model.Award{Name: "  "},
+			expectedResult: ErrInvalidAward,
+		},
+		{
+			name:           "DuplicateName",
+			award:          &model.Award{Name: "academy awards"},
+			expectedResult: ErrAwardExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := NewAwardService(awardManager, &mockMovieManager{}, &mockActorManager{})
+
+			err := as.Create(tt.award)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && tt.award.ID == uuid.Nil {
+				t.Errorf("Expected the award to get an ID")
+			}
+		})
+	}
+}
+
+func TestAwardService_CreateCeremony(t *testing.T) {
+	t.Parallel()
+
+	awardID := uuid.New()
+	awardManager := &mockAwardManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Award, error) {
+			if id != awardID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Award{ID: awardID, Ceremonies: []model.Ceremony{{Year: 2024}}}, nil
+		},
+		CreateCeremonyFunc: func(ceremony *model.Ceremony) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		awardID        uuid.UUID
+		ceremony       *model.Ceremony
+		expectedResult error
+	}{
+		{name: "Success", awardID: awardID, ceremony: &model.Ceremony{Year: 2025}},
+		{name: "InvalidYear", awardID: awardID, ceremony: &model.Ceremony{}, expectedResult: ErrInvalidCeremony},
+		{name: "DuplicateYear", awardID: awardID, ceremony: &model.Ceremony{Year: 2024}, expectedResult: ErrCeremonyExists},
+		{name: "AwardNotFound", awardID: uuid.New(), ceremony: &model.Ceremony{Year: 2025}, expectedResult: sql.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := NewAwardService(awardManager, &mockMovieManager{}, &mockActorManager{})
+
+			err := as.CreateCeremony(tt.awardID, tt.ceremony)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
+
+func TestAwardService_CreateNomination(t *testing.T) {
+	t.Parallel()
+
+	awardID := uuid.New()
+	ceremonyID := uuid.New()
+	categoryID := uuid.New()
+	otherCategoryID := uuid.New()
+	movieID := uuid.New()
+	personID := uuid.New()
+
+	awardManager := &mockAwardManager{
+		GetCeremonyByIDFunc: func(id uuid.UUID) (*model.Ceremony, error) {
+			return &model.Ceremony{ID: ceremonyID, AwardID: awardID, Year: 2024}, nil
+		},
+		GetCategoryByIDFunc: func(id uuid.UUID) (*model.AwardCategory, error) {
+			switch id {
+			case categoryID:
+				return &model.AwardCategory{ID: categoryID, AwardID: awardID, Name: "Best Actor"}, nil
+			case otherCategoryID:
+				return &model.AwardCategory{ID: otherCategoryID, AwardID: uuid.New(), Name: "Palme d'Or"}, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+		CreateNominationFunc: func(nomination *model.Nomination) error {
+			return nil
+		},
+	}
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Movie, error) {
+			if id != movieID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Movie{ID: movieID, Title: "Oppenheimer"}, nil
+		},
+	}
+	actorManager := &mockActorManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Actor, error) {
+			if id != personID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Actor{ID: personID, Name: "Cillian Murphy"}, nil
+		},
+	}
+	unknownPersonID := uuid.New()
+
+	tests := []struct {
+		name           string
+		nomination     *model.Nomination
+		expectedResult error
+	}{
+		{
+			name:       "Success",
+			nomination: &model.Nomination{CategoryID: categoryID, MovieID: movieID, PersonID: &personID, Won: true},
+		},
+		{
+			name:           "MissingMovie",
+			nomination:     &model.Nomination{CategoryID: categoryID},
+			expectedResult: ErrInvalidNomination,
+		},
+		{
+			name:           "CategoryOfAnotherAward",
+			nomination:     &model.Nomination{CategoryID: otherCategoryID, MovieID: movieID},
+			expectedResult: ErrInvalidNomination,
+		},
+		{
+			name:           "PersonNotFound",
+			nomination:     &model.Nomination{CategoryID: categoryID, MovieID: movieID, PersonID: &unknownPersonID},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := NewAwardService(awardManager, movieManager, actorManager)
+
+			err := as.CreateNomination(ceremonyID, tt.nomination)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && (tt.nomination.CategoryName != "Best Actor" || tt.nomination.MovieTitle != "Oppenheimer" ||
+				tt.nomination.PersonName != "Cillian Murphy" || tt.nomination.Year != 2024) {
+				t.Errorf("Expected the nomination to be resolved, got: %+v", tt.nomination)
+			}
+		})
+	}
+}
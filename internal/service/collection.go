@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Limits of the collection fields, matching the constraints of the collections table.
+const (
+	maxCollectionNameLength        = 150
+	maxCollectionDescriptionLength = 1000
+)
+
+// Errors returned by the CollectionService.
+var (
+	ErrInvalidCollection         = errors.New("collection name is required and must not exceed 150 characters, description must not exceed 1000 characters")
+	ErrInvalidCollectionOrdering = errors.New("collection ordering must be release or chronological")
+	ErrInvalidChronologicalOrder = errors.New("chronological order must not be negative")
+)
+
+// CollectionService represents a service for managing franchises and other collections of movies.
+type CollectionService interface {
+	Create(collection *model.Collection) error
+	GetByID(collectionID uuid.UUID, ordering string) (*model.Collection, error)
+	GetAll() ([]*model.Collection, error)
+	Update(collectionID uuid.UUID, collection model.Collection) error
+	Delete(collectionID uuid.UUID) error
+	SetMovie(collectionID uuid.UUID, movie *model.CollectionMovie) error
+	RemoveMovie(collectionID, movieID uuid.UUID) error
+}
+
+type collectionService struct {
+	collectionManager repository.CollectionManager
+	movieManager      repository.MovieManager
+}
+
+// NewCollectionService creates a new instance of the CollectionService.
+func NewCollectionService(collectionManager repository.CollectionManager,
+	movieManager repository.MovieManager) CollectionService {
+	return &collectionService{
+		collectionManager: collectionManager,
+		movieManager:      movieManager,
+	}
+}
+
+// Create creates a new collection, ordered by release unless another ordering is given.
+func (cs *collectionService) Create(collection *model.Collection) error {
+	if collection.Ordering == "" {
+		collection.Ordering = model.CollectionOrderRelease
+	}
+	if err := validateCollection(collection); err != nil {
+		return err
+	}
+
+	collection.ID = uuid.New()
+	collection.Movies = make([]model.CollectionMovie, 0)
+
+	return cs.collectionManager.Create(collection)
+}
+
+// GetByID retrieves a collection with its movies in the given ordering, or in its default ordering if none is given.
+func (cs *collectionService) GetByID(collectionID uuid.UUID, ordering string) (*model.Collection, error) {
+	if ordering != "" && !isCollectionOrdering(ordering) {
+		return nil, ErrInvalidCollectionOrdering
+	}
+
+	return cs.collectionManager.GetByID(collectionID, ordering)
+}
+
+// GetAll retrieves all collections without their movies.
+func (cs *collectionService) GetAll() ([]*model.Collection, error) {
+	return cs.collectionManager.GetAll()
+}
+
+// Update updates an existing collection.
+func (cs *collectionService) Update(collectionID uuid.UUID, collection model.Collection) error {
+	existingCollection, err := cs.collectionManager.GetByID(collectionID, "")
+	if err != nil {
+		return err
+	}
+
+	if collection.Name != "" {
+		existingCollection.Name = collection.Name
+	}
+	existingCollection.Description = collection.Description
+	if collection.Ordering != "" {
+		existingCollection.Ordering = collection.Ordering
+	}
+
+	if err := validateCollection(existingCollection); err != nil {
+		return err
+	}
+
+	return cs.collectionManager.Update(existingCollection)
+}
+
+// Delete removes a collection. The movies of the collection are kept.
+func (cs *collectionService) Delete(collectionID uuid.UUID) error {
+	return cs.collectionManager.Delete(collectionID)
+}
+
+// SetMovie adds a movie to a collection or changes its chronological order, moving it out of any other collection.
+// A movie without chronological order is placed last in the chronology.
+func (cs *collectionService) SetMovie(collectionID uuid.UUID, movie *model.CollectionMovie) error {
+	if movie.ChronologicalOrder < 0 {
+		return ErrInvalidChronologicalOrder
+	}
+
+	if _, err := cs.collectionManager.GetByID(collectionID, ""); err != nil {
+		return err
+	}
+	existingMovie, err := cs.movieManager.GetByID(movie.MovieID)
+	if err != nil {
+		return err
+	}
+	movie.MovieID = existingMovie.ID
+
+	if err := cs.collectionManager.SetMovie(collectionID, movie); err != nil {
+		return err
+	}
+	movie.Title = existingMovie.Title
+	movie.ReleaseDate = existingMovie.ReleaseDate
+	return nil
+}
+
+// RemoveMovie removes a movie from a collection.
+func (cs *collectionService) RemoveMovie(collectionID, movieID uuid.UUID) error {
+	return cs.collectionManager.RemoveMovie(collectionID, movieID)
+}
+
+func validateCollection(collection *model.Collection) error {
+	if strings.TrimSpace(collection.Name) == "" ||
+		utf8.RuneCountInString(collection.Name) > maxCollectionNameLength ||
+		utf8.RuneCountInString(collection.Description) > maxCollectionDescriptionLength {
+		return ErrInvalidCollection
+	}
+	if !isCollectionOrdering(collection.Ordering) {
+		return ErrInvalidCollectionOrdering
+	}
+	return nil
+}
+
+func isCollectionOrdering(ordering string) bool {
+	return ordering == model.CollectionOrderRelease || ordering == model.CollectionOrderChronological
+}
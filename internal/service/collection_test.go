@@ -0,0 +1,196 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockCollectionManager struct {
+	CreateFunc      func(collection *model.Collection) error
+	GetByIDFunc     func(collectionID uuid.UUID, ordering string) (*model.Collection, error)
+	GetAllFunc      func() ([]*model.Collection, error)
+	UpdateFunc      func(collection *model.Collection) error
+	DeleteFunc      func(collectionID uuid.UUID) error
+	SetMovieFunc    func(collectionID uuid.UUID, movie *model.CollectionMovie) error
+	RemoveMovieFunc func(collectionID, movieID uuid.UUID) error
+}
+
+func (m *mockCollectionManager) Create(collection *model.Collection) error {
+	return m.CreateFunc(collection)
+}
+
+func (m *mockCollectionManager) GetByID(collectionID uuid.UUID, ordering string) (*model.Collection, error) {
+	return m.GetByIDFunc(collectionID, ordering)
+}
+
+func (m *mockCollectionManager) GetAll() ([]*model.Collection, error) {
+	return m.GetAllFunc()
+}
+
+func (m *mockCollectionManager) Update(collection *model.Collection) error {
+	return m.UpdateFunc(collection)
+}
+
+func (m *mockCollectionManager) Delete(collectionID uuid.UUID) error {
+	return m.DeleteFunc(collectionID)
+}
+
+func (m *mockCollectionManager) SetMovie(collectionID uuid.UUID, movie *model.CollectionMovie) error {
+	return m.SetMovieFunc(collectionID, movie)
+}
+
+func (m *mockCollectionManager) RemoveMovie(collectionID, movieID uuid.UUID) error {
+	return m.RemoveMovieFunc(collectionID, movieID)
+}
+
+func TestCollectionService_Create(t *testing.T) {
+	t.Parallel()
+
+	collectionManager := &mockCollectionManager{
+		CreateFunc: func(collection *model.Collection) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name             string
+		collection       *model.Collection
+		expectedOrdering string
+		expectedResult   error
+	}{
+		{
+			name:             "SuccessDefaultOrdering",
+			collection:       &model.Collection{Name: "The Lord of the Rings"},
+			expectedOrdering: model.CollectionOrderRelease,
+		},
+		{
+			name:             "SuccessChronological",
+			collection:       &model.Collection{Name: "Star Wars", Ordering: model.CollectionOrderChronological},
+			expectedOrdering: model.CollectionOrderChronological,
+		},
+		{
+			name:           "MissingName",
+			collection:     &model.Collection{Name: " "},
+			expectedResult: ErrInvalidCollection,
+		},
+		{
+			name:           "InvalidOrdering",
+			collection:     &model.Collection{Name: "MCU", Ordering: "alphabetical"},
+			expectedResult: ErrInvalidCollectionOrdering,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := NewCollectionService(collectionManager, &mockMovieManager{})
+
+			err := cs.Create(tt.collection)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && tt.collection.Ordering != tt.expectedOrdering {
+				t.Errorf("Expected ordering: %s, got: %s", tt.expectedOrdering, tt.collection.Ordering)
+			}
+		})
+	}
+}
+
+func TestCollectionService_SetMovie(t *testing.T) {
+	t.Parallel()
+
+	collectionID := uuid.New()
+	movieID := uuid.New()
+
+	collectionManager := &mockCollectionManager{
+		GetByIDFunc: func(id uuid.UUID, ordering string) (*model.Collection, error) {
+			if id != collectionID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Collection{ID: collectionID, Name: "MCU", Ordering: model.CollectionOrderRelease}, nil
+		},
+		SetMovieFunc: func(id uuid.UUID, movie *model.CollectionMovie) error {
+			if movie.ChronologicalOrder == 0 {
+				movie.ChronologicalOrder = 3
+			}
+			return nil
+		},
+	}
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Movie, error) {
+			if id != movieID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Movie{ID: movieID, Title: "Iron Man"}, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		collectionID   uuid.UUID
+		movie          *model.CollectionMovie
+		expectedOrder  int
+		expectedResult error
+	}{
+		{
+			name:          "SuccessPlacedLast",
+			collectionID:  collectionID,
+			movie:         &model.CollectionMovie{MovieID: movieID},
+			expectedOrder: 3,
+		},
+		{
+			name:          "SuccessWithOrder",
+			collectionID:  collectionID,
+			movie:         &model.CollectionMovie{MovieID: movieID, ChronologicalOrder: 1},
+			expectedOrder: 1,
+		},
+		{
+			name:           "NegativeOrder",
+			collectionID:   collectionID,
+			movie:          &model.CollectionMovie{MovieID: movieID, ChronologicalOrder: -1},
+			expectedResult: ErrInvalidChronologicalOrder,
+		},
+		{
+			name:           "CollectionNotFound",
+			collectionID:   uuid.New(),
+			movie:          &model.CollectionMovie{MovieID: movieID},
+			expectedResult: sql.ErrNoRows,
+		},
+		{
+			name:           "MovieNotFound",
+			collectionID:   collectionID,
+			movie:          &model.CollectionMovie{MovieID: uuid.New()},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := NewCollectionService(collectionManager, movieManager)
+
+			err := cs.SetMovie(tt.collectionID, tt.movie)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && (tt.movie.ChronologicalOrder != tt.expectedOrder || tt.movie.Title != "Iron Man") {
+				t.Errorf("Unexpected collection movie: %+v", tt.movie)
+			}
+		})
+	}
+}
+
+func TestCollectionService_GetByIDInvalidOrdering(t *testing.T) {
+	t.Parallel()
+
+	cs := NewCollectionService(&mockCollectionManager{}, &mockMovieManager{})
+
+	if _, err := cs.GetByID(uuid.New(), "alphabetical"); !errors.Is(err, ErrInvalidCollectionOrdering) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidCollectionOrdering, err)
+	}
+}
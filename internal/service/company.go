@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Limits of the company fields, matching the constraints of the companies table.
+const (
+	maxCompanyNameLength        = 150
+	maxCompanyDescriptionLength = 1000
+)
+
+// Page sizes of the movies of a company.
+const (
+	DefaultCompanyPageSize = 20
+	MaxCompanyPageSize     = 100
+)
+
+// Errors returned by the CompanyService.
+var (
+	ErrInvalidCompanyRole = errors.New("company role must be production or distribution")
+	ErrInvalidPage        = errors.New("page must be positive and page size between 1 and 100")
+)
+
+// CompanyService represents a service for managing studios and production companies.
+type CompanyService interface {
+	Create(company *model.Company) error
+	GetByID(companyID uuid.UUID, role string, page, pageSize int) (*model.CompanyPage, error)
+	GetAll() ([]*model.Company, error)
+	Update(companyID uuid.UUID, company model.Company) error
+	Delete(companyID uuid.UUID) error
+}
+
+type companyService struct {
+	companyManager repository.CompanyManager
+}
+
+// NewCompanyService creates a new instance of the CompanyService.
+func NewCompanyService(companyManager repository.CompanyManager) CompanyService {
+	return &companyService{
+		companyManager: companyManager,
+	}
+}
+
+// Create creates a new company.
+func (cs *companyService) Create(company *model.Company) error {
+	company.Country = strings.ToUpper(company.Country)
+	if err := validateCompany(company); err != nil {
+		return err
+	}
+
+	company.ID = uuid.New()
+
+	return cs.companyManager.Create(company)
+}
+
+// GetByID retrieves a company with a page of the movies it worked on in the given role, or in any role when it is
+// empty. Pages start at 1; zero page and page size stand for the first page and the default page size.
+func (cs *companyService) GetByID(companyID uuid.UUID, role string, page, pageSize int) (*model.CompanyPage, error) {
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = DefaultCompanyPageSize
+	}
+	if page < 0 || pageSize < 0 || pageSize > MaxCompanyPageSize {
+		return nil, ErrInvalidPage
+	}
+	role = strings.ToLower(role)
+	if role != "" && !isCompanyRole(role) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCompanyRole, role)
+	}
+
+	company, err := cs.companyManager.GetByID(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, total, err := cs.companyManager.GetMovies(companyID, role, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CompanyPage{
+		Company:     *company,
+		Movies:      movies,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalMovies: total,
+	}, nil
+}
+
+// GetAll retrieves all companies.
+func (cs *companyService) GetAll() ([]*model.Company, error) {
+	return cs.companyManager.GetAll()
+}
+
+// Update updates an existing company.
+func (cs *companyService) Update(companyID uuid.UUID, company model.Company) error {
+	existingCompany, err := cs.companyManager.GetByID(companyID)
+	if err != nil {
+		return err
+	}
+
+	if company.Name != "" {
+		existingCompany.Name = company.Name
+	}
+	existingCompany.Country = strings.ToUpper(company.Country)
+	existingCompany.Description = company.Description
+
+	if err := validateCompany(existingCompany); err != nil {
+		return err
+	}
+
+	return cs.companyManager.Update(existingCompany)
+}
+
+// Delete removes a company. The movies of the company are kept.
+func (cs *companyService) Delete(companyID uuid.UUID) error {
+	return cs.companyManager.Delete(companyID)
+}
+
+func isCompanyRole(role string) bool {
+	return companyRoles[role]
+}
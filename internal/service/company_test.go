@@ -0,0 +1,189 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockCompanyManager struct {
+	CreateFunc    func(company *model.Company) error
+	GetByIDFunc   func(companyID uuid.UUID) (*model.Company, error)
+	GetAllFunc    func() ([]*model.Company, error)
+	UpdateFunc    func(company *model.Company) error
+	DeleteFunc    func(companyID uuid.UUID) error
+	GetMoviesFunc func(companyID uuid.UUID, role string, limit, offset int) ([]model.CompanyMovie, int, error)
+}
+
+func (m *mockCompanyManager) Create(company *model.Company) error {
+	return m.CreateFunc(company)
+}
+
+func (m *mockCompanyManager) GetByID(companyID uuid.UUID) (*model.Company, error) {
+	return m.GetByIDFunc(companyID)
+}
+
+func (m *mockCompanyManager) GetAll() ([]*model.Company, error) {
+	return m.GetAllFunc()
+}
+
+func (m *mockCompanyManager) Update(company *model.Company) error {
+	return m.UpdateFunc(company)
+}
+
+func (m *mockCompanyManager) Delete(companyID uuid.UUID) error {
+	return m.DeleteFunc(companyID)
+}
+
+func (m *mockCompanyManager) GetMovies(companyID uuid.UUID, role string, limit, offset int) ([]model.CompanyMovie, int, error) {
+	return m.GetMoviesFunc(companyID, role, limit, offset)
+}
+
+func TestCompanyService_Create(t *testing.T) {
+	t.Parallel()
+
+	companyManager := &mockCompanyManager{
+		CreateFunc: func(company *model.Company) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name            string
+		company         *model.Company
+		expectedCountry string
+		expectedResult  error
+	}{
+		{
+			name:            "Success",
+			company:         &model.Company{Name: "Warner Bros.", Country: "us"},
+			expectedCountry: "US",
+		},
+		{
+			name:           "MissingName",
+			company:        &model.Company{Name: " "},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "UnknownCountry",
+			company:        &model.Company{Name: "Studio Ghibli", Country: "XX"},
+			expectedResult: ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := NewCompanyService(companyManager)
+
+			err := cs.Create(tt.company)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && (tt.company.ID == uuid.Nil || tt.company.Country != tt.expectedCountry) {
+				t.Errorf("Unexpected company: %+v", tt.company)
+			}
+		})
+	}
+}
+
+func TestCompanyService_GetByID(t *testing.T) {
+	t.Parallel()
+
+	companyID := uuid.New()
+	companyManager := &mockCompanyManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Company, error) {
+			if id != companyID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Company{ID: companyID, Name: "Pixar"}, nil
+		},
+		GetMoviesFunc: func(id uuid.UUID, role string, limit, offset int) ([]model.CompanyMovie, int, error) {
+			return []model.CompanyMovie{{Title: "Toy Story", Roles: []string{model.CompanyRoleProduction}}}, limit + offset, nil
+		},
+	}
+
+	tests := []struct {
+		name             string
+		companyID        uuid.UUID
+		role             string
+		page             int
+		pageSize         int
+		expectedPageSize int
+		expectedTotal    int
+		expectedResult   error
+	}{
+		{
+			name:             "SuccessDefaultPage",
+			companyID:        companyID,
+			expectedPageSize: DefaultCompanyPageSize,
+			expectedTotal:    DefaultCompanyPageSize,
+		},
+		{
+			name:             "SuccessThirdPage",
+			companyID:        companyID,
+			role:             "Production",
+			page:             3,
+			pageSize:         10,
+			expectedPageSize: 10,
+			expectedTotal:    30,
+		},
+		{
+			name:           "PageSizeTooLarge",
+			companyID:      companyID,
+			pageSize:       MaxCompanyPageSize + 1,
+			expectedResult: ErrInvalidPage,
+		},
+		{
+			name:           "NegativePage",
+			companyID:      companyID,
+			page:           -1,
+			expectedResult: ErrInvalidPage,
+		},
+		{
+			name:           "InvalidRole",
+			companyID:      companyID,
+			role:           "financing",
+			expectedResult: ErrInvalidCompanyRole,
+		},
+		{
+			name:           "CompanyNotFound",
+			companyID:      uuid.New(),
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := NewCompanyService(companyManager)
+
+			page, err := cs.GetByID(tt.companyID, tt.role, tt.page, tt.pageSize)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && (page.Name != "Pixar" || page.PageSize != tt.expectedPageSize ||
+				page.TotalMovies != tt.expectedTotal || len(page.Movies) != 1) {
+				t.Errorf("Unexpected company page: %+v", page)
+			}
+		})
+	}
+}
+
+func TestMovieService_CreateInvalidCompanyRole(t *testing.T) {
+	t.Parallel()
+
+	ms := NewMovieService(&mockMovieManager{}, &mockHistoryManager{})
+
+	err := ms.Create(&model.Movie{
+		Title:     "Toy Story",
+		Companies: []model.MovieCompany{{CompanyID: uuid.New(), Role: "financing"}},
+	}, "admin")
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected error: %v, got: %v", ErrValidation, err)
+	}
+}
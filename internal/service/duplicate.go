@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// MaxDuplicateYearGap is the largest difference between the release years of movies with the same title
+// for them to be reported as duplicates, release dates often differ by country.
+const MaxDuplicateYearGap = 1
+
+// ErrInvalidMerge is returned when an actor or a movie is merged into itself.
+var ErrInvalidMerge = errors.New("an actor or a movie cannot be merged into itself")
+
+// DuplicateService represents a service for finding and merging duplicate actors and movies.
+type DuplicateService interface {
+	GetActorDuplicates() ([]model.DuplicateGroup, error)
+	GetMovieDuplicates() ([]model.DuplicateGroup, error)
+	MergeActors(sourceID, targetID uuid.UUID, username string) (*model.Actor, error)
+	MergeMovies(sourceID, targetID uuid.UUID, username string) (*model.Movie, error)
+}
+
+type duplicateService struct {
+	duplicateManager repository.DuplicateManager
+	actorManager     repository.ActorManager
+	movieManager     repository.MovieManager
+}
+
+// NewDuplicateService creates a new instance of the DuplicateService.
+func NewDuplicateService(duplicateManager repository.DuplicateManager, actorManager repository.ActorManager,
+	movieManager repository.MovieManager) DuplicateService {
+	return &duplicateService{
+		duplicateManager: duplicateManager,
+		actorManager:     actorManager,
+		movieManager:     movieManager,
+	}
+}
+
+// GetActorDuplicates reports the actors sharing the same normalized name and birth date.
+func (ds *duplicateService) GetActorDuplicates() ([]model.DuplicateGroup, error) {
+	records, err := ds.duplicateManager.GetActorRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*model.DuplicateGroup)
+	var keys []string
+	for _, record := range records {
+		name := normalizeName(record.Name)
+		key := name + "|" + record.Date.Format("2006-01-02")
+		if groups[key] == nil {
+			groups[key] = &model.DuplicateGroup{Key: name}
+			keys = append(keys, key)
+		}
+		groups[key].Records = append(groups[key].Records, record)
+	}
+	sort.Strings(keys)
+
+	duplicates := make([]model.DuplicateGroup, 0)
+	for _, key := range keys {
+		if len(groups[key].Records) > 1 {
+			duplicates = append(duplicates, *groups[key])
+		}
+	}
+	return duplicates, nil
+}
+
+// GetMovieDuplicates reports the movies sharing the same normalized title and released within
+// MaxDuplicateYearGap years of each other.
+func (ds *duplicateService) GetMovieDuplicates() ([]model.DuplicateGroup, error) {
+	records, err := ds.duplicateManager.GetMovieRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string][]model.DuplicateRecord)
+	var keys []string
+	for _, record := range records {
+		title := normalizeName(record.Name)
+		if titles[title] == nil {
+			keys = append(keys, title)
+		}
+		titles[title] = append(titles[title], record)
+	}
+	sort.Strings(keys)
+
+	duplicates := make([]model.DuplicateGroup, 0)
+	for _, key := range keys {
+		movies := titles[key]
+		sort.SliceStable(movies, func(i, j int) bool {
+			return movies[i].Date.Before(movies[j].Date)
+		})
+
+		// Movies are chained while the gap between consecutive release years stays small,
+		// so that remakes released decades apart are not reported.
+		start := 0
+		for i := 1; i <= len(movies); i++ {
+			if i < len(movies) && movies[i].Date.Year()-movies[i-1].Date.Year() <= MaxDuplicateYearGap {
+				continue
+			}
+			if i-start > 1 {
+				duplicates = append(duplicates, model.DuplicateGroup{Key: key, Records: movies[start:i]})
+			}
+			start = i
+		}
+	}
+	return duplicates, nil
+}
+
+// MergeActors merges the source actor into the target actor and returns the merged actor, recording the change made
+// by the user to both actors in the history. The ID of the source actor keeps resolving to the target actor.
+func (ds *duplicateService) MergeActors(sourceID, targetID uuid.UUID, username string) (*model.Actor, error) {
+	source, err := ds.actorManager.GetByID(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := ds.actorManager.GetByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		return nil, ErrInvalidMerge
+	}
+	if target.DeathDate.IsZero() && !source.DeathDate.IsZero() && source.DeathDate.Before(target.BirthDate) {
+		return nil, ErrInvalidLifeDates
+	}
+
+	history := func(merged *model.Actor) ([]*model.HistoryEntry, error) {
+		entries, err := changeEntries(model.HistoryActor, source.ID, model.HistoryMerge, username,
+			newActorVersion(source), nil)
+		if err != nil {
+			return nil, err
+		}
+		targetEntries, err := changeEntries(model.HistoryActor, target.ID, model.HistoryMerge, username,
+			newActorVersion(target), newActorVersion(merged))
+		if err != nil {
+			return nil, err
+		}
+		return append(entries, targetEntries...), nil
+	}
+	if err := ds.duplicateManager.MergeActors(source.ID, target.ID, history); err != nil {
+		return nil, err
+	}
+
+	return ds.actorManager.GetByID(target.ID)
+}
+
+// MergeMovies merges the source movie into the target movie and returns the merged movie, recording the change made
+// by the user to both movies and their casts in the history. The ID of the source movie keeps resolving to the target
+// movie.
+func (ds *duplicateService) MergeMovies(sourceID, targetID uuid.UUID, username string) (*model.Movie, error) {
+	source, err := ds.movieManager.GetByID(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := ds.movieManager.GetByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		return nil, ErrInvalidMerge
+	}
+
+	history := func(merged *model.Movie) ([]*model.HistoryEntry, error) {
+		entries, err := movieChangeEntries(model.HistoryMerge, username, source.ID, source, nil)
+		if err != nil {
+			return nil, err
+		}
+		targetEntries, err := movieChangeEntries(model.HistoryMerge, username, target.ID, target, merged)
+		if err != nil {
+			return nil, err
+		}
+		return append(entries, targetEntries...), nil
+	}
+	if err := ds.duplicateManager.MergeMovies(source.ID, target.ID, history); err != nil {
+		return nil, err
+	}
+
+	return ds.movieManager.GetByID(target.ID)
+}
+
+// normalizeName lowers the case of a name or a title, drops its punctuation and collapses its spaces,
+// so that "Keanu  Reeves" and "keanu reeves." compare equal.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+type mockDuplicateManager struct {
+	GetActorRecordsFunc func() ([]model.DuplicateRecord, error)
+	GetMovieRecordsFunc func() ([]model.DuplicateRecord, error)
+	MergeActorsFunc     func(sourceID, targetID uuid.UUID, history repository.ActorHistory) error
+	MergeMoviesFunc     func(sourceID, targetID uuid.UUID, history repository.MovieHistory) error
+}
+
+func (m *mockDuplicateManager) GetActorRecords() ([]model.DuplicateRecord, error) {
+	return m.GetActorRecordsFunc()
+}
+
+func (m *mockDuplicateManager) GetMovieRecords() ([]model.DuplicateRecord, error) {
+	return m.GetMovieRecordsFunc()
+}
+
+func (m *mockDuplicateManager) MergeActors(sourceID, targetID uuid.UUID, history repository.ActorHistory) error {
+	return m.MergeActorsFunc(sourceID, targetID, history)
+}
+
+func (m *mockDuplicateManager) MergeMovies(sourceID, targetID uuid.UUID, history repository.MovieHistory) error {
+	return m.MergeMoviesFunc(sourceID, targetID, history)
+}
+
+func TestDuplicateService_GetActorDuplicates(t *testing.T) {
+	t.Parallel()
+
+	birthDate := time.Date(1964, time.September, 2, 0, 0, 0, 0, time.UTC)
+	records := []model.DuplicateRecord{
+		{ID: uuid.New(), Name: "Keanu Reeves", Date: birthDate},
+		{ID: uuid.New(), Name: "keanu  reeves.", Date: birthDate},
+		{ID: uuid.New(), Name: "Keanu Reeves", Date: birthDate.AddDate(1, 0, 0)},
+		{ID: uuid.New(), Name: "Carrie-Anne Moss", Date: time.Date(1967, time.August, 21, 0, 0, 0, 0, time.UTC)},
+	}
+
+	ds := NewDuplicateService(&mockDuplicateManager{
+		GetActorRecordsFunc: func() ([]model.DuplicateRecord, error) {
+			return records, nil
+		},
+	}, nil, nil)
+
+	duplicates, err := ds.GetActorDuplicates()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(duplicates) != 1 {
+		t.Fatalf("Expected 1 duplicate group, got: %d", len(duplicates))
+	}
+	if duplicates[0].Key != "keanu reeves" || len(duplicates[0].Records) != 2 {
+		t.Errorf("Expected 2 records for keanu reeves, got: %+v", duplicates[0])
+	}
+}
+
+func TestDuplicateService_GetMovieDuplicates(t *testing.T) {
+	t.Parallel()
+
+	releaseDate := func(year int) time.Time {
+		return time.Date(year, time.March, 31, 0, 0, 0, 0, time.UTC)
+	}
+	records := []model.DuplicateRecord{
+		{ID: uuid.New(), Name: "The Matrix", Date: releaseDate(1999)},
+		{ID: uuid.New(), Name: "the matrix", Date: releaseDate(2000)},
+		{ID: uuid.New(), Name: "The Matrix", Date: releaseDate(2021)},
+		{ID: uuid.New(), Name: "Solaris", Date: releaseDate(1972)},
+		{ID: uuid.New(), Name: "Solaris", Date: releaseDate(2002)},
+	}
+
+	ds := NewDuplicateService(&mockDuplicateManager{
+		GetMovieRecordsFunc: func() ([]model.DuplicateRecord, error) {
+			return records, nil
+		},
+	}, nil, nil)
+
+	duplicates, err := ds.GetMovieDuplicates()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(duplicates) != 1 {
+		t.Fatalf("Expected 1 duplicate group, got: %d", len(duplicates))
+	}
+	if duplicates[0].Key != "the matrix" || len(duplicates[0].Records) != 2 {
+		t.Errorf("Expected 2 records for the matrix, got: %+v", duplicates[0])
+	}
+}
+
+func TestDuplicateService_MergeActors(t *testing.T) {
+	t.Parallel()
+
+	target := &model.Actor{ID: uuid.New(), Name: "Keanu Reeves",
+		BirthDate: time.Date(1964, time.September, 2, 0, 0, 0, 0, time.UTC)}
+	source := &model.Actor{ID: uuid.New(), Name: "K. Reeves", BirthDate: target.BirthDate}
+	deceased := &model.Actor{ID: uuid.New(), Name: "Keanu Reeves",
+		BirthDate: time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC),
+		DeathDate: time.Date(1950, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	actors := map[uuid.UUID]*model.Actor{target.ID: target, source.ID: source, deceased.ID: deceased}
+
+	var mergedSource, mergedTarget uuid.UUID
+	actorManager := &mockActorManager{
+		GetByIDFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+			if actorID == mergedSource {
+				actorID = mergedTarget
+			}
+			if actor, ok := actors[actorID]; ok {
+				return actor, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+	}
+	duplicateManager := &mockDuplicateManager{
+		MergeActorsFunc: func(sourceID, targetID uuid.UUID, history repository.ActorHistory) error {
+			mergedSource, mergedTarget = sourceID, targetID
+			return nil
+		},
+	}
+
+	ds := NewDuplicateService(duplicateManager, actorManager, nil)
+
+	tests := []struct {
+		name           string
+		sourceID       uuid.UUID
+		targetID       uuid.UUID
+		expectedResult error
+	}{
+		{
+			name:           "MergeIntoItself",
+			sourceID:       target.ID,
+			targetID:       target.ID,
+			expectedResult: ErrInvalidMerge,
+		},
+		{
+			name:           "DiedBeforeTargetBirth",
+			sourceID:       deceased.ID,
+			targetID:       target.ID,
+			expectedResult: ErrInvalidLifeDates,
+		},
+		{
+			name:           "ActorNotFound",
+			sourceID:       uuid.New(),
+			targetID:       target.ID,
+			expectedResult: sql.ErrNoRows,
+		},
+		{
+			name:     "Success",
+			sourceID: source.ID,
+			targetID: target.ID,
+		},
+		{
+			name:           "MergeIntoItselfThroughRedirect",
+			sourceID:       source.ID,
+			targetID:       target.ID,
+			expectedResult: ErrInvalidMerge,
+		},
+	}
+
+	// The cases run sequentially since a successful merge redirects the ID of the source actor.
+	for _, tt := range tests {
+		actor, err := ds.MergeActors(tt.sourceID, tt.targetID, "admin")
+
+		if !errors.Is(err, tt.expectedResult) {
+			t.Errorf("%s: expected error: %v, got: %v", tt.name, tt.expectedResult, err)
+		}
+		if err == nil && actor.ID != target.ID {
+			t.Errorf("%s: expected the merged actor %s, got: %s", tt.name, target.ID, actor.ID)
+		}
+	}
+}
+
+func TestDuplicateService_MergeMovies(t *testing.T) {
+	t.Parallel()
+
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	merged := false
+	var entries []*model.HistoryEntry
+
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+			switch movieID {
+			case sourceID:
+				return &model.Movie{ID: sourceID, Title: "the matrix"}, nil
+			case targetID:
+				return &model.Movie{ID: targetID, Title: "The Matrix"}, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+	}
+	duplicateManager := &mockDuplicateManager{
+		MergeMoviesFunc: func(source, target uuid.UUID, history repository.MovieHistory) error {
+			merged = source == sourceID && target == targetID
+			var err error
+			entries, err = history(&model.Movie{ID: targetID, Title: "The Matrix", Rating: 9})
+			return err
+		},
+	}
+
+	ds := NewDuplicateService(duplicateManager, nil, movieManager)
+
+	if _, err := ds.MergeMovies(sourceID, sourceID, "admin"); !errors.Is(err, ErrInvalidMerge) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidMerge, err)
+	}
+	if _, err := ds.MergeMovies(sourceID, uuid.New(), "admin"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected error: %v, got: %v", sql.ErrNoRows, err)
+	}
+
+	movie, err := ds.MergeMovies(sourceID, targetID, "admin")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !merged || movie.ID != targetID {
+		t.Errorf("Expected the movies to be merged into %s, got: %s", targetID, movie.ID)
+	}
+
+	// The source movie is recorded as merged away, its empty cast leaving nothing to record, and the target movie
+	// with its new rating.
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 history entries, got: %d", len(entries))
+	}
+	if entries[0].EntityID != sourceID || entries[0].EntityType != model.HistoryMovie || entries[0].After != nil {
+		t.Errorf("Expected the source movie to be recorded as merged away, got: %+v", entries[0])
+	}
+	if entries[1].EntityID != targetID || entries[1].Action != model.HistoryMerge || entries[1].Username != "admin" ||
+		len(entries[1].Diff) != 1 || string(entries[1].Diff["Rating"].After) != "9" {
+		t.Errorf("Expected the rating of the target movie to be recorded, got: %+v", entries[1])
+	}
+}
@@ -0,0 +1,455 @@
+package service
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// ErrDeletedVersion is returned when reverting to a version in which the movie or the actor did not exist.
+var ErrDeletedVersion = errors.New("the record does not exist in this version")
+
+// HistoryService represents a service for browsing the change history of movies and actors and reverting them
+// to previous versions.
+type HistoryService interface {
+	GetMovieHistory(movieID uuid.UUID) ([]model.HistoryEntry, error)
+	GetActorHistory(actorID uuid.UUID) ([]model.HistoryEntry, error)
+	RevertMovie(movieID uuid.UUID, historyID int64, username string) (*model.Movie, error)
+	RevertActor(actorID uuid.UUID, historyID int64, username string) (*model.Actor, error)
+}
+
+type historyService struct {
+	historyManager repository.HistoryManager
+	actorManager   repository.ActorManager
+	movieManager   repository.MovieManager
+}
+
+// NewHistoryService creates a new instance of the HistoryService.
+func NewHistoryService(historyManager repository.HistoryManager, actorManager repository.ActorManager,
+	movieManager repository.MovieManager) HistoryService {
+	return &historyService{
+		historyManager: historyManager,
+		actorManager:   actorManager,
+		movieManager:   movieManager,
+	}
+}
+
+// GetMovieHistory retrieves the changes made to a movie and its cast, most recent first.
+func (hs *historyService) GetMovieHistory(movieID uuid.UUID) ([]model.HistoryEntry, error) {
+	return hs.historyManager.GetByEntity(movieID)
+}
+
+// GetActorHistory retrieves the changes made to an actor, most recent first.
+func (hs *historyService) GetActorHistory(actorID uuid.UUID) ([]model.HistoryEntry, error) {
+	return hs.historyManager.GetByEntity(actorID)
+}
+
+// RevertMovie brings the details and the cast of a movie back to their state right after the change recorded by the
+// history entry with the given ID and returns the reverted movie. The revert is recorded as a new change.
+// sql.ErrNoRows is returned when the entry is not a change of the movie or when the movie was merged into another one.
+func (hs *historyService) RevertMovie(movieID uuid.UUID, historyID int64, username string) (*model.Movie, error) {
+	entries, err := hs.historyManager.GetByEntity(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasEntry(entries, historyID) {
+		return nil, sql.ErrNoRows
+	}
+
+	existingMovie, err := hs.movieManager.GetByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	// The ID of a merged movie resolves to the movie it was merged into, which has a history of its own.
+	if existingMovie.ID != movieID {
+		return nil, sql.ErrNoRows
+	}
+	revertedMovie := *existingMovie
+
+	if state, ok := stateAt(entries, model.HistoryMovie, historyID); ok {
+		if state == nil {
+			return nil, ErrDeletedVersion
+		}
+		var details movieVersion
+		if err := json.Unmarshal(state, &details); err != nil {
+			return nil, err
+		}
+		details.apply(&revertedMovie)
+	}
+	if state, ok := stateAt(entries, model.HistoryCast, historyID); ok {
+		// The cast is empty before its creation, which is recorded right after the one of the movie.
+		var cast castVersion
+		if state != nil {
+			if err := json.Unmarshal(state, &cast); err != nil {
+				return nil, err
+			}
+		}
+		cast.apply(&revertedMovie)
+	}
+
+	history, err := movieChangeEntries(model.HistoryRevert, username, movieID, existingMovie, &revertedMovie)
+	if err != nil {
+		return nil, err
+	}
+	if err := hs.movieManager.Update(&revertedMovie, history...); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	return hs.movieManager.GetByID(movieID)
+}
+
+// RevertActor brings an actor back to their state right after the change recorded by the history entry with the given
+// ID and returns the reverted actor. The revert is recorded as a new change.
+// sql.ErrNoRows is returned when the entry is not a change of the actor or when the actor was merged into another one.
+func (hs *historyService) RevertActor(actorID uuid.UUID, historyID int64, username string) (*model.Actor, error) {
+	entries, err := hs.historyManager.GetByEntity(actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasEntry(entries, historyID) {
+		return nil, sql.ErrNoRows
+	}
+
+	existingActor, err := hs.actorManager.GetByID(actorID)
+	if err != nil {
+		return nil, err
+	}
+	if existingActor.ID != actorID {
+		return nil, sql.ErrNoRows
+	}
+
+	state, ok := stateAt(entries, model.HistoryActor, historyID)
+	if !ok || state == nil {
+		return nil, ErrDeletedVersion
+	}
+	var revertedActor model.Actor
+	if err := json.Unmarshal(state, &revertedActor); err != nil {
+		return nil, err
+	}
+	revertedActor.ID = existingActor.ID
+	revertedActor.Version = existingActor.Version
+
+	history, err := changeEntries(model.HistoryActor, actorID, model.HistoryRevert, username,
+		newActorVersion(existingActor), newActorVersion(&revertedActor))
+	if err != nil {
+		return nil, err
+	}
+	if err := hs.actorManager.Update(actorID, &revertedActor, history...); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	return hs.actorManager.GetByID(actorID)
+}
+
+// hasEntry tells whether one of the entries has the ID.
+func hasEntry(entries []model.HistoryEntry, historyID int64) bool {
+	for _, entry := range entries {
+		if entry.ID == historyID {
+			return true
+		}
+	}
+	return false
+}
+
+// stateAt returns the state of the records of a kind right after the change recorded by the entry with the given ID,
+// from entries ordered most recent first. It is the state after the latest change of the kind up to the entry,
+// or the state before the earliest later change when there is none. The state is nil when the record did not exist,
+// false is returned when the entries hold no change of the kind.
+func stateAt(entries []model.HistoryEntry, entityType string, historyID int64) (json.RawMessage, bool) {
+	var next *model.HistoryEntry
+	for i := range entries {
+		if entries[i].EntityType != entityType {
+			continue
+		}
+		if entries[i].ID <= historyID {
+			return entries[i].After, true
+		}
+		next = &entries[i]
+	}
+	if next == nil {
+		return nil, false
+	}
+	return next.Before, true
+}
+
+// movieVersion is the state of the details of a movie recorded in its history. The names of the crew and of the
+// companies are left out as they are not part of the movie, the cast is recorded separately as a castVersion.
+type movieVersion struct {
+	Title            string
+	Description      string
+	ReleaseDate      time.Time
+	Rating           int
+	RuntimeMinutes   int
+	OriginalTitle    string
+	OriginalLanguage string
+	Countries        []string
+	Languages        []string
+	Certifications   map[string]string
+	Releases         []model.ReleaseEvent
+	Crew             []model.Credit
+	Companies        []model.MovieCompany
+	Tags             []string
+}
+
+// newMovieVersion returns the recorded state of the details of a movie, nil for a movie that does not exist.
+// Empty lists and times are normalized so that only actual changes differ.
+func newMovieVersion(movie *model.Movie) *movieVersion {
+	if movie == nil {
+		return nil
+	}
+
+	version := &movieVersion{
+		Title:            movie.Title,
+		Description:      movie.Description,
+		ReleaseDate:      movie.ReleaseDate.UTC(),
+		Rating:           movie.Rating,
+		RuntimeMinutes:   movie.RuntimeMinutes,
+		OriginalTitle:    movie.OriginalTitle,
+		OriginalLanguage: movie.OriginalLanguage,
+	}
+	if len(movie.Countries) > 0 {
+		version.Countries = movie.Countries
+	}
+	if len(movie.Languages) > 0 {
+		version.Languages = movie.Languages
+	}
+	if len(movie.Certifications) > 0 {
+		version.Certifications = movie.Certifications
+	}
+	for _, release := range movie.Releases {
+		release.Date = release.Date.UTC()
+		version.Releases = append(version.Releases, release)
+	}
+	for _, credit := range movie.Crew {
+		credit.Name = ""
+		version.Crew = append(version.Crew, credit)
+	}
+	for _, company := range movie.Companies {
+		company.Name = ""
+		version.Companies = append(version.Companies, company)
+	}
+	if len(movie.Tags) > 0 {
+		version.Tags = movie.Tags
+	}
+
+	return version
+}
+
+// apply sets the details of the movie to the recorded ones.
+func (v *movieVersion) apply(movie *model.Movie) {
+	movie.Title = v.Title
+	movie.Description = v.Description
+	movie.ReleaseDate = v.ReleaseDate
+	movie.Rating = v.Rating
+	movie.RuntimeMinutes = v.RuntimeMinutes
+	movie.OriginalTitle = v.OriginalTitle
+	movie.OriginalLanguage = v.OriginalLanguage
+	movie.Countries = v.Countries
+	movie.Languages = v.Languages
+	movie.Certifications = v.Certifications
+	movie.Releases = v.Releases
+	movie.Crew = v.Crew
+	movie.Companies = v.Companies
+	movie.Tags = v.Tags
+}
+
+// castVersion is the state of the cast of a movie recorded in its history.
+type castVersion struct {
+	Actors []castLink
+}
+
+// castLink is an actor starring in a movie as recorded in the history of its cast.
+type castLink struct {
+	ActorID       uuid.UUID
+	CharacterName string
+	BillingOrder  int
+}
+
+// newCastVersion returns the recorded state of the cast of a movie, nil for a movie that does not exist.
+func newCastVersion(movie *model.Movie) *castVersion {
+	if movie == nil {
+		return nil
+	}
+
+	version := &castVersion{}
+	for _, actor := range movie.Actors {
+		version.Actors = append(version.Actors, castLink{
+			ActorID:       actor.ID,
+			CharacterName: actor.CharacterName,
+			BillingOrder:  actor.BillingOrder,
+		})
+	}
+
+	return version
+}
+
+// apply sets the cast of the movie to the recorded one.
+func (v *castVersion) apply(movie *model.Movie) {
+	movie.Actors = nil
+	for _, link := range v.Actors {
+		movie.Actors = append(movie.Actors, model.CastMember{
+			Actor:         model.Actor{ID: link.ActorID},
+			CharacterName: link.CharacterName,
+			BillingOrder:  link.BillingOrder,
+		})
+	}
+}
+
+// translationVersion is the state of the translation of a movie into a language recorded in the history of the movie.
+type translationVersion struct {
+	Language    string
+	Title       string
+	Description string
+}
+
+// newTranslationVersion returns the recorded state of a translation, nil for a translation that does not exist.
+func newTranslationVersion(translation *model.MovieTranslation) *translationVersion {
+	if translation == nil {
+		return nil
+	}
+
+	return &translationVersion{
+		Language:    translation.Language,
+		Title:       translation.Title,
+		Description: translation.Description,
+	}
+}
+
+// newActorVersion returns the recorded state of an actor, nil for an actor that does not exist.
+func newActorVersion(actor *model.Actor) *model.Actor {
+	if actor == nil {
+		return nil
+	}
+
+	version := *actor
+	version.Version = 0
+	version.BirthDate = actor.BirthDate.UTC()
+	version.DeathDate = actor.DeathDate.UTC()
+	version.Aliases = nil
+	if len(actor.Aliases) > 0 {
+		version.Aliases = slices.Clone(actor.Aliases)
+	}
+	version.Links = nil
+	if len(actor.Links) > 0 {
+		version.Links = slices.Clone(actor.Links)
+	}
+
+	return &version
+}
+
+// movieChangeEntries returns the history entries of a change of the details and of the cast of a movie, nil standing
+// for the movie before its creation or after its deletion. The entries are recorded by the write making the change.
+func movieChangeEntries(action, username string, movieID uuid.UUID,
+	before, after *model.Movie) ([]*model.HistoryEntry, error) {
+	entries, err := changeEntries(model.HistoryMovie, movieID, action, username,
+		newMovieVersion(before), newMovieVersion(after))
+	if err != nil {
+		return nil, err
+	}
+
+	castEntries, err := changeEntries(model.HistoryCast, movieID, action, username,
+		newCastVersion(before), newCastVersion(after))
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, castEntries...), nil
+}
+
+// changeEntries returns the history entry of the change of a record from one state to another, nil standing for
+// a record that does not exist. No entry is returned when no field changes.
+func changeEntries(entityType string, entityID uuid.UUID, action, username string,
+	before, after interface{}) ([]*model.HistoryEntry, error) {
+	beforeState, err := marshalState(before)
+	if err != nil {
+		return nil, err
+	}
+	afterState, err := marshalState(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := diffStates(beforeState, afterState)
+	if err != nil {
+		return nil, err
+	}
+	if len(diff) == 0 {
+		return nil, nil
+	}
+
+	return []*model.HistoryEntry{{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Username:   username,
+		Before:     beforeState,
+		After:      afterState,
+		Diff:       diff,
+	}}, nil
+}
+
+// marshalState returns the JSON state of a record, nil when the record does not exist.
+func marshalState(record interface{}) (json.RawMessage, error) {
+	state, err := json.Marshal(record)
+	if err != nil || bytes.Equal(state, []byte("null")) {
+		return nil, err
+	}
+	return state, nil
+}
+
+// diffStates returns the top-level fields whose values differ between two JSON states, a field absent from a state
+// being null in it.
+func diffStates(before, after json.RawMessage) (map[string]model.FieldChange, error) {
+	beforeFields, err := stateFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := stateFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]model.FieldChange)
+	for name, value := range beforeFields {
+		if !bytes.Equal(value, afterFields[name]) {
+			diff[name] = model.FieldChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			diff[name] = model.FieldChange{Before: json.RawMessage("null"), After: value}
+		}
+	}
+	for name, change := range diff {
+		if change.After == nil {
+			change.After = json.RawMessage("null")
+			diff[name] = change
+		}
+	}
+
+	return diff, nil
+}
+
+// stateFields splits a JSON state into its top-level fields, leaving out the null ones.
+func stateFields(state json.RawMessage) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if state == nil {
+		return fields, nil
+	}
+	if err := json.Unmarshal(state, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range fields {
+		if bytes.Equal(value, []byte("null")) {
+			delete(fields, name)
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockHistoryManager struct {
+	GetByEntityFunc func(entityID uuid.UUID) ([]model.HistoryEntry, error)
+}
+
+func (m *mockHistoryManager) GetByEntity(entityID uuid.UUID) ([]model.HistoryEntry, error) {
+	return m.GetByEntityFunc(entityID)
+}
+
+func TestMovieService_UpdateRecordsHistory(t *testing.T) {
+	t.Parallel()
+
+	movieID := uuid.New()
+	actorID := uuid.New()
+	var entries []*model.HistoryEntry
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+			return &model.Movie{ID: movieID, Title: "Heat", ReleaseDate: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC),
+				Rating: 7, Countries: []string{"US"},
+				Actors: []model.CastMember{{Actor: model.Actor{ID: actorID, Name: "Al Pacino"}, BillingOrder: 1}}}, nil
+		},
+		UpdateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+			entries = history
+			return nil
+		},
+	}
+
+	ms := NewMovieService(movieManager, &mockHistoryManager{})
+
+	if err := ms.Update(movieID, model.Movie{Rating: 9, Countries: []string{"us"}}, "admin"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// The lowercase country is normalized to the recorded one and the unchanged cast is not recorded.
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 history entry, got: %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.EntityType != model.HistoryMovie || entry.EntityID != movieID || entry.Action != model.HistoryUpdate ||
+		entry.Username != "admin" {
+		t.Errorf("Unexpected history entry: %+v", entry)
+	}
+	change, ok := entry.Diff["Rating"]
+	if len(entry.Diff) != 1 || !ok || string(change.Before) != "7" || string(change.After) != "9" {
+		t.Errorf("Expected the rating to change from 7 to 9, got: %+v", entry.Diff)
+	}
+}
+
+func TestHistoryService_RevertMovie(t *testing.T) {
+	t.Parallel()
+
+	movieID := uuid.New()
+	actorID := uuid.New()
+	state := func(value interface{}) json.RawMessage {
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("Failed to marshal state: %v", err)
+		}
+		return data
+	}
+	created := state(newMovieVersion(&model.Movie{Title: "Heat", Rating: 7}))
+	cast := state(newCastVersion(&model.Movie{
+		Actors: []model.CastMember{{Actor: model.Actor{ID: actorID}, CharacterName: "Vincent Hanna"}},
+	}))
+	rerated := state(newMovieVersion(&model.Movie{Title: "Heat", Rating: 9}))
+
+	// Entries ordered most recent first as the history manager returns them.
+	entries := []model.HistoryEntry{
+		{ID: 4, EntityType: model.HistoryMovie, EntityID: movieID, Action: model.HistoryDelete, Before: rerated},
+		{ID: 3, EntityType: model.HistoryMovie, EntityID: movieID, Action: model.HistoryUpdate,
+			Before: created, After: rerated},
+		{ID: 2, EntityType: model.HistoryCast, EntityID: movieID, Action: model.HistoryCreate, After: cast},
+		{ID: 1, EntityType: model.HistoryMovie, EntityID: movieID, Action: model.HistoryCreate, After: created},
+	}
+
+	tests := []struct {
+		name           string
+		historyID      int64
+		expectedRating int
+		expectedCast   int
+		expectedResult error
+	}{
+		{
+			name:           "BeforeCast",
+			historyID:      1,
+			expectedRating: 7,
+			expectedCast:   0,
+		},
+		{
+			name:           "WithCast",
+			historyID:      2,
+			expectedRating: 7,
+			expectedCast:   1,
+		},
+		{
+			name:           "Rerated",
+			historyID:      3,
+			expectedRating: 9,
+			expectedCast:   1,
+		},
+		{
+			name:           "DeletedVersion",
+			historyID:      4,
+			expectedResult: ErrDeletedVersion,
+		},
+		{
+			name:           "VersionNotFound",
+			historyID:      5,
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *model.Movie
+			var reverts int
+			movieManager := &mockMovieManager{
+				GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+					if updated != nil {
+						return updated, nil
+					}
+					return &model.Movie{ID: movieID, Title: "Heat", Rating: 5}, nil
+				},
+				UpdateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+					updated = movie
+					for _, entry := range history {
+						if entry.Action == model.HistoryRevert && entry.Username == "admin" {
+							reverts++
+						}
+					}
+					return nil
+				},
+			}
+			historyManager := &mockHistoryManager{
+				GetByEntityFunc: func(entityID uuid.UUID) ([]model.HistoryEntry, error) {
+					return entries, nil
+				},
+			}
+
+			hs := NewHistoryService(historyManager, nil, movieManager)
+
+			movie, err := hs.RevertMovie(movieID, tt.historyID, "admin")
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Fatalf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err != nil {
+				return
+			}
+			if movie.Rating != tt.expectedRating || len(movie.Actors) != tt.expectedCast {
+				t.Errorf("Expected rating %d and %d cast members, got: %d and %d",
+					tt.expectedRating, tt.expectedCast, movie.Rating, len(movie.Actors))
+			}
+			if reverts == 0 {
+				t.Errorf("Expected the revert to be recorded")
+			}
+		})
+	}
+}
+
+func TestHistoryService_RevertActor(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+	state := func(actor *model.Actor) json.RawMessage {
+		data, err := json.Marshal(newActorVersion(actor))
+		if err != nil {
+			t.Fatalf("Failed to marshal state: %v", err)
+		}
+		return data
+	}
+	created := state(&model.Actor{ID: actorID, Name: "Al Pacino", Nationality: "US"})
+	renamed := state(&model.Actor{ID: actorID, Name: "Alfredo Pacino", Nationality: "US"})
+	entries := []model.HistoryEntry{
+		{ID: 7, EntityType: model.HistoryActor, EntityID: actorID, Action: model.HistoryUpdate,
+			Before: created, After: renamed},
+		{ID: 6, EntityType: model.HistoryActor, EntityID: actorID, Action: model.HistoryCreate, After: created},
+	}
+
+	var updated *model.Actor
+	var recorded *model.HistoryEntry
+	actorManager := &mockActorManager{
+		GetByIDFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+			if updated != nil {
+				return updated, nil
+			}
+			return &model.Actor{ID: actorID, Name: "Alfredo Pacino", Nationality: "US"}, nil
+		},
+		UpdateFunc: func(actorID uuid.UUID, actor *model.Actor, history []*model.HistoryEntry) error {
+			updated = actor
+			if len(history) > 0 {
+				recorded = history[0]
+			}
+			return nil
+		},
+	}
+	historyManager := &mockHistoryManager{
+		GetByEntityFunc: func(entityID uuid.UUID) ([]model.HistoryEntry, error) {
+			return entries, nil
+		},
+	}
+
+	hs := NewHistoryService(historyManager, actorManager, nil)
+
+	if _, err := hs.RevertActor(actorID, 8, "admin"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected error: %v, got: %v", sql.ErrNoRows, err)
+	}
+
+	actor, err := hs.RevertActor(actorID, 6, "admin")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if actor.ID != actorID || actor.Name != "Al Pacino" {
+		t.Errorf("Expected the actor to be reverted to Al Pacino, got: %+v", actor)
+	}
+	if recorded == nil || recorded.Action != model.HistoryRevert || len(recorded.Diff) != 1 {
+		t.Errorf("Expected the revert of the name to be recorded, got: %+v", recorded)
+	}
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Limits of the list fields, matching the constraints of the lists and list_entries tables.
+const (
+	maxListNameLength        = 150
+	maxListDescriptionLength = 1000
+	maxListNoteLength        = 500
+)
+
+// Errors returned by the ListService.
+var (
+	ErrDuplicateListEntry = errors.New("a movie can only appear once on a list")
+	ErrInvalidListOrder   = errors.New("the new order must contain every movie of the list exactly once")
+	ErrNotListOwner       = errors.New("only the owner can change the list")
+)
+
+// ListService represents a service for managing user-curated movie lists.
+type ListService interface {
+	Create(username string, list *model.MovieList) error
+	Get(username string, listID uuid.UUID) (*model.MovieList, error)
+	GetMine(username string) ([]*model.MovieList, error)
+	GetPublic() ([]*model.MovieList, error)
+	Update(username string, listID uuid.UUID, list *model.MovieList) error
+	Delete(username string, listID uuid.UUID) error
+	SetEntry(username string, listID uuid.UUID, entry *model.ListEntry) error
+	RemoveEntry(username string, listID, movieID uuid.UUID) error
+	Reorder(username string, listID uuid.UUID, movieIDs []uuid.UUID) error
+	Clone(username string, listID uuid.UUID) (*model.MovieList, error)
+	Share(username string, listID uuid.UUID) (*model.MovieList, error)
+}
+
+type listService struct {
+	listManager  repository.ListManager
+	movieManager repository.MovieManager
+	userManager  repository.UserManager
+}
+
+// NewListService creates a new instance of the ListService.
+func NewListService(listManager repository.ListManager, movieManager repository.MovieManager,
+	userManager repository.UserManager) ListService {
+	return &listService{
+		listManager:  listManager,
+		movieManager: movieManager,
+		userManager:  userManager,
+	}
+}
+
+// Create creates a new list owned by the user. Lists are private unless another visibility is given,
+// the entries keep the order in which they are given.
+func (ls *listService) Create(username string, list *model.MovieList) error {
+	if list.Visibility == "" {
+		list.Visibility = model.ListPrivate
+	}
+	if err := validateList(list); err != nil {
+		return err
+	}
+
+	userID, err := lookupUserID(ls.userManager, username)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(list.Entries))
+	for i := range list.Entries {
+		entry := &list.Entries[i]
+		movie, err := ls.movieManager.GetByID(entry.MovieID)
+		if err != nil {
+			return err
+		}
+		entry.MovieID = movie.ID
+
+		if seen[entry.MovieID] {
+			return ErrDuplicateListEntry
+		}
+		seen[entry.MovieID] = true
+		entry.Position = i + 1
+	}
+
+	now := time.Now().UTC()
+	list.ID = uuid.New()
+	list.OwnerID = userID
+	list.Owner = username
+	list.EntryCount = len(list.Entries)
+	list.CreatedAt = now
+	list.UpdatedAt = now
+
+	return ls.listManager.Create(list)
+}
+
+// Get retrieves a list with its entries. Private lists can only be seen by their owner,
+// sql.ErrNoRows is returned to anyone else so that their existence is not revealed.
+func (ls *listService) Get(username string, listID uuid.UUID) (*model.MovieList, error) {
+	list, err := ls.listManager.GetByID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Visibility == model.ListPrivate && list.Owner != username {
+		return nil, sql.ErrNoRows
+	}
+	return list, nil
+}
+
+// GetMine retrieves all lists of the user, whatever their visibility.
+func (ls *listService) GetMine(username string) ([]*model.MovieList, error) {
+	userID, err := lookupUserID(ls.userManager, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return ls.listManager.GetByOwner(userID)
+}
+
+// GetPublic retrieves all public lists.
+func (ls *listService) GetPublic() ([]*model.MovieList, error) {
+	return ls.listManager.GetByVisibility(model.ListPublic)
+}
+
+// Update changes the name, description and visibility of a list of the user.
+func (ls *listService) Update(username string, listID uuid.UUID, list *model.MovieList) error {
+	existingList, err := ls.ownedList(username, listID)
+	if err != nil {
+		return err
+	}
+
+	if list.Name != "" {
+		existingList.Name = list.Name
+	}
+	existingList.Description = list.Description
+	if list.Visibility != "" {
+		existingList.Visibility = list.Visibility
+	}
+
+	if err := validateList(existingList); err != nil {
+		return err
+	}
+
+	existingList.UpdatedAt = time.Now().UTC()
+
+	return ls.listManager.Update(existingList)
+}
+
+// Delete removes a list of the user.
+func (ls *listService) Delete(username string, listID uuid.UUID) error {
+	if _, err := ls.ownedList(username, listID); err != nil {
+		return err
+	}
+
+	return ls.listManager.Delete(listID)
+}
+
+// SetEntry adds a movie to the end of a list of the user, or updates its note if it is already on the list.
+func (ls *listService) SetEntry(username string, listID uuid.UUID, entry *model.ListEntry) error {
+	if err := validateListEntry(entry); err != nil {
+		return err
+	}
+
+	if _, err := ls.ownedList(username, listID); err != nil {
+		return err
+	}
+	movie, err := ls.movieManager.GetByID(entry.MovieID)
+	if err != nil {
+		return err
+	}
+	entry.MovieID = movie.ID
+
+	if err := ls.listManager.SetEntry(listID, entry); err != nil {
+		return err
+	}
+	entry.Movie = movie
+	return nil
+}
+
+// RemoveEntry removes a movie from a list of the user.
+func (ls *listService) RemoveEntry(username string, listID, movieID uuid.UUID) error {
+	if _, err := ls.ownedList(username, listID); err != nil {
+		return err
+	}
+
+	return ls.listManager.RemoveEntry(listID, movieID)
+}
+
+// Reorder puts the movies of a list of the user in the given order.
+// The order must contain every movie shown on the list exactly once, the movies in the trash following them.
+func (ls *listService) Reorder(username string, listID uuid.UUID, movieIDs []uuid.UUID) error {
+	list, err := ls.ownedList(username, listID)
+	if err != nil {
+		return err
+	}
+
+	if len(movieIDs) != len(list.Entries) {
+		return ErrInvalidListOrder
+	}
+	remaining := make(map[uuid.UUID]bool, len(list.Entries))
+	for _, entry := range list.Entries {
+		remaining[entry.MovieID] = true
+	}
+	for _, movieID := range movieIDs {
+		if !remaining[movieID] {
+			return ErrInvalidListOrder
+		}
+		delete(remaining, movieID)
+	}
+
+	return ls.listManager.Reorder(listID, movieIDs)
+}
+
+// Clone copies a list the user can see, including the notes of its entries, into a new private list of the user.
+func (ls *listService) Clone(username string, listID uuid.UUID) (*model.MovieList, error) {
+	source, err := ls.Get(username, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := lookupUserID(ls.userManager, username)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	clone := &model.MovieList{
+		ID:          uuid.New(),
+		OwnerID:     userID,
+		Owner:       username,
+		Name:        source.Name,
+		Description: source.Description,
+		Visibility:  model.ListPrivate,
+		EntryCount:  len(source.Entries),
+		Entries:     source.Entries,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := ls.listManager.Create(clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// Share makes a private list of the user reachable by anyone with its link by turning it unlisted.
+// Unlisted and public lists are returned unchanged.
+func (ls *listService) Share(username string, listID uuid.UUID) (*model.MovieList, error) {
+	list, err := ls.ownedList(username, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.Visibility != model.ListPrivate {
+		return list, nil
+	}
+
+	list.Visibility = model.ListUnlisted
+	list.UpdatedAt = time.Now().UTC()
+
+	if err := ls.listManager.Update(list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ownedList retrieves a list and checks that it is owned by the user.
+// Private lists of other users are reported as not found.
+func (ls *listService) ownedList(username string, listID uuid.UUID) (*model.MovieList, error) {
+	list, err := ls.Get(username, listID)
+	if err != nil {
+		return nil, err
+	}
+	if list.Owner != username {
+		return nil, ErrNotListOwner
+	}
+	return list, nil
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockListManager struct {
+	CreateFunc          func(list *model.MovieList) error
+	GetByIDFunc         func(listID uuid.UUID) (*model.MovieList, error)
+	UpdateFunc          func(list *model.MovieList) error
+	DeleteFunc          func(listID uuid.UUID) error
+	GetByOwnerFunc      func(userID uuid.UUID) ([]*model.MovieList, error)
+	GetByVisibilityFunc func(visibility string) ([]*model.MovieList, error)
+	SetEntryFunc        func(listID uuid.UUID, entry *model.ListEntry) error
+	RemoveEntryFunc     func(listID, movieID uuid.UUID) error
+	ReorderFunc         func(listID uuid.UUID, movieIDs []uuid.UUID) error
+}
+
+func (m *mockListManager) Create(list *model.MovieList) error {
+	return m.CreateFunc(list)
+}
+
+func (m *mockListManager) GetByID(listID uuid.UUID) (*model.MovieList, error) {
+	return m.GetByIDFunc(listID)
+}
+
+func (m *mockListManager) Update(list *model.MovieList) error {
+	return m.UpdateFunc(list)
+}
+
+func (m *mockListManager) Delete(listID uuid.UUID) error {
+	return m.DeleteFunc(listID)
+}
+
+func (m *mockListManager) GetByOwner(userID uuid.UUID) ([]*model.MovieList, error) {
+	return m.GetByOwnerFunc(userID)
+}
+
+func (m *mockListManager) GetByVisibility(visibility string) ([]*model.MovieList, error) {
+	return m.GetByVisibilityFunc(visibility)
+}
+
+func (m *mockListManager) SetEntry(listID uuid.UUID, entry *model.ListEntry) error {
+	return m.SetEntryFunc(listID, entry)
+}
+
+func (m *mockListManager) RemoveEntry(listID, movieID uuid.UUID) error {
+	return m.RemoveEntryFunc(listID, movieID)
+}
+
+func (m *mockListManager) Reorder(listID uuid.UUID, movieIDs []uuid.UUID) error {
+	return m.ReorderFunc(listID, movieIDs)
+}
+
+func TestListService_Create(t *testing.T) {
+	t.Parallel()
+
+	movieID := uuid.New()
+	userManager := newUsersManager(map[string]uuid.UUID{"forrest": uuid.New()})
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Movie, error) {
+			if id != movieID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Movie{ID: movieID}, nil
+		},
+	}
+	listManager := &mockListManager{
+		CreateFunc: func(list *model.MovieList) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		list           *model.MovieList
+		expectedResult error
+	}{
+		{
+			name: "Success",
+			list: &model.MovieList{Name: "Best of 1990s noir", Entries: []model.ListEntry{{MovieID: movieID, Note: "Rain"}}},
+		},
+		{
+			name:           "MissingName",
+			list:           &model.MovieList{Name: "  "},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "InvalidVisibility",
+			list:           &model.MovieList{Name: "Noir", Visibility: "secret"},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "DuplicateMovie",
+			list:           &model.MovieList{Name: "Noir", Entries: []model.ListEntry{{MovieID: movieID}, {MovieID: movieID}}},
+			expectedResult: ErrDuplicateListEntry,
+		},
+		{
+			name:           "MovieNotFound",
+			list:           &model.MovieList{Name: "Noir", Entries: []model.ListEntry{{MovieID: uuid.New()}}},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := NewListService(listManager, movieManager, userManager)
+
+			err := ls.Create("forrest", tt.list)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil {
+				if tt.list.Visibility != model.ListPrivate {
+					t.Errorf("Expected list to be private by default, got: %s", tt.list.Visibility)
+				}
+				for i, entry := range tt.list.Entries {
+					if entry.Position != i+1 {
+						t.Errorf("Expected entry %d at position %d, got: %d", i, i+1, entry.Position)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestListService_Get(t *testing.T) {
+	t.Parallel()
+
+	listManager := &mockListManager{
+		GetByIDFunc: func(listID uuid.UUID) (*model.MovieList, error) {
+			return &model.MovieList{ID: listID, Owner: "forrest", Name: "Noir", Visibility: model.ListPrivate}, nil
+		},
+	}
+	ls := NewListService(listManager, &mockMovieManager{}, &mockUserManager{})
+
+	if _, err := ls.Get("forrest", uuid.New()); err != nil {
+		t.Errorf("Expected the owner to see the private list, got: %v", err)
+	}
+	if _, err := ls.Get("jenny", uuid.New()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected private list to be hidden from other users, got: %v", err)
+	}
+}
+
+func TestListService_Reorder(t *testing.T) {
+	t.Parallel()
+
+	first, second := uuid.New(), uuid.New()
+	listManager := &mockListManager{
+		GetByIDFunc: func(listID uuid.UUID) (*model.MovieList, error) {
+			return &model.MovieList{ID: listID, Owner: "forrest", Name: "Noir", Visibility: model.ListPublic,
+				Entries: []model.ListEntry{{MovieID: first, Position: 1}, {MovieID: second, Position: 2}}}, nil
+		},
+		ReorderFunc: func(listID uuid.UUID, movieIDs []uuid.UUID) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		username       string
+		movieIDs       []uuid.UUID
+		expectedResult error
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			movieIDs: []uuid.UUID{second, first},
+		},
+		{
+			name:           "MissingMovie",
+			username:       "forrest",
+			movieIDs:       []uuid.UUID{second},
+			expectedResult: ErrInvalidListOrder,
+		},
+		{
+			name:           "RepeatedMovie",
+			username:       "forrest",
+			movieIDs:       []uuid.UUID{second, second},
+			expectedResult: ErrInvalidListOrder,
+		},
+		{
+			name:           "UnknownMovie",
+			username:       "forrest",
+			movieIDs:       []uuid.UUID{second, uuid.New()},
+			expectedResult: ErrInvalidListOrder,
+		},
+		{
+			name:           "NotOwner",
+			username:       "jenny",
+			movieIDs:       []uuid.UUID{second, first},
+			expectedResult: ErrNotListOwner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := NewListService(listManager, &mockMovieManager{}, &mockUserManager{})
+
+			err := ls.Reorder(tt.username, uuid.New(), tt.movieIDs)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
+
+func TestListService_CloneAndShare(t *testing.T) {
+	t.Parallel()
+
+	movieID := uuid.New()
+	jennyID := uuid.New()
+	source := &model.MovieList{ID: uuid.New(), Owner: "forrest", Name: "Noir", Visibility: model.ListUnlisted,
+		Entries: []model.ListEntry{{MovieID: movieID, Position: 1, Note: "Rain"}}}
+
+	var created *model.MovieList
+	var updated *model.MovieList
+	listManager := &mockListManager{
+		GetByIDFunc: func(listID uuid.UUID) (*model.MovieList, error) {
+			copied := *source
+			return &copied, nil
+		},
+		CreateFunc: func(list *model.MovieList) error {
+			created = list
+			return nil
+		},
+		UpdateFunc: func(list *model.MovieList) error {
+			updated = list
+			return nil
+		},
+	}
+	userManager := newUsersManager(map[string]uuid.UUID{"jenny": jennyID, "forrest": uuid.New()})
+	ls := NewListService(listManager, &mockMovieManager{}, userManager)
+
+	clone, err := ls.Clone("jenny", source.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if clone != created || clone.ID == source.ID || clone.OwnerID != jennyID || clone.Visibility != model.ListPrivate {
+		t.Errorf("Unexpected clone: %+v", clone)
+	}
+	if len(clone.Entries) != 1 || clone.Entries[0].Note != "Rain" {
+		t.Errorf("Expected entries to be copied with their notes, got: %+v", clone.Entries)
+	}
+
+	if _, err := ls.Share("jenny", source.ID); !errors.Is(err, ErrNotListOwner) {
+		t.Errorf("Expected error: %v, got: %v", ErrNotListOwner, err)
+	}
+
+	source.Visibility = model.ListPrivate
+	shared, err := ls.Share("forrest", source.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if shared.Visibility != model.ListUnlisted || updated != shared {
+		t.Errorf("Expected private list to become unlisted, got: %+v", shared)
+	}
+}
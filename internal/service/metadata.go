@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// Errors returned when a filter on the extended metadata of movies is not valid.
+var (
+	ErrInvalidRuntime       = errors.New("runtime must not be negative")
+	ErrInvalidCountry       = errors.New("invalid ISO 3166-1 alpha-2 country code")
+	ErrInvalidLanguage      = errors.New("invalid ISO 639-1 language code")
+	ErrInvalidCertification = errors.New("invalid age certification")
+	ErrInvalidRelease       = errors.New("invalid release event")
+)
+
+// countryCodes lists the ISO 3166-1 alpha-2 country codes.
+var countryCodes = codeSet(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+	CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO
+	FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE
+	JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO
+	MP MQ MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW
+	PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM
+	TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`)
+
+// languageCodes lists the ISO 639-1 language codes.
+var languageCodes = codeSet(`
+	aa ab ae af ak am an ar as av ay az ba be bg bi bm bn bo br bs ca ce ch co cr cs cu cv cy da de dv dz ee el en
+	eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho hr ht hu hy hz ia id ie ig ii ik io is it iu ja
+	jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb lg li ln lo lt lu lv mg mh mi mk ml mn mr ms mt my na nb
+	nd ne ng nl nn no nr nv ny oc oj om or os pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl sm sn so sq
+	sr ss st su sv sw ta te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo wa wo xh yi yo za zh zu`)
+
+// certificationSystems lists the age certifications of the countries with a known rating system.
+// Certifications in other countries are only checked for length.
+var certificationSystems = map[string]map[string]bool{
+	"US": codeSet("G PG PG-13 R NC-17 NR"),
+	"GB": codeSet("U PG 12A 12 15 18 R18"),
+	"DE": codeSet("0 6 12 16 18"),
+	"FR": codeSet("U 10 12 16 18"),
+	"RU": codeSet("0+ 6+ 12+ 16+ 18+"),
+	"CA": codeSet("G PG 14A 18A R"),
+	"AU": codeSet("G PG M MA15+ R18+ X18+"),
+	"JP": codeSet("G PG12 R15+ R18+"),
+}
+
+// releaseTypes lists the supported types of release events.
+var releaseTypes = codeSet(strings.Join([]string{model.ReleasePremiere, model.ReleaseTheatricalLimited,
+	model.ReleaseTheatrical, model.ReleaseDigital, model.ReleasePhysical, model.ReleaseTV}, " "))
+
+// maxCertificationLength is the maximum length of an age certification.
+const maxCertificationLength = 10
+
+// codeSet splits a whitespace-separated list of codes into a set.
+func codeSet(codes string) map[string]bool {
+	set := make(map[string]bool)
+	for _, code := range strings.Fields(codes) {
+		set[code] = true
+	}
+	return set
+}
+
+// normalizeMetadata converts the codes of the extended metadata of a movie to their canonical case.
+func normalizeMetadata(movie *model.Movie) {
+	movie.OriginalLanguage = strings.ToLower(movie.OriginalLanguage)
+	for i := range movie.Countries {
+		movie.Countries[i] = strings.ToUpper(movie.Countries[i])
+	}
+	for i := range movie.Languages {
+		movie.Languages[i] = strings.ToLower(movie.Languages[i])
+	}
+	for i := range movie.Releases {
+		movie.Releases[i].Country = strings.ToUpper(movie.Releases[i].Country)
+		movie.Releases[i].Type = strings.ToLower(movie.Releases[i].Type)
+	}
+	if movie.Certifications != nil {
+		certifications := make(map[string]string, len(movie.Certifications))
+		for country, certification := range movie.Certifications {
+			certifications[strings.ToUpper(country)] = strings.ToUpper(certification)
+		}
+		movie.Certifications = certifications
+	}
+}
+
+// applyReleases orders the release events of a movie by date and derives its primary release date from them:
+// the earliest theatrical release, or the earliest release of any type when the movie has no theatrical release.
+// The release date is left untouched when the movie has no release events.
+func applyReleases(movie *model.Movie) {
+	sort.SliceStable(movie.Releases, func(i, j int) bool {
+		return movie.Releases[i].Date.Before(movie.Releases[j].Date)
+	})
+
+	for _, release := range movie.Releases {
+		if release.Type == model.ReleaseTheatrical || release.Type == model.ReleaseTheatricalLimited {
+			movie.ReleaseDate = release.Date
+			return
+		}
+	}
+	if len(movie.Releases) > 0 {
+		movie.ReleaseDate = movie.Releases[0].Date
+	}
+}
+
+// validateCertification checks an age certification against the rating system of its country.
+func validateCertification(country, certification string) error {
+	if !countryCodes[country] {
+		return fmt.Errorf("%w: %q", ErrInvalidCountry, country)
+	}
+	if certification == "" || len(certification) > maxCertificationLength {
+		return fmt.Errorf("%w: %q in %s", ErrInvalidCertification, certification, country)
+	}
+	if system, ok := certificationSystems[country]; ok && !system[certification] {
+		return fmt.Errorf("%w: %q in %s", ErrInvalidCertification, certification, country)
+	}
+	return nil
+}
+
+// normalizeFilter converts the codes of a movie filter to their canonical case and checks them against the code lists.
+func normalizeFilter(filter *model.MovieFilter) error {
+	filter.Country = strings.ToUpper(filter.Country)
+	filter.Language = strings.ToLower(filter.Language)
+	filter.OriginalLanguage = strings.ToLower(filter.OriginalLanguage)
+	filter.CertificationCountry = strings.ToUpper(filter.CertificationCountry)
+	filter.Certification = strings.ToUpper(filter.Certification)
+
+	if filter.Country != "" && !countryCodes[filter.Country] {
+		return fmt.Errorf("%w: %q", ErrInvalidCountry, filter.Country)
+	}
+	for _, language := range []string{filter.Language, filter.OriginalLanguage} {
+		if language != "" && !languageCodes[language] {
+			return fmt.Errorf("%w: %q", ErrInvalidLanguage, language)
+		}
+	}
+	if filter.MinRuntime < 0 || filter.MaxRuntime < 0 {
+		return ErrInvalidRuntime
+	}
+	if filter.ReleaseCountry != "" || filter.ReleaseType != "" {
+		filter.ReleaseCountry = strings.ToUpper(filter.ReleaseCountry)
+		filter.ReleaseType = strings.ToLower(filter.ReleaseType)
+		if !countryCodes[filter.ReleaseCountry] {
+			return fmt.Errorf("%w: %q", ErrInvalidCountry, filter.ReleaseCountry)
+		}
+		if filter.ReleaseType != "" && !releaseTypes[filter.ReleaseType] {
+			return fmt.Errorf("%w: unknown type %q", ErrInvalidRelease, filter.ReleaseType)
+		}
+	}
+	if filter.CompanyRole != "" {
+		filter.CompanyRole = strings.ToLower(filter.CompanyRole)
+		if filter.Company == uuid.Nil || !isCompanyRole(filter.CompanyRole) {
+			return fmt.Errorf("%w: %q", ErrInvalidCompanyRole, filter.CompanyRole)
+		}
+	}
+	if filter.Tags != nil {
+		tags, err := normalizeTags(filter.Tags)
+		if err != nil {
+			return err
+		}
+		filter.Tags = tags
+	}
+	filter.TagMode = strings.ToLower(filter.TagMode)
+	if filter.TagMode != "" && filter.TagMode != model.TagModeAll && filter.TagMode != model.TagModeAny {
+		return fmt.Errorf("%w: %q", ErrInvalidTagMode, filter.TagMode)
+	}
+	if filter.Certification != "" {
+		return validateCertification(filter.CertificationCountry, filter.Certification)
+	}
+	if filter.CertificationCountry != "" && !countryCodes[filter.CertificationCountry] {
+		return fmt.Errorf("%w: %q", ErrInvalidCountry, filter.CertificationCountry)
+	}
+	return nil
+}
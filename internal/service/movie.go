@@ -1,6 +1,9 @@
 package service
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/google/uuid"
 
 	"github.com/EgMeln/filmLibraryPrivate/internal/model"
@@ -9,42 +12,73 @@ import (
 
 // Sorting options for movies.
 const (
-	SortingByTitle       = 1
-	SortingByReleaseDate = 2
+	SortingByTitle          = 1
+	SortingByReleaseDate    = 2
+	SortingByWeightedRating = 3
 )
 
 // MovieService represents a service for managing movies.
 type MovieService interface {
-	Create(movie *model.Movie) error
-	Update(movieID uuid.UUID, movie model.Movie) error
-	Delete(movieID uuid.UUID) error
-	GetAllWithSorting(flag int) ([]*model.Movie, error)
+	Create(movie *model.Movie, username string) error
+	GetByID(movieID uuid.UUID) (*model.Movie, error)
+	Update(movieID uuid.UUID, movie model.Movie, username string) error
+	Patch(movieID uuid.UUID, mediaType string, patch []byte, version int, username string) (*model.Movie, error)
+	Delete(movieID uuid.UUID, version int, username string) error
+	GetAllWithSorting(flag int, filter model.MovieFilter) ([]*model.Movie, error)
 	GetByTitleFragment(titleFragment string) ([]*model.Movie, error)
 	GetByActorNameFragment(actorNameFragment string) ([]*model.Movie, error)
 }
 
 type movieService struct {
-	movieManager repository.MovieManager
+	movieManager   repository.MovieManager
+	historyManager repository.HistoryManager
 }
 
 // NewMovieService creates a new instance of the MovieService.
-func NewMovieService(movieManager repository.MovieManager) MovieService {
+func NewMovieService(movieManager repository.MovieManager, historyManager repository.HistoryManager) MovieService {
 	return &movieService{
-		movieManager: movieManager,
+		movieManager:   movieManager,
+		historyManager: historyManager,
+	}
+}
+
+// Create creates a new movie, recording the change made by the user in the history.
+func (ms *movieService) Create(movie *model.Movie, username string) error {
+	normalizeMovie(movie)
+	if err := validateMovie(ms.movieManager, movie); err != nil {
+		return err
+	}
+	prepareMovie(movie)
+
+	history, err := movieChangeEntries(model.HistoryCreate, username, movie.ID, nil, movie)
+	if err != nil {
+		return err
 	}
+
+	return ms.movieManager.Create(movie, history...)
 }
 
-// Create creates a new movie.
-func (ms *movieService) Create(movie *model.Movie) error {
-	return ms.movieManager.Create(movie)
+// GetByID retrieves a movie by its ID along with its current version.
+func (ms *movieService) GetByID(movieID uuid.UUID) (*model.Movie, error) {
+	return ms.movieManager.GetByID(movieID)
 }
 
-// Update updates an existing movie.
-func (ms *movieService) Update(movieID uuid.UUID, movie model.Movie) error {
+// Update updates an existing movie, recording the change made by the user in the history. The version of the movie
+// is the one the movie is expected to have, any version when it is zero; ErrVersionMismatch is returned when the movie
+// has another version, including when it is changed by someone else during the update.
+func (ms *movieService) Update(movieID uuid.UUID, movie model.Movie, username string) error {
 	existingMovie, err := ms.movieManager.GetByID(movieID)
 	if err != nil {
 		return err
 	}
+	if err := checkVersion(movie.Version, existingMovie.Version); err != nil {
+		return err
+	}
+	// The metadata lists of the movie are normalized in place, the copy recorded in the history keeps them as they were.
+	previousMovie := *existingMovie
+	previousMovie.Countries = slices.Clone(existingMovie.Countries)
+	previousMovie.Languages = slices.Clone(existingMovie.Languages)
+	previousMovie.Releases = slices.Clone(existingMovie.Releases)
 
 	if movie.Title != "" {
 		existingMovie.Title = movie.Title
@@ -58,30 +92,150 @@ func (ms *movieService) Update(movieID uuid.UUID, movie model.Movie) error {
 	if movie.Rating != 0 {
 		existingMovie.Rating = movie.Rating
 	}
+	if movie.RuntimeMinutes != 0 {
+		existingMovie.RuntimeMinutes = movie.RuntimeMinutes
+	}
+	if movie.OriginalTitle != "" {
+		existingMovie.OriginalTitle = movie.OriginalTitle
+	}
+	if movie.OriginalLanguage != "" {
+		existingMovie.OriginalLanguage = movie.OriginalLanguage
+	}
+	if movie.Countries != nil {
+		existingMovie.Countries = movie.Countries
+	}
+	if movie.Languages != nil {
+		existingMovie.Languages = movie.Languages
+	}
+	if movie.Certifications != nil {
+		existingMovie.Certifications = movie.Certifications
+	}
+	if movie.Releases != nil {
+		existingMovie.Releases = movie.Releases
+	}
 	if movie.Actors != nil {
 		existingMovie.Actors = movie.Actors
 	}
+	if movie.Crew != nil {
+		existingMovie.Crew = movie.Crew
+	}
+	if movie.Companies != nil {
+		existingMovie.Companies = movie.Companies
+	}
+	if movie.Tags != nil {
+		existingMovie.Tags = movie.Tags
+	}
+	normalizeMovie(existingMovie)
+	if err := validateMovie(ms.movieManager, existingMovie); err != nil {
+		return err
+	}
+	prepareMovie(existingMovie)
+
+	history, err := movieChangeEntries(model.HistoryUpdate, username, existingMovie.ID, &previousMovie, existingMovie)
+	if err != nil {
+		return err
+	}
 
-	return ms.movieManager.Update(existingMovie)
+	if err := ms.movieManager.Update(existingMovie, history...); err != nil {
+		return versionConflict(err)
+	}
+	return nil
 }
 
-// Delete deletes a movie by its ID.
-func (ms *movieService) Delete(movieID uuid.UUID) error {
-	return ms.movieManager.Delete(movieID)
+// Patch partially updates an existing movie with a JSON Merge Patch or a JSON Patch document applied to the movie
+// as returned by its reads, recording the change made by the user in the history. Unlike Update, the fields the patch
+// sets to null, zero or empty are cleared, while the read-only fields of the movie are kept. The version of the movie
+// is checked as in Update.
+func (ms *movieService) Patch(movieID uuid.UUID, mediaType string, patch []byte, version int,
+	username string) (*model.Movie, error) {
+	existingMovie, err := ms.movieManager.GetByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(version, existingMovie.Version); err != nil {
+		return nil, err
+	}
+
+	var patchedMovie model.Movie
+	if err := patchRecord(existingMovie, mediaType, patch, &patchedMovie); err != nil {
+		return nil, err
+	}
+	updatedMovie := *existingMovie
+	updatedMovie.Title = patchedMovie.Title
+	updatedMovie.Description = patchedMovie.Description
+	updatedMovie.ReleaseDate = patchedMovie.ReleaseDate
+	updatedMovie.Rating = patchedMovie.Rating
+	updatedMovie.RuntimeMinutes = patchedMovie.RuntimeMinutes
+	updatedMovie.OriginalTitle = patchedMovie.OriginalTitle
+	updatedMovie.OriginalLanguage = patchedMovie.OriginalLanguage
+	updatedMovie.Countries = patchedMovie.Countries
+	updatedMovie.Languages = patchedMovie.Languages
+	updatedMovie.Certifications = patchedMovie.Certifications
+	updatedMovie.Releases = patchedMovie.Releases
+	updatedMovie.Actors = patchedMovie.Actors
+	updatedMovie.Crew = patchedMovie.Crew
+	updatedMovie.Companies = patchedMovie.Companies
+	updatedMovie.Tags = patchedMovie.Tags
+	normalizeMovie(&updatedMovie)
+	if err := validateMovie(ms.movieManager, &updatedMovie); err != nil {
+		return nil, err
+	}
+	prepareMovie(&updatedMovie)
+
+	history, err := movieChangeEntries(model.HistoryUpdate, username, existingMovie.ID, existingMovie, &updatedMovie)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ms.movieManager.Update(&updatedMovie, history...); err != nil {
+		return nil, versionConflict(err)
+	}
+	return &updatedMovie, nil
 }
 
-// GetAllWithSorting retrieves all movies sorted by the specified flag.
-func (ms *movieService) GetAllWithSorting(flag int) ([]*model.Movie, error) {
+// Delete moves a movie to the trash by its ID, recording the change made by the user in the history.
+// The cast of the movie is kept in the trash, so only the details of the movie are recorded as deleted.
+// ErrVersionMismatch is returned when the movie does not have the expected version, any version when it is zero,
+// including when it is changed by someone else during the delete.
+func (ms *movieService) Delete(movieID uuid.UUID, version int, username string) error {
+	movie, err := ms.movieManager.GetByID(movieID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version, movie.Version); err != nil {
+		return err
+	}
+
+	history, err := changeEntries(model.HistoryMovie, movie.ID, model.HistoryDelete, username,
+		newMovieVersion(movie), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := ms.movieManager.Delete(movie.ID, movie.Version, history...); err != nil {
+		return versionConflict(err)
+	}
+	return nil
+}
+
+// GetAllWithSorting retrieves all movies matching the filter sorted by the specified flag.
+func (ms *movieService) GetAllWithSorting(flag int, filter model.MovieFilter) ([]*model.Movie, error) {
+	if err := normalizeFilter(&filter); err != nil {
+		return nil, err
+	}
+
 	var movies []*model.Movie
 	var err error
 
 	switch flag {
 	case SortingByTitle:
-		movies, err = ms.movieManager.GetByTitle()
+		movies, err = ms.movieManager.GetByTitle(filter)
 	case SortingByReleaseDate:
-		movies, err = ms.movieManager.GetByReleaseDate()
+		movies, err = ms.movieManager.GetByReleaseDate(filter)
+	case SortingByWeightedRating:
+		movies, err = ms.movieManager.GetByWeightedRatingDesc(filter)
 	default:
-		movies, err = ms.movieManager.GetByRatingDesc()
+		movies, err = ms.movieManager.GetByRatingDesc(filter)
 	}
 
 	if err != nil {
@@ -99,3 +253,32 @@ func (ms *movieService) GetByTitleFragment(titleFragment string) ([]*model.Movie
 func (ms *movieService) GetByActorNameFragment(actorNameFragment string) ([]*model.Movie, error) {
 	return ms.movieManager.GetByActorNameFragment(actorNameFragment)
 }
+
+// normalizeMovie converts the codes, roles and tags of a movie to the case they are stored in. The order of the lists
+// of the movie is kept, so that validation errors point at their elements as given.
+func normalizeMovie(movie *model.Movie) {
+	normalizeMetadata(movie)
+	for i := range movie.Companies {
+		movie.Companies[i].Role = strings.ToLower(movie.Companies[i].Role)
+	}
+	for i := range movie.Tags {
+		movie.Tags[i] = tagName(movie.Tags[i])
+	}
+}
+
+// prepareMovie completes the details of a validated movie as they are stored: the duplicate tags are removed,
+// the release date is derived from the releases and the cast is given billing orders.
+func prepareMovie(movie *model.Movie) {
+	movie.Tags = uniqueTags(movie.Tags)
+	applyReleases(movie)
+	fillBillingOrder(movie.Actors)
+}
+
+// fillBillingOrder uses the position of an actor in the cast as billing order when none is provided.
+func fillBillingOrder(cast []model.CastMember) {
+	for i := range cast {
+		if cast[i].BillingOrder == 0 {
+			cast[i].BillingOrder = i + 1
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"database/sql"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -11,43 +13,49 @@ import (
 )
 
 type mockMovieManager struct {
-	CreateFunc                 func(movie *model.Movie) error
+	CreateFunc                 func(movie *model.Movie, history []*model.HistoryEntry) error
 	GetByIDFunc                func(movieID uuid.UUID) (*model.Movie, error)
-	UpdateFunc                 func(movie *model.Movie) error
-	DeleteFunc                 func(movieID uuid.UUID) error
-	GetByTitleFunc             func() ([]*model.Movie, error)
-	GetByReleaseDateFunc       func() ([]*model.Movie, error)
-	GetByRatingDescFunc        func() ([]*model.Movie, error)
+	UpdateFunc                 func(movie *model.Movie, history []*model.HistoryEntry) error
+	DeleteFunc                 func(movieID uuid.UUID, version int, history []*model.HistoryEntry) error
+	GetByTitleFunc             func(filter model.MovieFilter) ([]*model.Movie, error)
+	GetByReleaseDateFunc       func(filter model.MovieFilter) ([]*model.Movie, error)
+	GetByRatingDescFunc        func(filter model.MovieFilter) ([]*model.Movie, error)
+	GetByWeightedRatingFunc    func(filter model.MovieFilter) ([]*model.Movie, error)
 	GetByTitleFragmentFunc     func(titleFragment string) ([]*model.Movie, error)
 	GetByActorNameFragmentFunc func(actorNameFragment string) ([]*model.Movie, error)
+	GetMissingPeopleFunc       func(personIDs []uuid.UUID) ([]uuid.UUID, error)
 }
 
-func (m *mockMovieManager) Create(movie *model.Movie) error {
-	return m.CreateFunc(movie)
+func (m *mockMovieManager) Create(movie *model.Movie, history ...*model.HistoryEntry) error {
+	return m.CreateFunc(movie, history)
 }
 
 func (m *mockMovieManager) GetByID(movieID uuid.UUID) (*model.Movie, error) {
 	return m.GetByIDFunc(movieID)
 }
 
-func (m *mockMovieManager) Update(movie *model.Movie) error {
-	return m.UpdateFunc(movie)
+func (m *mockMovieManager) Update(movie *model.Movie, history ...*model.HistoryEntry) error {
+	return m.UpdateFunc(movie, history)
 }
 
-func (m *mockMovieManager) Delete(movieID uuid.UUID) error {
-	return m.DeleteFunc(movieID)
+func (m *mockMovieManager) Delete(movieID uuid.UUID, version int, history ...*model.HistoryEntry) error {
+	return m.DeleteFunc(movieID, version, history)
 }
 
-func (m *mockMovieManager) GetByTitle() ([]*model.Movie, error) {
-	return m.GetByTitleFunc()
+func (m *mockMovieManager) GetByTitle(filter model.MovieFilter) ([]*model.Movie, error) {
+	return m.GetByTitleFunc(filter)
 }
 
-func (m *mockMovieManager) GetByReleaseDate() ([]*model.Movie, error) {
-	return m.GetByReleaseDateFunc()
+func (m *mockMovieManager) GetByReleaseDate(filter model.MovieFilter) ([]*model.Movie, error) {
+	return m.GetByReleaseDateFunc(filter)
 }
 
-func (m *mockMovieManager) GetByRatingDesc() ([]*model.Movie, error) {
-	return m.GetByRatingDescFunc()
+func (m *mockMovieManager) GetByRatingDesc(filter model.MovieFilter) ([]*model.Movie, error) {
+	return m.GetByRatingDescFunc(filter)
+}
+
+func (m *mockMovieManager) GetByWeightedRatingDesc(filter model.MovieFilter) ([]*model.Movie, error) {
+	return m.GetByWeightedRatingFunc(filter)
 }
 
 func (m *mockMovieManager) GetByTitleFragment(titleFragment string) ([]*model.Movie, error) {
@@ -58,11 +66,18 @@ func (m *mockMovieManager) GetByActorNameFragment(actorNameFragment string) ([]*
 	return m.GetByActorNameFragmentFunc(actorNameFragment)
 }
 
+func (m *mockMovieManager) GetMissingPeople(personIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if m.GetMissingPeopleFunc == nil {
+		return nil, nil
+	}
+	return m.GetMissingPeopleFunc(personIDs)
+}
+
 func TestMovieService_Create(t *testing.T) {
 	t.Parallel()
 
 	mockManager := &mockMovieManager{
-		CreateFunc: func(movie *model.Movie) error {
+		CreateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
 			return nil
 		},
 	}
@@ -83,21 +98,247 @@ func TestMovieService_Create(t *testing.T) {
 			},
 			expectedResult: nil,
 		},
+		{
+			name: "InvalidCreditRole",
+			movie: &model.Movie{
+				ID:    uuid.New(),
+				Title: "Barbi",
+				Crew: []model.Credit{
+					{PersonID: uuid.New(), Role: "gaffer"},
+				},
+			},
+			expectedResult: ErrValidation,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ms := NewMovieService(mockManager)
+			ms := NewMovieService(mockManager, &mockHistoryManager{})
 			
-			err := ms.Create(tt.movie)
+			err := ms.Create(tt.movie, "admin")
 
-			if err != nil && err.Error() != tt.expectedResult.Error() {
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
+
+func TestMovieService_CreateFillsBillingOrder(t *testing.T) {
+	t.Parallel()
+
+	var created *model.Movie
+	mockManager := &mockMovieManager{
+		CreateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+			created = movie
+			return nil
+		},
+	}
+
+	movie := &model.Movie{
+		Title:       "Forrest Gump",
+		ReleaseDate: time.Date(1994, 7, 6, 0, 0, 0, 0, time.UTC),
+		Actors: []model.CastMember{
+			{Actor: model.Actor{ID: uuid.New(), Name: "Tom Hanks"}, CharacterName: "Forrest Gump"},
+			{Actor: model.Actor{ID: uuid.New(), Name: "Robin Wright"}, CharacterName: "Jenny Curran"},
+			{Actor: model.Actor{ID: uuid.New(), Name: "Gary Sinise"}, CharacterName: "Lt. Dan Taylor", BillingOrder: 10},
+		},
+	}
+
+	ms := NewMovieService(mockManager, &mockHistoryManager{})
+	if err := ms.Create(movie, "admin"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedOrder := []int{1, 2, 10}
+	for i, actor := range created.Actors {
+		if actor.BillingOrder != expectedOrder[i] {
+			t.Errorf("Expected billing order %d for %s, got: %d", expectedOrder[i], actor.Name, actor.BillingOrder)
+		}
+	}
+}
+
+func TestMovieService_CreateValidatesMetadata(t *testing.T) {
+	t.Parallel()
+
+	mockManager := &mockMovieManager{
+		CreateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+			return nil
+		},
+	}
+
+	released := time.Date(2001, 4, 25, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		movie          *model.Movie
+		expectedResult error
+	}{
+		{
+			name: "Valid",
+			movie: &model.Movie{
+				Title:            "Amelie",
+				ReleaseDate:      released,
+				RuntimeMinutes:   122,
+				OriginalTitle:    "Le Fabuleux Destin d'Amelie Poulain",
+				OriginalLanguage: "FR",
+				Countries:        []string{"fr", "de"},
+				Languages:        []string{"fr"},
+				Certifications:   map[string]string{"us": "r", "GB": "15"},
+			},
+		},
+		{
+			name:           "NegativeRuntime",
+			movie:          &model.Movie{Title: "Amelie", ReleaseDate: released, RuntimeMinutes: -1},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "UnknownCountry",
+			movie:          &model.Movie{Title: "Amelie", ReleaseDate: released, Countries: []string{"XX"}},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "UnknownLanguage",
+			movie:          &model.Movie{Title: "Amelie", ReleaseDate: released, Languages: []string{"fr", "xx"}},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "UnknownCertification",
+			movie:          &model.Movie{Title: "Amelie", ReleaseDate: released, Certifications: map[string]string{"US": "15"}},
+			expectedResult: ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := NewMovieService(mockManager, &mockHistoryManager{})
+
+			err := ms.Create(tt.movie, "admin")
+
+			if !errors.Is(err, tt.expectedResult) {
 				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
 			}
 		})
 	}
 }
 
+func TestMovieService_CreateNormalizesMetadata(t *testing.T) {
+	t.Parallel()
+
+	var created *model.Movie
+	mockManager := &mockMovieManager{
+		CreateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+			created = movie
+			return nil
+		},
+	}
+
+	movie := &model.Movie{
+		Title:            "Amelie",
+		ReleaseDate:      time.Date(2001, 4, 25, 0, 0, 0, 0, time.UTC),
+		OriginalLanguage: "FR",
+		Countries:        []string{"fr"},
+		Languages:        []string{"FR"},
+		Certifications:   map[string]string{"us": "pg-13"},
+	}
+
+	ms := NewMovieService(mockManager, &mockHistoryManager{})
+	if err := ms.Create(movie, "admin"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if created.OriginalLanguage != "fr" || created.Countries[0] != "FR" || created.Languages[0] != "fr" {
+		t.Errorf("Expected normalized codes, got: %q, %v, %v", created.OriginalLanguage, created.Countries, created.Languages)
+	}
+	if created.Certifications["US"] != "PG-13" {
+		t.Errorf("Expected certification PG-13 in US, got: %v", created.Certifications)
+	}
+}
+
+func TestMovieService_CreateDerivesReleaseDate(t *testing.T) {
+	t.Parallel()
+
+	var created *model.Movie
+	mockManager := &mockMovieManager{
+		CreateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+			created = movie
+			return nil
+		},
+	}
+
+	premiere := time.Date(2001, time.April, 25, 0, 0, 0, 0, time.UTC)
+	theatrical := time.Date(2001, time.November, 2, 0, 0, 0, 0, time.UTC)
+	digital := time.Date(2002, time.July, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		releases            []model.ReleaseEvent
+		expectedReleaseDate time.Time
+		expectedResult      error
+	}{
+		{
+			name: "EarliestTheatrical",
+			releases: []model.ReleaseEvent{
+				{Country: "us", Type: "digital", Date: digital},
+				{Country: "US", Type: "theatrical_limited", Date: theatrical},
+				{Country: "FR", Type: "premiere", Date: premiere, Note: "Cannes"},
+			},
+			expectedReleaseDate: theatrical,
+		},
+		{
+			name:                "EarliestOfAnyType",
+			releases:            []model.ReleaseEvent{{Country: "US", Type: "digital", Date: digital}, {Country: "FR", Type: "premiere", Date: premiere}},
+			expectedReleaseDate: premiere,
+		},
+		{
+			name:                "NoReleases",
+			expectedReleaseDate: time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "UnknownType",
+			releases:       []model.ReleaseEvent{{Country: "US", Type: "drive-in", Date: digital}},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "MissingDate",
+			releases:       []model.ReleaseEvent{{Country: "US", Type: "digital"}},
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "Duplicate",
+			releases:       []model.ReleaseEvent{{Country: "US", Type: "digital", Date: digital}, {Country: "us", Type: "digital", Date: premiere}},
+			expectedResult: ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := NewMovieService(mockManager, &mockHistoryManager{})
+
+			movie := &model.Movie{
+				Title:       "Amelie",
+				ReleaseDate: time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Releases:    tt.releases,
+			}
+			err := ms.Create(movie, "admin")
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Fatalf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err != nil {
+				return
+			}
+			if !created.ReleaseDate.Equal(tt.expectedReleaseDate) {
+				t.Errorf("Expected release date %v, got: %v", tt.expectedReleaseDate, created.ReleaseDate)
+			}
+			for i := 1; i < len(created.Releases); i++ {
+				if created.Releases[i].Date.Before(created.Releases[i-1].Date) {
+					t.Errorf("Expected releases ordered by date, got: %+v", created.Releases)
+				}
+			}
+		})
+	}
+}
+
 func TestMovieService_Update(t *testing.T) {
 	t.Parallel()
 
@@ -114,7 +355,7 @@ func TestMovieService_Update(t *testing.T) {
 				Rating:      8,
 			}, nil
 		},
-		UpdateFunc: func(movie *model.Movie) error {
+		UpdateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
 			return nil
 		},
 	}
@@ -134,9 +375,9 @@ func TestMovieService_Update(t *testing.T) {
 				Description: "Barbie and Ken are having the time of their lives in the colorful and seemingly perfect world of Barbie Land.",
 				ReleaseDate: time.Date(2024, time.July, 16, 0, 0, 0, 0, time.UTC),
 				Rating:      8,
-				Actors: []model.Actor{
+				Actors: []model.CastMember{
 					{
-						Name: "Tom Hanks",
+						Actor: model.Actor{ID: uuid.New(), Name: "Tom Hanks"},
 					},
 				},
 			},
@@ -158,9 +399,9 @@ func TestMovieService_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ms := NewMovieService(mockManager)
+			ms := NewMovieService(mockManager, &mockHistoryManager{})
 
-			err := ms.Update(tt.movieID, tt.movie)
+			err := ms.Update(tt.movieID, tt.movie, "admin")
 
 			if err != nil && err.Error() != tt.expectedResult.Error() {
 				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
@@ -172,8 +413,19 @@ func TestMovieService_Update(t *testing.T) {
 func TestMovieService_Delete(t *testing.T) {
 	t.Parallel()
 
+	changedID := uuid.New()
 	mockManager := &mockMovieManager{
-		DeleteFunc: func(movieID uuid.UUID) error {
+		GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+			return &model.Movie{ID: movieID, Title: "Cast Away", Version: 2}, nil
+		},
+		DeleteFunc: func(movieID uuid.UUID, version int, history []*model.HistoryEntry) error {
+			if version != 2 {
+				return errors.New("unexpected version")
+			}
+			// The movie is changed by someone else after it is read.
+			if movieID == changedID {
+				return sql.ErrNoRows
+			}
 			return nil
 		},
 	}
@@ -181,6 +433,7 @@ func TestMovieService_Delete(t *testing.T) {
 	tests := []struct {
 		name           string
 		movieID        uuid.UUID
+		version        int
 		expectedResult error
 	}{
 		{
@@ -188,13 +441,31 @@ func TestMovieService_Delete(t *testing.T) {
 			movieID:        uuid.New(),
 			expectedResult: nil,
 		},
+		{
+			name:           "MatchingVersion",
+			movieID:        uuid.New(),
+			version:        2,
+			expectedResult: nil,
+		},
+		{
+			name:           "VersionMismatch",
+			movieID:        uuid.New(),
+			version:        1,
+			expectedResult: ErrVersionMismatch,
+		},
+		{
+			name:           "ChangedDuringDelete",
+			movieID:        changedID,
+			version:        2,
+			expectedResult: ErrVersionMismatch,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ms := NewMovieService(mockManager)
+			ms := NewMovieService(mockManager, &mockHistoryManager{})
 
-			err := ms.Delete(tt.movieID)
+			err := ms.Delete(tt.movieID, tt.version, "admin")
 
 			if err != nil && err.Error() != tt.expectedResult.Error() {
 				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
@@ -207,24 +478,30 @@ func TestMovieService_GetAllWithSorting(t *testing.T) {
 	t.Parallel()
 
 	mockManager := &mockMovieManager{
-		GetByTitleFunc: func() ([]*model.Movie, error) {
+		GetByTitleFunc: func(filter model.MovieFilter) ([]*model.Movie, error) {
 			return []*model.Movie{
 				{Title: "Movie1"},
 				{Title: "Movie2"},
 			}, nil
 		},
-		GetByReleaseDateFunc: func() ([]*model.Movie, error) {
+		GetByReleaseDateFunc: func(filter model.MovieFilter) ([]*model.Movie, error) {
 			return []*model.Movie{
 				{Title: "Movie1", ReleaseDate: time.Date(2024, time.July, 16, 0, 0, 0, 0, time.UTC)},
 				{Title: "Movie2", ReleaseDate: time.Date(2023, time.July, 16, 0, 0, 0, 0, time.UTC)},
 			}, nil
 		},
-		GetByRatingDescFunc: func() ([]*model.Movie, error) {
+		GetByRatingDescFunc: func(filter model.MovieFilter) ([]*model.Movie, error) {
 			return []*model.Movie{
 				{Title: "Movie2", Rating: 8},
 				{Title: "Movie1", Rating: 7},
 			}, nil
 		},
+		GetByWeightedRatingFunc: func(filter model.MovieFilter) ([]*model.Movie, error) {
+			return []*model.Movie{
+				{Title: "Movie1", UserRating: model.RatingSummary{Weighted: 8.1}},
+				{Title: "Movie2", UserRating: model.RatingSummary{Weighted: 6.4}},
+			}, nil
+		},
 	}
 
 	tests := []struct {
@@ -242,6 +519,11 @@ func TestMovieService_GetAllWithSorting(t *testing.T) {
 			flag:           SortingByReleaseDate,
 			expectedResult: []*model.Movie{{Title: "Movie1", ReleaseDate: time.Date(2024, time.July, 16, 0, 0, 0, 0, time.UTC)}, {Title: "Movie2", ReleaseDate: time.Date(2023, time.July, 16, 0, 0, 0, 0, time.UTC)}},
 		},
+		{
+			name:           "SortingByWeightedRating",
+			flag:           SortingByWeightedRating,
+			expectedResult: []*model.Movie{{Title: "Movie1"}, {Title: "Movie2"}},
+		},
 		{
 			name:           "SortingByRating",
 			expectedResult: []*model.Movie{{Title: "Movie2", Rating: 8}, {Title: "Movie1", Rating: 7}},
@@ -250,9 +532,9 @@ func TestMovieService_GetAllWithSorting(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ms := NewMovieService(mockManager)
+			ms := NewMovieService(mockManager, &mockHistoryManager{})
 
-			movies, err := ms.GetAllWithSorting(tt.flag)
+			movies, err := ms.GetAllWithSorting(tt.flag, model.MovieFilter{})
 
 			if err != nil {
 				t.Errorf("Expected no error, got: %v", err)
@@ -297,7 +579,7 @@ func TestMovieService_GetByTitleFragment(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ms := NewMovieService(mockManager)
+			ms := NewMovieService(mockManager, &mockHistoryManager{})
 
 			movies, err := ms.GetByTitleFragment(tt.titleFragment)
 
@@ -343,7 +625,7 @@ func TestMovieService_GetByActorNameFragment(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ms := NewMovieService(mockManager)
+			ms := NewMovieService(mockManager, &mockHistoryManager{})
 
 			movies, err := ms.GetByActorNameFragment(tt.actorNameFragment)
 
@@ -363,3 +645,51 @@ func TestMovieService_GetByActorNameFragment(t *testing.T) {
 		})
 	}
 }
+
+func TestMovieService_GetAllWithSortingFilter(t *testing.T) {
+	t.Parallel()
+
+	var received model.MovieFilter
+	mockManager := &mockMovieManager{
+		GetByTitleFunc: func(filter model.MovieFilter) ([]*model.Movie, error) {
+			received = filter
+			return nil, nil
+		},
+	}
+
+	ms := NewMovieService(mockManager, &mockHistoryManager{})
+
+	filter := model.MovieFilter{Country: "fr", Language: "EN", CertificationCountry: "us", Certification: "pg-13", MaxRuntime: 120}
+	if _, err := ms.GetAllWithSorting(SortingByTitle, filter); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := model.MovieFilter{Country: "FR", Language: "en", CertificationCountry: "US", Certification: "PG-13", MaxRuntime: 120}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Expected filter: %+v, got: %+v", expected, received)
+	}
+
+	if _, err := ms.GetAllWithSorting(SortingByTitle, model.MovieFilter{Country: "XX"}); !errors.Is(err, ErrInvalidCountry) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidCountry, err)
+	}
+	if _, err := ms.GetAllWithSorting(SortingByTitle, model.MovieFilter{Certification: "R"}); !errors.Is(err, ErrInvalidCountry) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidCountry, err)
+	}
+	if _, err := ms.GetAllWithSorting(SortingByTitle, model.MovieFilter{ReleaseType: "digital"}); !errors.Is(err, ErrInvalidCountry) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidCountry, err)
+	}
+	if _, err := ms.GetAllWithSorting(SortingByTitle, model.MovieFilter{ReleaseCountry: "US", ReleaseType: "vhs"}); !errors.Is(err, ErrInvalidRelease) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidRelease, err)
+	}
+	if _, err := ms.GetAllWithSorting(SortingByTitle, model.MovieFilter{Tags: []string{"heist"}, TagMode: "none"}); !errors.Is(err, ErrInvalidTagMode) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidTagMode, err)
+	}
+
+	filter = model.MovieFilter{Tags: []string{" Time  Travel", "heist", "time travel"}, TagMode: "ANY"}
+	if _, err := ms.GetAllWithSorting(SortingByTitle, filter); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected = model.MovieFilter{Tags: []string{"time travel", "heist"}, TagMode: model.TagModeAny}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Expected filter: %+v, got: %+v", expected, received)
+	}
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+// Errors returned when a patch cannot be applied to a movie or an actor.
+var (
+	ErrUnsupportedPatch = errors.New("unsupported patch media type")
+	ErrInvalidPatch     = errors.New("invalid patch")
+	ErrPatchConflict    = errors.New("patch cannot be applied to the current state")
+)
+
+// patchOperation represents an operation of a JSON Patch document.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"` // Nil when the member is absent, unlike a null value
+}
+
+// patchRecord applies a patch of the given media type to the JSON representation of a record, as returned by its
+// reads, and decodes the patched document into result. Fields absent from the patched document are left zero in
+// result, so a patch can clear any field; fields unknown to the record are rejected.
+func patchRecord(record interface{}, mediaType string, patch []byte, result interface{}) error {
+	document, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	switch mediaType {
+	case model.PatchMerge:
+		document, err = applyMergePatch(document, patch)
+	case model.PatchJSON:
+		document, err = applyJSONPatch(document, patch)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedPatch, mediaType)
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(document, []byte("{")) {
+		return fmt.Errorf("%w: the patched document must be an object", ErrInvalidPatch)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(result); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return nil
+}
+
+// applyMergePatch applies a JSON Merge Patch to a JSON document: members of the patch replace the ones of the
+// document, objects being merged recursively, and null members remove them.
+func applyMergePatch(document, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(document)
+	if err != nil {
+		return nil, err
+	}
+	mergePatch, err := decodeJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(mergeValue(target, mergePatch))
+}
+
+func mergeValue(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergeValue(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// applyJSONPatch applies the operations of a JSON Patch to a JSON document in order, none of them being applied
+// when one fails.
+func applyJSONPatch(document, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(document)
+	if err != nil {
+		return nil, err
+	}
+	var operations []patchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	for i, operation := range operations {
+		target, err = applyOperation(target, operation)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func applyOperation(target interface{}, operation patchOperation) (interface{}, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, fmt.Errorf("%w: %s operation without a value", ErrInvalidPatch, operation.Op)
+		}
+		value, err := decodeJSON(operation.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch operation.Op {
+		case "add":
+			return addValue(target, path, value)
+		case "replace":
+			return replaceValue(target, path, value)
+		}
+		current, err := getValue(target, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equalValues(current, value) {
+			return nil, fmt.Errorf("%w: test of %q failed", ErrPatchConflict, operation.Path)
+		}
+		return target, nil
+	case "remove":
+		target, _, err = removeValue(target, path)
+		return target, err
+	case "move", "copy":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if operation.Op == "move" {
+			if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+				return nil, fmt.Errorf("%w: cannot move %q into itself", ErrInvalidPatch, operation.From)
+			}
+			target, value, err = removeValue(target, from)
+		} else {
+			value, err = getValue(target, from)
+			value = copyValue(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return addValue(target, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, operation.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer into its unescaped reference tokens, none for the whole document.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: invalid path %q", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses the index of an array element referenced by a token, which must be lower than limit.
+func arrayIndex(token string, limit int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || strconv.Itoa(index) != token {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+	if index >= limit {
+		return 0, fmt.Errorf("%w: array index %d out of range", ErrPatchConflict, index)
+	}
+	return index, nil
+}
+
+func getValue(target interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch container := target.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q not found", ErrPatchConflict, token)
+			}
+			target = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			target = container[index]
+		default:
+			return nil, fmt.Errorf("%w: %q is not an object or an array", ErrPatchConflict, token)
+		}
+	}
+	return target, nil
+}
+
+// updateParent applies update to the object or array holding the value referenced by a non-empty path, storing
+// the containers returned along the way back into their parents so that arrays can grow and shrink.
+func updateParent(target interface{}, path []string,
+	update func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return update(target, path[0])
+	}
+
+	child, err := getValue(target, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = updateParent(child, path[1:], update)
+	if err != nil {
+		return nil, err
+	}
+
+	switch container := target.(type) {
+	case map[string]interface{}:
+		container[path[0]] = child
+	case []interface{}:
+		index, _ := arrayIndex(path[0], len(container))
+		container[index] = child
+	}
+	return target, nil
+}
+
+func addValue(target interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateParent(target, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			index := len(container)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(container)+1); err != nil {
+					return nil, err
+				}
+			}
+			return slices.Insert(container, index, value), nil
+		default:
+			return nil, fmt.Errorf("%w: %q is not an object or an array", ErrPatchConflict, token)
+		}
+	})
+}
+
+func replaceValue(target interface{}, path []string, value interface{}) (interface{}, error) {
+	if _, err := getValue(target, path); err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateParent(target, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			container[token] = value
+		case []interface{}:
+			index, _ := arrayIndex(token, len(container))
+			container[index] = value
+		}
+		return container, nil
+	})
+}
+
+func removeValue(target interface{}, path []string) (interface{}, interface{}, error) {
+	removed, err := getValue(target, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+
+	target, err = updateParent(target, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			index, _ := arrayIndex(token, len(container))
+			return slices.Delete(container, index, index+1), nil
+		}
+		return container, nil
+	})
+	return target, removed, err
+}
+
+// decodeJSON decodes a JSON value keeping its numbers as written.
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+func copyValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(value))
+		for name, member := range value {
+			object[name] = copyValue(member)
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(value))
+		for i, element := range value {
+			array[i] = copyValue(element)
+		}
+		return array
+	default:
+		return value
+	}
+}
+
+// equalValues reports whether two JSON values are equal, numbers being compared by value.
+func equalValues(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for name, member := range a {
+			other, ok := b[name]
+			if !ok || !equalValues(member, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalValues(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, errA := a.Float64()
+		y, errB := b.Float64()
+		return errA == nil && errB == nil && x == y
+	default:
+		return a == b
+	}
+}
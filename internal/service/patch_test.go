@@ -0,0 +1,272 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "ReplaceAndRemove",
+			document: `{"a":"b","c":{"d":"e","f":"g"}}`,
+			patch:    `{"a":"z","c":{"f":null}}`,
+			expected: `{"a":"z","c":{"d":"e"}}`,
+		},
+		{
+			name:     "ReplaceArray",
+			document: `{"a":[{"b":"c"}],"e":1}`,
+			patch:    `{"a":[1],"e":0}`,
+			expected: `{"a":[1],"e":0}`,
+		},
+		{
+			name:     "AddNestedObject",
+			document: `{"a":"b"}`,
+			patch:    `{"c":{"d":null,"e":"f"}}`,
+			expected: `{"a":"b","c":{"e":"f"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := applyMergePatch([]byte(tt.document), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("Expected %s, got: %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		document       string
+		patch          string
+		expected       string
+		expectedResult error
+	}{
+		{
+			name:     "AddObjectMember",
+			document: `{"foo":"bar"}`,
+			patch:    `[{"op":"add","path":"/baz","value":"qux"}]`,
+			expected: `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:     "AddArrayElement",
+			document: `{"foo":["bar","baz"]}`,
+			patch:    `[{"op":"add","path":"/foo/1","value":"qux"},{"op":"add","path":"/foo/-","value":"end"}]`,
+			expected: `{"foo":["bar","qux","baz","end"]}`,
+		},
+		{
+			name:     "RemoveArrayElement",
+			document: `{"foo":["bar","qux","baz"]}`,
+			patch:    `[{"op":"remove","path":"/foo/1"}]`,
+			expected: `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:     "ReplaceWithNull",
+			document: `{"baz":"qux","foo":"bar"}`,
+			patch:    `[{"op":"replace","path":"/baz","value":null}]`,
+			expected: `{"baz":null,"foo":"bar"}`,
+		},
+		{
+			name:     "MoveAndCopy",
+			document: `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"},` +
+				`{"op":"copy","from":"/qux/corge","path":"/foo/corge"}]`,
+			expected: `{"foo":{"bar":"baz","corge":"grault"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:     "EscapedPointer",
+			document: `{"a/b":1,"m~n":2}`,
+			patch:    `[{"op":"test","path":"/a~1b","value":1.0},{"op":"remove","path":"/m~0n"}]`,
+			expected: `{"a/b":1}`,
+		},
+		{
+			name:           "TestFailed",
+			document:       `{"baz":"qux"}`,
+			patch:          `[{"op":"test","path":"/baz","value":"bar"}]`,
+			expectedResult: ErrPatchConflict,
+		},
+		{
+			name:           "MissingMember",
+			document:       `{"foo":"bar"}`,
+			patch:          `[{"op":"replace","path":"/baz","value":1}]`,
+			expectedResult: ErrPatchConflict,
+		},
+		{
+			name:           "MissingValue",
+			document:       `{"foo":"bar"}`,
+			patch:          `[{"op":"add","path":"/baz"}]`,
+			expectedResult: ErrInvalidPatch,
+		},
+		{
+			name:           "UnknownOperation",
+			document:       `{"foo":"bar"}`,
+			patch:          `[{"op":"append","path":"/foo","value":1}]`,
+			expectedResult: ErrInvalidPatch,
+		},
+		{
+			name:           "MoveIntoChild",
+			document:       `{"foo":{"bar":1}}`,
+			patch:          `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`,
+			expectedResult: ErrInvalidPatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := applyJSONPatch([]byte(tt.document), []byte(tt.patch))
+			if !errors.Is(err, tt.expectedResult) {
+				t.Fatalf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && string(result) != tt.expected {
+				t.Errorf("Expected %s, got: %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMovieService_Patch(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+	tests := []struct {
+		name           string
+		mediaType      string
+		patch          string
+		expectedResult error
+		check          func(movie *model.Movie) bool
+	}{
+		{
+			name:      "ClearFields",
+			mediaType: model.PatchMerge,
+			patch:     `{"Rating":0,"Description":null,"Actors":[]}`,
+			check: func(movie *model.Movie) bool {
+				return movie.Title == "Heat" && movie.Rating == 0 && movie.Description == "" && len(movie.Actors) == 0
+			},
+		},
+		{
+			name:      "JSONPatch",
+			mediaType: model.PatchJSON,
+			patch: `[{"op":"test","path":"/Rating","value":7},{"op":"replace","path":"/Rating","value":8},` +
+				`{"op":"replace","path":"/Actors/0/CharacterName","value":"Lt. Vincent Hanna"}]`,
+			check: func(movie *model.Movie) bool {
+				return movie.Rating == 8 && movie.Description == "Crime saga" && len(movie.Actors) == 1 &&
+					movie.Actors[0].ID == actorID && movie.Actors[0].CharacterName == "Lt. Vincent Hanna"
+			},
+		},
+		{
+			name:      "ReadOnlyFieldsKept",
+			mediaType: model.PatchMerge,
+			patch:     `{"ReviewCount":100,"Version":9}`,
+			check: func(movie *model.Movie) bool {
+				return movie.ReviewCount == 3 && movie.Version == 2
+			},
+		},
+		{
+			name:           "UnknownField",
+			mediaType:      model.PatchMerge,
+			patch:          `{"Budget":1000000}`,
+			expectedResult: ErrInvalidPatch,
+		},
+		{
+			name:           "InvalidRuntime",
+			mediaType:      model.PatchMerge,
+			patch:          `{"RuntimeMinutes":-5}`,
+			expectedResult: ErrValidation,
+		},
+		{
+			name:           "UnsupportedMediaType",
+			mediaType:      "application/json",
+			patch:          `{"Rating":0}`,
+			expectedResult: ErrUnsupportedPatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *model.Movie
+			movieManager := &mockMovieManager{
+				GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+					return &model.Movie{ID: movieID, Title: "Heat", Description: "Crime saga",
+						ReleaseDate: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC), Rating: 7, ReviewCount: 3, Version: 2, Actors: []model.CastMember{
+							{Actor: model.Actor{ID: actorID, Name: "Al Pacino"}, CharacterName: "Vincent Hanna",
+								BillingOrder: 1},
+						}}, nil
+				},
+				UpdateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+					updated = movie
+					return nil
+				},
+			}
+			ms := NewMovieService(movieManager, &mockHistoryManager{})
+
+			movie, err := ms.Patch(uuid.New(), tt.mediaType, []byte(tt.patch), 2, "admin")
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Fatalf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err != nil {
+				return
+			}
+			if updated == nil || !tt.check(movie) {
+				data, _ := json.Marshal(movie)
+				t.Errorf("Unexpected patched movie: %s", data)
+			}
+		})
+	}
+}
+
+func TestActorService_Patch(t *testing.T) {
+	t.Parallel()
+
+	var recorded *model.HistoryEntry
+	actorManager := &mockActorManager{
+		GetByIDFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+			return &model.Actor{ID: actorID, Name: "Al Pacino", Biography: "Actor", Aliases: []string{"Sonny"},
+				Version: 4}, nil
+		},
+		UpdateFunc: func(actorID uuid.UUID, actor *model.Actor, history []*model.HistoryEntry) error {
+			if len(history) > 0 {
+				recorded = history[0]
+			}
+			return nil
+		},
+	}
+	as := NewActorService(actorManager, nil, &mockHistoryManager{})
+
+	_, err := as.Patch(uuid.New(), model.PatchMerge, []byte(`{"Biography":null}`), 3, "admin")
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("Expected error: %v, got: %v", ErrVersionMismatch, err)
+	}
+
+	actor, err := as.Patch(uuid.New(), model.PatchMerge, []byte(`{"Biography":null,"Aliases":[]}`), 4, "admin")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if actor.Name != "Al Pacino" || actor.Biography != "" || len(actor.Aliases) != 0 {
+		t.Errorf("Expected the biography and aliases to be cleared, got: %+v", actor)
+	}
+	if recorded == nil || len(recorded.Diff) != 2 {
+		t.Errorf("Expected the change of the biography and aliases to be recorded, got: %+v", recorded)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Bounds of the score a user can give to a movie.
+const (
+	MinScore = 1
+	MaxScore = 10
+)
+
+// ErrInvalidScore is returned when a user score is out of the allowed range.
+var ErrInvalidScore = errors.New("score must be between 1 and 10")
+
+// RatingService represents a service for managing the ratings users give to movies.
+type RatingService interface {
+	SetMyRating(username string, movieID uuid.UUID, score int) (*model.UserRating, error)
+	DeleteMyRating(username string, movieID uuid.UUID) error
+}
+
+type ratingService struct {
+	ratingManager repository.RatingManager
+	movieManager  repository.MovieManager
+	userManager   repository.UserManager
+}
+
+// NewRatingService creates a new instance of the RatingService.
+func NewRatingService(ratingManager repository.RatingManager, movieManager repository.MovieManager,
+	userManager repository.UserManager) RatingService {
+	return &ratingService{
+		ratingManager: ratingManager,
+		movieManager:  movieManager,
+		userManager:   userManager,
+	}
+}
+
+// SetMyRating creates or replaces the score the user gives to a movie.
+func (rs *ratingService) SetMyRating(username string, movieID uuid.UUID, score int) (*model.UserRating, error) {
+	if score < MinScore || score > MaxScore {
+		return nil, ErrInvalidScore
+	}
+
+	userID, err := lookupUserID(rs.userManager, username)
+	if err != nil {
+		return nil, err
+	}
+	movie, err := rs.movieManager.GetByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	rating := &model.UserRating{
+		UserID:  userID,
+		MovieID: movie.ID,
+		Score:   score,
+		RatedAt: time.Now().UTC(),
+	}
+	if err := rs.ratingManager.Set(rating); err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+// DeleteMyRating removes the score the user gave to a movie.
+func (rs *ratingService) DeleteMyRating(username string, movieID uuid.UUID) error {
+	userID, err := lookupUserID(rs.userManager, username)
+	if err != nil {
+		return err
+	}
+
+	return rs.ratingManager.Delete(userID, movieID)
+}
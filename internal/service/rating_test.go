@@ -0,0 +1,178 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockRatingManager struct {
+	SetFunc    func(rating *model.UserRating) error
+	GetFunc    func(userID, movieID uuid.UUID) (*model.UserRating, error)
+	DeleteFunc func(userID, movieID uuid.UUID) error
+}
+
+func (m *mockRatingManager) Set(rating *model.UserRating) error {
+	return m.SetFunc(rating)
+}
+
+func (m *mockRatingManager) Get(userID, movieID uuid.UUID) (*model.UserRating, error) {
+	return m.GetFunc(userID, movieID)
+}
+
+func (m *mockRatingManager) Delete(userID, movieID uuid.UUID) error {
+	return m.DeleteFunc(userID, movieID)
+}
+
+func newUsersManager(users map[string]uuid.UUID) *mockUserManager {
+	return &mockUserManager{
+		GetByUsernameFunc: func(username string) (*model.User, error) {
+			id, ok := users[username]
+			if !ok {
+				return nil, sql.ErrNoRows
+			}
+			return &model.User{ID: id, Username: username}, nil
+		},
+	}
+}
+
+func TestRatingService_SetMyRating(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	movieID := uuid.New()
+
+	userManager := newUsersManager(map[string]uuid.UUID{"forrest": userID})
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Movie, error) {
+			if id != movieID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Movie{ID: movieID}, nil
+		},
+	}
+	ratingManager := &mockRatingManager{
+		SetFunc: func(rating *model.UserRating) error {
+			if rating.UserID != userID || rating.MovieID != movieID {
+				return errors.New("unexpected rating owner")
+			}
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		username       string
+		movieID        uuid.UUID
+		score          int
+		expectedResult error
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			movieID:  movieID,
+			score:    9,
+		},
+		{
+			name:           "ScoreTooLow",
+			username:       "forrest",
+			movieID:        movieID,
+			score:          0,
+			expectedResult: ErrInvalidScore,
+		},
+		{
+			name:           "ScoreTooHigh",
+			username:       "forrest",
+			movieID:        movieID,
+			score:          11,
+			expectedResult: ErrInvalidScore,
+		},
+		{
+			name:           "UserNotFound",
+			username:       "jenny",
+			movieID:        movieID,
+			score:          7,
+			expectedResult: ErrUserNotFound,
+		},
+		{
+			name:           "MovieNotFound",
+			username:       "forrest",
+			movieID:        uuid.New(),
+			score:          7,
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewRatingService(ratingManager, movieManager, userManager)
+
+			rating, err := rs.SetMyRating(tt.username, tt.movieID, tt.score)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && rating.Score != tt.score {
+				t.Errorf("Expected score %d, got: %d", tt.score, rating.Score)
+			}
+		})
+	}
+}
+
+func TestRatingService_DeleteMyRating(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	movieID := uuid.New()
+
+	userManager := newUsersManager(map[string]uuid.UUID{"forrest": userID})
+	ratingManager := &mockRatingManager{
+		DeleteFunc: func(uID, mID uuid.UUID) error {
+			if uID != userID || mID != movieID {
+				return sql.ErrNoRows
+			}
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		username       string
+		movieID        uuid.UUID
+		expectedResult error
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			movieID:  movieID,
+		},
+		{
+			name:           "RatingNotFound",
+			username:       "forrest",
+			movieID:        uuid.New(),
+			expectedResult: sql.ErrNoRows,
+		},
+		{
+			name:           "UserNotFound",
+			username:       "jenny",
+			movieID:        movieID,
+			expectedResult: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewRatingService(ratingManager, &mockMovieManager{}, userManager)
+
+			err := rs.DeleteMyRating(tt.username, tt.movieID)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Limits of the review fields, matching the constraints of the reviews table.
+const (
+	maxReviewTitleLength = 150
+	maxReviewBodyLength  = 10000
+)
+
+// Errors returned by the ReviewService.
+var (
+	ErrInvalidReview       = errors.New("review title and body are required and must not exceed 150 and 10000 characters")
+	ErrReviewExists        = errors.New("the user has already reviewed this movie")
+	ErrNotReviewAuthor     = errors.New("only the author can change the review")
+	ErrInvalidReviewStatus = errors.New("review status must be approved or hidden")
+)
+
+// ReviewService represents a service for managing movie reviews and their moderation.
+type ReviewService interface {
+	Create(username string, movieID uuid.UUID, review *model.Review) error
+	Update(username string, reviewID uuid.UUID, update *model.ReviewUpdate) error
+	Delete(username string, reviewID uuid.UUID) error
+	GetByMovie(movieID uuid.UUID) ([]*model.Review, error)
+	GetModerationQueue() ([]*model.Review, error)
+	Moderate(reviewID uuid.UUID, status string) error
+}
+
+type reviewService struct {
+	reviewManager repository.ReviewManager
+	movieManager  repository.MovieManager
+	userManager   repository.UserManager
+}
+
+// NewReviewService creates a new instance of the ReviewService.
+func NewReviewService(reviewManager repository.ReviewManager, movieManager repository.MovieManager,
+	userManager repository.UserManager) ReviewService {
+	return &reviewService{
+		reviewManager: reviewManager,
+		movieManager:  movieManager,
+		userManager:   userManager,
+	}
+}
+
+// Create submits a new review of a movie. The review waits in the moderation queue until an admin approves it.
+func (rs *reviewService) Create(username string, movieID uuid.UUID, review *model.Review) error {
+	if err := validateReview(review); err != nil {
+		return err
+	}
+
+	userID, err := lookupUserID(rs.userManager, username)
+	if err != nil {
+		return err
+	}
+	movie, err := rs.movieManager.GetByID(movieID)
+	if err != nil {
+		return err
+	}
+	movieID = movie.ID
+
+	exists, err := rs.reviewManager.IfExist(movieID, userID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrReviewExists
+	}
+
+	now := time.Now().UTC()
+	review.ID = uuid.New()
+	review.MovieID = movieID
+	review.UserID = userID
+	review.Username = username
+	review.Status = model.ReviewPending
+	review.CreatedAt = now
+	review.UpdatedAt = now
+
+	return rs.reviewManager.Create(review)
+}
+
+// Update edits a review of the user, keeping the fields the update leaves out.
+// An edited review goes back to the moderation queue.
+func (rs *reviewService) Update(username string, reviewID uuid.UUID, update *model.ReviewUpdate) error {
+	existingReview, err := rs.authorReview(username, reviewID)
+	if err != nil {
+		return err
+	}
+
+	if update.Title != "" {
+		existingReview.Title = update.Title
+	}
+	if update.Body != "" {
+		existingReview.Body = update.Body
+	}
+	if update.Spoiler != nil {
+		existingReview.Spoiler = *update.Spoiler
+	}
+
+	if err := validateReview(existingReview); err != nil {
+		return err
+	}
+
+	existingReview.Status = model.ReviewPending
+	existingReview.UpdatedAt = time.Now().UTC()
+
+	return rs.reviewManager.Update(existingReview)
+}
+
+// Delete removes a review of the user.
+func (rs *reviewService) Delete(username string, reviewID uuid.UUID) error {
+	if _, err := rs.authorReview(username, reviewID); err != nil {
+		return err
+	}
+
+	return rs.reviewManager.Delete(reviewID)
+}
+
+// GetByMovie retrieves the approved reviews of a movie.
+func (rs *reviewService) GetByMovie(movieID uuid.UUID) ([]*model.Review, error) {
+	return rs.reviewManager.GetByMovie(movieID, model.ReviewApproved)
+}
+
+// GetModerationQueue retrieves the reviews waiting for moderation, oldest first.
+func (rs *reviewService) GetModerationQueue() ([]*model.Review, error) {
+	return rs.reviewManager.GetByStatus(model.ReviewPending)
+}
+
+// Moderate approves or hides a review.
+func (rs *reviewService) Moderate(reviewID uuid.UUID, status string) error {
+	if status != model.ReviewApproved && status != model.ReviewHidden {
+		return ErrInvalidReviewStatus
+	}
+
+	review, err := rs.reviewManager.GetByID(reviewID)
+	if err != nil {
+		return err
+	}
+
+	review.Status = status
+
+	return rs.reviewManager.Update(review)
+}
+
+// authorReview retrieves a review and checks that it was written by the user.
+func (rs *reviewService) authorReview(username string, reviewID uuid.UUID) (*model.Review, error) {
+	userID, err := lookupUserID(rs.userManager, username)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := rs.reviewManager.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID != userID {
+		return nil, ErrNotReviewAuthor
+	}
+	return review, nil
+}
+
+func validateReview(review *model.Review) error {
+	title := strings.TrimSpace(review.Title)
+	body := strings.TrimSpace(review.Body)
+
+	if title == "" || body == "" ||
+		utf8.RuneCountInString(review.Title) > maxReviewTitleLength ||
+		utf8.RuneCountInString(review.Body) > maxReviewBodyLength {
+		return ErrInvalidReview
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockReviewManager struct {
+	CreateFunc      func(review *model.Review) error
+	GetByIDFunc     func(reviewID uuid.UUID) (*model.Review, error)
+	UpdateFunc      func(review *model.Review) error
+	DeleteFunc      func(reviewID uuid.UUID) error
+	IfExistFunc     func(movieID, userID uuid.UUID) (bool, error)
+	GetByMovieFunc  func(movieID uuid.UUID, status string) ([]*model.Review, error)
+	GetByStatusFunc func(status string) ([]*model.Review, error)
+}
+
+func (m *mockReviewManager) Create(review *model.Review) error {
+	return m.CreateFunc(review)
+}
+
+func (m *mockReviewManager) GetByID(reviewID uuid.UUID) (*model.Review, error) {
+	return m.GetByIDFunc(reviewID)
+}
+
+func (m *mockReviewManager) Update(review *model.Review) error {
+	return m.UpdateFunc(review)
+}
+
+func (m *mockReviewManager) Delete(reviewID uuid.UUID) error {
+	return m.DeleteFunc(reviewID)
+}
+
+func (m *mockReviewManager) IfExist(movieID, userID uuid.UUID) (bool, error) {
+	return m.IfExistFunc(movieID, userID)
+}
+
+func (m *mockReviewManager) GetByMovie(movieID uuid.UUID, status string) ([]*model.Review, error) {
+	return m.GetByMovieFunc(movieID, status)
+}
+
+func (m *mockReviewManager) GetByStatus(status string) ([]*model.Review, error) {
+	return m.GetByStatusFunc(status)
+}
+
+func TestReviewService_Create(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	movieID := uuid.New()
+	reviewedMovieID := uuid.New()
+
+	userManager := newUsersManager(map[string]uuid.UUID{"forrest": userID})
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Movie, error) {
+			if id != movieID && id != reviewedMovieID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Movie{ID: id}, nil
+		},
+	}
+	reviewManager := &mockReviewManager{
+		IfExistFunc: func(mID, uID uuid.UUID) (bool, error) {
+			return mID == reviewedMovieID, nil
+		},
+		CreateFunc: func(review *model.Review) error {
+			if review.Status != model.ReviewPending {
+				return errors.New("review must wait for moderation")
+			}
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		username       string
+		movieID        uuid.UUID
+		review         *model.Review
+		expectedResult error
+	}{
+		{
+			name:     "Success",
+			username: "forrest",
+			movieID:  movieID,
+			review:   &model.Review{Title: "Run, Forrest", Body: "A box of chocolates"},
+		},
+		{
+			name:           "EmptyBody",
+			username:       "forrest",
+			movieID:        movieID,
+			review:         &model.Review{Title: "Run, Forrest", Body: "   "},
+			expectedResult: ErrInvalidReview,
+		},
+		{
+			name:           "TitleTooLong",
+			username:       "forrest",
+			movieID:        movieID,
+			review:         &model.Review{Title: strings.Repeat("a", 151), Body: "A box of chocolates"},
+			expectedResult: ErrInvalidReview,
+		},
+		{
+			name:           "AlreadyReviewed",
+			username:       "forrest",
+			movieID:        reviewedMovieID,
+			review:         &model.Review{Title: "Again", Body: "A box of chocolates"},
+			expectedResult: ErrReviewExists,
+		},
+		{
+			name:           "MovieNotFound",
+			username:       "forrest",
+			movieID:        uuid.New(),
+			review:         &model.Review{Title: "Run, Forrest", Body: "A box of chocolates"},
+			expectedResult: sql.ErrNoRows,
+		},
+		{
+			name:           "UserNotFound",
+			username:       "jenny",
+			movieID:        movieID,
+			review:         &model.Review{Title: "Run, Forrest", Body: "A box of chocolates"},
+			expectedResult: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewReviewService(reviewManager, movieManager, userManager)
+
+			err := rs.Create(tt.username, tt.movieID, tt.review)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
+
+func TestReviewService_Update(t *testing.T) {
+	t.Parallel()
+
+	authorID := uuid.New()
+	reviewID := uuid.New()
+
+	userManager := newUsersManager(map[string]uuid.UUID{"forrest": authorID, "jenny": uuid.New()})
+
+	var updated *model.Review
+	reviewManager := &mockReviewManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Review, error) {
+			if id != reviewID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Review{ID: reviewID, UserID: authorID, Title: "Run", Body: "Chocolates", Spoiler: true,
+				Status: model.ReviewApproved}, nil
+		},
+		UpdateFunc: func(review *model.Review) error {
+			updated = review
+			return nil
+		},
+	}
+
+	noSpoiler := false
+	tests := []struct {
+		name            string
+		username        string
+		reviewID        uuid.UUID
+		update          *model.ReviewUpdate
+		expectedSpoiler bool
+		expectedResult  error
+	}{
+		{
+			name:            "Success",
+			username:        "forrest",
+			reviewID:        reviewID,
+			update:          &model.ReviewUpdate{Body: "Life is like a box of chocolates"},
+			expectedSpoiler: true,
+		},
+		{
+			name:     "ClearSpoiler",
+			username: "forrest",
+			reviewID: reviewID,
+			update:   &model.ReviewUpdate{Spoiler: &noSpoiler},
+		},
+		{
+			name:           "NotAuthor",
+			username:       "jenny",
+			reviewID:       reviewID,
+			update:         &model.ReviewUpdate{Body: "Mine now"},
+			expectedResult: ErrNotReviewAuthor,
+		},
+		{
+			name:           "ReviewNotFound",
+			username:       "forrest",
+			reviewID:       uuid.New(),
+			update:         &model.ReviewUpdate{Body: "Missing"},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewReviewService(reviewManager, &mockMovieManager{}, userManager)
+
+			err := rs.Update(tt.username, tt.reviewID, tt.update)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil {
+				if updated.Status != model.ReviewPending {
+					t.Errorf("Expected edited review to go back to moderation, got status: %s", updated.Status)
+				}
+				expectedBody := tt.update.Body
+				if expectedBody == "" {
+					expectedBody = "Chocolates"
+				}
+				if updated.Title != "Run" || updated.Body != expectedBody || updated.Spoiler != tt.expectedSpoiler {
+					t.Errorf("Unexpected updated review: %+v", updated)
+				}
+			}
+		})
+	}
+}
+
+func TestReviewService_Moderate(t *testing.T) {
+	t.Parallel()
+
+	reviewID := uuid.New()
+
+	reviewManager := &mockReviewManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Review, error) {
+			if id != reviewID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Review{ID: reviewID, Status: model.ReviewPending}, nil
+		},
+		UpdateFunc: func(review *model.Review) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		reviewID       uuid.UUID
+		status         string
+		expectedResult error
+	}{
+		{
+			name:     "Approve",
+			reviewID: reviewID,
+			status:   model.ReviewApproved,
+		},
+		{
+			name:     "Hide",
+			reviewID: reviewID,
+			status:   model.ReviewHidden,
+		},
+		{
+			name:           "InvalidStatus",
+			reviewID:       reviewID,
+			status:         model.ReviewPending,
+			expectedResult: ErrInvalidReviewStatus,
+		},
+		{
+			name:           "ReviewNotFound",
+			reviewID:       uuid.New(),
+			status:         model.ReviewApproved,
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewReviewService(reviewManager, &mockMovieManager{}, &mockUserManager{})
+
+			err := rs.Moderate(tt.reviewID, tt.status)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
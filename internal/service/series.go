@@ -0,0 +1,252 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Limits of the series fields, matching the constraints of the series, seasons and episodes tables.
+const (
+	maxSeriesTitleLength       = 150
+	maxSeriesDescriptionLength = 1000
+)
+
+// Errors returned by the SeriesService.
+var (
+	ErrInvalidSeries  = errors.New("series title is required and must not exceed 150 characters, description must not exceed 1000 characters")
+	ErrInvalidSeason  = errors.New("season number must be positive and title must not exceed 150 characters")
+	ErrSeasonExists   = errors.New("the series already has a season with this number")
+	ErrInvalidEpisode = errors.New("episode number must be positive, title and air date are required, title must not exceed 150 characters and description 1000 characters")
+	ErrEpisodeExists  = errors.New("the season already has an episode with this number")
+)
+
+// SeriesService represents a service for managing TV series, their seasons and episodes.
+type SeriesService interface {
+	Create(series *model.Series) error
+	GetByID(seriesID uuid.UUID) (*model.Series, error)
+	Update(seriesID uuid.UUID, series model.Series) error
+	Delete(seriesID uuid.UUID) error
+	Search(titleFragment, actorNameFragment string) ([]*model.Series, error)
+	CreateSeason(seriesID uuid.UUID, season *model.Season) error
+	DeleteSeason(seriesID uuid.UUID, seasonNumber int) error
+	CreateEpisode(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error
+	GetEpisodeByID(episodeID uuid.UUID) (*model.Episode, error)
+	UpdateEpisode(episodeID uuid.UUID, episode model.Episode) error
+	DeleteEpisode(episodeID uuid.UUID) error
+}
+
+type seriesService struct {
+	seriesManager repository.SeriesManager
+}
+
+// NewSeriesService creates a new instance of the SeriesService.
+func NewSeriesService(seriesManager repository.SeriesManager) SeriesService {
+	return &seriesService{
+		seriesManager: seriesManager,
+	}
+}
+
+// Create creates a new series without seasons.
+func (ss *seriesService) Create(series *model.Series) error {
+	if err := validateSeries(series); err != nil {
+		return err
+	}
+
+	series.ID = uuid.New()
+	series.Seasons = make([]model.Season, 0)
+
+	return ss.seriesManager.Create(series)
+}
+
+// GetByID retrieves a series with its seasons and episodes.
+func (ss *seriesService) GetByID(seriesID uuid.UUID) (*model.Series, error) {
+	return ss.seriesManager.GetByID(seriesID)
+}
+
+// Update updates the title and description of an existing series.
+func (ss *seriesService) Update(seriesID uuid.UUID, series model.Series) error {
+	existingSeries, err := ss.seriesManager.GetByID(seriesID)
+	if err != nil {
+		return err
+	}
+
+	if series.Title != "" {
+		existingSeries.Title = series.Title
+	}
+	if series.Description != "" {
+		existingSeries.Description = series.Description
+	}
+
+	if err := validateSeries(existingSeries); err != nil {
+		return err
+	}
+
+	return ss.seriesManager.Update(existingSeries)
+}
+
+// Delete removes a series along with its seasons and episodes.
+func (ss *seriesService) Delete(seriesID uuid.UUID) error {
+	return ss.seriesManager.Delete(seriesID)
+}
+
+// Search retrieves the series matching a title fragment and, optionally, the name fragment of an actor of an episode.
+func (ss *seriesService) Search(titleFragment, actorNameFragment string) ([]*model.Series, error) {
+	return ss.seriesManager.Search(titleFragment, actorNameFragment)
+}
+
+// CreateSeason adds a season to a series. Season numbers are unique within a series.
+func (ss *seriesService) CreateSeason(seriesID uuid.UUID, season *model.Season) error {
+	if season.Number <= 0 || utf8.RuneCountInString(season.Title) > maxSeriesTitleLength {
+		return ErrInvalidSeason
+	}
+
+	series, err := ss.seriesManager.GetByID(seriesID)
+	if err != nil {
+		return err
+	}
+	if _, err := findSeason(series, season.Number); err == nil {
+		return ErrSeasonExists
+	}
+
+	season.ID = uuid.New()
+	season.SeriesID = seriesID
+	season.Episodes = make([]model.Episode, 0)
+
+	return ss.seriesManager.CreateSeason(season)
+}
+
+// DeleteSeason removes a season of a series along with its episodes.
+func (ss *seriesService) DeleteSeason(seriesID uuid.UUID, seasonNumber int) error {
+	series, err := ss.seriesManager.GetByID(seriesID)
+	if err != nil {
+		return err
+	}
+	season, err := findSeason(series, seasonNumber)
+	if err != nil {
+		return err
+	}
+
+	return ss.seriesManager.DeleteSeason(season.ID)
+}
+
+// CreateEpisode adds an episode to a season of a series. Episode numbers are unique within a season.
+func (ss *seriesService) CreateEpisode(seriesID uuid.UUID, seasonNumber int, episode *model.Episode) error {
+	if err := validateEpisode(episode); err != nil {
+		return err
+	}
+
+	series, err := ss.seriesManager.GetByID(seriesID)
+	if err != nil {
+		return err
+	}
+	season, err := findSeason(series, seasonNumber)
+	if err != nil {
+		return err
+	}
+	for _, existingEpisode := range season.Episodes {
+		if existingEpisode.Number == episode.Number {
+			return ErrEpisodeExists
+		}
+	}
+
+	episode.ID = uuid.New()
+	episode.SeriesID = seriesID
+	episode.SeasonID = season.ID
+	episode.SeasonNumber = season.Number
+	if episode.Cast == nil {
+		episode.Cast = make([]model.CastMember, 0)
+	}
+	fillBillingOrder(episode.Cast)
+
+	return ss.seriesManager.CreateEpisode(episode)
+}
+
+// GetEpisodeByID retrieves an episode with its cast.
+func (ss *seriesService) GetEpisodeByID(episodeID uuid.UUID) (*model.Episode, error) {
+	return ss.seriesManager.GetEpisodeByID(episodeID)
+}
+
+// UpdateEpisode updates an existing episode. The cast is replaced when one is given.
+func (ss *seriesService) UpdateEpisode(episodeID uuid.UUID, episode model.Episode) error {
+	existingEpisode, err := ss.seriesManager.GetEpisodeByID(episodeID)
+	if err != nil {
+		return err
+	}
+
+	if episode.Number != 0 && episode.Number != existingEpisode.Number {
+		series, err := ss.seriesManager.GetByID(existingEpisode.SeriesID)
+		if err != nil {
+			return err
+		}
+		season, err := findSeason(series, existingEpisode.SeasonNumber)
+		if err != nil {
+			return err
+		}
+		for _, sibling := range season.Episodes {
+			if sibling.Number == episode.Number {
+				return ErrEpisodeExists
+			}
+		}
+		existingEpisode.Number = episode.Number
+	}
+	if episode.Title != "" {
+		existingEpisode.Title = episode.Title
+	}
+	if episode.Description != "" {
+		existingEpisode.Description = episode.Description
+	}
+	if !episode.AirDate.IsZero() {
+		existingEpisode.AirDate = episode.AirDate
+	}
+	if episode.Cast != nil {
+		fillBillingOrder(episode.Cast)
+		existingEpisode.Cast = episode.Cast
+	}
+
+	if err := validateEpisode(existingEpisode); err != nil {
+		return err
+	}
+
+	return ss.seriesManager.UpdateEpisode(existingEpisode)
+}
+
+// DeleteEpisode removes an episode.
+func (ss *seriesService) DeleteEpisode(episodeID uuid.UUID) error {
+	return ss.seriesManager.DeleteEpisode(episodeID)
+}
+
+// findSeason returns the season of a series with the given number, or sql.ErrNoRows if there is none.
+func findSeason(series *model.Series, number int) (*model.Season, error) {
+	for i := range series.Seasons {
+		if series.Seasons[i].Number == number {
+			return &series.Seasons[i], nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func validateSeries(series *model.Series) error {
+	if strings.TrimSpace(series.Title) == "" ||
+		utf8.RuneCountInString(series.Title) > maxSeriesTitleLength ||
+		utf8.RuneCountInString(series.Description) > maxSeriesDescriptionLength {
+		return ErrInvalidSeries
+	}
+	return nil
+}
+
+func validateEpisode(episode *model.Episode) error {
+	if episode.Number <= 0 || episode.AirDate.IsZero() ||
+		strings.TrimSpace(episode.Title) == "" ||
+		utf8.RuneCountInString(episode.Title) > maxSeriesTitleLength ||
+		utf8.RuneCountInString(episode.Description) > maxSeriesDescriptionLength {
+		return ErrInvalidEpisode
+	}
+	return nil
+}
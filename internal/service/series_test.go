@@ -0,0 +1,272 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockSeriesManager struct {
+	CreateFunc         func(series *model.Series) error
+	GetByIDFunc        func(seriesID uuid.UUID) (*model.Series, error)
+	UpdateFunc         func(series *model.Series) error
+	DeleteFunc         func(seriesID uuid.UUID) error
+	SearchFunc         func(titleFragment, actorNameFragment string) ([]*model.Series, error)
+	CreateSeasonFunc   func(season *model.Season) error
+	DeleteSeasonFunc   func(seasonID uuid.UUID) error
+	CreateEpisodeFunc  func(episode *model.Episode) error
+	GetEpisodeByIDFunc func(episodeID uuid.UUID) (*model.Episode, error)
+	UpdateEpisodeFunc  func(episode *model.Episode) error
+	DeleteEpisodeFunc  func(episodeID uuid.UUID) error
+}
+
+func (m *mockSeriesManager) Create(series *model.Series) error {
+	return m.CreateFunc(series)
+}
+
+func (m *mockSeriesManager) GetByID(seriesID uuid.UUID) (*model.Series, error) {
+	return m.GetByIDFunc(seriesID)
+}
+
+func (m *mockSeriesManager) Update(series *model.Series) error {
+	return m.UpdateFunc(series)
+}
+
+func (m *mockSeriesManager) Delete(seriesID uuid.UUID) error {
+	return m.DeleteFunc(seriesID)
+}
+
+func (m *mockSeriesManager) Search(titleFragment, actorNameFragment string) ([]*model.Series, error) {
+	return m.SearchFunc(titleFragment, actorNameFragment)
+}
+
+func (m *mockSeriesManager) CreateSeason(season *model.Season) error {
+	return m.CreateSeasonFunc(season)
+}
+
+func (m *mockSeriesManager) DeleteSeason(seasonID uuid.UUID) error {
+	return m.DeleteSeasonFunc(seasonID)
+}
+
+func (m *mockSeriesManager) CreateEpisode(episode *model.Episode) error {
+	return m.CreateEpisodeFunc(episode)
+}
+
+func (m *mockSeriesManager) GetEpisodeByID(episodeID uuid.UUID) (*model.Episode, error) {
+	return m.GetEpisodeByIDFunc(episodeID)
+}
+
+func (m *mockSeriesManager) UpdateEpisode(episode *model.Episode) error {
+	return m.UpdateEpisodeFunc(episode)
+}
+
+func (m *mockSeriesManager) DeleteEpisode(episodeID uuid.UUID) error {
+	return m.DeleteEpisodeFunc(episodeID)
+}
+
+// newSeriesManager returns a series manager holding a single series with one season of one episode.
+func newSeriesManager(series *model.Series) *mockSeriesManager {
+	return &mockSeriesManager{
+		GetByIDFunc: func(seriesID uuid.UUID) (*model.Series, error) {
+			if seriesID != series.ID {
+				return nil, sql.ErrNoRows
+			}
+			return series, nil
+		},
+		CreateSeasonFunc: func(season *model.Season) error {
+			return nil
+		},
+		CreateEpisodeFunc: func(episode *model.Episode) error {
+			return nil
+		},
+		GetEpisodeByIDFunc: func(episodeID uuid.UUID) (*model.Episode, error) {
+			for _, season := range series.Seasons {
+				for _, episode := range season.Episodes {
+					if episode.ID == episodeID {
+						copied := episode
+						return &copied, nil
+					}
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+		UpdateEpisodeFunc: func(episode *model.Episode) error {
+			return nil
+		},
+	}
+}
+
+func TestSeriesService_CreateSeason(t *testing.T) {
+	t.Parallel()
+
+	series := &model.Series{ID: uuid.New(), Title: "Twin Peaks", Seasons: []model.Season{{ID: uuid.New(), Number: 1}}}
+
+	tests := []struct {
+		name           string
+		seriesID       uuid.UUID
+		season         *model.Season
+		expectedResult error
+	}{
+		{
+			name:     "Success",
+			seriesID: series.ID,
+			season:   &model.Season{Number: 2},
+		},
+		{
+			name:           "InvalidNumber",
+			seriesID:       series.ID,
+			season:         &model.Season{Number: 0},
+			expectedResult: ErrInvalidSeason,
+		},
+		{
+			name:           "SeasonExists",
+			seriesID:       series.ID,
+			season:         &model.Season{Number: 1},
+			expectedResult: ErrSeasonExists,
+		},
+		{
+			name:           "SeriesNotFound",
+			seriesID:       uuid.New(),
+			season:         &model.Season{Number: 2},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := NewSeriesService(newSeriesManager(series))
+
+			err := ss.CreateSeason(tt.seriesID, tt.season)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
+
+func TestSeriesService_CreateEpisode(t *testing.T) {
+	t.Parallel()
+
+	seasonID := uuid.New()
+	airDate := time.Date(1990, 4, 8, 0, 0, 0, 0, time.UTC)
+	series := &model.Series{
+		ID:    uuid.New(),
+		Title: "Twin Peaks",
+		Seasons: []model.Season{{
+			ID:       seasonID,
+			Number:   1,
+			Episodes: []model.Episode{{ID: uuid.New(), Number: 1, Title: "Pilot", AirDate: airDate}},
+		}},
+	}
+
+	tests := []struct {
+		name           string
+		seasonNumber   int
+		episode        *model.Episode
+		expectedResult error
+	}{
+		{
+			name:         "Success",
+			seasonNumber: 1,
+			episode: &model.Episode{Number: 2, Title: "Traces to Nowhere", AirDate: airDate,
+				Cast: []model.CastMember{{Actor: model.Actor{ID: uuid.New()}}, {Actor: model.Actor{ID: uuid.New()}}}},
+		},
+		{
+			name:           "MissingAirDate",
+			seasonNumber:   1,
+			episode:        &model.Episode{Number: 2, Title: "Traces to Nowhere"},
+			expectedResult: ErrInvalidEpisode,
+		},
+		{
+			name:           "EpisodeExists",
+			seasonNumber:   1,
+			episode:        &model.Episode{Number: 1, Title: "Pilot", AirDate: airDate},
+			expectedResult: ErrEpisodeExists,
+		},
+		{
+			name:           "SeasonNotFound",
+			seasonNumber:   3,
+			episode:        &model.Episode{Number: 1, Title: "Episode 1", AirDate: airDate},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := NewSeriesService(newSeriesManager(series))
+
+			err := ss.CreateEpisode(series.ID, tt.seasonNumber, tt.episode)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil {
+				if tt.episode.SeasonID != seasonID || tt.episode.SeriesID != series.ID {
+					t.Errorf("Expected episode to be attached to the season, got: %+v", tt.episode)
+				}
+				for i, member := range tt.episode.Cast {
+					if member.BillingOrder != i+1 {
+						t.Errorf("Expected billing order %d, got: %d", i+1, member.BillingOrder)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSeriesService_UpdateEpisode(t *testing.T) {
+	t.Parallel()
+
+	seriesID := uuid.New()
+	airDate := time.Date(1990, 4, 8, 0, 0, 0, 0, time.UTC)
+	pilot := model.Episode{ID: uuid.New(), SeriesID: seriesID, SeasonNumber: 1, Number: 1, Title: "Pilot", AirDate: airDate}
+	second := model.Episode{ID: uuid.New(), SeriesID: seriesID, SeasonNumber: 1, Number: 2, Title: "Traces", AirDate: airDate}
+	series := &model.Series{
+		ID:      seriesID,
+		Title:   "Twin Peaks",
+		Seasons: []model.Season{{ID: uuid.New(), Number: 1, Episodes: []model.Episode{pilot, second}}},
+	}
+
+	tests := []struct {
+		name           string
+		episodeID      uuid.UUID
+		episode        model.Episode
+		expectedResult error
+	}{
+		{
+			name:      "Success",
+			episodeID: second.ID,
+			episode:   model.Episode{Number: 3, Title: "Traces to Nowhere"},
+		},
+		{
+			name:           "NumberTaken",
+			episodeID:      second.ID,
+			episode:        model.Episode{Number: 1},
+			expectedResult: ErrEpisodeExists,
+		},
+		{
+			name:           "EpisodeNotFound",
+			episodeID:      uuid.New(),
+			episode:        model.Episode{Title: "Lost"},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := NewSeriesService(newSeriesManager(series))
+
+			err := ss.UpdateEpisode(tt.episodeID, tt.episode)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
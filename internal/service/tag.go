@@ -0,0 +1,167 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Limits of the tag fields, matching the constraints of the tags table, and of the tag statistics.
+const (
+	maxTagNameLength     = 50
+	DefaultTagStatsLimit = 50
+	MaxTagStatsLimit     = 500
+)
+
+// Errors returned by the TagService.
+var (
+	ErrInvalidTag      = errors.New("tag name is required and must not exceed 50 characters")
+	ErrTagExists       = errors.New("a tag with this name already exists, merge the tags instead")
+	ErrInvalidTagMerge = errors.New("a tag cannot be merged into itself")
+	ErrInvalidTagMode  = errors.New("tag mode must be all or any")
+	ErrInvalidTagLimit = errors.New("tag statistics limit must be between 1 and 500")
+)
+
+// TagService represents a service for managing the tags of movies.
+type TagService interface {
+	GetAll() ([]*model.Tag, error)
+	GetStats(limit int) ([]*model.Tag, error)
+	Rename(tagID uuid.UUID, name string) (*model.Tag, error)
+	Merge(sourceID, targetID uuid.UUID) (*model.Tag, error)
+	Delete(tagID uuid.UUID) error
+}
+
+type tagService struct {
+	tagManager repository.TagManager
+}
+
+// NewTagService creates a new instance of the TagService.
+func NewTagService(tagManager repository.TagManager) TagService {
+	return &tagService{
+		tagManager: tagManager,
+	}
+}
+
+// GetAll retrieves all tags in alphabetical order with their usage counts.
+func (ts *tagService) GetAll() ([]*model.Tag, error) {
+	return ts.tagManager.GetAll()
+}
+
+// GetStats retrieves the most used tags with their usage counts, e.g. to draw a tag cloud.
+// A zero limit stands for the default limit.
+func (ts *tagService) GetStats(limit int) ([]*model.Tag, error) {
+	if limit == 0 {
+		limit = DefaultTagStatsLimit
+	}
+	if limit < 0 || limit > MaxTagStatsLimit {
+		return nil, ErrInvalidTagLimit
+	}
+
+	return ts.tagManager.GetStats(limit)
+}
+
+// Rename changes the name of a tag. Renaming a tag to the name of another tag fails, the tags have to be merged.
+func (ts *tagService) Rename(tagID uuid.UUID, name string) (*model.Tag, error) {
+	name, err := normalizeTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := ts.tagManager.GetByID(tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingTag, err := ts.tagManager.GetByName(name)
+	switch {
+	case err == nil && existingTag.ID != tagID:
+		return nil, ErrTagExists
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	if err := ts.tagManager.Rename(tagID, name); err != nil {
+		return nil, err
+	}
+	tag.Name = name
+	return tag, nil
+}
+
+// Merge moves the movies of the source tag to the target tag, removes the source tag and returns the target tag.
+func (ts *tagService) Merge(sourceID, targetID uuid.UUID) (*model.Tag, error) {
+	if sourceID == targetID {
+		return nil, ErrInvalidTagMerge
+	}
+	if _, err := ts.tagManager.GetByID(sourceID); err != nil {
+		return nil, err
+	}
+	if _, err := ts.tagManager.GetByID(targetID); err != nil {
+		return nil, err
+	}
+
+	if err := ts.tagManager.Merge(sourceID, targetID); err != nil {
+		return nil, err
+	}
+
+	return ts.tagManager.GetByID(targetID)
+}
+
+// Delete removes a tag from all movies.
+func (ts *tagService) Delete(tagID uuid.UUID) error {
+	return ts.tagManager.Delete(tagID)
+}
+
+// tagName trims a tag name, collapses its inner spaces and lowers its case.
+func tagName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// normalizeTag normalizes a tag name and checks its length.
+func normalizeTag(name string) (string, error) {
+	name = tagName(name)
+	if name == "" || utf8.RuneCountInString(name) > maxTagNameLength {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTag, name)
+	}
+	return name, nil
+}
+
+// normalizeTags normalizes tag names and removes the duplicates, keeping the first occurrence of each tag.
+func normalizeTags(names []string) ([]string, error) {
+	if names == nil {
+		return nil, nil
+	}
+
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := normalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return uniqueTags(tags), nil
+}
+
+// uniqueTags removes the duplicates of normalized tag names, keeping the first occurrence of each tag.
+func uniqueTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	unique := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			unique = append(unique, tag)
+		}
+	}
+	return unique
+}
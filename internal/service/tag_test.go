@@ -0,0 +1,224 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockTagManager struct {
+	GetAllFunc    func() ([]*model.Tag, error)
+	GetStatsFunc  func(limit int) ([]*model.Tag, error)
+	GetByIDFunc   func(tagID uuid.UUID) (*model.Tag, error)
+	GetByNameFunc func(name string) (*model.Tag, error)
+	RenameFunc    func(tagID uuid.UUID, name string) error
+	MergeFunc     func(sourceID, targetID uuid.UUID) error
+	DeleteFunc    func(tagID uuid.UUID) error
+}
+
+func (m *mockTagManager) GetAll() ([]*model.Tag, error) {
+	return m.GetAllFunc()
+}
+
+func (m *mockTagManager) GetStats(limit int) ([]*model.Tag, error) {
+	return m.GetStatsFunc(limit)
+}
+
+func (m *mockTagManager) GetByID(tagID uuid.UUID) (*model.Tag, error) {
+	return m.GetByIDFunc(tagID)
+}
+
+func (m *mockTagManager) GetByName(name string) (*model.Tag, error) {
+	return m.GetByNameFunc(name)
+}
+
+func (m *mockTagManager) Rename(tagID uuid.UUID, name string) error {
+	return m.RenameFunc(tagID, name)
+}
+
+func (m *mockTagManager) Merge(sourceID, targetID uuid.UUID) error {
+	return m.MergeFunc(sourceID, targetID)
+}
+
+func (m *mockTagManager) Delete(tagID uuid.UUID) error {
+	return m.DeleteFunc(tagID)
+}
+
+func TestTagService_Rename(t *testing.T) {
+	t.Parallel()
+
+	heist := &model.Tag{ID: uuid.New(), Name: "heist", MovieCount: 3}
+	caper := &model.Tag{ID: uuid.New(), Name: "caper", MovieCount: 1}
+	tags := map[uuid.UUID]*model.Tag{heist.ID: heist, caper.ID: caper}
+
+	tagManager := &mockTagManager{
+		GetByIDFunc: func(tagID uuid.UUID) (*model.Tag, error) {
+			if tag, ok := tags[tagID]; ok {
+				copied := *tag
+				return &copied, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+		GetByNameFunc: func(name string) (*model.Tag, error) {
+			for _, tag := range tags {
+				if tag.Name == name {
+					return tag, nil
+				}
+			}
+			return nil, sql.ErrNoRows
+		},
+		RenameFunc: func(tagID uuid.UUID, name string) error {
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		tagID          uuid.UUID
+		newName        string
+		expectedName   string
+		expectedResult error
+	}{
+		{
+			name:         "Success",
+			tagID:        caper.ID,
+			newName:      "  Heist   Comedy ",
+			expectedName: "heist comedy",
+		},
+		{
+			name:         "SameNameDifferentCase",
+			tagID:        heist.ID,
+			newName:      "HEIST",
+			expectedName: "heist",
+		},
+		{
+			name:           "NameOfAnotherTag",
+			tagID:          caper.ID,
+			newName:        "Heist",
+			expectedResult: ErrTagExists,
+		},
+		{
+			name:           "EmptyName",
+			tagID:          caper.ID,
+			newName:        "  ",
+			expectedResult: ErrInvalidTag,
+		},
+		{
+			name:           "TagNotFound",
+			tagID:          uuid.New(),
+			newName:        "noir",
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := NewTagService(tagManager)
+
+			tag, err := ts.Rename(tt.tagID, tt.newName)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && tag.Name != tt.expectedName {
+				t.Errorf("Expected name: %s, got: %s", tt.expectedName, tag.Name)
+			}
+		})
+	}
+}
+
+func TestTagService_Merge(t *testing.T) {
+	t.Parallel()
+
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	merged := false
+
+	tagManager := &mockTagManager{
+		GetByIDFunc: func(tagID uuid.UUID) (*model.Tag, error) {
+			switch tagID {
+			case sourceID:
+				return &model.Tag{ID: sourceID, Name: "time-travel", MovieCount: 2}, nil
+			case targetID:
+				if merged {
+					return &model.Tag{ID: targetID, Name: "time travel", MovieCount: 5}, nil
+				}
+				return &model.Tag{ID: targetID, Name: "time travel", MovieCount: 4}, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+		MergeFunc: func(source, target uuid.UUID) error {
+			merged = true
+			return nil
+		},
+	}
+
+	ts := NewTagService(tagManager)
+
+	if _, err := ts.Merge(sourceID, sourceID); !errors.Is(err, ErrInvalidTagMerge) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidTagMerge, err)
+	}
+	if _, err := ts.Merge(sourceID, uuid.New()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected error: %v, got: %v", sql.ErrNoRows, err)
+	}
+
+	target, err := ts.Merge(sourceID, targetID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if target.MovieCount != 5 {
+		t.Errorf("Expected movie count: 5, got: %d", target.MovieCount)
+	}
+}
+
+func TestTagService_GetStats(t *testing.T) {
+	t.Parallel()
+
+	var receivedLimit int
+	tagManager := &mockTagManager{
+		GetStatsFunc: func(limit int) ([]*model.Tag, error) {
+			receivedLimit = limit
+			return []*model.Tag{{Name: "heist", MovieCount: 3}}, nil
+		},
+	}
+
+	ts := NewTagService(tagManager)
+
+	if _, err := ts.GetStats(0); err != nil || receivedLimit != DefaultTagStatsLimit {
+		t.Errorf("Expected the default limit, got limit %d and error %v", receivedLimit, err)
+	}
+	if _, err := ts.GetStats(MaxTagStatsLimit + 1); !errors.Is(err, ErrInvalidTagLimit) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidTagLimit, err)
+	}
+}
+
+func TestMovieService_CreateNormalizesTags(t *testing.T) {
+	t.Parallel()
+
+	var created *model.Movie
+	released := time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC)
+	ms := NewMovieService(&mockMovieManager{
+		CreateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+			created = movie
+			return nil
+		},
+	}, &mockHistoryManager{})
+
+	err := ms.Create(&model.Movie{Title: "Inception", ReleaseDate: released, Tags: []string{"Heist", " dream  within a dream", "heist"}}, "admin")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if expected := []string{"heist", "dream within a dream"}; !reflect.DeepEqual(created.Tags, expected) {
+		t.Errorf("Expected tags: %v, got: %v", expected, created.Tags)
+	}
+
+	if err := ms.Create(&model.Movie{Title: "Inception", ReleaseDate: released, Tags: []string{""}}, "admin"); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected error: %v, got: %v", ErrValidation, err)
+	}
+}
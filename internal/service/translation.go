@@ -0,0 +1,233 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Errors returned by the TranslationService.
+var (
+	ErrInvalidLanguageTag = errors.New("invalid BCP 47 language tag")
+	ErrInvalidTranslation = errors.New("translated title is required and must not exceed 150 characters, description must not exceed 1000 characters")
+)
+
+// languageTagPattern matches the well-formed BCP 47 language tags made of a primary language subtag
+// followed by optional script, region and variant subtags.
+var languageTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// TranslationService represents a service for managing the translations of movies and localizing movies.
+type TranslationService interface {
+	SetTranslation(translation *model.MovieTranslation, username string) error
+	DeleteTranslation(movieID uuid.UUID, language, username string) error
+	GetTranslations(movieID uuid.UUID) ([]*model.MovieTranslation, error)
+	Localize(languages []string, movies []*model.Movie) error
+}
+
+type translationService struct {
+	translationManager repository.TranslationManager
+	movieManager       repository.MovieManager
+}
+
+// NewTranslationService creates a new instance of the TranslationService.
+func NewTranslationService(translationManager repository.TranslationManager, movieManager repository.MovieManager) TranslationService {
+	return &translationService{
+		translationManager: translationManager,
+		movieManager:       movieManager,
+	}
+}
+
+// SetTranslation creates or replaces the translation of a movie into a language, recording the change made by the user
+// in the history of the movie.
+func (ts *translationService) SetTranslation(translation *model.MovieTranslation, username string) error {
+	language, err := canonicalLanguageTag(translation.Language)
+	if err != nil {
+		return err
+	}
+	translation.Language = language
+
+	if translation.Title == "" || utf8.RuneCountInString(translation.Title) > 150 ||
+		utf8.RuneCountInString(translation.Description) > 1000 {
+		return ErrInvalidTranslation
+	}
+	movie, err := ts.movieManager.GetByID(translation.MovieID)
+	if err != nil {
+		return err
+	}
+	translation.MovieID = movie.ID
+
+	action := model.HistoryUpdate
+	previous, err := ts.getTranslation(movie.ID, translation.Language)
+	if errors.Is(err, sql.ErrNoRows) {
+		action = model.HistoryCreate
+	} else if err != nil {
+		return err
+	}
+	history, err := changeEntries(model.HistoryTranslation, movie.ID, action, username,
+		newTranslationVersion(previous), newTranslationVersion(translation))
+	if err != nil {
+		return err
+	}
+
+	return ts.translationManager.Set(translation, history...)
+}
+
+// DeleteTranslation removes the translation of a movie into a language, recording the change made by the user
+// in the history of the movie.
+func (ts *translationService) DeleteTranslation(movieID uuid.UUID, language, username string) error {
+	language, err := canonicalLanguageTag(language)
+	if err != nil {
+		return err
+	}
+	movie, err := ts.movieManager.GetByID(movieID)
+	if err != nil {
+		return err
+	}
+
+	previous, err := ts.getTranslation(movie.ID, language)
+	if err != nil {
+		return err
+	}
+	history, err := changeEntries(model.HistoryTranslation, movie.ID, model.HistoryDelete, username,
+		newTranslationVersion(previous), nil)
+	if err != nil {
+		return err
+	}
+
+	return ts.translationManager.Delete(movie.ID, language, history...)
+}
+
+// getTranslation retrieves the translation of a movie into a language, sql.ErrNoRows is returned when there is none.
+func (ts *translationService) getTranslation(movieID uuid.UUID, language string) (*model.MovieTranslation, error) {
+	translations, err := ts.translationManager.GetByMovie(movieID)
+	if err != nil {
+		return nil, err
+	}
+	for _, translation := range translations {
+		if translation.Language == language {
+			return translation, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// GetTranslations retrieves the translations of a movie.
+func (ts *translationService) GetTranslations(movieID uuid.UUID) ([]*model.MovieTranslation, error) {
+	movie, err := ts.movieManager.GetByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.translationManager.GetByMovie(movie.ID)
+}
+
+// Localize replaces the titles and descriptions of the given movies with their translations into the most
+// preferred of the given languages. Each language falls back to its less specific tags, so "pt-BR" also
+// matches a "pt" translation. A movie keeps its original title and description when no translation matches,
+// or when its original language is preferred over the available translations; in the latter case the original
+// title is used if the movie has one. Malformed language tags are ignored.
+func (ts *translationService) Localize(languages []string, movies []*model.Movie) error {
+	var candidates []string
+	for _, language := range languages {
+		language, err := canonicalLanguageTag(language)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, languageFallbacks(language)...)
+	}
+	if len(candidates) == 0 || len(movies) == 0 {
+		return nil
+	}
+
+	movieIDs := make([]uuid.UUID, 0, len(movies))
+	for _, movie := range movies {
+		movieIDs = append(movieIDs, movie.ID)
+	}
+
+	translations, err := ts.translationManager.GetByMovies(movieIDs, candidates)
+	if err != nil {
+		return err
+	}
+
+	for _, movie := range movies {
+		localizeMovie(movie, candidates, translations[movie.ID])
+	}
+	return nil
+}
+
+// localizeMovie applies to a movie the first of the candidate languages it is available in.
+func localizeMovie(movie *model.Movie, candidates []string, translations []*model.MovieTranslation) {
+	byLanguage := make(map[string]*model.MovieTranslation, len(translations))
+	for _, translation := range translations {
+		byLanguage[translation.Language] = translation
+	}
+
+	for _, language := range candidates {
+		if language == movie.OriginalLanguage {
+			if movie.OriginalTitle != "" {
+				movie.Title = movie.OriginalTitle
+			}
+			movie.Language = language
+			return
+		}
+
+		translation, ok := byLanguage[language]
+		if !ok {
+			continue
+		}
+		movie.Title = translation.Title
+		if translation.Description != "" {
+			movie.Description = translation.Description
+		}
+		movie.Language = language
+		return
+	}
+
+	movie.Language = movie.OriginalLanguage
+}
+
+// canonicalLanguageTag checks that a language tag is well-formed and returns it in its canonical case:
+// lowercase language, titlecase script, uppercase region, e.g. "zh-Hant-TW".
+func canonicalLanguageTag(tag string) (string, error) {
+	if !languageTagPattern.MatchString(tag) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLanguageTag, tag)
+	}
+
+	subtags := strings.Split(tag, "-")
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 4 && !strings.ContainsAny(subtag, "0123456789"):
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case len(subtag) == 2:
+			subtags[i] = strings.ToUpper(subtag)
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+
+	if len(subtags[0]) == 2 && !languageCodes[subtags[0]] {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLanguageTag, tag)
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// languageFallbacks returns a canonical language tag followed by its less specific tags, e.g. "zh-Hant-TW",
+// "zh-Hant" and "zh".
+func languageFallbacks(tag string) []string {
+	fallbacks := []string{tag}
+	for i := strings.LastIndex(tag, "-"); i > 0; i = strings.LastIndex(tag, "-") {
+		tag = tag[:i]
+		fallbacks = append(fallbacks, tag)
+	}
+	return fallbacks
+}
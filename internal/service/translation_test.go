@@ -0,0 +1,205 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockTranslationManager struct {
+	SetFunc         func(translation *model.MovieTranslation, history []*model.HistoryEntry) error
+	DeleteFunc      func(movieID uuid.UUID, language string, history []*model.HistoryEntry) error
+	GetByMovieFunc  func(movieID uuid.UUID) ([]*model.MovieTranslation, error)
+	GetByMoviesFunc func(movieIDs []uuid.UUID, languages []string) (map[uuid.UUID][]*model.MovieTranslation, error)
+}
+
+func (m *mockTranslationManager) Set(translation *model.MovieTranslation, history ...*model.HistoryEntry) error {
+	return m.SetFunc(translation, history)
+}
+
+func (m *mockTranslationManager) Delete(movieID uuid.UUID, language string, history ...*model.HistoryEntry) error {
+	return m.DeleteFunc(movieID, language, history)
+}
+
+func (m *mockTranslationManager) GetByMovie(movieID uuid.UUID) ([]*model.MovieTranslation, error) {
+	return m.GetByMovieFunc(movieID)
+}
+
+func (m *mockTranslationManager) GetByMovies(movieIDs []uuid.UUID, languages []string) (map[uuid.UUID][]*model.MovieTranslation, error) {
+	return m.GetByMoviesFunc(movieIDs, languages)
+}
+
+func TestTranslationService_SetTranslation(t *testing.T) {
+	t.Parallel()
+
+	existingMovieID := uuid.New()
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+			if movieID != existingMovieID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Movie{ID: movieID}, nil
+		},
+	}
+
+	var saved *model.MovieTranslation
+	var recorded []*model.HistoryEntry
+	translationManager := &mockTranslationManager{
+		SetFunc: func(translation *model.MovieTranslation, history []*model.HistoryEntry) error {
+			saved = translation
+			recorded = history
+			return nil
+		},
+		GetByMovieFunc: func(movieID uuid.UUID) ([]*model.MovieTranslation, error) {
+			return []*model.MovieTranslation{{MovieID: movieID, Language: "de", Title: "Die Verurteilten"}}, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		translation    *model.MovieTranslation
+		expectedResult error
+	}{
+		{
+			name:        "Success",
+			translation: &model.MovieTranslation{MovieID: existingMovieID, Language: "PT-br", Title: "Um Sonho de Liberdade"},
+		},
+		{
+			name:           "MalformedLanguageTag",
+			translation:    &model.MovieTranslation{MovieID: existingMovieID, Language: "portuguese", Title: "Um Sonho de Liberdade"},
+			expectedResult: ErrInvalidLanguageTag,
+		},
+		{
+			name:           "UnknownLanguage",
+			translation:    &model.MovieTranslation{MovieID: existingMovieID, Language: "xx", Title: "Um Sonho de Liberdade"},
+			expectedResult: ErrInvalidLanguageTag,
+		},
+		{
+			name:           "MissingTitle",
+			translation:    &model.MovieTranslation{MovieID: existingMovieID, Language: "pt"},
+			expectedResult: ErrInvalidTranslation,
+		},
+		{
+			name:           "MovieNotFound",
+			translation:    &model.MovieTranslation{MovieID: uuid.New(), Language: "pt", Title: "Um Sonho de Liberdade"},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := NewTranslationService(translationManager, movieManager)
+
+			err := ts.SetTranslation(tt.translation, "admin")
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+
+	if saved == nil || saved.Language != "pt-BR" {
+		t.Errorf("Expected the translation to be saved with the canonical tag pt-BR, got: %+v", saved)
+	}
+	if len(recorded) != 1 || recorded[0].EntityType != model.HistoryTranslation ||
+		recorded[0].Action != model.HistoryCreate || recorded[0].EntityID != existingMovieID {
+		t.Errorf("Expected the new translation to be recorded in the history of the movie, got: %+v", recorded)
+	}
+}
+
+func TestTranslationService_Localize(t *testing.T) {
+	t.Parallel()
+
+	shawshank := uuid.New()
+	amelie := uuid.New()
+	translations := map[uuid.UUID][]*model.MovieTranslation{
+		shawshank: {
+			{MovieID: shawshank, Language: "de", Title: "Die Verurteilten", Description: "Gefängnisdrama"},
+			{MovieID: shawshank, Language: "pt", Title: "Um Sonho de Liberdade"},
+		},
+		amelie: {
+			{MovieID: amelie, Language: "de", Title: "Die fabelhafte Welt der Amélie"},
+		},
+	}
+
+	var requestedLanguages []string
+	translationManager := &mockTranslationManager{
+		GetByMoviesFunc: func(movieIDs []uuid.UUID, languages []string) (map[uuid.UUID][]*model.MovieTranslation, error) {
+			requestedLanguages = languages
+			return translations, nil
+		},
+	}
+
+	tests := []struct {
+		name                string
+		languages           []string
+		expectedTitles      []string
+		expectedLanguages   []string
+		expectedDescription string
+	}{
+		{
+			name:                "Translation",
+			languages:           []string{"de"},
+			expectedTitles:      []string{"Die Verurteilten", "Die fabelhafte Welt der Amélie"},
+			expectedLanguages:   []string{"de", "de"},
+			expectedDescription: "Gefängnisdrama",
+		},
+		{
+			name:                "LessSpecificTag",
+			languages:           []string{"pt-BR", "de"},
+			expectedTitles:      []string{"Um Sonho de Liberdade", "Die fabelhafte Welt der Amélie"},
+			expectedLanguages:   []string{"pt", "de"},
+			expectedDescription: "Prison drama",
+		},
+		{
+			name:                "OriginalLanguagePreferred",
+			languages:           []string{"fr", "de"},
+			expectedTitles:      []string{"Die Verurteilten", "Le Fabuleux Destin d'Amélie Poulain"},
+			expectedLanguages:   []string{"de", "fr"},
+			expectedDescription: "Gefängnisdrama",
+		},
+		{
+			name:                "FallbackToOriginal",
+			languages:           []string{"ja", "not a tag"},
+			expectedTitles:      []string{"The Shawshank Redemption", "Amelie"},
+			expectedLanguages:   []string{"en", "fr"},
+			expectedDescription: "Prison drama",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movies := []*model.Movie{
+				{ID: shawshank, Title: "The Shawshank Redemption", Description: "Prison drama", OriginalLanguage: "en"},
+				{ID: amelie, Title: "Amelie", OriginalTitle: "Le Fabuleux Destin d'Amélie Poulain", OriginalLanguage: "fr"},
+			}
+			ts := NewTranslationService(translationManager, &mockMovieManager{})
+
+			if err := ts.Localize(tt.languages, movies); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			for i, movie := range movies {
+				if movie.Title != tt.expectedTitles[i] || movie.Language != tt.expectedLanguages[i] {
+					t.Errorf("Expected %q in %s, got: %q in %s", tt.expectedTitles[i], tt.expectedLanguages[i], movie.Title, movie.Language)
+				}
+			}
+			if movies[0].Description != tt.expectedDescription {
+				t.Errorf("Expected description %q, got: %q", tt.expectedDescription, movies[0].Description)
+			}
+		})
+	}
+
+	ts := NewTranslationService(translationManager, &mockMovieManager{})
+	if err := ts.Localize([]string{"zh-hant-tw"}, []*model.Movie{{ID: shawshank}}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if expected := []string{"zh-Hant-TW", "zh-Hant", "zh"}; !reflect.DeepEqual(requestedLanguages, expected) {
+		t.Errorf("Expected languages %v, got: %v", expected, requestedLanguages)
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// ErrInvalidRetention is returned when the trash is purged with a negative retention.
+var ErrInvalidRetention = errors.New("trash retention must not be negative")
+
+// TrashService represents a service for restoring and purging the deleted actors and movies.
+type TrashService interface {
+	GetTrash() (*model.Trash, error)
+	RestoreActor(actorID uuid.UUID, username string) (*model.Actor, error)
+	RestoreMovie(movieID uuid.UUID, username string) (*model.Movie, error)
+	Purge(retention time.Duration) (int, error)
+}
+
+type trashService struct {
+	trashManager repository.TrashManager
+	actorManager repository.ActorManager
+	movieManager repository.MovieManager
+}
+
+// NewTrashService creates a new instance of the TrashService.
+func NewTrashService(trashManager repository.TrashManager, actorManager repository.ActorManager,
+	movieManager repository.MovieManager) TrashService {
+	return &trashService{
+		trashManager: trashManager,
+		actorManager: actorManager,
+		movieManager: movieManager,
+	}
+}
+
+// GetTrash retrieves the actors and movies in the trash, most recently deleted first.
+func (ts *trashService) GetTrash() (*model.Trash, error) {
+	actors, err := ts.trashManager.GetActors()
+	if err != nil {
+		return nil, err
+	}
+	movies, err := ts.trashManager.GetMovies()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Trash{Actors: actors, Movies: movies}, nil
+}
+
+// RestoreActor takes an actor out of the trash and returns the restored actor, recording the change made by the user
+// in the history.
+func (ts *trashService) RestoreActor(actorID uuid.UUID, username string) (*model.Actor, error) {
+	history := func(actor *model.Actor) ([]*model.HistoryEntry, error) {
+		return changeEntries(model.HistoryActor, actor.ID, model.HistoryRestore, username, nil, newActorVersion(actor))
+	}
+	if err := ts.trashManager.RestoreActor(actorID, history); err != nil {
+		return nil, err
+	}
+
+	return ts.actorManager.GetByID(actorID)
+}
+
+// RestoreMovie takes a movie out of the trash and returns the restored movie, recording the change made by the user
+// in the history. As for the deletion, only the details of the movie are recorded since its cast is kept in the trash.
+func (ts *trashService) RestoreMovie(movieID uuid.UUID, username string) (*model.Movie, error) {
+	history := func(movie *model.Movie) ([]*model.HistoryEntry, error) {
+		return changeEntries(model.HistoryMovie, movie.ID, model.HistoryRestore, username, nil, newMovieVersion(movie))
+	}
+	if err := ts.trashManager.RestoreMovie(movieID, history); err != nil {
+		return nil, err
+	}
+
+	return ts.movieManager.GetByID(movieID)
+}
+
+// Purge permanently removes the actors and movies that have been in the trash for longer than the retention
+// and returns the number of removed actors and movies.
+func (ts *trashService) Purge(retention time.Duration) (int, error) {
+	if retention < 0 {
+		return 0, ErrInvalidRetention
+	}
+
+	return ts.trashManager.Purge(retention)
+}
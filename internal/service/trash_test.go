@@ -0,0 +1,133 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+type mockTrashManager struct {
+	GetActorsFunc    func() ([]model.TrashItem, error)
+	GetMoviesFunc    func() ([]model.TrashItem, error)
+	RestoreActorFunc func(actorID uuid.UUID, history repository.ActorHistory) error
+	RestoreMovieFunc func(movieID uuid.UUID, history repository.MovieHistory) error
+	PurgeFunc        func(retention time.Duration) (int, error)
+}
+
+func (m *mockTrashManager) GetActors() ([]model.TrashItem, error) {
+	return m.GetActorsFunc()
+}
+
+func (m *mockTrashManager) GetMovies() ([]model.TrashItem, error) {
+	return m.GetMoviesFunc()
+}
+
+func (m *mockTrashManager) RestoreActor(actorID uuid.UUID, history repository.ActorHistory) error {
+	return m.RestoreActorFunc(actorID, history)
+}
+
+func (m *mockTrashManager) RestoreMovie(movieID uuid.UUID, history repository.MovieHistory) error {
+	return m.RestoreMovieFunc(movieID, history)
+}
+
+func (m *mockTrashManager) Purge(retention time.Duration) (int, error) {
+	return m.PurgeFunc(retention)
+}
+
+func TestTrashService_GetTrash(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	trashManager := &mockTrashManager{
+		GetActorsFunc: func() ([]model.TrashItem, error) {
+			return []model.TrashItem{{ID: uuid.New(), Name: "Tom Hanks", DeletedAt: deletedAt}}, nil
+		},
+		GetMoviesFunc: func() ([]model.TrashItem, error) {
+			return []model.TrashItem{}, nil
+		},
+	}
+
+	ts := NewTrashService(trashManager, nil, nil)
+
+	trash, err := ts.GetTrash()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(trash.Actors) != 1 || len(trash.Movies) != 0 {
+		t.Errorf("Expected 1 actor and no movie, got: %d actors and %d movies", len(trash.Actors), len(trash.Movies))
+	}
+}
+
+func TestTrashService_RestoreMovie(t *testing.T) {
+	t.Parallel()
+
+	trashedID := uuid.New()
+	restored := false
+	var entries []*model.HistoryEntry
+
+	trashManager := &mockTrashManager{
+		RestoreMovieFunc: func(movieID uuid.UUID, history repository.MovieHistory) error {
+			if movieID != trashedID || restored {
+				return sql.ErrNoRows
+			}
+			restored = true
+			var err error
+			entries, err = history(&model.Movie{ID: trashedID, Title: "Speed"})
+			return err
+		},
+	}
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+			if movieID == trashedID && restored {
+				return &model.Movie{ID: trashedID, Title: "Speed"}, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+	}
+
+	ts := NewTrashService(trashManager, nil, movieManager)
+
+	movie, err := ts.RestoreMovie(trashedID, "admin")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if movie.ID != trashedID {
+		t.Errorf("Expected the restored movie %s, got: %s", trashedID, movie.ID)
+	}
+	if len(entries) != 1 || entries[0].EntityType != model.HistoryMovie || entries[0].Action != model.HistoryRestore ||
+		entries[0].Before != nil || entries[0].Username != "admin" {
+		t.Errorf("Expected the restore of the movie details to be recorded, got: %+v", entries)
+	}
+	if _, err := ts.RestoreMovie(trashedID, "admin"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected error: %v, got: %v", sql.ErrNoRows, err)
+	}
+}
+
+func TestTrashService_Purge(t *testing.T) {
+	t.Parallel()
+
+	var receivedRetention time.Duration
+	trashManager := &mockTrashManager{
+		PurgeFunc: func(retention time.Duration) (int, error) {
+			receivedRetention = retention
+			return 3, nil
+		},
+	}
+
+	ts := NewTrashService(trashManager, nil, nil)
+
+	purged, err := ts.Purge(24 * time.Hour)
+	if err != nil || purged != 3 || receivedRetention != 24*time.Hour {
+		t.Errorf("Expected 3 purged items with a 24h retention, got %d items, %v retention and error %v",
+			purged, receivedRetention, err)
+	}
+	if _, err := ts.Purge(-time.Hour); !errors.Is(err, ErrInvalidRetention) {
+		t.Errorf("Expected error: %v, got: %v", ErrInvalidRetention, err)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"database/sql"
 	"errors"
 
 	"github.com/google/uuid"
@@ -10,6 +11,9 @@ import (
 	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
 )
 
+// ErrUserNotFound is returned when the user a request is made on behalf of does not exist.
+var ErrUserNotFound = errors.New("user not found")
+
 // UserService represents a service for managing user accounts.
 type UserService interface {
 	Register(user *model.User) error
@@ -29,6 +33,9 @@ func NewUserService(userManager repository.UserManager) UserService {
 
 // Register creates a new user account if the provided username is unique,
 func (us *userService) Register(user *model.User) error {
+	if err := validateUser(user); err != nil {
+		return err
+	}
 	ifExist, err := us.userManager.IfExist(user.Username)
 	if err != nil {
 		return err
@@ -83,3 +90,15 @@ func hashPassword(password string) (string, error) {
 func comparePassword(hashedPassword, password string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) == nil
 }
+
+// lookupUserID resolves the ID of the user with the given username.
+func lookupUserID(userManager repository.UserManager, username string) (uuid.UUID, error) {
+	user, err := userManager.GetByUsername(username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrUserNotFound
+		}
+		return uuid.Nil, err
+	}
+	return user.ID, nil
+}
@@ -0,0 +1,528 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// Names of the rules reported by validation errors.
+const (
+	RuleRequired  = "required"
+	RuleMaxLength = "max_length"
+	RuleRange     = "range"
+	RuleOneOf     = "one_of"
+	RuleNotFuture = "not_future"
+	RuleExists    = "exists"
+	RuleUnique    = "unique"
+	RuleURL       = "url"
+)
+
+// Limits of the validated fields, matching the constraints of the movies, releases, credits, actors and users tables.
+// Passwords are limited to the 72 bytes hashed by bcrypt.
+const (
+	maxTitleLength         = 150
+	maxDescriptionLength   = 1000
+	minRating              = 0
+	maxRating              = 10
+	maxCharacterNameLength = 255
+	maxNameLength          = 255
+	maxUsernameLength      = 30
+	maxPasswordBytes       = 72
+	maxReleaseNoteLength   = 200
+)
+
+// Genders an actor can have, compared case-insensitively.
+var genders = []string{"male", "female", "non-binary", "other"}
+
+// Roles of the crew and the companies of a movie, and visibilities of a list.
+var (
+	creditRoles = codeSet(strings.Join([]string{model.RoleDirector, model.RoleWriter, model.RoleProducer,
+		model.RoleComposer}, " "))
+	companyRoles     = codeSet(model.CompanyRoleProduction + " " + model.CompanyRoleDistribution)
+	listVisibilities = codeSet(strings.Join([]string{model.ListPrivate, model.ListUnlisted, model.ListPublic}, " "))
+)
+
+// ErrValidation is matched by the errors listing the fields of an input that break their rules.
+var ErrValidation = errors.New("validation failed")
+
+// FieldError describes a field of an input breaking one of its rules.
+type FieldError struct {
+	Field   string // Path of the field, e.g. "Actors[1].ID"
+	Rule    string // Name of the broken rule
+	Message string // Description of what the field must be
+}
+
+// ValidationError is returned when fields of an input, e.g. a movie, an actor or a user, break their rules,
+// it lists every such field and matches ErrValidation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+" "+field.Message)
+	}
+	return fmt.Sprintf("%v: %s", ErrValidation, strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// rule checks the value of a field, message describing what the value must be.
+type rule struct {
+	name    string
+	message string
+	valid   func(value interface{}) bool
+}
+
+// fieldRules declares the rules of a field of an input of type T, value reading the field from the input.
+type fieldRules[T any] struct {
+	field string
+	value func(input *T) interface{}
+	rules []rule
+}
+
+var movieRules = []fieldRules[model.Movie]{
+	{"Title", func(movie *model.Movie) interface{} { return movie.Title },
+		[]rule{required(), maxLength(maxTitleLength)}},
+	{"Description", func(movie *model.Movie) interface{} { return movie.Description },
+		[]rule{maxLength(maxDescriptionLength)}},
+	{"ReleaseDate", func(movie *model.Movie) interface{} { return movie.ReleaseDate },
+		[]rule{required()}},
+	{"Rating", func(movie *model.Movie) interface{} { return movie.Rating },
+		[]rule{between(minRating, maxRating)}},
+	{"RuntimeMinutes", func(movie *model.Movie) interface{} { return movie.RuntimeMinutes },
+		[]rule{atLeast(0)}},
+	{"OriginalTitle", func(movie *model.Movie) interface{} { return movie.OriginalTitle },
+		[]rule{maxLength(maxTitleLength)}},
+	{"OriginalLanguage", func(movie *model.Movie) interface{} { return movie.OriginalLanguage },
+		[]rule{languageCode()}},
+}
+
+var countryRules = []rule{required(), countryCode()}
+
+var languageRules = []rule{required(), languageCode()}
+
+var releaseRules = []fieldRules[model.ReleaseEvent]{
+	{"Country", func(release *model.ReleaseEvent) interface{} { return release.Country },
+		[]rule{required(), countryCode()}},
+	{"Type", func(release *model.ReleaseEvent) interface{} { return release.Type },
+		[]rule{required(), oneOfCodes(releaseTypes,
+			"premiere, theatrical_limited, theatrical, digital, physical or tv")}},
+	{"Date", func(release *model.ReleaseEvent) interface{} { return release.Date },
+		[]rule{required()}},
+	{"Note", func(release *model.ReleaseEvent) interface{} { return release.Note },
+		[]rule{maxLength(maxReleaseNoteLength)}},
+}
+
+var castRules = []fieldRules[model.CastMember]{
+	{"ID", func(member *model.CastMember) interface{} { return member.ID },
+		[]rule{required()}},
+	{"CharacterName", func(member *model.CastMember) interface{} { return member.CharacterName },
+		[]rule{maxLength(maxCharacterNameLength)}},
+}
+
+var crewRules = []fieldRules[model.Credit]{
+	{"PersonID", func(credit *model.Credit) interface{} { return credit.PersonID },
+		[]rule{required()}},
+	{"Role", func(credit *model.Credit) interface{} { return credit.Role },
+		[]rule{required(), oneOfCodes(creditRoles, "director, writer, producer or composer")}},
+}
+
+var movieCompanyRules = []fieldRules[model.MovieCompany]{
+	{"Role", func(company *model.MovieCompany) interface{} { return company.Role },
+		[]rule{required(), oneOfCodes(companyRoles, "production or distribution")}},
+}
+
+var tagRules = []rule{required(), maxLength(maxTagNameLength)}
+
+var actorRules = []fieldRules[model.Actor]{
+	{"Name", func(actor *model.Actor) interface{} { return actor.Name },
+		[]rule{required(), maxLength(maxNameLength)}},
+	{"Gender", func(actor *model.Actor) interface{} { return actor.Gender },
+		[]rule{oneOf(genders...)}},
+	{"BirthDate", func(actor *model.Actor) interface{} { return actor.BirthDate },
+		[]rule{notFuture()}},
+	{"DeathDate", func(actor *model.Actor) interface{} { return lifeDates{actor.BirthDate, actor.DeathDate} },
+		[]rule{lifeSpan()}},
+	{"BirthPlace", func(actor *model.Actor) interface{} { return actor.BirthPlace },
+		[]rule{maxLength(maxBirthPlaceLength)}},
+	{"Nationality", func(actor *model.Actor) interface{} { return actor.Nationality },
+		[]rule{countryCode()}},
+	{"Biography", func(actor *model.Actor) interface{} { return actor.Biography },
+		[]rule{maxLength(maxBiographyLength)}},
+}
+
+var aliasRules = []rule{required(), maxLength(maxAliasLength)}
+
+var linkRules = []fieldRules[model.ProfileLink]{
+	{"Site", func(link *model.ProfileLink) interface{} { return link.Site },
+		[]rule{required(), maxLength(maxLinkSiteLength)}},
+	{"URL", func(link *model.ProfileLink) interface{} { return link.URL },
+		[]rule{maxBytes(maxLinkURLLength), absoluteURL()}},
+}
+
+var companyRules = []fieldRules[model.Company]{
+	{"Name", func(company *model.Company) interface{} { return company.Name },
+		[]rule{required(), maxLength(maxCompanyNameLength)}},
+	{"Description", func(company *model.Company) interface{} { return company.Description },
+		[]rule{maxLength(maxCompanyDescriptionLength)}},
+	{"Country", func(company *model.Company) interface{} { return company.Country },
+		[]rule{countryCode()}},
+}
+
+var listRules = []fieldRules[model.MovieList]{
+	{"Name", func(list *model.MovieList) interface{} { return list.Name },
+		[]rule{required(), maxLength(maxListNameLength)}},
+	{"Description", func(list *model.MovieList) interface{} { return list.Description },
+		[]rule{maxLength(maxListDescriptionLength)}},
+	{"Visibility", func(list *model.MovieList) interface{} { return list.Visibility },
+		[]rule{required(), oneOfCodes(listVisibilities, "private, unlisted or public")}},
+}
+
+var listEntryRules = []fieldRules[model.ListEntry]{
+	{"Note", func(entry *model.ListEntry) interface{} { return entry.Note },
+		[]rule{maxLength(maxListNoteLength)}},
+}
+
+var userRules = []fieldRules[model.User]{
+	{"Username", func(user *model.User) interface{} { return user.Username },
+		[]rule{required(), maxLength(maxUsernameLength)}},
+	{"Password", func(user *model.User) interface{} { return user.Password },
+		[]rule{required(), maxBytes(maxPasswordBytes)}},
+}
+
+// validateMovie checks the fields of a movie, its metadata, cast, crew, companies and tags against their rules,
+// as well as the existence of the people credited on it. The codes and tags of the movie are expected normalized.
+func validateMovie(movieManager repository.MovieManager, movie *model.Movie) error {
+	fieldErrors := validate(movie, movieRules)
+	fieldErrors = append(fieldErrors, validateItems("Countries", movie.Countries, countryRules)...)
+	fieldErrors = append(fieldErrors, validateItems("Languages", movie.Languages, languageRules)...)
+	fieldErrors = append(fieldErrors, validateCertifications(movie.Certifications)...)
+	fieldErrors = append(fieldErrors, validateEach("Releases", movie.Releases, releaseRules)...)
+	fieldErrors = append(fieldErrors, validateUnique("Releases", movie.Releases, "Type",
+		func(release *model.ReleaseEvent) interface{} { return [2]string{release.Country, release.Type} },
+		"must not repeat the type of another release in the same country")...)
+	fieldErrors = append(fieldErrors, validateEach("Actors", movie.Actors, castRules)...)
+	fieldErrors = append(fieldErrors, validateEach("Crew", movie.Crew, crewRules)...)
+	fieldErrors = append(fieldErrors, validateEach("Companies", movie.Companies, movieCompanyRules)...)
+	fieldErrors = append(fieldErrors, validateItems("Tags", movie.Tags, tagRules)...)
+
+	var personIDs []uuid.UUID
+	for _, member := range movie.Actors {
+		if member.ID != uuid.Nil {
+			personIDs = append(personIDs, member.ID)
+		}
+	}
+	for _, credit := range movie.Crew {
+		if credit.PersonID != uuid.Nil {
+			personIDs = append(personIDs, credit.PersonID)
+		}
+	}
+	if len(personIDs) > 0 {
+		missingIDs, err := movieManager.GetMissingPeople(personIDs)
+		if err != nil {
+			return err
+		}
+		missing := make(map[uuid.UUID]bool, len(missingIDs))
+		for _, personID := range missingIDs {
+			missing[personID] = true
+		}
+		for i, member := range movie.Actors {
+			if missing[member.ID] {
+				fieldErrors = append(fieldErrors, FieldError{Field: fmt.Sprintf("Actors[%d].ID", i), Rule: RuleExists,
+					Message: "must be the ID of an existing actor"})
+			}
+		}
+		for i, credit := range movie.Crew {
+			if missing[credit.PersonID] {
+				fieldErrors = append(fieldErrors, FieldError{Field: fmt.Sprintf("Crew[%d].PersonID", i),
+					Rule: RuleExists, Message: "must be the ID of an existing person"})
+			}
+		}
+	}
+
+	return validationError(fieldErrors)
+}
+
+// validateActor checks the fields of an actor and of their profile against their rules. The previous state of
+// an updated actor is given so that only the fields the update changes are checked: values stored before the rules
+// were introduced, e.g. free-text genders, do not keep the other fields from being edited.
+func validateActor(previous, actor *model.Actor) error {
+	fields := actorRules
+	if previous != nil {
+		fields = changedFields(previous, actor, actorRules)
+	}
+	fieldErrors := validate(actor, fields)
+	if previous == nil || !slices.Equal(previous.Aliases, actor.Aliases) {
+		fieldErrors = append(fieldErrors, validateItems("Aliases", actor.Aliases, aliasRules)...)
+	}
+	if previous == nil || !slices.Equal(previous.Links, actor.Links) {
+		fieldErrors = append(fieldErrors, validateEach("Links", actor.Links, linkRules)...)
+		fieldErrors = append(fieldErrors, validateUnique("Links", actor.Links, "Site",
+			func(link *model.ProfileLink) interface{} { return link.Site }, "must not repeat the site of another link")...)
+	}
+	return validationError(fieldErrors)
+}
+
+// validateCompany checks the fields of a company against their rules.
+func validateCompany(company *model.Company) error {
+	return validationError(validate(company, companyRules))
+}
+
+// validateList checks the fields of a list and the notes of its entries against their rules.
+func validateList(list *model.MovieList) error {
+	fieldErrors := validate(list, listRules)
+	fieldErrors = append(fieldErrors, validateEach("Entries", list.Entries, listEntryRules)...)
+	return validationError(fieldErrors)
+}
+
+// validateListEntry checks the note of a list entry against its rules.
+func validateListEntry(entry *model.ListEntry) error {
+	return validationError(validate(entry, listEntryRules))
+}
+
+// validateUser checks the credentials of a user against their rules.
+func validateUser(user *model.User) error {
+	return validationError(validate(user, userRules))
+}
+
+// validate checks the fields of an input against their rules, reporting the first rule broken by each field.
+func validate[T any](input *T, fields []fieldRules[T]) []FieldError {
+	var fieldErrors []FieldError
+	for _, field := range fields {
+		if fieldError := check(field.field, field.value(input), field.rules); fieldError != nil {
+			fieldErrors = append(fieldErrors, *fieldError)
+		}
+	}
+	return fieldErrors
+}
+
+// check checks the value of a field against its rules, reporting the first rule it breaks.
+func check(field string, value interface{}, rules []rule) *FieldError {
+	for _, rule := range rules {
+		if !rule.valid(value) {
+			return &FieldError{Field: field, Rule: rule.name, Message: rule.message}
+		}
+	}
+	return nil
+}
+
+// changedFields keeps the fields of an input whose values differ from its previous state.
+func changedFields[T any](previous, input *T, fields []fieldRules[T]) []fieldRules[T] {
+	var changed []fieldRules[T]
+	for _, field := range fields {
+		if !sameValue(field.value(previous), field.value(input)) {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
+
+// sameValue reports whether two values of a field are equal, times being compared as instants.
+func sameValue(a, b interface{}) bool {
+	switch a := a.(type) {
+	case time.Time:
+		return a.Equal(b.(time.Time))
+	case lifeDates:
+		return a.birth.Equal(b.(lifeDates).birth) && a.death.Equal(b.(lifeDates).death)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// validateEach checks the elements of a list, their fields being reported under the name of the list and their index.
+func validateEach[T any](list string, inputs []T, fields []fieldRules[T]) []FieldError {
+	var fieldErrors []FieldError
+	for i := range inputs {
+		for _, fieldError := range validate(&inputs[i], fields) {
+			fieldError.Field = fmt.Sprintf("%s[%d].%s", list, i, fieldError.Field)
+			fieldErrors = append(fieldErrors, fieldError)
+		}
+	}
+	return fieldErrors
+}
+
+// validateItems checks the strings of a list, reported under the name of the list and their index.
+func validateItems(list string, values []string, rules []rule) []FieldError {
+	var fieldErrors []FieldError
+	for i, value := range values {
+		if fieldError := check(fmt.Sprintf("%s[%d]", list, i), value, rules); fieldError != nil {
+			fieldErrors = append(fieldErrors, *fieldError)
+		}
+	}
+	return fieldErrors
+}
+
+// validateUnique reports the field of the elements of a list whose key repeats the key of an earlier element.
+func validateUnique[T any](list string, inputs []T, field string, key func(input *T) interface{},
+	message string) []FieldError {
+	var fieldErrors []FieldError
+	seen := make(map[interface{}]bool, len(inputs))
+	for i := range inputs {
+		k := key(&inputs[i])
+		if seen[k] {
+			fieldErrors = append(fieldErrors, FieldError{Field: fmt.Sprintf("%s[%d].%s", list, i, field),
+				Rule: RuleUnique, Message: message})
+		}
+		seen[k] = true
+	}
+	return fieldErrors
+}
+
+// validateCertifications checks the age certifications of a movie against the rating systems of their countries,
+// reported under their country codes in alphabetical order.
+func validateCertifications(certifications map[string]string) []FieldError {
+	countries := make([]string, 0, len(certifications))
+	for country := range certifications {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+
+	var fieldErrors []FieldError
+	for _, country := range countries {
+		field := fmt.Sprintf("Certifications[%s]", country)
+		if !countryCodes[country] {
+			fieldErrors = append(fieldErrors, FieldError{Field: field, Rule: RuleOneOf,
+				Message: "must be given for an ISO 3166-1 alpha-2 country code"})
+			continue
+		}
+		rules := []rule{required(), maxLength(maxCertificationLength)}
+		if system, ok := certificationSystems[country]; ok {
+			rules = append(rules, oneOfCodes(system, "a certification of the rating system of "+country))
+		}
+		if fieldError := check(field, certifications[country], rules); fieldError != nil {
+			fieldErrors = append(fieldErrors, *fieldError)
+		}
+	}
+	return fieldErrors
+}
+
+func validationError(fieldErrors []FieldError) error {
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: fieldErrors}
+}
+
+// required is broken by blank strings, nil IDs and zero times.
+func required() rule {
+	return rule{name: RuleRequired, message: "is required", valid: func(value interface{}) bool {
+		switch value := value.(type) {
+		case string:
+			return strings.TrimSpace(value) != ""
+		case uuid.UUID:
+			return value != uuid.Nil
+		case time.Time:
+			return !value.IsZero()
+		}
+		return true
+	}}
+}
+
+// maxLength is broken by strings longer than limit characters.
+func maxLength(limit int) rule {
+	return rule{name: RuleMaxLength, message: fmt.Sprintf("must not exceed %d characters", limit),
+		valid: func(value interface{}) bool {
+			return utf8.RuneCountInString(value.(string)) <= limit
+		}}
+}
+
+// maxBytes is broken by strings longer than limit bytes.
+func maxBytes(limit int) rule {
+	return rule{name: RuleMaxLength, message: fmt.Sprintf("must not exceed %d bytes", limit),
+		valid: func(value interface{}) bool {
+			return len(value.(string)) <= limit
+		}}
+}
+
+// between is broken by integers out of the range from min to max inclusive.
+func between(min, max int) rule {
+	return rule{name: RuleRange, message: fmt.Sprintf("must be between %d and %d", min, max),
+		valid: func(value interface{}) bool {
+			return value.(int) >= min && value.(int) <= max
+		}}
+}
+
+// atLeast is broken by integers less than min.
+func atLeast(min int) rule {
+	return rule{name: RuleRange, message: fmt.Sprintf("must be at least %d", min),
+		valid: func(value interface{}) bool {
+			return value.(int) >= min
+		}}
+}
+
+// oneOf is broken by non-empty strings other than the values, ignoring case.
+func oneOf(values ...string) rule {
+	return rule{name: RuleOneOf, message: "must be one of " + strings.Join(values, ", "),
+		valid: func(value interface{}) bool {
+			if value.(string) == "" {
+				return true
+			}
+			for _, allowed := range values {
+				if strings.EqualFold(value.(string), allowed) {
+					return true
+				}
+			}
+			return false
+		}}
+}
+
+// notFuture is broken by times after the current time.
+func notFuture() rule {
+	return rule{name: RuleNotFuture, message: "must not be in the future", valid: func(value interface{}) bool {
+		return !value.(time.Time).After(time.Now())
+	}}
+}
+
+// oneOfCodes is broken by non-empty strings missing from the codes, compared as given, description listing the codes.
+func oneOfCodes(codes map[string]bool, description string) rule {
+	return rule{name: RuleOneOf, message: "must be " + description, valid: func(value interface{}) bool {
+		return value.(string) == "" || codes[value.(string)]
+	}}
+}
+
+// countryCode is broken by non-empty strings other than upper-case ISO 3166-1 alpha-2 country codes.
+func countryCode() rule {
+	return oneOfCodes(countryCodes, "an ISO 3166-1 alpha-2 country code")
+}
+
+// languageCode is broken by non-empty strings other than lower-case ISO 639-1 language codes.
+func languageCode() rule {
+	return oneOfCodes(languageCodes, "an ISO 639-1 language code")
+}
+
+// absoluteURL is broken by strings other than absolute http or https URLs.
+func absoluteURL() rule {
+	return rule{name: RuleURL, message: "must be an absolute http or https URL", valid: func(value interface{}) bool {
+		parsed, err := url.Parse(value.(string))
+		return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+	}}
+}
+
+// lifeDates are the birth and death dates of a person, checked together.
+type lifeDates struct {
+	birth, death time.Time
+}
+
+// lifeSpan is broken by death dates before the birth date or in the future.
+func lifeSpan() rule {
+	return rule{name: RuleRange, message: "must not be before the birth date nor in the future",
+		valid: func(value interface{}) bool {
+			dates := value.(lifeDates)
+			return dates.death.IsZero() || (!dates.death.Before(dates.birth) && !dates.death.After(time.Now()))
+		}}
+}
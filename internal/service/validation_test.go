@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestValidateMovie(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+	missingID := uuid.New()
+	released := time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		movie          model.Movie
+		expectedFields []FieldError
+	}{
+		{
+			name: "Valid",
+			movie: model.Movie{Title: "Heat", ReleaseDate: released, Rating: 10,
+				Actors: []model.CastMember{{Actor: model.Actor{ID: actorID}}}},
+		},
+		{
+			name:  "TitleAndRating",
+			movie: model.Movie{Title: strings.Repeat("a", 151), ReleaseDate: released, Rating: 11},
+			expectedFields: []FieldError{
+				{Field: "Title", Rule: RuleMaxLength, Message: "must not exceed 150 characters"},
+				{Field: "Rating", Rule: RuleRange, Message: "must be between 0 and 10"},
+			},
+		},
+		{
+			name: "Cast",
+			movie: model.Movie{Title: " ", ReleaseDate: released, Actors: []model.CastMember{
+				{Actor: model.Actor{ID: actorID}},
+				{CharacterName: "Neil McCauley"},
+				{Actor: model.Actor{ID: missingID}},
+			}},
+			expectedFields: []FieldError{
+				{Field: "Title", Rule: RuleRequired, Message: "is required"},
+				{Field: "Actors[1].ID", Rule: RuleRequired, Message: "is required"},
+				{Field: "Actors[2].ID", Rule: RuleExists, Message: "must be the ID of an existing actor"},
+			},
+		},
+		{
+			name:  "ReleaseDate",
+			movie: model.Movie{Title: "Heat", Rating: 8},
+			expectedFields: []FieldError{
+				{Field: "ReleaseDate", Rule: RuleRequired, Message: "is required"},
+			},
+		},
+		{
+			name: "Metadata",
+			movie: model.Movie{Title: "Heat", ReleaseDate: released, RuntimeMinutes: -1, OriginalLanguage: "xx",
+				Countries:      []string{"US", "XX"},
+				Certifications: map[string]string{"US": "PG-15", "XX": "R"},
+				Releases: []model.ReleaseEvent{
+					{Country: "US", Type: model.ReleaseTheatrical, Date: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC)},
+					{Country: "US", Type: model.ReleaseTheatrical, Date: time.Date(1995, 12, 20, 0, 0, 0, 0, time.UTC)},
+					{Country: "FR", Type: "vhs"},
+				},
+				Crew:      []model.Credit{{PersonID: actorID, Role: "gaffer"}},
+				Companies: []model.MovieCompany{{CompanyID: uuid.New(), Role: "catering"}},
+				Tags:      []string{"heist", " "},
+			},
+			expectedFields: []FieldError{
+				{Field: "RuntimeMinutes", Rule: RuleRange, Message: "must be at least 0"},
+				{Field: "OriginalLanguage", Rule: RuleOneOf, Message: "must be an ISO 639-1 language code"},
+				{Field: "Countries[1]", Rule: RuleOneOf, Message: "must be an ISO 3166-1 alpha-2 country code"},
+				{Field: "Certifications[US]", Rule: RuleOneOf, Message: "must be a certification of the rating system of US"},
+				{Field: "Certifications[XX]", Rule: RuleOneOf, Message: "must be given for an ISO 3166-1 alpha-2 country code"},
+				{Field: "Releases[2].Type", Rule: RuleOneOf,
+					Message: "must be premiere, theatrical_limited, theatrical, digital, physical or tv"},
+				{Field: "Releases[2].Date", Rule: RuleRequired, Message: "is required"},
+				{Field: "Releases[1].Type", Rule: RuleUnique,
+					Message: "must not repeat the type of another release in the same country"},
+				{Field: "Crew[0].Role", Rule: RuleOneOf, Message: "must be director, writer, producer or composer"},
+				{Field: "Companies[0].Role", Rule: RuleOneOf, Message: "must be production or distribution"},
+				{Field: "Tags[1]", Rule: RuleRequired, Message: "is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieManager := &mockMovieManager{
+				GetMissingPeopleFunc: func(personIDs []uuid.UUID) ([]uuid.UUID, error) {
+					var missingIDs []uuid.UUID
+					for _, personID := range personIDs {
+						if personID != actorID {
+							missingIDs = append(missingIDs, personID)
+						}
+					}
+					return missingIDs, nil
+				},
+			}
+
+			err := validateMovie(movieManager, &tt.movie)
+			checkFieldErrors(t, err, tt.expectedFields)
+		})
+	}
+}
+
+func TestValidateActor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		actor          model.Actor
+		expectedFields []FieldError
+	}{
+		{
+			name:  "Valid",
+			actor: model.Actor{Name: "Al Pacino", Gender: "Male", BirthDate: time.Date(1940, 4, 25, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:  "GenderAndBirthDate",
+			actor: model.Actor{Name: "Al Pacino", Gender: "robot", BirthDate: time.Now().AddDate(1, 0, 0)},
+			expectedFields: []FieldError{
+				{Field: "Gender", Rule: RuleOneOf, Message: "must be one of male, female, non-binary, other"},
+				{Field: "BirthDate", Rule: RuleNotFuture, Message: "must not be in the future"},
+			},
+		},
+		{
+			name: "Profile",
+			actor: model.Actor{Name: "Marilyn Monroe", BirthDate: time.Date(1926, 6, 1, 0, 0, 0, 0, time.UTC),
+				DeathDate: time.Date(1920, 1, 1, 0, 0, 0, 0, time.UTC), Nationality: "XX", Aliases: []string{""},
+				Links: []model.ProfileLink{
+					{Site: "imdb", URL: "https://www.imdb.com/name/nm0000054/"},
+					{Site: "imdb", URL: "/name/nm0000054/"},
+				}},
+			expectedFields: []FieldError{
+				{Field: "DeathDate", Rule: RuleRange, Message: "must not be before the birth date nor in the future"},
+				{Field: "Nationality", Rule: RuleOneOf, Message: "must be an ISO 3166-1 alpha-2 country code"},
+				{Field: "Aliases[0]", Rule: RuleRequired, Message: "is required"},
+				{Field: "Links[1].URL", Rule: RuleURL, Message: "must be an absolute http or https URL"},
+				{Field: "Links[1].Site", Rule: RuleUnique, Message: "must not repeat the site of another link"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldErrors(t, validateActor(nil, &tt.actor), tt.expectedFields)
+		})
+	}
+}
+
+func TestValidateActor_OnlyChangedFields(t *testing.T) {
+	t.Parallel()
+
+	// The gender was stored as free text before it was validated.
+	stored := model.Actor{Name: "Al Pacino", Gender: "M", BirthDate: time.Date(1940, 4, 25, 0, 0, 0, 0, time.UTC)}
+
+	renamed := stored
+	renamed.Name = "Alfredo James Pacino"
+	checkFieldErrors(t, validateActor(&stored, &renamed), nil)
+
+	regendered := stored
+	regendered.Gender = "robot"
+	checkFieldErrors(t, validateActor(&stored, &regendered), []FieldError{
+		{Field: "Gender", Rule: RuleOneOf, Message: "must be one of male, female, non-binary, other"},
+	})
+
+	// The death date is checked again when the birth date moves after it.
+	stored.DeathDate = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	reborn := stored
+	reborn.BirthDate = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	checkFieldErrors(t, validateActor(&stored, &reborn), []FieldError{
+		{Field: "DeathDate", Rule: RuleRange, Message: "must not be before the birth date nor in the future"},
+	})
+}
+
+func TestValidateUser(t *testing.T) {
+	t.Parallel()
+
+	user := model.User{Username: strings.Repeat("u", 31), Password: strings.Repeat("p", 73)}
+	checkFieldErrors(t, validateUser(&user), []FieldError{
+		{Field: "Username", Rule: RuleMaxLength, Message: "must not exceed 30 characters"},
+		{Field: "Password", Rule: RuleMaxLength, Message: "must not exceed 72 bytes"},
+	})
+}
+
+func checkFieldErrors(t *testing.T, err error, expectedFields []FieldError) {
+	t.Helper()
+
+	if expectedFields == nil {
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected %v, got: %v", ErrValidation, err)
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || !reflect.DeepEqual(validationErr.Fields, expectedFields) {
+		t.Errorf("Expected fields %v, got: %v", expectedFields, err)
+	}
+}
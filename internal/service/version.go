@@ -0,0 +1,28 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ErrVersionMismatch is returned when a movie or an actor is changed with a version other than its current one,
+// i.e. it has been changed by someone else since it was read.
+var ErrVersionMismatch = errors.New("the record has been changed since it was read")
+
+// checkVersion checks the version a movie or an actor is expected to have against its current one,
+// a zero expected version matching any version.
+func checkVersion(expected, current int) error {
+	if expected != 0 && expected != current {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// versionConflict maps the error of an update checking the version of a record read just before to ErrVersionMismatch:
+// no row is updated when the record has been changed or deleted in between.
+func versionConflict(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrVersionMismatch
+	}
+	return err
+}
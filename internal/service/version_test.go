@@ -0,0 +1,84 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+func TestMovieService_UpdateVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		version        int
+		updateResult   error
+		expectedResult error
+	}{
+		{
+			name:    "AnyVersion",
+			version: 0,
+		},
+		{
+			name:    "MatchingVersion",
+			version: 3,
+		},
+		{
+			name:           "VersionMismatch",
+			version:        2,
+			expectedResult: ErrVersionMismatch,
+		},
+		{
+			name:           "ChangedDuringUpdate",
+			version:        3,
+			updateResult:   sql.ErrNoRows,
+			expectedResult: ErrVersionMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movieManager := &mockMovieManager{
+				GetByIDFunc: func(movieID uuid.UUID) (*model.Movie, error) {
+					return &model.Movie{ID: movieID, Title: "Heat",
+						ReleaseDate: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC), Version: 3}, nil
+				},
+				UpdateFunc: func(movie *model.Movie, history []*model.HistoryEntry) error {
+					return tt.updateResult
+				},
+			}
+			ms := NewMovieService(movieManager, &mockHistoryManager{})
+
+			err := ms.Update(uuid.New(), model.Movie{Rating: 8, Version: tt.version}, "admin")
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+		})
+	}
+}
+
+func TestActorService_UpdateVersion(t *testing.T) {
+	t.Parallel()
+
+	actorManager := &mockActorManager{
+		GetByIDFunc: func(actorID uuid.UUID) (*model.Actor, error) {
+			return &model.Actor{ID: actorID, Name: "Al Pacino", Version: 4}, nil
+		},
+		UpdateFunc: func(actorID uuid.UUID, actor *model.Actor, history []*model.HistoryEntry) error {
+			return nil
+		},
+	}
+	as := NewActorService(actorManager, nil, &mockHistoryManager{})
+
+	err := as.Update(uuid.New(), &model.Actor{Name: "Alfredo Pacino", Version: 3}, "admin")
+
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("Expected error: %v, got: %v", ErrVersionMismatch, err)
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+	"github.com/EgMeln/filmLibraryPrivate/internal/repository"
+)
+
+// maxDiaryNoteLength is the maximum length of a personal note, matching the constraint of the watch_log table.
+const maxDiaryNoteLength = 1000
+
+// Errors returned by the WatchService.
+var (
+	ErrInvalidWatchDate = errors.New("watched date must not be in the future")
+	ErrInvalidDiaryNote = errors.New("note must not exceed 1000 characters")
+)
+
+// WatchService represents a service for managing the watchlists and watch diaries of users.
+type WatchService interface {
+	AddToWatchlist(username string, movieID uuid.UUID) error
+	RemoveFromWatchlist(username string, movieID uuid.UUID) error
+	GetWatchlist(username string) ([]*model.WatchlistEntry, error)
+	LogWatch(username string, entry *model.DiaryEntry) error
+	RemoveFromDiary(username string, movieID uuid.UUID) error
+	GetDiary(username string) ([]*model.DiaryEntry, error)
+	MarkWatchStatus(username string, movies []*model.Movie) error
+}
+
+type watchService struct {
+	watchManager repository.WatchManager
+	movieManager repository.MovieManager
+	userManager  repository.UserManager
+}
+
+// NewWatchService creates a new instance of the WatchService.
+func NewWatchService(watchManager repository.WatchManager, movieManager repository.MovieManager,
+	userManager repository.UserManager) WatchService {
+	return &watchService{
+		watchManager: watchManager,
+		movieManager: movieManager,
+		userManager:  userManager,
+	}
+}
+
+// AddToWatchlist adds a movie to the watchlist of the user.
+func (ws *watchService) AddToWatchlist(username string, movieID uuid.UUID) error {
+	userID, err := lookupUserID(ws.userManager, username)
+	if err != nil {
+		return err
+	}
+	movie, err := ws.movieManager.GetByID(movieID)
+	if err != nil {
+		return err
+	}
+
+	return ws.watchManager.AddToWatchlist(userID, movie.ID)
+}
+
+// RemoveFromWatchlist removes a movie from the watchlist of the user.
+func (ws *watchService) RemoveFromWatchlist(username string, movieID uuid.UUID) error {
+	userID, err := lookupUserID(ws.userManager, username)
+	if err != nil {
+		return err
+	}
+
+	return ws.watchManager.RemoveFromWatchlist(userID, movieID)
+}
+
+// GetWatchlist retrieves the watchlist of the user.
+func (ws *watchService) GetWatchlist(username string) ([]*model.WatchlistEntry, error) {
+	userID, err := lookupUserID(ws.userManager, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return ws.watchManager.GetWatchlist(userID)
+}
+
+// LogWatch records that the user has watched a movie, today unless a date is given.
+func (ws *watchService) LogWatch(username string, entry *model.DiaryEntry) error {
+	now := time.Now().UTC()
+	if entry.WatchedAt.IsZero() {
+		entry.WatchedAt = now
+	}
+	if entry.WatchedAt.After(now) {
+		return ErrInvalidWatchDate
+	}
+	if utf8.RuneCountInString(entry.Note) > maxDiaryNoteLength {
+		return ErrInvalidDiaryNote
+	}
+
+	userID, err := lookupUserID(ws.userManager, username)
+	if err != nil {
+		return err
+	}
+	movie, err := ws.movieManager.GetByID(entry.MovieID)
+	if err != nil {
+		return err
+	}
+	entry.MovieID = movie.ID
+
+	return ws.watchManager.LogWatch(userID, entry)
+}
+
+// RemoveFromDiary removes a movie from the watch diary of the user.
+func (ws *watchService) RemoveFromDiary(username string, movieID uuid.UUID) error {
+	userID, err := lookupUserID(ws.userManager, username)
+	if err != nil {
+		return err
+	}
+
+	return ws.watchManager.RemoveFromDiary(userID, movieID)
+}
+
+// GetDiary retrieves the watch diary of the user.
+func (ws *watchService) GetDiary(username string) ([]*model.DiaryEntry, error) {
+	userID, err := lookupUserID(ws.userManager, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return ws.watchManager.GetDiary(userID)
+}
+
+// MarkWatchStatus sets the watchlist and watched flags of the given movies for the user.
+func (ws *watchService) MarkWatchStatus(username string, movies []*model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	userID, err := lookupUserID(ws.userManager, username)
+	if err != nil {
+		return err
+	}
+
+	movieIDs := make([]uuid.UUID, 0, len(movies))
+	for _, movie := range movies {
+		movieIDs = append(movieIDs, movie.ID)
+	}
+
+	statuses, err := ws.watchManager.GetStatuses(userID, movieIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, movie := range movies {
+		status := statuses[movie.ID]
+		movie.OnWatchlist = status.OnWatchlist
+		movie.Watched = status.Watched
+	}
+	return nil
+}
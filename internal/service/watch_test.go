@@ -0,0 +1,158 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EgMeln/filmLibraryPrivate/internal/model"
+)
+
+type mockWatchManager struct {
+	AddToWatchlistFunc      func(userID, movieID uuid.UUID) error
+	RemoveFromWatchlistFunc func(userID, movieID uuid.UUID) error
+	GetWatchlistFunc        func(userID uuid.UUID) ([]*model.WatchlistEntry, error)
+	LogWatchFunc            func(userID uuid.UUID, entry *model.DiaryEntry) error
+	RemoveFromDiaryFunc     func(userID, movieID uuid.UUID) error
+	GetDiaryFunc            func(userID uuid.UUID) ([]*model.DiaryEntry, error)
+	GetStatusesFunc         func(userID uuid.UUID, movieIDs []uuid.UUID) (map[uuid.UUID]model.WatchStatus, error)
+}
+
+func (m *mockWatchManager) AddToWatchlist(userID, movieID uuid.UUID) error {
+	return m.AddToWatchlistFunc(userID, movieID)
+}
+
+func (m *mockWatchManager) RemoveFromWatchlist(userID, movieID uuid.UUID) error {
+	return m.RemoveFromWatchlistFunc(userID, movieID)
+}
+
+func (m *mockWatchManager) GetWatchlist(userID uuid.UUID) ([]*model.WatchlistEntry, error) {
+	return m.GetWatchlistFunc(userID)
+}
+
+func (m *mockWatchManager) LogWatch(userID uuid.UUID, entry *model.DiaryEntry) error {
+	return m.LogWatchFunc(userID, entry)
+}
+
+func (m *mockWatchManager) RemoveFromDiary(userID, movieID uuid.UUID) error {
+	return m.RemoveFromDiaryFunc(userID, movieID)
+}
+
+func (m *mockWatchManager) GetDiary(userID uuid.UUID) ([]*model.DiaryEntry, error) {
+	return m.GetDiaryFunc(userID)
+}
+
+func (m *mockWatchManager) GetStatuses(userID uuid.UUID, movieIDs []uuid.UUID) (map[uuid.UUID]model.WatchStatus, error) {
+	return m.GetStatusesFunc(userID, movieIDs)
+}
+
+func TestWatchService_LogWatch(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	movieID := uuid.New()
+
+	userManager := newUsersManager(map[string]uuid.UUID{"forrest": userID})
+	movieManager := &mockMovieManager{
+		GetByIDFunc: func(id uuid.UUID) (*model.Movie, error) {
+			if id != movieID {
+				return nil, sql.ErrNoRows
+			}
+			return &model.Movie{ID: movieID}, nil
+		},
+	}
+	watchManager := &mockWatchManager{
+		LogWatchFunc: func(uID uuid.UUID, entry *model.DiaryEntry) error {
+			if uID != userID {
+				return errors.New("unexpected user")
+			}
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		entry          *model.DiaryEntry
+		expectedResult error
+	}{
+		{
+			name:  "SuccessDefaultsToNow",
+			entry: &model.DiaryEntry{MovieID: movieID},
+		},
+		{
+			name:  "SuccessWithDate",
+			entry: &model.DiaryEntry{MovieID: movieID, WatchedAt: time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC), Note: "First time"},
+		},
+		{
+			name:           "FutureDate",
+			entry:          &model.DiaryEntry{MovieID: movieID, WatchedAt: time.Now().Add(48 * time.Hour)},
+			expectedResult: ErrInvalidWatchDate,
+		},
+		{
+			name:           "NoteTooLong",
+			entry:          &model.DiaryEntry{MovieID: movieID, Note: strings.Repeat("a", 1001)},
+			expectedResult: ErrInvalidDiaryNote,
+		},
+		{
+			name:           "MovieNotFound",
+			entry:          &model.DiaryEntry{MovieID: uuid.New()},
+			expectedResult: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := NewWatchService(watchManager, movieManager, userManager)
+
+			err := ws.LogWatch("forrest", tt.entry)
+
+			if !errors.Is(err, tt.expectedResult) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectedResult, err)
+			}
+			if err == nil && tt.entry.WatchedAt.IsZero() {
+				t.Errorf("Expected watched date to be set")
+			}
+		})
+	}
+}
+
+func TestWatchService_MarkWatchStatus(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	listed := &model.Movie{ID: uuid.New(), Title: "Cast Away"}
+	watched := &model.Movie{ID: uuid.New(), Title: "Forrest Gump"}
+	unknown := &model.Movie{ID: uuid.New(), Title: "Big"}
+
+	watchManager := &mockWatchManager{
+		GetStatusesFunc: func(uID uuid.UUID, movieIDs []uuid.UUID) (map[uuid.UUID]model.WatchStatus, error) {
+			if len(movieIDs) != 3 {
+				return nil, errors.New("unexpected movie ids")
+			}
+			return map[uuid.UUID]model.WatchStatus{
+				listed.ID:  {OnWatchlist: true},
+				watched.ID: {Watched: true},
+			}, nil
+		},
+	}
+
+	ws := NewWatchService(watchManager, &mockMovieManager{}, newUsersManager(map[string]uuid.UUID{"forrest": userID}))
+
+	if err := ws.MarkWatchStatus("forrest", []*model.Movie{listed, watched, unknown}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !listed.OnWatchlist || listed.Watched {
+		t.Errorf("Unexpected flags for listed movie: %+v", listed)
+	}
+	if watched.OnWatchlist || !watched.Watched {
+		t.Errorf("Unexpected flags for watched movie: %+v", watched)
+	}
+	if unknown.OnWatchlist || unknown.Watched {
+		t.Errorf("Unexpected flags for unknown movie: %+v", unknown)
+	}
+}
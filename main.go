@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	_ "github.com/lib/pq"
 
@@ -35,30 +36,192 @@ func main() {
 	actorManager := repository.NewActorManager(db)
 	movieManager := repository.NewMovieManager(db)
 	userManager := repository.NewUserManager(db)
+	ratingManager := repository.NewRatingManager(db)
+	reviewManager := repository.NewReviewManager(db)
+	watchManager := repository.NewWatchManager(db)
+	listManager := repository.NewListManager(db)
+	collectionManager := repository.NewCollectionManager(db)
+	seriesManager := repository.NewSeriesManager(db)
+	translationManager := repository.NewTranslationManager(db)
+	awardManager := repository.NewAwardManager(db)
+	companyManager := repository.NewCompanyManager(db)
+	tagManager := repository.NewTagManager(db)
+	duplicateManager := repository.NewDuplicateManager(db)
+	trashManager := repository.NewTrashManager(db)
+	historyManager := repository.NewHistoryManager(db)
 
-	actorService := service.NewActorService(actorManager)
-	movieService := service.NewMovieService(movieManager)
+	actorService := service.NewActorService(actorManager, movieManager, historyManager)
+	movieService := service.NewMovieService(movieManager, historyManager)
 	userService := service.NewUserService(userManager)
+	ratingService := service.NewRatingService(ratingManager, movieManager, userManager)
+	reviewService := service.NewReviewService(reviewManager, movieManager, userManager)
+	watchService := service.NewWatchService(watchManager, movieManager, userManager)
+	listService := service.NewListService(listManager, movieManager, userManager)
+	collectionService := service.NewCollectionService(collectionManager, movieManager)
+	seriesService := service.NewSeriesService(seriesManager)
+	translationService := service.NewTranslationService(translationManager, movieManager)
+	awardService := service.NewAwardService(awardManager, movieManager, actorManager)
+	companyService := service.NewCompanyService(companyManager)
+	tagService := service.NewTagService(tagManager)
+	duplicateService := service.NewDuplicateService(duplicateManager, actorManager, movieManager)
+	trashService := service.NewTrashService(trashManager, actorManager, movieManager)
+	historyService := service.NewHistoryService(historyManager, actorManager, movieManager)
 
 	actorHandler := handler.NewActorHandler(actorService)
-	movieHandler := handler.NewMovieHandler(movieService)
+	movieHandler := handler.NewMovieHandler(movieService, watchService, translationService)
 	userHandler := handler.NewUserHandler(userService)
+	ratingHandler := handler.NewRatingHandler(ratingService)
+	reviewHandler := handler.NewReviewHandler(reviewService)
+	watchHandler := handler.NewWatchHandler(watchService)
+	listHandler := handler.NewListHandler(listService)
+	collectionHandler := handler.NewCollectionHandler(collectionService)
+	seriesHandler := handler.NewSeriesHandler(seriesService)
+	translationHandler := handler.NewTranslationHandler(translationService)
+	awardHandler := handler.NewAwardHandler(awardService)
+	companyHandler := handler.NewCompanyHandler(companyService)
+	tagHandler := handler.NewTagHandler(tagService)
+	duplicateHandler := handler.NewDuplicateHandler(duplicateService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	historyHandler := handler.NewHistoryHandler(historyService)
+
+	requireIfMatch := middleware.RequireIfMatch(cfg.RequireIfMatch)
 
 	http.HandleFunc("/register", userHandler.Register)
 	http.HandleFunc("/login", userHandler.Login)
 
 	http.HandleFunc("/actors/create", middleware.AuthAdminMiddleware(actorHandler.Create))
-	http.HandleFunc("/actors/update", middleware.AuthAdminMiddleware(actorHandler.Update))
-	http.HandleFunc("/actors/delete", middleware.AuthAdminMiddleware(actorHandler.Delete))
+	http.HandleFunc("/actors/getByID", middleware.AuthUserMiddleware(actorHandler.GetByID))
+	http.HandleFunc("/actors/update", middleware.AuthAdminMiddleware(requireIfMatch(actorHandler.Update)))
+	http.HandleFunc("PATCH /actors/patch", middleware.AuthAdminMiddleware(requireIfMatch(actorHandler.Patch)))
+	http.HandleFunc("/actors/delete", middleware.AuthAdminMiddleware(requireIfMatch(actorHandler.Delete)))
 	http.HandleFunc("/actors/getAllWithMovies", middleware.AuthUserMiddleware(actorHandler.GetAllWithMovies))
+	http.HandleFunc("/actors/getFilmography", middleware.AuthUserMiddleware(actorHandler.GetFilmography))
+	http.HandleFunc("GET /actors", middleware.AuthUserMiddleware(actorHandler.GetAll))
+	http.HandleFunc("GET /actors/{id}/timeline", middleware.AuthUserMiddleware(actorHandler.GetCareerTimeline))
+	http.HandleFunc("GET /actors/{id}/collaborators", middleware.AuthUserMiddleware(actorHandler.GetCollaborators))
+	http.HandleFunc("GET /actors/{id}/connection/{targetId}", middleware.AuthUserMiddleware(actorHandler.GetConnection))
 
 	http.HandleFunc("/movies/create", middleware.AuthAdminMiddleware(movieHandler.Create))
-	http.HandleFunc("/movies/update", middleware.AuthAdminMiddleware(movieHandler.Update))
-	http.HandleFunc("/movies/delete", middleware.AuthAdminMiddleware(movieHandler.Delete))
+	http.HandleFunc("/movies/getByID", middleware.AuthUserMiddleware(movieHandler.GetByID))
+	http.HandleFunc("/movies/update", middleware.AuthAdminMiddleware(requireIfMatch(movieHandler.Update)))
+	http.HandleFunc("PATCH /movies/patch", middleware.AuthAdminMiddleware(requireIfMatch(movieHandler.Patch)))
+	http.HandleFunc("/movies/delete", middleware.AuthAdminMiddleware(requireIfMatch(movieHandler.Delete)))
 	http.HandleFunc("/movies/getAllWithSorting", middleware.AuthUserMiddleware(movieHandler.GetAllWithSorting))
 	http.HandleFunc("/movies/getByTitleFragment", middleware.AuthUserMiddleware(movieHandler.GetByTitleFragment))
 	http.HandleFunc("/movies/getByActorNameFragment", middleware.AuthUserMiddleware(movieHandler.GetByActorNameFragment))
 
+	http.HandleFunc("GET /movies/{id}/translations", middleware.AuthUserMiddleware(translationHandler.GetByMovie))
+	http.HandleFunc("PUT /movies/{id}/translations/{language}", middleware.AuthAdminMiddleware(translationHandler.Put))
+	http.HandleFunc("DELETE /movies/{id}/translations/{language}", middleware.AuthAdminMiddleware(translationHandler.Delete))
+
+	http.HandleFunc("PUT /movies/{id}/my-rating", middleware.AuthUserMiddleware(ratingHandler.PutMyRating))
+	http.HandleFunc("DELETE /movies/{id}/my-rating", middleware.AuthUserMiddleware(ratingHandler.DeleteMyRating))
+
+	http.HandleFunc("GET /movies/{id}/reviews", middleware.AuthUserMiddleware(reviewHandler.GetByMovie))
+	http.HandleFunc("POST /movies/{id}/reviews", middleware.AuthUserMiddleware(reviewHandler.Create))
+	http.HandleFunc("PUT /reviews/{id}", middleware.AuthUserMiddleware(reviewHandler.Update))
+	http.HandleFunc("DELETE /reviews/{id}", middleware.AuthUserMiddleware(reviewHandler.Delete))
+	http.HandleFunc("GET /reviews/moderation", middleware.AuthAdminMiddleware(reviewHandler.GetModerationQueue))
+	http.HandleFunc("POST /reviews/{id}/moderate", middleware.AuthAdminMiddleware(reviewHandler.Moderate))
+
+	http.HandleFunc("GET /me/watchlist", middleware.AuthUserMiddleware(watchHandler.GetWatchlist))
+	http.HandleFunc("PUT /me/watchlist/{movieId}", middleware.AuthUserMiddleware(watchHandler.AddToWatchlist))
+	http.HandleFunc("DELETE /me/watchlist/{movieId}", middleware.AuthUserMiddleware(watchHandler.RemoveFromWatchlist))
+	http.HandleFunc("GET /me/diary", middleware.AuthUserMiddleware(watchHandler.GetDiary))
+	http.HandleFunc("POST /me/diary", middleware.AuthUserMiddleware(watchHandler.LogWatch))
+	http.HandleFunc("DELETE /me/diary/{movieId}", middleware.AuthUserMiddleware(watchHandler.RemoveFromDiary))
+
+	http.HandleFunc("GET /me/lists", middleware.AuthUserMiddleware(listHandler.GetMine))
+	http.HandleFunc("GET /lists", middleware.AuthUserMiddleware(listHandler.GetPublic))
+	http.HandleFunc("POST /lists", middleware.AuthUserMiddleware(listHandler.Create))
+	http.HandleFunc("GET /lists/{id}", middleware.AuthUserMiddleware(listHandler.Get))
+	http.HandleFunc("PUT /lists/{id}", middleware.AuthUserMiddleware(listHandler.Update))
+	http.HandleFunc("DELETE /lists/{id}", middleware.AuthUserMiddleware(listHandler.Delete))
+	http.HandleFunc("PUT /lists/{id}/entries/{movieId}", middleware.AuthUserMiddleware(listHandler.SetEntry))
+	http.HandleFunc("DELETE /lists/{id}/entries/{movieId}", middleware.AuthUserMiddleware(listHandler.RemoveEntry))
+	http.HandleFunc("PUT /lists/{id}/order", middleware.AuthUserMiddleware(listHandler.Reorder))
+	http.HandleFunc("POST /lists/{id}/clone", middleware.AuthUserMiddleware(listHandler.Clone))
+	http.HandleFunc("POST /lists/{id}/share", middleware.AuthUserMiddleware(listHandler.Share))
+
+	http.HandleFunc("GET /collections", middleware.AuthUserMiddleware(collectionHandler.GetAll))
+	http.HandleFunc("POST /collections", middleware.AuthAdminMiddleware(collectionHandler.Create))
+	http.HandleFunc("GET /collections/{id}", middleware.AuthUserMiddleware(collectionHandler.GetByID))
+	http.HandleFunc("PUT /collections/{id}", middleware.AuthAdminMiddleware(collectionHandler.Update))
+	http.HandleFunc("DELETE /collections/{id}", middleware.AuthAdminMiddleware(collectionHandler.Delete))
+	http.HandleFunc("PUT /collections/{id}/movies/{movieId}", middleware.AuthAdminMiddleware(collectionHandler.SetMovie))
+	http.HandleFunc("DELETE /collections/{id}/movies/{movieId}", middleware.AuthAdminMiddleware(collectionHandler.RemoveMovie))
+
+	http.HandleFunc("GET /series", middleware.AuthUserMiddleware(seriesHandler.Search))
+	http.HandleFunc("POST /series", middleware.AuthAdminMiddleware(seriesHandler.Create))
+	http.HandleFunc("GET /series/{id}", middleware.AuthUserMiddleware(seriesHandler.GetByID))
+	http.HandleFunc("PUT /series/{id}", middleware.AuthAdminMiddleware(seriesHandler.Update))
+	http.HandleFunc("DELETE /series/{id}", middleware.AuthAdminMiddleware(seriesHandler.Delete))
+	http.HandleFunc("POST /series/{id}/seasons", middleware.AuthAdminMiddleware(seriesHandler.CreateSeason))
+	http.HandleFunc("DELETE /series/{id}/seasons/{number}", middleware.AuthAdminMiddleware(seriesHandler.DeleteSeason))
+	http.HandleFunc("POST /series/{id}/seasons/{number}/episodes", middleware.AuthAdminMiddleware(seriesHandler.CreateEpisode))
+	http.HandleFunc("GET /episodes/{id}", middleware.AuthUserMiddleware(seriesHandler.GetEpisodeByID))
+	http.HandleFunc("PUT /episodes/{id}", middleware.AuthAdminMiddleware(seriesHandler.UpdateEpisode))
+	http.HandleFunc("DELETE /episodes/{id}", middleware.AuthAdminMiddleware(seriesHandler.DeleteEpisode))
+
+	http.HandleFunc("GET /awards", middleware.AuthUserMiddleware(awardHandler.GetAll))
+	http.HandleFunc("POST /awards", middleware.AuthAdminMiddleware(awardHandler.Create))
+	http.HandleFunc("GET /awards/{id}", middleware.AuthUserMiddleware(awardHandler.GetByID))
+	http.HandleFunc("PUT /awards/{id}", middleware.AuthAdminMiddleware(awardHandler.Update))
+	http.HandleFunc("DELETE /awards/{id}", middleware.AuthAdminMiddleware(awardHandler.Delete))
+	http.HandleFunc("POST /awards/{id}/categories", middleware.AuthAdminMiddleware(awardHandler.CreateCategory))
+	http.HandleFunc("DELETE /award-categories/{id}", middleware.AuthAdminMiddleware(awardHandler.DeleteCategory))
+	http.HandleFunc("POST /awards/{id}/ceremonies", middleware.AuthAdminMiddleware(awardHandler.CreateCeremony))
+	http.HandleFunc("GET /ceremonies/{id}", middleware.AuthUserMiddleware(awardHandler.GetCeremonyByID))
+	http.HandleFunc("DELETE /ceremonies/{id}", middleware.AuthAdminMiddleware(awardHandler.DeleteCeremony))
+	http.HandleFunc("POST /ceremonies/{id}/nominations", middleware.AuthAdminMiddleware(awardHandler.CreateNomination))
+	http.HandleFunc("PUT /nominations/{id}", middleware.AuthAdminMiddleware(awardHandler.UpdateNomination))
+	http.HandleFunc("DELETE /nominations/{id}", middleware.AuthAdminMiddleware(awardHandler.DeleteNomination))
+
+	http.HandleFunc("GET /companies", middleware.AuthUserMiddleware(companyHandler.GetAll))
+	http.HandleFunc("POST /companies", middleware.AuthAdminMiddleware(companyHandler.Create))
+	http.HandleFunc("GET /companies/{id}", middleware.AuthUserMiddleware(companyHandler.GetByID))
+	http.HandleFunc("PUT /companies/{id}", middleware.AuthAdminMiddleware(companyHandler.Update))
+	http.HandleFunc("DELETE /companies/{id}", middleware.AuthAdminMiddleware(companyHandler.Delete))
+
+	http.HandleFunc("GET /tags", middleware.AuthUserMiddleware(tagHandler.GetAll))
+	http.HandleFunc("GET /tags/stats", middleware.AuthUserMiddleware(tagHandler.GetStats))
+	http.HandleFunc("PUT /tags/{id}", middleware.AuthAdminMiddleware(tagHandler.Rename))
+	http.HandleFunc("DELETE /tags/{id}", middleware.AuthAdminMiddleware(tagHandler.Delete))
+	http.HandleFunc("POST /tags/{id}/merge/{targetId}", middleware.AuthAdminMiddleware(tagHandler.Merge))
+
+	http.HandleFunc("GET /actors/duplicates", middleware.AuthAdminMiddleware(duplicateHandler.GetActorDuplicates))
+	http.HandleFunc("POST /actors/{id}/merge/{targetId}", middleware.AuthAdminMiddleware(duplicateHandler.MergeActors))
+	http.HandleFunc("GET /movies/duplicates", middleware.AuthAdminMiddleware(duplicateHandler.GetMovieDuplicates))
+	http.HandleFunc("POST /movies/{id}/merge/{targetId}", middleware.AuthAdminMiddleware(duplicateHandler.MergeMovies))
+
+	http.HandleFunc("GET /trash", middleware.AuthAdminMiddleware(trashHandler.GetTrash))
+	http.HandleFunc("POST /trash/actors/{id}/restore", middleware.AuthAdminMiddleware(trashHandler.RestoreActor))
+	http.HandleFunc("POST /trash/movies/{id}/restore", middleware.AuthAdminMiddleware(trashHandler.RestoreMovie))
+
+	http.HandleFunc("GET /movies/{id}/history", middleware.AuthAdminMiddleware(historyHandler.GetMovieHistory))
+	http.HandleFunc("POST /movies/{id}/history/{historyID}/revert", middleware.AuthAdminMiddleware(historyHandler.RevertMovie))
+	http.HandleFunc("GET /actors/{id}/history", middleware.AuthAdminMiddleware(historyHandler.GetActorHistory))
+	http.HandleFunc("POST /actors/{id}/history/{historyID}/revert", middleware.AuthAdminMiddleware(historyHandler.RevertActor))
+
+	go purgeTrash(trashService, cfg.TrashRetention, cfg.TrashPurgeInterval)
+
 	log.Printf("Server is running on %s", cfg.ServerPort)
 	log.Fatal(http.ListenAndServe(cfg.ServerPort, nil))
 }
+
+// purgeTrash removes the actors and movies that have been in the trash for longer than the retention every interval,
+// the trash is never purged when the interval is zero.
+func purgeTrash(trashService service.TrashService, retention, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	for range time.Tick(interval) {
+		purged, err := trashService.Purge(retention)
+		if err != nil {
+			log.Printf("Failed to purge the trash: %v", err)
+			continue
+		}
+		log.Printf("Purged %d actors and movies from the trash.", purged)
+	}
+}
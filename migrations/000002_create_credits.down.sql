@@ -0,0 +1 @@
+DROP TABLE IF EXISTS credits CASCADE;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS credits (
+    movie_id       UUID REFERENCES movies(id),
+    person_id      UUID REFERENCES actors(id),
+    role           VARCHAR(20) NOT NULL CHECK (role IN ('director', 'writer', 'producer', 'composer')),
+    billing_order  INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (movie_id, person_id, role)
+);
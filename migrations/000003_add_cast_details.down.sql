@@ -0,0 +1,3 @@
+ALTER TABLE movie_actor
+    DROP COLUMN IF EXISTS character_name,
+    DROP COLUMN IF EXISTS billing_order;
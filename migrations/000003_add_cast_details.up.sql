@@ -0,0 +1,3 @@
+ALTER TABLE movie_actor
+    ADD COLUMN IF NOT EXISTS character_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS billing_order  INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS movies_weighted_rating_idx;
+
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS user_rating_avg,
+    DROP COLUMN IF EXISTS user_rating_count,
+    DROP COLUMN IF EXISTS weighted_rating;
+
+DROP TABLE IF EXISTS user_ratings CASCADE;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_ratings (
+    user_id   UUID REFERENCES users(id) ON DELETE CASCADE,
+    movie_id  UUID REFERENCES movies(id) ON DELETE CASCADE,
+    score     INTEGER NOT NULL CHECK (score >= 1 AND score <= 10),
+    rated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, movie_id)
+);
+
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS user_rating_avg    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS user_rating_count  INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS weighted_rating    DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS movies_weighted_rating_idx ON movies (weighted_rating DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reviews CASCADE;
\ No newline at end of file
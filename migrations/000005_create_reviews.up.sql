@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    id          UUID PRIMARY KEY,
+    movie_id    UUID NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title       VARCHAR(150) NOT NULL CHECK (LENGTH(title) > 0 AND LENGTH(title) <= 150),
+    body        TEXT NOT NULL CHECK (LENGTH(body) > 0 AND LENGTH(body) <= 10000),
+    spoiler     BOOLEAN NOT NULL DEFAULT FALSE,
+    status      VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'hidden')),
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (movie_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS reviews_status_created_at_idx ON reviews (status, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS watch_log CASCADE;
+DROP TABLE IF EXISTS watchlist CASCADE;
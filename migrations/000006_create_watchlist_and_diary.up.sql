@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS watchlist (
+    user_id   UUID REFERENCES users(id) ON DELETE CASCADE,
+    movie_id  UUID REFERENCES movies(id) ON DELETE CASCADE,
+    added_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, movie_id)
+);
+
+CREATE TABLE IF NOT EXISTS watch_log (
+    user_id        UUID REFERENCES users(id) ON DELETE CASCADE,
+    movie_id       UUID REFERENCES movies(id) ON DELETE CASCADE,
+    watched_at     TIMESTAMP NOT NULL,
+    rewatch_count  INTEGER NOT NULL DEFAULT 0 CHECK (rewatch_count >= 0),
+    note           TEXT NOT NULL DEFAULT '' CHECK (LENGTH(note) <= 1000),
+    PRIMARY KEY (user_id, movie_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS list_entries CASCADE;
+DROP TABLE IF EXISTS lists CASCADE;
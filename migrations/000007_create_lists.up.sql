@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS lists (
+    id           UUID PRIMARY KEY,
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(150) NOT NULL CHECK (LENGTH(name) > 0 AND LENGTH(name) <= 150),
+    description  TEXT NOT NULL DEFAULT '' CHECK (LENGTH(description) <= 1000),
+    visibility   VARCHAR(10) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'unlisted', 'public')),
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS lists_user_id_idx ON lists (user_id);
+CREATE INDEX IF NOT EXISTS lists_visibility_updated_at_idx ON lists (visibility, updated_at);
+
+CREATE TABLE IF NOT EXISTS list_entries (
+    list_id   UUID REFERENCES lists(id) ON DELETE CASCADE,
+    movie_id  UUID REFERENCES movies(id) ON DELETE CASCADE,
+    position  INTEGER NOT NULL CHECK (position > 0),
+    note      TEXT NOT NULL DEFAULT '' CHECK (LENGTH(note) <= 500),
+    PRIMARY KEY (list_id, movie_id),
+    UNIQUE (list_id, position) DEFERRABLE INITIALLY DEFERRED
+);
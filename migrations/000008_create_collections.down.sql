@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS collection_movies CASCADE;
+DROP TABLE IF EXISTS collections CASCADE;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id           UUID PRIMARY KEY,
+    name         VARCHAR(150) NOT NULL CHECK (LENGTH(name) > 0 AND LENGTH(name) <= 150),
+    description  TEXT NOT NULL DEFAULT '' CHECK (LENGTH(description) <= 1000),
+    ordering     VARCHAR(15) NOT NULL DEFAULT 'release' CHECK (ordering IN ('release', 'chronological'))
+);
+
+CREATE TABLE IF NOT EXISTS collection_movies (
+    movie_id             UUID PRIMARY KEY REFERENCES movies(id) ON DELETE CASCADE,
+    collection_id        UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    chronological_order  INTEGER NOT NULL CHECK (chronological_order > 0)
+);
+
+CREATE INDEX IF NOT EXISTS collection_movies_collection_id_idx ON collection_movies (collection_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS episode_actor CASCADE;
+DROP TABLE IF EXISTS episodes CASCADE;
+DROP TABLE IF EXISTS seasons CASCADE;
+DROP TABLE IF EXISTS series CASCADE;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS series (
+    id           UUID PRIMARY KEY,
+    title        VARCHAR(150) NOT NULL CHECK (LENGTH(title) > 0 AND LENGTH(title) <= 150),
+    description  TEXT NOT NULL CHECK (LENGTH(description) <= 1000)
+);
+
+CREATE TABLE IF NOT EXISTS seasons (
+    id         UUID PRIMARY KEY,
+    series_id  UUID NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    number     INTEGER NOT NULL CHECK (number > 0),
+    title      VARCHAR(150) NOT NULL DEFAULT '' CHECK (LENGTH(title) <= 150),
+    UNIQUE (series_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS episodes (
+    id           UUID PRIMARY KEY,
+    season_id    UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    number       INTEGER NOT NULL CHECK (number > 0),
+    title        VARCHAR(150) NOT NULL CHECK (LENGTH(title) > 0 AND LENGTH(title) <= 150),
+    description  TEXT NOT NULL CHECK (LENGTH(description) <= 1000),
+    air_date     TIMESTAMP NOT NULL,
+    UNIQUE (season_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS episode_actor (
+    episode_id      UUID REFERENCES episodes(id) ON DELETE CASCADE,
+    actor_id        UUID REFERENCES actors(id) ON DELETE CASCADE,
+    character_name  VARCHAR(255) NOT NULL DEFAULT '',
+    billing_order   INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (episode_id, actor_id)
+);
+
+CREATE INDEX IF NOT EXISTS episode_actor_actor_id_idx ON episode_actor (actor_id);
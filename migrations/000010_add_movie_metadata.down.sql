@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS movie_certifications CASCADE;
+
+DROP INDEX IF EXISTS movies_spoken_languages_idx;
+DROP INDEX IF EXISTS movies_production_countries_idx;
+
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS spoken_languages,
+    DROP COLUMN IF EXISTS production_countries,
+    DROP COLUMN IF EXISTS original_language,
+    DROP COLUMN IF EXISTS original_title,
+    DROP COLUMN IF EXISTS runtime_minutes;
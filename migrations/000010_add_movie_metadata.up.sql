@@ -0,0 +1,16 @@
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS runtime_minutes       INTEGER NOT NULL DEFAULT 0 CHECK (runtime_minutes >= 0),
+    ADD COLUMN IF NOT EXISTS original_title        VARCHAR(150) NOT NULL DEFAULT '' CHECK (LENGTH(original_title) <= 150),
+    ADD COLUMN IF NOT EXISTS original_language     VARCHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS production_countries  VARCHAR(2)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS spoken_languages      VARCHAR(2)[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS movies_production_countries_idx ON movies USING GIN (production_countries);
+CREATE INDEX IF NOT EXISTS movies_spoken_languages_idx ON movies USING GIN (spoken_languages);
+
+CREATE TABLE IF NOT EXISTS movie_certifications (
+    movie_id       UUID REFERENCES movies(id) ON DELETE CASCADE,
+    country_code   VARCHAR(2) NOT NULL,
+    certification  VARCHAR(10) NOT NULL CHECK (LENGTH(certification) > 0),
+    PRIMARY KEY (movie_id, country_code)
+);
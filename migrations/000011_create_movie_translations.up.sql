@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS movie_translations (
+    movie_id     UUID REFERENCES movies(id) ON DELETE CASCADE,
+    language     VARCHAR(35) NOT NULL,
+    title        VARCHAR(150) NOT NULL CHECK (LENGTH(title) > 0 AND LENGTH(title) <= 150),
+    description  TEXT NOT NULL DEFAULT '' CHECK (LENGTH(description) <= 1000),
+    PRIMARY KEY (movie_id, language)
+);
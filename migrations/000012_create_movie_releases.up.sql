@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS movie_releases (
+    movie_id      UUID REFERENCES movies(id) ON DELETE CASCADE,
+    country_code  VARCHAR(2) NOT NULL,
+    release_type  VARCHAR(20) NOT NULL CHECK (release_type IN ('premiere', 'theatrical_limited', 'theatrical', 'digital', 'physical', 'tv')),
+    release_date  TIMESTAMP NOT NULL,
+    note          VARCHAR(200) NOT NULL DEFAULT '' CHECK (LENGTH(note) <= 200),
+    PRIMARY KEY (movie_id, country_code, release_type)
+);
+
+CREATE INDEX IF NOT EXISTS movie_releases_country_code_idx ON movie_releases (country_code, release_date);
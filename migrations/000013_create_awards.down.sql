@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS nominations CASCADE;
+DROP TABLE IF EXISTS award_ceremonies CASCADE;
+DROP TABLE IF EXISTS award_categories CASCADE;
+DROP TABLE IF EXISTS awards CASCADE;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS awards (
+    id           UUID PRIMARY KEY,
+    name         VARCHAR(150) NOT NULL UNIQUE CHECK (LENGTH(name) > 0 AND LENGTH(name) <= 150),
+    description  TEXT NOT NULL DEFAULT '' CHECK (LENGTH(description) <= 1000)
+);
+
+CREATE TABLE IF NOT EXISTS award_categories (
+    id        UUID PRIMARY KEY,
+    award_id  UUID NOT NULL REFERENCES awards(id) ON DELETE CASCADE,
+    name      VARCHAR(150) NOT NULL CHECK (LENGTH(name) > 0 AND LENGTH(name) <= 150),
+    UNIQUE (award_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS award_ceremonies (
+    id             UUID PRIMARY KEY,
+    award_id       UUID NOT NULL REFERENCES awards(id) ON DELETE CASCADE,
+    year           INTEGER NOT NULL CHECK (year > 0),
+    ceremony_date  TIMESTAMP,
+    UNIQUE (award_id, year)
+);
+
+CREATE TABLE IF NOT EXISTS nominations (
+    id           UUID PRIMARY KEY,
+    ceremony_id  UUID NOT NULL REFERENCES award_ceremonies(id) ON DELETE CASCADE,
+    category_id  UUID NOT NULL REFERENCES award_categories(id) ON DELETE CASCADE,
+    movie_id     UUID NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    person_id    UUID REFERENCES actors(id) ON DELETE CASCADE,
+    won          BOOLEAN NOT NULL DEFAULT FALSE,
+    note         VARCHAR(200) NOT NULL DEFAULT '' CHECK (LENGTH(note) <= 200)
+);
+
+CREATE INDEX IF NOT EXISTS nominations_ceremony_id_idx ON nominations (ceremony_id);
+CREATE INDEX IF NOT EXISTS nominations_movie_id_idx ON nominations (movie_id);
+CREATE INDEX IF NOT EXISTS nominations_person_id_idx ON nominations (person_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS movie_company CASCADE;
+DROP TABLE IF EXISTS companies CASCADE;
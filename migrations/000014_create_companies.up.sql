@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS companies (
+    id           UUID PRIMARY KEY,
+    name         VARCHAR(150) NOT NULL CHECK (LENGTH(name) > 0 AND LENGTH(name) <= 150),
+    country      VARCHAR(2) NOT NULL DEFAULT '',
+    description  TEXT NOT NULL DEFAULT '' CHECK (LENGTH(description) <= 1000)
+);
+
+CREATE TABLE IF NOT EXISTS movie_company (
+    movie_id    UUID REFERENCES movies(id) ON DELETE CASCADE,
+    company_id  UUID REFERENCES companies(id) ON DELETE CASCADE,
+    role        VARCHAR(15) NOT NULL CHECK (role IN ('production', 'distribution')),
+    PRIMARY KEY (movie_id, company_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS movie_company_company_id_idx ON movie_company (company_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS movie_tags CASCADE;
+DROP TABLE IF EXISTS tags CASCADE;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id    UUID PRIMARY KEY,
+    name  VARCHAR(50) NOT NULL UNIQUE CHECK (LENGTH(name) > 0 AND LENGTH(name) <= 50)
+);
+
+CREATE TABLE IF NOT EXISTS movie_tags (
+    movie_id  UUID REFERENCES movies(id) ON DELETE CASCADE,
+    tag_id    UUID REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (movie_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS movie_tags_tag_id_idx ON movie_tags (tag_id);
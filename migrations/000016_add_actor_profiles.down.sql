@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS actor_links CASCADE;
+
+ALTER TABLE actors
+    DROP CONSTRAINT IF EXISTS actors_death_after_birth,
+    DROP COLUMN IF EXISTS aliases,
+    DROP COLUMN IF EXISTS biography,
+    DROP COLUMN IF EXISTS nationality,
+    DROP COLUMN IF EXISTS birth_place,
+    DROP COLUMN IF EXISTS death_date;
@@ -0,0 +1,14 @@
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS death_date   TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS birth_place  VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS nationality  VARCHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS biography    TEXT NOT NULL DEFAULT '' CHECK (LENGTH(biography) <= 5000),
+    ADD COLUMN IF NOT EXISTS aliases      VARCHAR(255)[] NOT NULL DEFAULT '{}',
+    ADD CONSTRAINT actors_death_after_birth CHECK (death_date IS NULL OR death_date >= birth_date);
+
+CREATE TABLE IF NOT EXISTS actor_links (
+    actor_id  UUID REFERENCES actors(id) ON DELETE CASCADE,
+    site      VARCHAR(50) NOT NULL CHECK (LENGTH(site) > 0),
+    url       VARCHAR(500) NOT NULL CHECK (LENGTH(url) > 0),
+    PRIMARY KEY (actor_id, site)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS movie_redirects CASCADE;
+DROP TABLE IF EXISTS actor_redirects CASCADE;
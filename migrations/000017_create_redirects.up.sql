@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS actor_redirects (
+    old_id    UUID PRIMARY KEY,
+    actor_id  UUID NOT NULL REFERENCES actors(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS actor_redirects_actor_id_idx ON actor_redirects (actor_id);
+
+CREATE TABLE IF NOT EXISTS movie_redirects (
+    old_id    UUID PRIMARY KEY,
+    movie_id  UUID NOT NULL REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS movie_redirects_movie_id_idx ON movie_redirects (movie_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS actors_deleted_at_idx;
+
+ALTER TABLE actors
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS history (
+    id           BIGSERIAL PRIMARY KEY,
+    entity_type  VARCHAR(10) NOT NULL CHECK (entity_type IN ('movie', 'actor', 'cast')),
+    entity_id    UUID NOT NULL,
+    action       VARCHAR(10) NOT NULL CHECK (action IN ('create', 'update', 'delete', 'revert')),
+    username     VARCHAR(30) NOT NULL,
+    changed_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    before       JSONB,
+    after        JSONB,
+    diff         JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS history_entity_id_idx ON history (entity_id, id);
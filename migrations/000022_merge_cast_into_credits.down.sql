@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS movie_actor (
+    movie_id        UUID REFERENCES movies(id),
+    actor_id        UUID REFERENCES actors(id),
+    character_name  VARCHAR(255) NOT NULL DEFAULT '',
+    billing_order   INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (movie_id, actor_id)
+);
+
+INSERT INTO movie_actor (movie_id, actor_id, character_name, billing_order)
+SELECT movie_id, person_id, character_name, billing_order
+FROM credits
+WHERE role = 'actor';
+
+DELETE FROM credits WHERE role = 'actor';
+
+ALTER TABLE credits
+    DROP COLUMN IF EXISTS character_name,
+    DROP CONSTRAINT IF EXISTS credits_role_check,
+    ADD CONSTRAINT credits_role_check CHECK (role IN ('director', 'writer', 'producer', 'composer'));
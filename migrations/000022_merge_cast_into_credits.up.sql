@@ -0,0 +1,11 @@
+ALTER TABLE credits
+    DROP CONSTRAINT IF EXISTS credits_role_check,
+    ADD CONSTRAINT credits_role_check CHECK (role IN ('actor', 'director', 'writer', 'producer', 'composer')),
+    ADD COLUMN IF NOT EXISTS character_name VARCHAR(255) NOT NULL DEFAULT '';
+
+INSERT INTO credits (movie_id, person_id, role, character_name, billing_order)
+SELECT movie_id, actor_id, 'actor', character_name, billing_order
+FROM movie_actor
+ON CONFLICT (movie_id, person_id, role) DO NOTHING;
+
+DROP TABLE IF EXISTS movie_actor;
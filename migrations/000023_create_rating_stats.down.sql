@@ -0,0 +1,12 @@
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS weighted_rating DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE movies m
+SET weighted_rating = (m.user_rating_count * m.user_rating_avg + 10 * s.score_sum::DOUBLE PRECISION / s.score_count)
+    / (m.user_rating_count + 10)
+FROM rating_stats s
+WHERE m.user_rating_count > 0 AND s.score_count > 0;
+
+CREATE INDEX IF NOT EXISTS movies_weighted_rating_idx ON movies (weighted_rating DESC);
+
+DROP TABLE IF EXISTS rating_stats;
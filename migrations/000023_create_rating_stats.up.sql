@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS rating_stats (
+    id           BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    score_sum    BIGINT NOT NULL DEFAULT 0,
+    score_count  BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO rating_stats (score_sum, score_count)
+SELECT COALESCE(SUM(score), 0), COUNT(*)
+FROM user_ratings
+ON CONFLICT (id) DO NOTHING;
+
+DROP INDEX IF EXISTS movies_weighted_rating_idx;
+
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS weighted_rating;
@@ -0,0 +1,8 @@
+DELETE FROM history WHERE entity_type = 'translation' OR action IN ('restore', 'merge');
+
+ALTER TABLE history
+    DROP CONSTRAINT IF EXISTS history_action_check,
+    ADD CONSTRAINT history_action_check CHECK (action IN ('create', 'update', 'delete', 'revert')),
+    DROP CONSTRAINT IF EXISTS history_entity_type_check,
+    ADD CONSTRAINT history_entity_type_check CHECK (entity_type IN ('movie', 'actor', 'cast')),
+    ALTER COLUMN entity_type TYPE VARCHAR(10);
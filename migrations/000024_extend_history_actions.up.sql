@@ -0,0 +1,7 @@
+ALTER TABLE history
+    ALTER COLUMN entity_type TYPE VARCHAR(20),
+    DROP CONSTRAINT IF EXISTS history_entity_type_check,
+    ADD CONSTRAINT history_entity_type_check CHECK (entity_type IN ('movie', 'actor', 'cast', 'translation')),
+    DROP CONSTRAINT IF EXISTS history_action_check,
+    ADD CONSTRAINT history_action_check
+        CHECK (action IN ('create', 'update', 'delete', 'revert', 'restore', 'merge'));